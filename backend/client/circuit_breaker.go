@@ -0,0 +1,65 @@
+package client
+
+import (
+	"sync"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+)
+
+// circuitBreaker stops calling a failing dependency. It opens after failureThreshold consecutive failures, and
+// lets one call through after openDuration, which closes it again if succeeded. It is always closed if
+// failureThreshold is not positive.
+type circuitBreaker struct {
+	clock            clockwork.Clock
+	failureThreshold int
+	openDuration     time.Duration
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	// whether the call let through after openDuration is in flight
+	trial bool
+}
+
+func newCircuitBreaker(clock clockwork.Clock, failureThreshold int, openDuration time.Duration) *circuitBreaker {
+	return &circuitBreaker{
+		clock:            clock,
+		failureThreshold: failureThreshold,
+		openDuration:     openDuration,
+	}
+}
+
+// Allow returns whether a call can be made. The caller should report the result with Success or Failure if allowed.
+func (b *circuitBreaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failureThreshold <= 0 || b.failures < b.failureThreshold {
+		return true
+	}
+	if b.clock.Now().Before(b.openUntil) || b.trial {
+		return false
+	}
+	b.trial = true
+	return true
+}
+
+func (b *circuitBreaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = 0
+	b.trial = false
+}
+
+func (b *circuitBreaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	b.trial = false
+	if b.failureThreshold > 0 && b.failures >= b.failureThreshold {
+		b.openUntil = b.clock.Now().Add(b.openDuration)
+	}
+}
@@ -0,0 +1,46 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	clock := clockwork.NewFakeClock()
+
+	b := newCircuitBreaker(clock, 2, 30*time.Second)
+
+	assert.True(t, b.Allow())
+	b.Failure()
+	assert.True(t, b.Allow(), "closed until the threshold")
+	b.Failure()
+	assert.False(t, b.Allow(), "opened at the threshold")
+
+	clock.Advance(29 * time.Second)
+	assert.False(t, b.Allow(), "open for the duration")
+
+	clock.Advance(time.Second)
+	assert.True(t, b.Allow(), "half-open after the duration")
+	assert.False(t, b.Allow(), "only one trial at a time")
+	b.Failure()
+	assert.False(t, b.Allow(), "opened again by the failed trial")
+
+	clock.Advance(30 * time.Second)
+	assert.True(t, b.Allow())
+	b.Success()
+	assert.True(t, b.Allow(), "closed by the succeeded trial")
+	b.Failure()
+	assert.True(t, b.Allow(), "failures are counted from zero again")
+}
+
+func TestCircuitBreaker_Disabled(t *testing.T) {
+	b := newCircuitBreaker(clockwork.NewFakeClock(), 0, 30*time.Second)
+
+	for i := 0; i < 10; i++ {
+		b.Failure()
+	}
+	assert.True(t, b.Allow())
+}
@@ -0,0 +1,102 @@
+package client
+
+//go:generate mockgen -package client -destination ./email_sender_mock.go -mock_names EmailSender=MockEmailSender github.com/sean-ahn/user/backend/client EmailSender
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	EmailSenderMock    = "mock"
+	EmailSenderSMTP    = "smtp"
+	EmailSenderMaildir = "maildir"
+)
+
+type Email struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+type EmailSender interface {
+	Send(ctx context.Context, email *Email) error
+}
+
+var _ EmailSender = (*MockEmailSender)(nil)
+
+// mockEmailSender only logs emails, so that confirmation links can be followed in development.
+type mockEmailSender struct{}
+
+func (s *mockEmailSender) Send(_ context.Context, email *Email) error {
+	logrus.WithField("to", email.To).WithField("subject", email.Subject).Info(email.Body)
+	return nil
+}
+
+func GetMockEmailSender() EmailSender {
+	return &mockEmailSender{}
+}
+
+// buildEmailMessage formats the email as a plain text RFC 5322 message.
+func buildEmailMessage(from *mail.Address, to *mail.Address, email *Email, date time.Time) ([]byte, error) {
+	messageID, err := newMessageID(from)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	for _, h := range [][2]string{
+		{"From", from.String()},
+		{"To", to.String()},
+		{"Subject", mime.QEncoding.Encode("utf-8", email.Subject)},
+		{"Date", date.Format(time.RFC1123Z)},
+		{"Message-ID", messageID},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "text/plain; charset=utf-8"},
+		{"Content-Transfer-Encoding", "quoted-printable"},
+	} {
+		fmt.Fprintf(&buf, "%s: %s\r\n", h[0], h[1])
+	}
+	buf.WriteString("\r\n")
+
+	w := quotedprintable.NewWriter(&buf)
+	if _, err := w.Write([]byte(email.Body)); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return buf.Bytes(), nil
+}
+
+func newMessageID(from *mail.Address) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	domain := "localhost"
+	if i := strings.LastIndex(from.Address, "@"); i >= 0 {
+		domain = from.Address[i+1:]
+	}
+	return fmt.Sprintf("<%s@%s>", hex.EncodeToString(b), domain), nil
+}
+
+func parseAddress(s string) (*mail.Address, error) {
+	addr, err := mail.ParseAddress(s)
+	if err != nil {
+		return nil, errors.Wrap(err, s)
+	}
+	return addr, nil
+}
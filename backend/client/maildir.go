@@ -0,0 +1,95 @@
+package client
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/pkg/errors"
+)
+
+const (
+	maildirTmp = "tmp"
+	maildirNew = "new"
+	maildirCur = "cur"
+)
+
+var maildirHostnameReplacer = strings.NewReplacer("/", `\057`, ":", `\072`)
+
+// MaildirEmailSender drops emails into a local maildir instead of delivering them, so that they can be read with a mail
+// client in development and inspected in tests.
+// See https://cr.yp.to/proto/maildir.html
+type MaildirEmailSender struct {
+	clock clockwork.Clock
+
+	dir  string
+	from string
+}
+
+var _ EmailSender = (*MaildirEmailSender)(nil)
+
+func NewMaildirEmailSender(clock clockwork.Clock, dir, from string) *MaildirEmailSender {
+	return &MaildirEmailSender{
+		clock: clock,
+		dir:   dir,
+		from:  from,
+	}
+}
+
+func (s *MaildirEmailSender) Send(_ context.Context, email *Email) error {
+	from, err := parseAddress(s.from)
+	if err != nil {
+		return err
+	}
+	to, err := parseAddress(email.To)
+	if err != nil {
+		return err
+	}
+
+	now := s.clock.Now()
+
+	msg, err := buildEmailMessage(from, to, email, now)
+	if err != nil {
+		return err
+	}
+
+	for _, sub := range []string{maildirTmp, maildirNew, maildirCur} {
+		if err := os.MkdirAll(filepath.Join(s.dir, sub), 0o700); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	name, err := s.uniqueName(now.UnixNano())
+	if err != nil {
+		return err
+	}
+
+	// written in tmp first, so that readers never see a partially written message in new
+	tmp := filepath.Join(s.dir, maildirTmp, name)
+	if err := os.WriteFile(tmp, msg, 0o600); err != nil {
+		return errors.WithStack(err)
+	}
+	if err := os.Rename(tmp, filepath.Join(s.dir, maildirNew, name)); err != nil {
+		_ = os.Remove(tmp)
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+func (s *MaildirEmailSender) uniqueName(nanos int64) (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "localhost"
+	}
+	return fmt.Sprintf("%d.%s.%s", nanos, hex.EncodeToString(b), maildirHostnameReplacer.Replace(hostname)), nil
+}
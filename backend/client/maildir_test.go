@@ -0,0 +1,67 @@
+package client
+
+import (
+	"context"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaildirEmailSender_Send(t *testing.T) {
+	now := time.Date(2021, 10, 24, 7, 39, 46, 127956672, time.UTC)
+
+	dir := t.TempDir()
+
+	sender := NewMaildirEmailSender(clockwork.NewFakeClockAt(now), dir, "User <no-reply@example.com>")
+
+	err := sender.Send(context.Background(), &Email{
+		To:      "john.doe@example.com",
+		Subject: "Confirm your email address",
+		Body:    "Open the link below to confirm your email address.\n\nhttps://example.com/email/confirm?code=abc\n",
+	})
+	assert.NoError(t, err)
+
+	tmp, err := os.ReadDir(filepath.Join(dir, maildirTmp))
+	assert.NoError(t, err)
+	assert.Empty(t, tmp)
+
+	entries, err := os.ReadDir(filepath.Join(dir, maildirNew))
+	assert.NoError(t, err)
+	if !assert.Len(t, entries, 1) {
+		return
+	}
+
+	f, err := os.Open(filepath.Join(dir, maildirNew, entries[0].Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	msg, err := mail.ReadMessage(f)
+	assert.NoError(t, err)
+	assert.Equal(t, `"User" <no-reply@example.com>`, msg.Header.Get("From"))
+	assert.Equal(t, "<john.doe@example.com>", msg.Header.Get("To"))
+	assert.Equal(t, "Confirm your email address", msg.Header.Get("Subject"))
+	assert.Equal(t, "Sun, 24 Oct 2021 07:39:46 +0000", msg.Header.Get("Date"))
+	assert.Regexp(t, `^<[0-9a-f]{32}@example\.com>$`, msg.Header.Get("Message-Id"))
+}
+
+func TestMaildirEmailSender_Send_InvalidAddress(t *testing.T) {
+	dir := t.TempDir()
+
+	sender := NewMaildirEmailSender(clockwork.NewFakeClock(), dir, "no-reply@example.com")
+
+	err := sender.Send(context.Background(), &Email{
+		To:      "john.doe@example.com\r\nBcc: jane.doe@example.com",
+		Subject: "Confirm your email address",
+	})
+	assert.Error(t, err)
+
+	_, err = os.Stat(filepath.Join(dir, maildirNew))
+	assert.True(t, os.IsNotExist(err))
+}
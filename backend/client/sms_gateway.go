@@ -0,0 +1,164 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/nyaruka/phonenumbers"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	smsv1 "github.com/sean-ahn/user/proto/gen/go/sms/v1"
+)
+
+var ErrNoSMSProvider = errors.New("no sms provider available")
+
+type SMSProvider struct {
+	Name   string
+	Client smsv1.SmsServiceClient
+}
+
+type SMSGatewayPolicy struct {
+	// Routes are the names of the providers in priority order per CLDR region code of the recipients. The
+	// recipients of the other regions are sent with all the providers in the order they are given.
+	Routes map[string][]string
+
+	// MaxAttempts is the number of rounds over the providers, the first of which has no backoff
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+
+	// the circuit of a provider opens after FailureThreshold consecutive failures for OpenDuration.
+	// it is disabled if FailureThreshold is zero.
+	FailureThreshold int
+	OpenDuration     time.Duration
+}
+
+// SMSGateway sends with the first provider which succeeds, skipping the ones whose circuit is open, and tries again
+// with exponential backoff if all of them fail.
+type SMSGateway struct {
+	clock     clockwork.Clock
+	providers []*smsGatewayProvider
+	routes    map[string][]*smsGatewayProvider
+	policy    SMSGatewayPolicy
+}
+
+type smsGatewayProvider struct {
+	SMSProvider
+	breaker *circuitBreaker
+}
+
+var _ smsv1.SmsServiceClient = (*SMSGateway)(nil)
+
+// NewSMSGateway returns a gateway over the providers, which are given in priority order.
+func NewSMSGateway(clock clockwork.Clock, providers []SMSProvider, policy SMSGatewayPolicy) (*SMSGateway, error) {
+	if len(providers) == 0 {
+		return nil, errors.WithStack(ErrNoSMSProvider)
+	}
+
+	g := &SMSGateway{
+		clock:  clock,
+		routes: map[string][]*smsGatewayProvider{},
+		policy: policy,
+	}
+
+	byName := map[string]*smsGatewayProvider{}
+	for _, p := range providers {
+		if _, ok := byName[p.Name]; ok {
+			return nil, errors.Errorf("duplicate sms provider: %s", p.Name)
+		}
+		gp := &smsGatewayProvider{
+			SMSProvider: p,
+			breaker:     newCircuitBreaker(clock, policy.FailureThreshold, policy.OpenDuration),
+		}
+		byName[p.Name] = gp
+		g.providers = append(g.providers, gp)
+	}
+
+	for region, names := range policy.Routes {
+		for _, name := range names {
+			p, ok := byName[name]
+			if !ok {
+				return nil, errors.Errorf("unknown sms provider in the route of %s: %s", region, name)
+			}
+			g.routes[region] = append(g.routes[region], p)
+		}
+	}
+
+	return g, nil
+}
+
+func (g *SMSGateway) Send(ctx context.Context, in *smsv1.SendRequest, opts ...grpc.CallOption) (*smsv1.SendResponse, error) {
+	providers := g.route(in.To)
+
+	lastErr := ErrNoSMSProvider
+	backoff := g.policy.InitialBackoff
+	for attempt := 0; attempt == 0 || attempt < g.policy.MaxAttempts; attempt++ {
+		if attempt > 0 {
+			if err := g.sleep(ctx, backoff); err != nil {
+				return nil, err
+			}
+			if backoff *= 2; backoff > g.policy.MaxBackoff {
+				backoff = g.policy.MaxBackoff
+			}
+		}
+
+		for _, p := range providers {
+			if !p.breaker.Allow() {
+				continue
+			}
+
+			resp, err := p.Client.Send(ctx, in, opts...)
+			if err == nil {
+				p.breaker.Success()
+				return resp, nil
+			}
+			if !IsRetryableSMSError(err) {
+				// the provider is up but rejects the message, which the others would do too
+				p.breaker.Success()
+				return nil, errors.WithStack(err)
+			}
+
+			p.breaker.Failure()
+			logrus.WithError(err).WithField("provider", p.Name).Warn("failed to send sms")
+			lastErr = err
+
+			if ctx.Err() != nil {
+				return nil, errors.WithStack(ctx.Err())
+			}
+		}
+	}
+	return nil, errors.WithStack(lastErr)
+}
+
+func (g *SMSGateway) route(phoneNumber string) []*smsGatewayProvider {
+	if p, err := phonenumbers.Parse(phoneNumber, ""); err == nil {
+		if providers, ok := g.routes[phonenumbers.GetRegionCodeForNumber(p)]; ok {
+			return providers
+		}
+	}
+	return g.providers
+}
+
+func (g *SMSGateway) sleep(ctx context.Context, d time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return errors.WithStack(ctx.Err())
+	case <-g.clock.After(d):
+		return nil
+	}
+}
+
+// IsRetryableSMSError returns whether the error of sending an SMS is temporary, so that it may succeed if tried again.
+func IsRetryableSMSError(err error) bool {
+	switch status.Code(errors.Cause(err)) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted, codes.Internal, codes.Unknown:
+		return true
+	default:
+		return false
+	}
+}
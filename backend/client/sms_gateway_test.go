@@ -0,0 +1,242 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jonboulle/clockwork"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	smsv1 "github.com/sean-ahn/user/proto/gen/go/sms/v1"
+)
+
+func TestSMSGateway_Send(t *testing.T) {
+	var (
+		errUnavailable = status.Error(codes.Unavailable, "unavailable")
+		errInvalid     = status.Error(codes.InvalidArgument, "invalid")
+	)
+
+	type expectFunc func(ctx context.Context, req *smsv1.SendRequest) func(*MockSmsServiceClient)
+
+	succeed := func(ctx context.Context, req *smsv1.SendRequest) func(*MockSmsServiceClient) {
+		return func(mock *MockSmsServiceClient) {
+			mock.EXPECT().Send(ctx, req).Return(&smsv1.SendResponse{}, nil)
+		}
+	}
+	fail := func(err error, times int) expectFunc {
+		return func(ctx context.Context, req *smsv1.SendRequest) func(*MockSmsServiceClient) {
+			return func(mock *MockSmsServiceClient) {
+				mock.EXPECT().Send(ctx, req).Return(nil, err).Times(times)
+			}
+		}
+	}
+	// gomock matches the calls to the expectations in the order they are declared
+	then := func(fs ...expectFunc) expectFunc {
+		return func(ctx context.Context, req *smsv1.SendRequest) func(*MockSmsServiceClient) {
+			return func(mock *MockSmsServiceClient) {
+				for _, f := range fs {
+					f(ctx, req)(mock)
+				}
+			}
+		}
+	}
+
+	cases := []struct {
+		name   string
+		to     string
+		routes map[string][]string
+
+		primaryExpectFunc   expectFunc
+		secondaryExpectFunc expectFunc
+
+		expectedErr error
+	}{
+		{
+			name:              "primary",
+			to:                "+821012345678",
+			primaryExpectFunc: succeed,
+		},
+		{
+			name:                "failover to secondary",
+			to:                  "+821012345678",
+			primaryExpectFunc:   fail(errUnavailable, 1),
+			secondaryExpectFunc: succeed,
+		},
+		{
+			name:                "retry after all failed",
+			to:                  "+821012345678",
+			primaryExpectFunc:   then(fail(errUnavailable, 1), succeed),
+			secondaryExpectFunc: fail(errUnavailable, 1),
+		},
+		{
+			name:                "all attempts failed",
+			to:                  "+821012345678",
+			primaryExpectFunc:   fail(errUnavailable, 2),
+			secondaryExpectFunc: fail(errUnavailable, 2),
+			expectedErr:         errUnavailable,
+		},
+		{
+			name:              "not retryable",
+			to:                "+821012345678",
+			primaryExpectFunc: fail(errInvalid, 1),
+			expectedErr:       errInvalid,
+		},
+		{
+			name:                "routed by region",
+			to:                  "+819012345678",
+			routes:              map[string][]string{"JP": {"secondary"}},
+			secondaryExpectFunc: succeed,
+		},
+		{
+			name:              "not routed region",
+			to:                "+821012345678",
+			routes:            map[string][]string{"JP": {"secondary"}},
+			primaryExpectFunc: succeed,
+		},
+		{
+			name:                "failover in route",
+			to:                  "+819012345678",
+			routes:              map[string][]string{"JP": {"secondary", "primary"}},
+			secondaryExpectFunc: fail(errUnavailable, 1),
+			primaryExpectFunc:   succeed,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			req := &smsv1.SendRequest{To: tc.to, Message: "123456 is your authentication code."}
+
+			ctrl := gomock.NewController(t)
+
+			primary := NewMockSmsServiceClient(ctrl)
+			if tc.primaryExpectFunc != nil {
+				tc.primaryExpectFunc(ctx, req)(primary)
+			}
+			secondary := NewMockSmsServiceClient(ctrl)
+			if tc.secondaryExpectFunc != nil {
+				tc.secondaryExpectFunc(ctx, req)(secondary)
+			}
+
+			gateway, err := NewSMSGateway(clockwork.NewFakeClock(), []SMSProvider{
+				{Name: "primary", Client: primary},
+				{Name: "secondary", Client: secondary},
+			}, SMSGatewayPolicy{
+				Routes:      tc.routes,
+				MaxAttempts: 2,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = gateway.Send(ctx, req)
+
+			if tc.expectedErr != nil {
+				assert.Equal(t, tc.expectedErr, errors.Cause(err))
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSMSGateway_Send_Backoff(t *testing.T) {
+	ctx := context.Background()
+	req := &smsv1.SendRequest{To: "+821012345678", Message: "123456 is your authentication code."}
+
+	ctrl := gomock.NewController(t)
+
+	provider := NewMockSmsServiceClient(ctrl)
+	gomock.InOrder(
+		provider.EXPECT().Send(ctx, req).Return(nil, status.Error(codes.Unavailable, "unavailable")).Times(2),
+		provider.EXPECT().Send(ctx, req).Return(&smsv1.SendResponse{}, nil),
+	)
+
+	clock := clockwork.NewFakeClock()
+
+	gateway, err := NewSMSGateway(clock, []SMSProvider{{Name: "provider", Client: provider}}, SMSGatewayPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 200 * time.Millisecond,
+		MaxBackoff:     300 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan error)
+	go func() {
+		_, err := gateway.Send(ctx, req)
+		done <- err
+	}()
+
+	clock.BlockUntil(1)
+	clock.Advance(199 * time.Millisecond)
+	select {
+	case <-done:
+		t.Fatal("sent before the backoff")
+	default:
+	}
+	clock.Advance(time.Millisecond)
+
+	clock.BlockUntil(1)
+	clock.Advance(300 * time.Millisecond) // capped by MaxBackoff
+
+	assert.NoError(t, <-done)
+}
+
+func TestSMSGateway_Send_CircuitOpen(t *testing.T) {
+	ctx := context.Background()
+	req := &smsv1.SendRequest{To: "+821012345678", Message: "123456 is your authentication code."}
+
+	ctrl := gomock.NewController(t)
+
+	primary := NewMockSmsServiceClient(ctrl)
+	primary.EXPECT().Send(ctx, req).Return(nil, status.Error(codes.Unavailable, "unavailable")).Times(2)
+
+	secondary := NewMockSmsServiceClient(ctrl)
+	secondary.EXPECT().Send(ctx, req).Return(&smsv1.SendResponse{}, nil).Times(3)
+
+	gateway, err := NewSMSGateway(clockwork.NewFakeClock(), []SMSProvider{
+		{Name: "primary", Client: primary},
+		{Name: "secondary", Client: secondary},
+	}, SMSGatewayPolicy{
+		MaxAttempts:      1,
+		FailureThreshold: 2,
+		OpenDuration:     30 * time.Second,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		_, err := gateway.Send(ctx, req)
+		assert.NoError(t, err)
+	}
+}
+
+func TestNewSMSGateway(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	provider := NewMockSmsServiceClient(ctrl)
+
+	_, err := NewSMSGateway(clockwork.NewFakeClock(), nil, SMSGatewayPolicy{})
+	assert.Equal(t, ErrNoSMSProvider, errors.Cause(err))
+
+	_, err = NewSMSGateway(clockwork.NewFakeClock(), []SMSProvider{
+		{Name: "provider", Client: provider},
+		{Name: "provider", Client: provider},
+	}, SMSGatewayPolicy{})
+	assert.EqualError(t, err, "duplicate sms provider: provider")
+
+	_, err = NewSMSGateway(clockwork.NewFakeClock(), []SMSProvider{
+		{Name: "provider", Client: provider},
+	}, SMSGatewayPolicy{Routes: map[string][]string{"KR": {"unknown"}}})
+	assert.EqualError(t, err, "unknown sms provider in the route of KR: unknown")
+}
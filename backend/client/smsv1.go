@@ -4,46 +4,44 @@ package client
 
 import (
 	"context"
-	"sync"
 
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 
 	smsv1 "github.com/sean-ahn/user/proto/gen/go/sms/v1"
 )
 
-const smsServiceConfig = `{"loadBalancingPolicy":"round_robin"}`
-
-var (
-	smsOnce sync.Once
-	smsCli  smsv1.SmsServiceClient
-
-	_ smsv1.SmsServiceClient = (*MockSmsServiceClient)(nil)
+const (
+	SMSSenderMock    = "mock"
+	SMSSenderGateway = "gateway"
 )
 
-func GetSmsV1Service(serviceHost string) smsv1.SmsServiceClient {
-	smsOnce.Do(func() {
-		conn, err := grpc.Dial(
-			serviceHost,
-			grpc.WithInsecure(),
-			grpc.WithDefaultServiceConfig(smsServiceConfig),
-		)
-		if err != nil {
-			logrus.Panic(err)
-		}
-
-		smsCli = smsv1.NewSmsServiceClient(conn)
-	})
-
-	return smsCli
+const smsServiceConfig = `{"loadBalancingPolicy":"round_robin"}`
+
+var _ smsv1.SmsServiceClient = (*MockSmsServiceClient)(nil)
+
+// DialSmsV1Service returns the client of the SMS service at the endpoint, which connects lazily.
+func DialSmsV1Service(serviceHost string) (smsv1.SmsServiceClient, error) {
+	conn, err := grpc.Dial(
+		serviceHost,
+		grpc.WithInsecure(),
+		grpc.WithDefaultServiceConfig(smsServiceConfig),
+	)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return smsv1.NewSmsServiceClient(conn), nil
 }
 
+// mockSmsV1ServiceClient only logs messages instead of delivering them, so that OTP codes can be read in development.
 type mockSmsV1ServiceClient struct{}
 
-func (c *mockSmsV1ServiceClient) Send(_ context.Context, _ *smsv1.SendRequest, _ ...grpc.CallOption) (*smsv1.SendResponse, error) {
+func (c *mockSmsV1ServiceClient) Send(_ context.Context, in *smsv1.SendRequest, _ ...grpc.CallOption) (*smsv1.SendResponse, error) {
+	logrus.WithField("to", in.To).Info(in.Message)
 	return &smsv1.SendResponse{}, nil
 }
 
-func GetMockSmsV1Service(_ string) smsv1.SmsServiceClient {
+func GetMockSmsV1Service() smsv1.SmsServiceClient {
 	return &mockSmsV1ServiceClient{}
 }
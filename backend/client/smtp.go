@@ -0,0 +1,109 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/smtp"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/pkg/errors"
+)
+
+// SMTPEmailSender delivers emails to an SMTP server, upgrading the connection with STARTTLS if the server supports it.
+type SMTPEmailSender struct {
+	clock clockwork.Clock
+
+	addr     string
+	username string
+	password string
+	from     string
+}
+
+var _ EmailSender = (*SMTPEmailSender)(nil)
+
+// NewSMTPEmailSender returns a sender which authenticates with PLAIN if username is not empty.
+func NewSMTPEmailSender(clock clockwork.Clock, addr, username, password, from string) *SMTPEmailSender {
+	return &SMTPEmailSender{
+		clock:    clock,
+		addr:     addr,
+		username: username,
+		password: password,
+		from:     from,
+	}
+}
+
+func (s *SMTPEmailSender) Send(ctx context.Context, email *Email) error {
+	from, err := parseAddress(s.from)
+	if err != nil {
+		return err
+	}
+	to, err := parseAddress(email.To)
+	if err != nil {
+		return err
+	}
+
+	msg, err := buildEmailMessage(from, to, email, s.clock.Now())
+	if err != nil {
+		return err
+	}
+
+	host, _, err := net.SplitHostPort(s.addr)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", s.addr)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			_ = conn.Close()
+			return errors.WithStack(err)
+		}
+	}
+
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		_ = conn.Close()
+		return errors.WithStack(err)
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	if s.username != "" {
+		if err := c.Auth(smtp.PlainAuth("", s.username, s.password, host)); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	if err := c.Mail(from.Address); err != nil {
+		return errors.WithStack(err)
+	}
+	if err := c.Rcpt(to.Address); err != nil {
+		return errors.WithStack(err)
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if _, err := w.Write(msg); err != nil {
+		return errors.WithStack(err)
+	}
+	if err := w.Close(); err != nil {
+		return errors.WithStack(err)
+	}
+
+	if err := c.Quit(); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
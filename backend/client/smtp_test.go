@@ -0,0 +1,136 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+)
+
+// testSMTPServer accepts a single message without authentication and records it.
+type testSMTPServer struct {
+	lis net.Listener
+
+	from string
+	to   []string
+	data string
+}
+
+func newTestSMTPServer(t *testing.T) *testSMTPServer {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = lis.Close() })
+
+	return &testSMTPServer{lis: lis}
+}
+
+func (s *testSMTPServer) serve() error {
+	conn, err := s.lis.Accept()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	reply := func(line string) error {
+		_, err := io.WriteString(conn, line+"\r\n")
+		return err
+	}
+
+	if err := reply("220 localhost ESMTP"); err != nil {
+		return err
+	}
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return err
+		}
+		line = strings.TrimRight(line, "\r\n")
+
+		cmd := strings.ToUpper(line)
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"):
+			err = reply("250 localhost")
+		case strings.HasPrefix(cmd, "MAIL FROM:"):
+			s.from = line[len("MAIL FROM:"):]
+			err = reply("250 OK")
+		case strings.HasPrefix(cmd, "RCPT TO:"):
+			s.to = append(s.to, line[len("RCPT TO:"):])
+			err = reply("250 OK")
+		case cmd == "DATA":
+			if err := reply("354 End data with <CR><LF>.<CR><LF>"); err != nil {
+				return err
+			}
+			var b strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return err
+				}
+				if l == ".\r\n" {
+					break
+				}
+				b.WriteString(l)
+			}
+			s.data = b.String()
+			err = reply("250 OK")
+		case cmd == "QUIT":
+			return reply("221 Bye")
+		default:
+			err = reply("502 Command not implemented")
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func TestSMTPEmailSender_Send(t *testing.T) {
+	now := time.Date(2021, 10, 24, 7, 39, 46, 127956672, time.UTC)
+
+	server := newTestSMTPServer(t)
+
+	done := make(chan error, 1)
+	go func() { done <- server.serve() }()
+
+	sender := NewSMTPEmailSender(clockwork.NewFakeClockAt(now), server.lis.Addr().String(), "", "", "no-reply@example.com")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := sender.Send(ctx, &Email{
+		To:      "john.doe@example.com",
+		Subject: "이메일 주소를 확인해주세요",
+		Body:    "Open the link below to confirm your email address.\n\nhttps://example.com/email/confirm?code=abc\n",
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, <-done)
+
+	assert.Equal(t, "<no-reply@example.com>", server.from)
+	assert.Equal(t, []string{"<john.doe@example.com>"}, server.to)
+
+	msg, err := mail.ReadMessage(strings.NewReader(server.data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	assert.NoError(t, err)
+	assert.Equal(t, "이메일 주소를 확인해주세요", subject)
+	assert.Equal(t, "text/plain; charset=utf-8", msg.Header.Get("Content-Type"))
+
+	body, err := io.ReadAll(quotedprintable.NewReader(msg.Body))
+	assert.NoError(t, err)
+	assert.Equal(t, "Open the link below to confirm your email address.\r\n\r\nhttps://example.com/email/confirm?code=abc\r\n", string(body))
+}
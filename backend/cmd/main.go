@@ -2,17 +2,18 @@ package main
 
 import (
 	"context"
-	"encoding/base64"
-	"math/rand"
+	"fmt"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/jonboulle/clockwork"
+	"github.com/nyaruka/phonenumbers"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 
@@ -21,11 +22,9 @@ import (
 	"github.com/sean-ahn/user/backend/crypto"
 	"github.com/sean-ahn/user/backend/persistence/mysql"
 	"github.com/sean-ahn/user/backend/server"
+	"github.com/sean-ahn/user/backend/server/message"
 	"github.com/sean-ahn/user/backend/server/service"
-)
-
-const (
-	passwordSalt = "DdUvj62VZaFEJkHOQkxT1A=="
+	smsv1 "github.com/sean-ahn/user/proto/gen/go/sms/v1"
 )
 
 func main() {
@@ -41,31 +40,171 @@ func run() error {
 	defer cancel()
 
 	clock := clockwork.NewRealClock()
-	rand.Seed(clock.Now().UnixNano())
 
 	setting := config.NewSetting()
 
 	db := mysql.MustGetDB(setting.DB)
 
-	salt, err := base64.StdEncoding.DecodeString(passwordSalt)
+	if phonenumbers.GetCountryCodeForRegion(setting.DefaultPhoneRegion) == 0 {
+		logrus.Panicf("unknown default phone region: %s", setting.DefaultPhoneRegion)
+	}
+
+	passwordHasher, err := crypto.NewMultiHasher(setting.PasswordHashAlgorithm, map[string]crypto.Hasher{
+		crypto.HashAlgorithmScrypt: crypto.NewScryptHasher(setting.PasswordScryptN),
+		crypto.HashAlgorithmArgon2id: crypto.NewArgon2idHasher(
+			uint32(setting.PasswordArgon2idMemoryKiB),
+			uint32(setting.PasswordArgon2idIterations),
+			uint8(setting.PasswordArgon2idParallelism),
+		),
+		crypto.HashAlgorithmBcrypt: crypto.NewBcryptHasher(setting.PasswordBcryptCost),
+	})
 	if err != nil {
 		logrus.Panic(err)
 	}
 
+	dummyPasswordHash, err := crypto.NewDummyHash(passwordHasher)
+	if err != nil {
+		logrus.Panic(err)
+	}
+
+	smsOTPHasher, err := crypto.NewOTPHasher([]byte(setting.SMSOTPHMACSecret))
+	if err != nil {
+		logrus.Panic(err)
+	}
+
+	smsv1Cli, err := newSmsV1Client(clock, setting)
+	if err != nil {
+		logrus.Panic(err)
+	}
+
+	smsOutboxDeliverer := service.NewSMSOutboxDeliverer(clock, db, smsv1Cli, service.SMSOutboxDeliveryPolicy{
+		PollInterval:   time.Duration(setting.SMSOutboxPollIntervalMs) * time.Millisecond,
+		BatchSize:      setting.SMSOutboxBatchSize,
+		MaxAttempts:    setting.SMSOutboxMaxAttempts,
+		InitialBackoff: time.Duration(setting.SMSOutboxInitialBackoffMs) * time.Millisecond,
+		MaxBackoff:     time.Duration(setting.SMSOutboxMaxBackoffMs) * time.Millisecond,
+	})
+	go smsOutboxDeliverer.RunDelivery(ctx)
+
+	smsTemplateFS := message.DefaultTemplates()
+	if setting.SMSTemplateDir != "" {
+		smsTemplateFS = os.DirFS(setting.SMSTemplateDir)
+	}
+	smsTemplates, err := message.LoadRegistry(smsTemplateFS, setting.SMSDefaultLocale)
+	if err != nil {
+		logrus.Panic(err)
+	}
+
+	signingKeyStore, err := service.NewDBSigningKeyStore(clock, db, setting.JWTSigningAlgorithm, service.SigningKeyRotationPolicy{
+		RotationPeriod: time.Duration(setting.JWTSigningKeyRotationPeriodMs) * time.Millisecond,
+		PublishDelay:   time.Duration(setting.JWTSigningKeyPublishDelayMs) * time.Millisecond,
+		RetireDelay:    time.Duration(setting.RefreshTokenExpiresInMs) * time.Millisecond,
+	})
+	if err != nil {
+		logrus.Panic(err)
+	}
+	go signingKeyStore.RunRotation(ctx)
+
 	userTokenService := service.NewJWTTokenService(
 		clock,
 		db,
+		signingKeyStore,
 		time.Duration(setting.AccessTokenExpiresInMs)*time.Millisecond,
 		time.Duration(setting.RefreshTokenExpiresInMs)*time.Millisecond,
 	)
 
+	emailSender, err := newEmailSender(clock, setting)
+	if err != nil {
+		logrus.Panic(err)
+	}
+
+	emailConfirmationService := service.NewDBEmailConfirmationService(
+		clock,
+		db,
+		emailSender,
+		setting.EmailConfirmationURL,
+		time.Duration(setting.EmailConfirmationExpiresInMs)*time.Millisecond,
+		time.Duration(setting.EmailConfirmationResendCooldownMs)*time.Millisecond,
+	)
+
+	signInThrottle := service.NewDBSignInThrottle(clock, db, service.SignInThrottlePolicy{
+		Account: service.SignInThrottleLimit{
+			FreeFailures:     setting.SignInAccountFreeFailures,
+			LockoutThreshold: setting.SignInAccountLockoutThreshold,
+			LockoutDuration:  time.Duration(setting.SignInAccountLockoutMs) * time.Millisecond,
+		},
+		IPAddress: service.SignInThrottleLimit{
+			FreeFailures:     setting.SignInIPFreeFailures,
+			LockoutThreshold: setting.SignInIPLockoutThreshold,
+			LockoutDuration:  time.Duration(setting.SignInIPLockoutMs) * time.Millisecond,
+		},
+		InitialDelay:  time.Duration(setting.SignInInitialDelayMs) * time.Millisecond,
+		MaxDelay:      time.Duration(setting.SignInMaxDelayMs) * time.Millisecond,
+		FailureWindow: time.Duration(setting.SignInFailureWindowMs) * time.Millisecond,
+	})
+
+	mfaService := service.NewDBMFAService(clock, db, emailSender, signInThrottle, setting.TOTPIssuer)
+
+	webAuthnService := service.NewDBWebAuthnService(
+		clock,
+		db,
+		&crypto.WebAuthnRelyingParty{ID: setting.WebAuthnRPID, Origins: setting.WebAuthnOrigins},
+		setting.WebAuthnRPName,
+	)
+
+	smsOTPLimiter := service.NewDBSMSOTPLimiter(clock, db, service.SMSOTPLimitPolicy{
+		AllowedRegions:           setting.SMSOTPAllowedRegions,
+		ResendCooldown:           time.Duration(setting.SMSOTPResendCooldownMs) * time.Millisecond,
+		DailyLimitPerPhoneNumber: setting.SMSOTPDailyLimitPerPhoneNumber,
+		HourlyLimitPerIPAddress:  setting.SMSOTPHourlyLimitPerIPAddress,
+	})
+
+	accountNoticeService := service.NewEmailAccountNoticeService(clock, db, emailSender, time.Duration(setting.AccountNoticeCooldownMs)*time.Millisecond)
+
+	passwordChecker, err := service.NewPasswordChecker(service.PasswordPolicy{
+		MinLength:           setting.PasswordMinLength,
+		MaxLength:           setting.PasswordMaxLength,
+		RequiredCharClasses: setting.PasswordRequiredCharClasses,
+		MinCharClasses:      setting.PasswordMinCharClasses,
+		MinEntropyBits:      float64(setting.PasswordMinEntropyBits),
+		RejectPersonalInfo:  setting.PasswordRejectPersonalInfo,
+	})
+	if err != nil {
+		logrus.Panic(err)
+	}
+
+	passwordHistoryService := service.NewDBPasswordHistoryService(clock, db, passwordHasher, service.PasswordHistoryPolicy{
+		Size:   setting.PasswordHistorySize,
+		MinAge: time.Duration(setting.PasswordMinAgeMs) * time.Millisecond,
+	})
+
+	introspectionClients, err := newIntrospectionClients(setting)
+	if err != nil {
+		logrus.Panic(err)
+	}
+
 	cfg := config.New(
 		setting,
 		clock,
 		db,
-		crypto.NewScryptHasher(salt),
-		client.GetMockSmsV1Service(setting.SMSV1ServiceEndpoint),
+		passwordHasher,
+		dummyPasswordHash,
+		smsOTPHasher,
+		smsv1Cli,
+		smsTemplates,
+		emailSender,
+		signingKeyStore,
+		signingKeyStore,
 		userTokenService,
+		emailConfirmationService,
+		mfaService,
+		webAuthnService,
+		smsOTPLimiter,
+		signInThrottle,
+		accountNoticeService,
+		passwordHistoryService,
+		passwordChecker,
+		introspectionClients,
 	)
 
 	grpcServer, err := server.NewGRPCServer(cfg)
@@ -73,6 +212,11 @@ func run() error {
 		logrus.Panic(err)
 	}
 
+	adminGRPCServer, err := server.NewAdminGRPCServer(cfg)
+	if err != nil {
+		logrus.Panic(err)
+	}
+
 	httpServer, err := server.NewHTTPServer(ctx, cfg)
 	if err != nil {
 		logrus.Panic(err)
@@ -90,6 +234,18 @@ func run() error {
 		}
 	}()
 
+	go func() {
+		lis, err := net.Listen("tcp", ":"+strconv.Itoa(setting.AdminGRPCServerPort))
+		if err != nil {
+			logrus.Panic(err)
+		}
+
+		logrus.WithField("port", setting.AdminGRPCServerPort).Info("starting admin gRPC server")
+		if err := adminGRPCServer.Serve(lis); err != nil && err != grpc.ErrServerStopped {
+			logrus.Panic(err)
+		}
+	}()
+
 	go func() {
 		logrus.WithField("port", setting.HTTPServerPort).Info("starting HTTP server")
 		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
@@ -113,5 +269,83 @@ func run() error {
 	logrus.Info("stopping gRPC server")
 	grpcServer.GracefulStop()
 
+	logrus.Info("stopping admin gRPC server")
+	adminGRPCServer.GracefulStop()
+
 	return nil
 }
+
+func newSmsV1Client(clock clockwork.Clock, setting config.Setting) (smsv1.SmsServiceClient, error) {
+	switch setting.SMSSender {
+	case client.SMSSenderMock:
+		return client.GetMockSmsV1Service(), nil
+	case client.SMSSenderGateway:
+		var providers []client.SMSProvider
+		for _, s := range setting.SMSProviders {
+			name, endpoint, ok := cutEnvPair(s)
+			if !ok {
+				return nil, fmt.Errorf("invalid sms provider: %s", s)
+			}
+			cli, err := client.DialSmsV1Service(endpoint)
+			if err != nil {
+				return nil, err
+			}
+			providers = append(providers, client.SMSProvider{Name: name, Client: cli})
+		}
+
+		routes := map[string][]string{}
+		for _, s := range setting.SMSProviderRoutes {
+			region, names, ok := cutEnvPair(s)
+			if !ok {
+				return nil, fmt.Errorf("invalid sms provider route: %s", s)
+			}
+			routes[strings.ToUpper(region)] = strings.Split(names, "|")
+		}
+
+		return client.NewSMSGateway(clock, providers, client.SMSGatewayPolicy{
+			Routes:           routes,
+			MaxAttempts:      setting.SMSMaxAttempts,
+			InitialBackoff:   time.Duration(setting.SMSInitialBackoffMs) * time.Millisecond,
+			MaxBackoff:       time.Duration(setting.SMSMaxBackoffMs) * time.Millisecond,
+			FailureThreshold: setting.SMSCircuitFailureThreshold,
+			OpenDuration:     time.Duration(setting.SMSCircuitOpenMs) * time.Millisecond,
+		})
+	default:
+		return nil, fmt.Errorf("unknown sms sender: %s", setting.SMSSender)
+	}
+}
+
+func newIntrospectionClients(setting config.Setting) (map[string]string, error) {
+	clients := map[string]string{}
+	for _, s := range setting.IntrospectionClients {
+		id, secret, ok := cutEnvPair(s)
+		if !ok {
+			// not printed, since it has the secret
+			return nil, fmt.Errorf("invalid introspection client, not in <client_id>=<secret>")
+		}
+		clients[id] = secret
+	}
+	return clients, nil
+}
+
+// cutEnvPair splits `<key>=<value>` of the list environment variables.
+func cutEnvPair(s string) (key, value string, ok bool) {
+	i := strings.Index(s, "=")
+	if i <= 0 || i == len(s)-1 {
+		return "", "", false
+	}
+	return s[:i], s[i+1:], true
+}
+
+func newEmailSender(clock clockwork.Clock, setting config.Setting) (client.EmailSender, error) {
+	switch setting.EmailSender {
+	case client.EmailSenderMock:
+		return client.GetMockEmailSender(), nil
+	case client.EmailSenderSMTP:
+		return client.NewSMTPEmailSender(clock, setting.SMTPAddr, setting.SMTPUsername, setting.SMTPPassword, setting.EmailFrom), nil
+	case client.EmailSenderMaildir:
+		return client.NewMaildirEmailSender(clock, setting.EmailMaildirPath, setting.EmailFrom), nil
+	default:
+		return nil, fmt.Errorf("unknown email sender: %s", setting.EmailSender)
+	}
+}
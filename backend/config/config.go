@@ -5,7 +5,9 @@ import (
 
 	"github.com/jonboulle/clockwork"
 
+	"github.com/sean-ahn/user/backend/client"
 	"github.com/sean-ahn/user/backend/crypto"
+	"github.com/sean-ahn/user/backend/server/message"
 	"github.com/sean-ahn/user/backend/server/service"
 	smsv1 "github.com/sean-ahn/user/proto/gen/go/sms/v1"
 )
@@ -16,17 +18,48 @@ type Config interface {
 	Clock() clockwork.Clock
 	DB() *sql.DB
 	PasswordHasher() crypto.Hasher
+	DummyPasswordHash() []byte
+	SMSOTPHasher() *crypto.OTPHasher
 	SmsV1Client() smsv1.SmsServiceClient
+	SMSTemplates() *message.Registry
+	EmailSender() client.EmailSender
+	SigningKeyStore() service.SigningKeyStore
+	SigningKeyRotator() service.SigningKeyRotator
 	UserTokenService() service.UserTokenService
+	EmailConfirmationService() service.EmailConfirmationService
+	MFAService() service.MFAService
+	WebAuthnService() service.WebAuthnService
+	SMSOTPLimiter() service.SMSOTPLimiter
+	SignInThrottle() service.SignInThrottle
+	AccountNoticeService() service.AccountNoticeService
+	PasswordHistoryService() service.PasswordHistoryService
+	PasswordChecker() *service.PasswordChecker
+	// IntrospectionClients is the secrets of the resource servers allowed to introspect tokens by the client ID.
+	IntrospectionClients() map[string]string
 }
 
 type DefaultConfig struct {
-	setting          Setting
-	clock            clockwork.Clock
-	db               *sql.DB
-	passwordHasher   crypto.Hasher
-	smsv1Cli         smsv1.SmsServiceClient
-	userTokenService service.UserTokenService
+	setting                  Setting
+	clock                    clockwork.Clock
+	db                       *sql.DB
+	passwordHasher           crypto.Hasher
+	dummyPasswordHash        []byte
+	smsOTPHasher             *crypto.OTPHasher
+	smsv1Cli                 smsv1.SmsServiceClient
+	smsTemplates             *message.Registry
+	emailSender              client.EmailSender
+	signingKeyStore          service.SigningKeyStore
+	signingKeyRotator        service.SigningKeyRotator
+	userTokenService         service.UserTokenService
+	emailConfirmationService service.EmailConfirmationService
+	mfaService               service.MFAService
+	webAuthnService          service.WebAuthnService
+	smsOTPLimiter            service.SMSOTPLimiter
+	signInThrottle           service.SignInThrottle
+	accountNoticeService     service.AccountNoticeService
+	passwordHistoryService   service.PasswordHistoryService
+	passwordChecker          *service.PasswordChecker
+	introspectionClients     map[string]string
 }
 
 var _ Config = (*DefaultConfig)(nil)
@@ -47,28 +80,118 @@ func (c *DefaultConfig) PasswordHasher() crypto.Hasher {
 	return c.passwordHasher
 }
 
+func (c *DefaultConfig) DummyPasswordHash() []byte {
+	return c.dummyPasswordHash
+}
+
+func (c *DefaultConfig) SMSOTPHasher() *crypto.OTPHasher {
+	return c.smsOTPHasher
+}
+
 func (c *DefaultConfig) SmsV1Client() smsv1.SmsServiceClient {
 	return c.smsv1Cli
 }
 
+func (c *DefaultConfig) SMSTemplates() *message.Registry {
+	return c.smsTemplates
+}
+
+func (c *DefaultConfig) EmailSender() client.EmailSender {
+	return c.emailSender
+}
+
+func (c *DefaultConfig) SigningKeyStore() service.SigningKeyStore {
+	return c.signingKeyStore
+}
+
+func (c *DefaultConfig) SigningKeyRotator() service.SigningKeyRotator {
+	return c.signingKeyRotator
+}
+
 func (c *DefaultConfig) UserTokenService() service.UserTokenService {
 	return c.userTokenService
 }
 
+func (c *DefaultConfig) EmailConfirmationService() service.EmailConfirmationService {
+	return c.emailConfirmationService
+}
+
+func (c *DefaultConfig) MFAService() service.MFAService {
+	return c.mfaService
+}
+
+func (c *DefaultConfig) WebAuthnService() service.WebAuthnService {
+	return c.webAuthnService
+}
+
+func (c *DefaultConfig) SMSOTPLimiter() service.SMSOTPLimiter {
+	return c.smsOTPLimiter
+}
+
+func (c *DefaultConfig) SignInThrottle() service.SignInThrottle {
+	return c.signInThrottle
+}
+
+func (c *DefaultConfig) AccountNoticeService() service.AccountNoticeService {
+	return c.accountNoticeService
+}
+
+func (c *DefaultConfig) PasswordHistoryService() service.PasswordHistoryService {
+	return c.passwordHistoryService
+}
+
+func (c *DefaultConfig) PasswordChecker() *service.PasswordChecker {
+	return c.passwordChecker
+}
+
+func (c *DefaultConfig) IntrospectionClients() map[string]string {
+	return c.introspectionClients
+}
+
 func New(
 	setting Setting,
 	clock clockwork.Clock,
 	db *sql.DB,
 	passwordHasher crypto.Hasher,
+	dummyPasswordHash []byte,
+	smsOTPHasher *crypto.OTPHasher,
 	smsv1Cli smsv1.SmsServiceClient,
+	smsTemplates *message.Registry,
+	emailSender client.EmailSender,
+	signingKeyStore service.SigningKeyStore,
+	signingKeyRotator service.SigningKeyRotator,
 	userTokenService service.UserTokenService,
+	emailConfirmationService service.EmailConfirmationService,
+	mfaService service.MFAService,
+	webAuthnService service.WebAuthnService,
+	smsOTPLimiter service.SMSOTPLimiter,
+	signInThrottle service.SignInThrottle,
+	accountNoticeService service.AccountNoticeService,
+	passwordHistoryService service.PasswordHistoryService,
+	passwordChecker *service.PasswordChecker,
+	introspectionClients map[string]string,
 ) *DefaultConfig {
 	return &DefaultConfig{
-		setting:          setting,
-		clock:            clock,
-		db:               db,
-		passwordHasher:   passwordHasher,
-		smsv1Cli:         smsv1Cli,
-		userTokenService: userTokenService,
+		setting:                  setting,
+		clock:                    clock,
+		db:                       db,
+		passwordHasher:           passwordHasher,
+		dummyPasswordHash:        dummyPasswordHash,
+		smsOTPHasher:             smsOTPHasher,
+		smsv1Cli:                 smsv1Cli,
+		smsTemplates:             smsTemplates,
+		emailSender:              emailSender,
+		signingKeyStore:          signingKeyStore,
+		signingKeyRotator:        signingKeyRotator,
+		userTokenService:         userTokenService,
+		emailConfirmationService: emailConfirmationService,
+		mfaService:               mfaService,
+		webAuthnService:          webAuthnService,
+		smsOTPLimiter:            smsOTPLimiter,
+		signInThrottle:           signInThrottle,
+		accountNoticeService:     accountNoticeService,
+		passwordHistoryService:   passwordHistoryService,
+		passwordChecker:          passwordChecker,
+		introspectionClients:     introspectionClients,
 	}
 }
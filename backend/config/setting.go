@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/sirupsen/logrus"
 
@@ -12,24 +13,108 @@ import (
 type Setting struct {
 	GRPCServerPort            int
 	HTTPServerPort            int
+	AdminGRPCServerPort       int
 	GracefulShutdownTimeoutMs int
 
+	TrustedProxyCIDRs []string
+
 	DB mysql.Setting
 
-	AccessTokenExpiresInMs  int
-	RefreshTokenExpiresInMs int
+	IntrospectionClients []string
+
+	AccessTokenExpiresInMs        int
+	RefreshTokenExpiresInMs       int
+	JWTSigningAlgorithm           string
+	JWTSigningKeyRotationPeriodMs int
+	JWTSigningKeyPublishDelayMs   int
+
+	PasswordHashAlgorithm       string
+	PasswordScryptN             int
+	PasswordArgon2idMemoryKiB   int
+	PasswordArgon2idIterations  int
+	PasswordArgon2idParallelism int
+	PasswordBcryptCost          int
+
+	PasswordHistorySize int
+	PasswordMinAgeMs    int
+
+	PasswordMinLength           int
+	PasswordMaxLength           int
+	PasswordRequiredCharClasses []string
+	PasswordMinCharClasses      int
+	PasswordMinEntropyBits      int
+	PasswordRejectPersonalInfo  bool
+
+	EmailSender      string
+	EmailFrom        string
+	SMTPAddr         string
+	SMTPUsername     string
+	SMTPPassword     string
+	EmailMaildirPath string
+
+	EmailConfirmationURL              string
+	EmailConfirmationExpiresInMs      int
+	EmailConfirmationResendCooldownMs int
+
+	TOTPIssuer string
+
+	WebAuthnRPID    string
+	WebAuthnRPName  string
+	WebAuthnOrigins []string
+
+	DefaultPhoneRegion string
 
 	SMSOTPCodeLength int
+	SMSOTPHMACSecret string
+
+	SMSOTPAllowedRegions           []string
+	SMSOTPResendCooldownMs         int
+	SMSOTPDailyLimitPerPhoneNumber int
+	SMSOTPHourlyLimitPerIPAddress  int
 
-	SMSV1ServiceEndpoint string
+	SMSTemplateDir    string
+	SMSDefaultLocale  string
+	SMSWebOTPDomain   string
+	SMSAndroidAppHash string
+
+	SMSSender                  string
+	SMSProviders               []string
+	SMSProviderRoutes          []string
+	SMSMaxAttempts             int
+	SMSInitialBackoffMs        int
+	SMSMaxBackoffMs            int
+	SMSCircuitFailureThreshold int
+	SMSCircuitOpenMs           int
+
+	SMSOutboxPollIntervalMs   int
+	SMSOutboxBatchSize        int
+	SMSOutboxMaxAttempts      int
+	SMSOutboxInitialBackoffMs int
+	SMSOutboxMaxBackoffMs     int
+
+	SignInAccountFreeFailures     int
+	SignInAccountLockoutThreshold int
+	SignInAccountLockoutMs        int
+	SignInIPFreeFailures          int
+	SignInIPLockoutThreshold      int
+	SignInIPLockoutMs             int
+	SignInInitialDelayMs          int
+	SignInMaxDelayMs              int
+	SignInFailureWindowMs         int
+
+	EnumerationResistant    bool
+	AccountNoticeCooldownMs int
 }
 
 func NewSetting() Setting {
 	return Setting{
 		GRPCServerPort:            mustAtoi(getEnv("GRPC_SERVER_PORT", "8080")),
 		HTTPServerPort:            mustAtoi(getEnv("HTTP_SERVER_PORT", "8081")),
+		AdminGRPCServerPort:       mustAtoi(getEnv("ADMIN_GRPC_SERVER_PORT", "8082")), // UserAdminService without authentication. never expose it publicly
 		GracefulShutdownTimeoutMs: mustAtoi(getEnv("GRACEFUL_SHUTDOWN_TIMEOUT_MS", "3000")),
 
+		TrustedProxyCIDRs: strings.Split(getEnv("TRUSTED_PROXY_CIDRS", "127.0.0.1/32,::1/128"), ","), // comma separated. X-Forwarded-For is trusted only from these, the HTTP gateway on localhost by default
+
 		DB: mysql.Setting{
 			Host:              getEnv("DB_HOST", "localhost"),
 			Port:              mustAtoi(getEnv("DB_PORT", "3306")),
@@ -41,11 +126,90 @@ func NewSetting() Setting {
 			ConnMaxLifetimeMs: mustAtoi(getEnv("DB_CONN_MAX_LIFETIME_MS", "14400000")),
 		},
 
-		AccessTokenExpiresInMs:  mustAtoi(getEnv("ACCESS_TOKEN_EXPIRES_IN_MS", "600000")),      // 10 min
-		RefreshTokenExpiresInMs: mustAtoi(getEnv("REFRESH_TOKEN_EXPIRES_IN_MS", "1209600000")), // 14 days
+		IntrospectionClients: splitOptionalEnv(getOptionalEnv("INTROSPECTION_CLIENTS")), // comma separated <client_id>=<secret> of the resource servers allowed to call IntrospectToken, e.g. api=s3cr3t. none if empty
+
+		AccessTokenExpiresInMs:        mustAtoi(getEnv("ACCESS_TOKEN_EXPIRES_IN_MS", "600000")),             // 10 min
+		RefreshTokenExpiresInMs:       mustAtoi(getEnv("REFRESH_TOKEN_EXPIRES_IN_MS", "1209600000")),        // 14 days
+		JWTSigningAlgorithm:           getEnv("JWT_SIGNING_ALGORITHM", "ES256"),                             // RS256, ES256 or EdDSA
+		JWTSigningKeyRotationPeriodMs: mustAtoi(getEnv("JWT_SIGNING_KEY_ROTATION_PERIOD_MS", "2592000000")), // 30 days, 0 to disable
+		JWTSigningKeyPublishDelayMs:   mustAtoi(getEnv("JWT_SIGNING_KEY_PUBLISH_DELAY_MS", "600000")),       // 10 min
+
+		PasswordHashAlgorithm:       getEnv("PASSWORD_HASH_ALGORITHM", "scrypt"), // scrypt, argon2id or bcrypt
+		PasswordScryptN:             mustAtoi(getEnv("PASSWORD_SCRYPT_N", "32768")),
+		PasswordArgon2idMemoryKiB:   mustAtoi(getEnv("PASSWORD_ARGON2ID_MEMORY_KIB", "65536")), // 64 MiB
+		PasswordArgon2idIterations:  mustAtoi(getEnv("PASSWORD_ARGON2ID_ITERATIONS", "3")),
+		PasswordArgon2idParallelism: mustAtoi(getEnv("PASSWORD_ARGON2ID_PARALLELISM", "4")),
+		PasswordBcryptCost:          mustAtoi(getEnv("PASSWORD_BCRYPT_COST", "12")),
+
+		PasswordHistorySize: mustAtoi(getEnv("PASSWORD_HISTORY_SIZE", "5")),      // the latest passwords including the current one which cannot be set again, 0 to disable. each is verified on change
+		PasswordMinAgeMs:    mustAtoi(getEnv("PASSWORD_MIN_AGE_MS", "86400000")), // 1 day, 0 to disable. only for ChangePassword so that a forgotten password can always be reset
+
+		PasswordMinLength:           mustAtoi(getEnv("PASSWORD_MIN_LENGTH", "8")),                       // in characters
+		PasswordMaxLength:           mustAtoi(getEnv("PASSWORD_MAX_LENGTH", "64")),                      // in characters, long enough for passphrases. 0 not to limit
+		PasswordRequiredCharClasses: splitOptionalEnv(getOptionalEnv("PASSWORD_REQUIRED_CHAR_CLASSES")), // comma separated of lower, upper, digit, symbol and other, e.g. digit,symbol. none if empty
+		PasswordMinCharClasses:      mustAtoi(getEnv("PASSWORD_MIN_CHAR_CLASSES", "1")),                 // different ones of lower, upper, digit, symbol and other, the letters without case such as Hangul
+		PasswordMinEntropyBits:      mustAtoi(getEnv("PASSWORD_MIN_ENTROPY_BITS", "40")),                // estimated, lowered by repeated or sequential characters. 0 to disable
+		PasswordRejectPersonalInfo:  mustParseBool(getEnv("PASSWORD_REJECT_PERSONAL_INFO", "true")),     // rejects passwords containing the name, nickname, email or phone number of the user
+
+		EmailSender:      getEnv("EMAIL_SENDER", ""), // mock, smtp or maildir. mock only logs messages
+		EmailFrom:        getEnv("EMAIL_FROM", "no-reply@localhost"),
+		SMTPAddr:         getEnv("SMTP_ADDR", "localhost:25"),
+		SMTPUsername:     getOptionalEnv("SMTP_USERNAME"),
+		SMTPPassword:     getOptionalEnv("SMTP_PASSWORD"),
+		EmailMaildirPath: getEnv("EMAIL_MAILDIR_PATH", "maildir"),
 
-		SMSOTPCodeLength:     mustAtoi(getEnv("SMS_OTP_CODE_LENGTH", "6")),
-		SMSV1ServiceEndpoint: getEnv("SMS_V1_SERVICE_ENDPOINT", ""),
+		EmailConfirmationURL:              getEnv("EMAIL_CONFIRMATION_URL", "http://localhost:3000/email/confirm"),
+		EmailConfirmationExpiresInMs:      mustAtoi(getEnv("EMAIL_CONFIRMATION_EXPIRES_IN_MS", "86400000")),   // 1 day
+		EmailConfirmationResendCooldownMs: mustAtoi(getEnv("EMAIL_CONFIRMATION_RESEND_COOLDOWN_MS", "60000")), // 1 min
+
+		TOTPIssuer: getEnv("TOTP_ISSUER", "user"), // shown in authenticator apps
+
+		WebAuthnRPID:    getEnv("WEBAUTHN_RP_ID", "localhost"),                                   // the domain passkeys are bound to
+		WebAuthnRPName:  getEnv("WEBAUTHN_RP_NAME", "user"),                                      // shown in passkey prompts
+		WebAuthnOrigins: strings.Split(getEnv("WEBAUTHN_ORIGINS", "http://localhost:3000"), ","), // comma separated
+
+		DefaultPhoneRegion: getEnv("DEFAULT_PHONE_REGION", "KR"), // CLDR region code of phone numbers given without the country calling code
+
+		SMSOTPCodeLength: mustAtoi(getEnv("SMS_OTP_CODE_LENGTH", "6")),
+		SMSOTPHMACSecret: getEnv("SMS_OTP_HMAC_SECRET", ""), // at least 32 bytes
+
+		SMSOTPAllowedRegions:           splitOptionalEnv(getOptionalEnv("SMS_OTP_ALLOWED_REGIONS")),    // comma separated, e.g. KR,JP. all if empty
+		SMSOTPResendCooldownMs:         mustAtoi(getEnv("SMS_OTP_RESEND_COOLDOWN_MS", "60000")),        // 1 min, 0 to disable
+		SMSOTPDailyLimitPerPhoneNumber: mustAtoi(getEnv("SMS_OTP_DAILY_LIMIT_PER_PHONE_NUMBER", "10")), // 0 to disable
+		SMSOTPHourlyLimitPerIPAddress:  mustAtoi(getEnv("SMS_OTP_HOURLY_LIMIT_PER_IP_ADDRESS", "30")),  // 0 to disable
+
+		SMSTemplateDir:    getOptionalEnv("SMS_TEMPLATE_DIR"),     // has <kind>/<locale>.tmpl files. built-in ones if empty
+		SMSDefaultLocale:  getEnv("SMS_DEFAULT_LOCALE", "en"),     // BCP 47 language tag
+		SMSWebOTPDomain:   getOptionalEnv("SMS_WEBOTP_DOMAIN"),    // the domain of the site to autofill OTPs with WebOTP. disabled if empty
+		SMSAndroidAppHash: getOptionalEnv("SMS_ANDROID_APP_HASH"), // the app hash to read OTPs with SMS Retriever API. disabled if empty
+
+		SMSSender:                  getEnv("SMS_SENDER", ""),                                // mock or gateway. mock only logs messages
+		SMSProviders:               splitOptionalEnv(getOptionalEnv("SMS_PROVIDERS")),       // comma separated <name>=<endpoint> in priority order, e.g. a=sms-a:8080,b=sms-b:8080
+		SMSProviderRoutes:          splitOptionalEnv(getOptionalEnv("SMS_PROVIDER_ROUTES")), // comma separated <region>=<name>[|<name>...], e.g. KR=a|b,JP=b. all providers for the other regions
+		SMSMaxAttempts:             mustAtoi(getEnv("SMS_MAX_ATTEMPTS", "3")),               // rounds over the providers
+		SMSInitialBackoffMs:        mustAtoi(getEnv("SMS_INITIAL_BACKOFF_MS", "200")),       // doubled every round
+		SMSMaxBackoffMs:            mustAtoi(getEnv("SMS_MAX_BACKOFF_MS", "2000")),          // 2 sec
+		SMSCircuitFailureThreshold: mustAtoi(getEnv("SMS_CIRCUIT_FAILURE_THRESHOLD", "5")),  // consecutive failures, 0 to disable
+		SMSCircuitOpenMs:           mustAtoi(getEnv("SMS_CIRCUIT_OPEN_MS", "30000")),        // 30 sec
+
+		SMSOutboxPollIntervalMs:   mustAtoi(getEnv("SMS_OUTBOX_POLL_INTERVAL_MS", "500")),    // how often messages to send are checked
+		SMSOutboxBatchSize:        mustAtoi(getEnv("SMS_OUTBOX_BATCH_SIZE", "100")),          // messages sent per poll
+		SMSOutboxMaxAttempts:      mustAtoi(getEnv("SMS_OUTBOX_MAX_ATTEMPTS", "5")),          // given up after this, or once the OTP code has expired
+		SMSOutboxInitialBackoffMs: mustAtoi(getEnv("SMS_OUTBOX_INITIAL_BACKOFF_MS", "5000")), // doubled every attempt
+		SMSOutboxMaxBackoffMs:     mustAtoi(getEnv("SMS_OUTBOX_MAX_BACKOFF_MS", "60000")),    // 1 min
+
+		SignInAccountFreeFailures:     mustAtoi(getEnv("SIGN_IN_ACCOUNT_FREE_FAILURES", "3")),      // consecutive failures without a delay
+		SignInAccountLockoutThreshold: mustAtoi(getEnv("SIGN_IN_ACCOUNT_LOCKOUT_THRESHOLD", "10")), // consecutive failures, 0 to disable. unlocked with an SMS OTP
+		SignInAccountLockoutMs:        mustAtoi(getEnv("SIGN_IN_ACCOUNT_LOCKOUT_MS", "3600000")),   // 1 hour
+		SignInIPFreeFailures:          mustAtoi(getEnv("SIGN_IN_IP_FREE_FAILURES", "10")),          // consecutive failures without a delay
+		SignInIPLockoutThreshold:      mustAtoi(getEnv("SIGN_IN_IP_LOCKOUT_THRESHOLD", "100")),     // consecutive failures, 0 to disable
+		SignInIPLockoutMs:             mustAtoi(getEnv("SIGN_IN_IP_LOCKOUT_MS", "3600000")),        // 1 hour
+		SignInInitialDelayMs:          mustAtoi(getEnv("SIGN_IN_INITIAL_DELAY_MS", "1000")),        // doubled every failure, 0 to disable
+		SignInMaxDelayMs:              mustAtoi(getEnv("SIGN_IN_MAX_DELAY_MS", "300000")),          // 5 min
+		SignInFailureWindowMs:         mustAtoi(getEnv("SIGN_IN_FAILURE_WINDOW_MS", "86400000")),   // failures are forgotten after 1 day without one, 0 to keep
+
+		EnumerationResistant:    mustParseBool(getEnv("ENUMERATION_RESISTANT", "false")),   // Register, ResetPassword, SignIn, ResendConfirmationEmail and UnlockAccount respond alike whether or not the account exists, and the owner is noticed instead
+		AccountNoticeCooldownMs: mustAtoi(getEnv("ACCOUNT_NOTICE_COOLDOWN_MS", "3600000")), // 1 hour. an owner is noticed at most once in this
 	}
 }
 
@@ -59,6 +223,25 @@ func getEnv(key string, defaultValue string) string {
 	return defaultValue
 }
 
+func getOptionalEnv(key string) string {
+	return os.Getenv(key)
+}
+
+func splitOptionalEnv(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
+}
+
+func mustParseBool(s string) bool {
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		logrus.Panic(err)
+	}
+	return b
+}
+
 func mustAtoi(s string) int {
 	i, err := strconv.Atoi(s)
 	if err != nil {
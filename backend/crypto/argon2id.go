@@ -0,0 +1,140 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	argon2idPrefix = "argon2id"
+
+	argon2idSaltLen = 16
+)
+
+type Argon2idHasher struct {
+	rand   io.Reader
+	params argon2idParams
+}
+
+var _ Hasher = (*Argon2idHasher)(nil)
+
+type argon2idParams struct {
+	memory  uint32 // KiB
+	time    uint32
+	threads uint8
+	keyLen  uint32
+}
+
+// NewArgon2idHasher returns an Argon2id hasher with the given memory (in KiB), number of iterations and degree of parallelism.
+// See https://datatracker.ietf.org/doc/html/rfc9106#section-4 for the recommended values.
+func NewArgon2idHasher(memory, time uint32, threads uint8) *Argon2idHasher {
+	return &Argon2idHasher{
+		rand: rand.Reader,
+		params: argon2idParams{
+			memory:  memory,
+			time:    time,
+			threads: threads,
+			keyLen:  32,
+		},
+	}
+}
+
+func (h *Argon2idHasher) Hash(s []byte) ([]byte, error) {
+	salt := make([]byte, argon2idSaltLen)
+	if _, err := io.ReadFull(h.rand, salt); err != nil {
+		return nil, err
+	}
+
+	dk := argon2.IDKey(s, salt, h.params.time, h.params.memory, h.params.threads, h.params.keyLen)
+
+	return []byte(fmt.Sprintf(
+		"%s$%d$%d$%d$%d$%s$%s",
+		argon2idPrefix,
+		argon2.Version,
+		h.params.memory,
+		h.params.time,
+		h.params.threads,
+		base64.StdEncoding.EncodeToString(salt),
+		base64.StdEncoding.EncodeToString(dk),
+	)), nil
+}
+
+func (h *Argon2idHasher) Verify(hash, s []byte) (bool, error) {
+	params, salt, dk, err := parseArgon2idHash(string(hash))
+	if err != nil {
+		return false, err
+	}
+
+	actual := argon2.IDKey(s, salt, params.time, params.memory, params.threads, params.keyLen)
+
+	return subtle.ConstantTimeCompare(dk, actual) == 1, nil
+}
+
+func (h *Argon2idHasher) NeedsRehash(hash []byte) bool {
+	params, _, _, err := parseArgon2idHash(string(hash))
+	if err != nil {
+		return true
+	}
+
+	return params.memory < h.params.memory ||
+		params.time < h.params.time ||
+		params.threads < h.params.threads ||
+		params.keyLen < h.params.keyLen
+}
+
+// parseArgon2idHash parses a hash in the form of `argon2id$v$m$t$p$salt$dk`.
+func parseArgon2idHash(hash string) (argon2idParams, []byte, []byte, error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 7 || parts[0] != argon2idPrefix {
+		return argon2idParams{}, nil, nil, ErrMalformedHash
+	}
+
+	version, err := strconv.Atoi(parts[1])
+	if err != nil || version != argon2.Version {
+		return argon2idParams{}, nil, nil, ErrMalformedHash
+	}
+
+	memory, err := strconv.ParseUint(parts[2], 10, 32)
+	if err != nil {
+		return argon2idParams{}, nil, nil, ErrMalformedHash
+	}
+
+	time, err := strconv.ParseUint(parts[3], 10, 32)
+	if err != nil || time == 0 {
+		return argon2idParams{}, nil, nil, ErrMalformedHash
+	}
+
+	threads, err := strconv.ParseUint(parts[4], 10, 8)
+	if err != nil || threads == 0 {
+		return argon2idParams{}, nil, nil, ErrMalformedHash
+	}
+
+	// argon2.IDKey panics with no iterations or no threads, and Argon2 requires at least 8 KiB of memory per thread
+	if memory < 8*threads {
+		return argon2idParams{}, nil, nil, ErrMalformedHash
+	}
+
+	salt, err := base64.StdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return argon2idParams{}, nil, nil, ErrMalformedHash
+	}
+
+	dk, err := base64.StdEncoding.DecodeString(parts[6])
+	if err != nil || len(dk) == 0 {
+		return argon2idParams{}, nil, nil, ErrMalformedHash
+	}
+
+	return argon2idParams{
+		memory:  uint32(memory),
+		time:    uint32(time),
+		threads: uint8(threads),
+		keyLen:  uint32(len(dk)),
+	}, salt, dk, nil
+}
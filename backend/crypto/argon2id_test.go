@@ -0,0 +1,134 @@
+package crypto
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArgon2idHasher_Hash(t *testing.T) {
+	hasher := Argon2idHasher{
+		rand:   bytes.NewReader([]byte{0x72, 0x2c, 0x99, 0x5e, 0x3c, 0x65, 0xf8, 0x1c, 0x80, 0xd2, 0x3c, 0x7b, 0xd0, 0xe8, 0x4a, 0xfd}),
+		params: argon2idParams{memory: 64 * 1024, time: 3, threads: 4, keyLen: 32},
+	}
+
+	hash, err := hasher.Hash([]byte("P@ssw0rd"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "argon2id$19$65536$3$4$ciyZXjxl+ByA0jx70OhK/Q==$", string(hash[:47]))
+	assert.Len(t, string(hash), 91)
+}
+
+func TestArgon2idHasher_Verify(t *testing.T) {
+	hasher := NewArgon2idHasher(1024, 1, 1)
+
+	hash, err := hasher.Hash([]byte("P@ssw0rd"))
+	assert.NoError(t, err)
+
+	cases := []struct {
+		name  string
+		hash  string
+		given []byte
+
+		expected    bool
+		expectedErr string
+	}{
+		{
+			name:     "match",
+			hash:     string(hash),
+			given:    []byte("P@ssw0rd"),
+			expected: true,
+		},
+		{
+			name:     "mismatch",
+			hash:     string(hash),
+			given:    []byte("qwerty123"),
+			expected: false,
+		},
+		{
+			name:        "unknown algorithm",
+			hash:        "scrypt$32768$8$1$ciyZXjxl+ByA0jx70OhK/Q==$LEWwE8woAWO5fCb5JjoSZN/0TJP6nHAWa45Jm0jXBM8=",
+			given:       []byte("P@ssw0rd"),
+			expectedErr: "malformed hash",
+		},
+		{
+			name:        "unknown version",
+			hash:        "argon2id$16$1024$1$1$ciyZXjxl+ByA0jx70OhK/Q==$LEWwE8woAWO5fCb5JjoSZN/0TJP6nHAWa45Jm0jXBM8=",
+			given:       []byte("P@ssw0rd"),
+			expectedErr: "malformed hash",
+		},
+		{
+			name:        "no threads",
+			hash:        "argon2id$19$1024$1$0$ciyZXjxl+ByA0jx70OhK/Q==$LEWwE8woAWO5fCb5JjoSZN/0TJP6nHAWa45Jm0jXBM8=",
+			given:       []byte("P@ssw0rd"),
+			expectedErr: "malformed hash",
+		},
+		{
+			name:        "no iterations",
+			hash:        "argon2id$19$1024$0$1$ciyZXjxl+ByA0jx70OhK/Q==$LEWwE8woAWO5fCb5JjoSZN/0TJP6nHAWa45Jm0jXBM8=",
+			given:       []byte("P@ssw0rd"),
+			expectedErr: "malformed hash",
+		},
+		{
+			name:        "too little memory for threads",
+			hash:        "argon2id$19$15$1$2$ciyZXjxl+ByA0jx70OhK/Q==$LEWwE8woAWO5fCb5JjoSZN/0TJP6nHAWa45Jm0jXBM8=",
+			given:       []byte("P@ssw0rd"),
+			expectedErr: "malformed hash",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := hasher.Verify([]byte(tc.hash), tc.given)
+
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expected, ok)
+		})
+	}
+}
+
+func TestArgon2idHasher_NeedsRehash(t *testing.T) {
+	cases := []struct {
+		name     string
+		hash     string
+		expected bool
+	}{
+		{
+			name:     "same params",
+			hash:     "argon2id$19$65536$3$4$ciyZXjxl+ByA0jx70OhK/Q==$LEWwE8woAWO5fCb5JjoSZN/0TJP6nHAWa45Jm0jXBM8=",
+			expected: false,
+		},
+		{
+			name:     "less memory",
+			hash:     "argon2id$19$32768$3$4$ciyZXjxl+ByA0jx70OhK/Q==$LEWwE8woAWO5fCb5JjoSZN/0TJP6nHAWa45Jm0jXBM8=",
+			expected: true,
+		},
+		{
+			name:     "fewer iterations",
+			hash:     "argon2id$19$65536$2$4$ciyZXjxl+ByA0jx70OhK/Q==$LEWwE8woAWO5fCb5JjoSZN/0TJP6nHAWa45Jm0jXBM8=",
+			expected: true,
+		},
+		{
+			name:     "malformed",
+			hash:     "argon2id$19$65536$3$4$LEWwE8woAWO5fCb5JjoSZN/0TJP6nHAWa45Jm0jXBM8=",
+			expected: true,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			hasher := NewArgon2idHasher(64*1024, 3, 4)
+
+			assert.Equal(t, tc.expected, hasher.NeedsRehash([]byte(tc.hash)))
+		})
+	}
+}
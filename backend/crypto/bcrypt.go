@@ -0,0 +1,82 @@
+package crypto
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// bcryptSHA256Prefix marks the hashes of the pre-hashed inputs, telling them apart from the plain bcrypt ones.
+	bcryptSHA256Prefix = "bcrypt-sha256"
+)
+
+// BcryptHasher hashes with bcrypt. bcrypt only uses the first 72 bytes of the input, so the input is pre-hashed with
+// SHA-256 and encoded in base64 so that the longer ones are not cut off. The plain bcrypt hashes made before are
+// still verified, and need to be rehashed.
+type BcryptHasher struct {
+	cost int
+}
+
+var _ Hasher = (*BcryptHasher)(nil)
+
+func NewBcryptHasher(cost int) *BcryptHasher {
+	return &BcryptHasher{cost: cost}
+}
+
+func (h *BcryptHasher) Hash(s []byte) ([]byte, error) {
+	hash, err := bcrypt.GenerateFromPassword(preHashBcryptInput(s), h.cost)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(bcryptSHA256Prefix), hash...), nil
+}
+
+func (h *BcryptHasher) Verify(hash, s []byte) (bool, error) {
+	if hash, ok := cutBcryptSHA256Prefix(hash); ok {
+		return verifyBcrypt(hash, preHashBcryptInput(s))
+	}
+	return verifyBcrypt(hash, s)
+}
+
+func (h *BcryptHasher) NeedsRehash(hash []byte) bool {
+	hash, ok := cutBcryptSHA256Prefix(hash)
+	if !ok {
+		return true
+	}
+	cost, err := bcrypt.Cost(hash)
+	if err != nil {
+		return true
+	}
+	return cost < h.cost
+}
+
+func verifyBcrypt(hash, s []byte) (bool, error) {
+	err := bcrypt.CompareHashAndPassword(hash, s)
+	switch err {
+	case nil:
+		return true, nil
+	case bcrypt.ErrMismatchedHashAndPassword:
+		return false, nil
+	case bcrypt.ErrHashTooShort:
+		return false, ErrMalformedHash
+	default:
+		return false, err
+	}
+}
+
+// preHashBcryptInput returns 44 bytes whatever the length of s. base64 keeps NUL bytes out, at which some bcrypt
+// implementations stop.
+func preHashBcryptInput(s []byte) []byte {
+	sum := sha256.Sum256(s)
+	return []byte(base64.StdEncoding.EncodeToString(sum[:]))
+}
+
+func cutBcryptSHA256Prefix(hash []byte) ([]byte, bool) {
+	if !strings.HasPrefix(string(hash), bcryptSHA256Prefix+"$") {
+		return hash, false
+	}
+	return hash[len(bcryptSHA256Prefix):], true
+}
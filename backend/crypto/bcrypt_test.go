@@ -0,0 +1,115 @@
+package crypto
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestBcryptHasher_Verify(t *testing.T) {
+	hasher := NewBcryptHasher(bcrypt.MinCost)
+
+	hash, err := hasher.Hash([]byte("P@ssw0rd"))
+	assert.NoError(t, err)
+	assert.Len(t, string(hash), len(bcryptSHA256Prefix)+60)
+
+	long := strings.Repeat("a", 72)
+	longHash, err := hasher.Hash([]byte(long + "P@ssw0rd"))
+	assert.NoError(t, err)
+
+	legacyHash, err := bcrypt.GenerateFromPassword([]byte("P@ssw0rd"), bcrypt.MinCost)
+	assert.NoError(t, err)
+
+	cases := []struct {
+		name  string
+		hash  string
+		given []byte
+
+		expected    bool
+		expectedErr string
+	}{
+		{
+			name:     "match",
+			hash:     string(hash),
+			given:    []byte("P@ssw0rd"),
+			expected: true,
+		},
+		{
+			name:     "mismatch",
+			hash:     string(hash),
+			given:    []byte("qwerty123"),
+			expected: false,
+		},
+		{
+			name:     "match longer than 72 bytes",
+			hash:     string(longHash),
+			given:    []byte(long + "P@ssw0rd"),
+			expected: true,
+		},
+		{
+			name:     "mismatch past 72 bytes",
+			hash:     string(longHash),
+			given:    []byte(long + "qwerty123"),
+			expected: false,
+		},
+		{
+			name:     "match legacy",
+			hash:     string(legacyHash),
+			given:    []byte("P@ssw0rd"),
+			expected: true,
+		},
+		{
+			name:     "mismatch legacy",
+			hash:     string(legacyHash),
+			given:    []byte("qwerty123"),
+			expected: false,
+		},
+		{
+			name:        "malformed",
+			hash:        bcryptSHA256Prefix + "$2a$04$",
+			given:       []byte("P@ssw0rd"),
+			expectedErr: "malformed hash",
+		},
+		{
+			name:        "malformed legacy",
+			hash:        "$2a$04$",
+			given:       []byte("P@ssw0rd"),
+			expectedErr: "malformed hash",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := hasher.Verify([]byte(tc.hash), tc.given)
+
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expected, ok)
+		})
+	}
+}
+
+func TestBcryptHasher_NeedsRehash(t *testing.T) {
+	weak, err := NewBcryptHasher(bcrypt.MinCost).Hash([]byte("P@ssw0rd"))
+	assert.NoError(t, err)
+
+	hasher := NewBcryptHasher(bcrypt.MinCost + 1)
+
+	strong, err := hasher.Hash([]byte("P@ssw0rd"))
+	assert.NoError(t, err)
+
+	legacy, err := bcrypt.GenerateFromPassword([]byte("P@ssw0rd"), bcrypt.MinCost+1)
+	assert.NoError(t, err)
+
+	assert.True(t, hasher.NeedsRehash(weak))
+	assert.False(t, hasher.NeedsRehash(strong))
+	assert.True(t, hasher.NeedsRehash(legacy))
+	assert.True(t, hasher.NeedsRehash([]byte("malformed")))
+}
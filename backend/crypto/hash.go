@@ -1,5 +1,89 @@
 package crypto
 
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+const (
+	HashAlgorithmScrypt   = "scrypt"
+	HashAlgorithmArgon2id = "argon2id"
+	HashAlgorithmBcrypt   = "bcrypt"
+
+	dummySecretLen = 16
+)
+
+var (
+	ErrMalformedHash            = errors.New("malformed hash")
+	ErrUnsupportedHashAlgorithm = errors.New("unsupported hash algorithm")
+)
+
 type Hasher interface {
 	Hash(s []byte) ([]byte, error)
+	// Verify reports whether s matches the hash produced by Hash.
+	Verify(hash, s []byte) (bool, error)
+	// NeedsRehash reports whether the hash was produced by an outdated algorithm or with weaker parameters
+	// than the ones the hasher is configured with.
+	NeedsRehash(hash []byte) bool
+}
+
+// MultiHasher hashes with the preferred algorithm, and verifies hashes produced by any of the given hashers
+// so that users can sign in with a password hashed before the algorithm was changed.
+type MultiHasher struct {
+	preferred string
+	hashers   map[string]Hasher
+}
+
+var _ Hasher = (*MultiHasher)(nil)
+
+func NewMultiHasher(preferred string, hashers map[string]Hasher) (*MultiHasher, error) {
+	if _, ok := hashers[preferred]; !ok {
+		return nil, ErrUnsupportedHashAlgorithm
+	}
+	return &MultiHasher{preferred: preferred, hashers: hashers}, nil
+}
+
+func (h *MultiHasher) Hash(s []byte) ([]byte, error) {
+	return h.hashers[h.preferred].Hash(s)
+}
+
+func (h *MultiHasher) Verify(hash, s []byte) (bool, error) {
+	hasher, ok := h.hashers[detectHashAlgorithm(hash)]
+	if !ok {
+		return false, ErrUnsupportedHashAlgorithm
+	}
+	return hasher.Verify(hash, s)
+}
+
+func (h *MultiHasher) NeedsRehash(hash []byte) bool {
+	if detectHashAlgorithm(hash) != h.preferred {
+		return true
+	}
+	return h.hashers[h.preferred].NeedsRehash(hash)
+}
+
+// NewDummyHash hashes a random secret with the hasher, which can be verified against to take as long as verifying a
+// real hash, e.g. when there is none to verify.
+func NewDummyHash(h Hasher) ([]byte, error) {
+	b := make([]byte, dummySecretLen)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	return h.Hash([]byte(hex.EncodeToString(b)))
+}
+
+func detectHashAlgorithm(hash []byte) string {
+	s := string(hash)
+	switch {
+	case strings.HasPrefix(s, scryptPrefix+"$"):
+		return HashAlgorithmScrypt
+	case strings.HasPrefix(s, argon2idPrefix+"$"):
+		return HashAlgorithmArgon2id
+	case strings.HasPrefix(s, bcryptSHA256Prefix+"$"), strings.HasPrefix(s, "$2a$"), strings.HasPrefix(s, "$2b$"), strings.HasPrefix(s, "$2y$"):
+		return HashAlgorithmBcrypt
+	default:
+		return ""
+	}
 }
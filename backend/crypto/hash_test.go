@@ -0,0 +1,63 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestMultiHasher(t *testing.T) {
+	hashers := map[string]Hasher{
+		HashAlgorithmScrypt:   NewScryptHasher(1 << 10),
+		HashAlgorithmArgon2id: NewArgon2idHasher(1024, 1, 1),
+		HashAlgorithmBcrypt:   NewBcryptHasher(bcrypt.MinCost),
+	}
+
+	hasher, err := NewMultiHasher(HashAlgorithmArgon2id, hashers)
+	assert.NoError(t, err)
+
+	hash, err := hasher.Hash([]byte("P@ssw0rd"))
+	assert.NoError(t, err)
+	assert.Equal(t, HashAlgorithmArgon2id, detectHashAlgorithm(hash))
+	assert.False(t, hasher.NeedsRehash(hash))
+
+	for algorithm, h := range hashers {
+		algorithm, h := algorithm, h
+
+		t.Run(algorithm, func(t *testing.T) {
+			hash, err := h.Hash([]byte("P@ssw0rd"))
+			assert.NoError(t, err)
+			assert.Equal(t, algorithm, detectHashAlgorithm(hash))
+
+			ok, err := hasher.Verify(hash, []byte("P@ssw0rd"))
+			assert.NoError(t, err)
+			assert.True(t, ok)
+
+			ok, err = hasher.Verify(hash, []byte("qwerty123"))
+			assert.NoError(t, err)
+			assert.False(t, ok)
+
+			assert.Equal(t, algorithm != HashAlgorithmArgon2id, hasher.NeedsRehash(hash))
+		})
+	}
+
+	_, err = hasher.Verify([]byte("md5$1a1dc91c907325c69271ddf0c944bc72"), []byte("P@ssw0rd"))
+	assert.EqualError(t, err, "unsupported hash algorithm")
+
+	_, err = NewMultiHasher("md5", hashers)
+	assert.EqualError(t, err, "unsupported hash algorithm")
+}
+
+func TestNewDummyHash(t *testing.T) {
+	hasher := NewScryptHasher(1 << 10)
+
+	hash, err := NewDummyHash(hasher)
+	assert.NoError(t, err)
+	assert.Equal(t, HashAlgorithmScrypt, detectHashAlgorithm(hash))
+	assert.False(t, hasher.NeedsRehash(hash))
+
+	ok, err := hasher.Verify(hash, []byte("P@ssw0rd"))
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
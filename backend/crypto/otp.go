@@ -0,0 +1,54 @@
+package crypto
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+)
+
+// OTPKeyMinLen is the minimum length of the OTP key, which is as long as the output of HMAC-SHA256 as RFC 2104
+// recommends.
+const OTPKeyMinLen = sha256.Size
+
+var ErrShortOTPKey = errors.New("short otp key")
+
+// OTPHasher digests one-time codes with a server secret, so that the codes of a leaked database cannot be recovered
+// by trying every possible code, which is feasible with unkeyed hashes since the codes are short.
+type OTPHasher struct {
+	key []byte
+}
+
+func NewOTPHasher(key []byte) (*OTPHasher, error) {
+	if len(key) < OTPKeyMinLen {
+		return nil, ErrShortOTPKey
+	}
+	return &OTPHasher{key: key}, nil
+}
+
+// Hash returns the hex encoded HMAC-SHA256 of the code. The code is bound to the token it is issued with, so that
+// equal codes of different tokens do not have equal hashes.
+func (h *OTPHasher) Hash(token, code string) string {
+	return hex.EncodeToString(h.mac(token, code))
+}
+
+// Verify reports whether the code matches the hash produced by Hash, in constant time.
+func (h *OTPHasher) Verify(hash, token, code string) bool {
+	b, err := hex.DecodeString(hash)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal(b, h.mac(token, code))
+}
+
+func (h *OTPHasher) mac(token, code string) []byte {
+	m := hmac.New(sha256.New, h.key)
+	// the token is prefixed with its length, so that the boundary between the token and the code is unambiguous
+	var l [8]byte
+	binary.BigEndian.PutUint64(l[:], uint64(len(token)))
+	m.Write(l[:])
+	m.Write([]byte(token))
+	m.Write([]byte(code))
+	return m.Sum(nil)
+}
@@ -0,0 +1,92 @@
+package crypto
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testOTPKey = bytes.Repeat([]byte{0x0b}, OTPKeyMinLen)
+
+func TestNewOTPHasher(t *testing.T) {
+	_, err := NewOTPHasher(testOTPKey[:OTPKeyMinLen-1])
+	assert.Equal(t, ErrShortOTPKey, err)
+
+	_, err = NewOTPHasher(testOTPKey)
+	assert.NoError(t, err)
+}
+
+func TestOTPHasher_Verify(t *testing.T) {
+	h, _ := NewOTPHasher(testOTPKey)
+	hash := h.Hash("verification_token", "123456")
+
+	cases := []struct {
+		name  string
+		hash  string
+		token string
+		code  string
+
+		expected bool
+	}{
+		{
+			name:     "match",
+			hash:     hash,
+			token:    "verification_token",
+			code:     "123456",
+			expected: true,
+		},
+		{
+			name:     "different code",
+			hash:     hash,
+			token:    "verification_token",
+			code:     "123457",
+			expected: false,
+		},
+		{
+			name:     "different token",
+			hash:     hash,
+			token:    "another_verification_token",
+			code:     "123456",
+			expected: false,
+		},
+		{
+			name:     "token and code split differently",
+			hash:     hash,
+			token:    "verification_token1",
+			code:     "23456",
+			expected: false,
+		},
+		{
+			name:     "plaintext",
+			hash:     "123456",
+			token:    "verification_token",
+			code:     "123456",
+			expected: false,
+		},
+		{
+			name:     "malformed hash",
+			hash:     "not hex",
+			token:    "verification_token",
+			code:     "123456",
+			expected: false,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, h.Verify(tc.hash, tc.token, tc.code))
+		})
+	}
+}
+
+func TestOTPHasher_Hash(t *testing.T) {
+	h, _ := NewOTPHasher(testOTPKey)
+	another, _ := NewOTPHasher(bytes.Repeat([]byte{0x0c}, OTPKeyMinLen))
+
+	assert.Len(t, h.Hash("verification_token", "123456"), 64)
+	assert.Equal(t, h.Hash("verification_token", "123456"), h.Hash("verification_token", "123456"))
+	assert.NotEqual(t, h.Hash("verification_token", "123456"), another.Hash("verification_token", "123456"))
+}
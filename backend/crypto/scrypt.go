@@ -1,14 +1,25 @@
 package crypto
 
 import (
+	"crypto/rand"
+	"crypto/subtle"
 	"encoding/base64"
 	"fmt"
+	"io"
+	"strconv"
+	"strings"
 
 	"golang.org/x/crypto/scrypt"
 )
 
+const (
+	scryptPrefix = "scrypt"
+
+	scryptSaltLen = 16
+)
+
 type ScryptHasher struct {
-	salt   []byte
+	rand   io.Reader
 	params scryptParams
 }
 
@@ -21,12 +32,13 @@ type scryptParams struct {
 	keyLen int
 }
 
-func NewScryptHasher(salt []byte) *ScryptHasher {
+// NewScryptHasher returns a scrypt hasher with the given CPU/memory cost parameter N.
+// See https://github.com/golang/go/issues/22082 for the recommended value.
+func NewScryptHasher(n int) *ScryptHasher {
 	return &ScryptHasher{
-		salt: salt,
+		rand: rand.Reader,
 		params: scryptParams{
-			// See https://github.com/golang/go/issues/22082
-			n:      1 << 15,
+			n:      n,
 			r:      8,
 			p:      1,
 			keyLen: 32,
@@ -35,17 +47,78 @@ func NewScryptHasher(salt []byte) *ScryptHasher {
 }
 
 func (h *ScryptHasher) Hash(s []byte) ([]byte, error) {
-	dk, err := scrypt.Key(s, h.salt, h.params.n, h.params.r, h.params.p, h.params.keyLen)
+	salt := make([]byte, scryptSaltLen)
+	if _, err := io.ReadFull(h.rand, salt); err != nil {
+		return nil, err
+	}
+
+	dk, err := scrypt.Key(s, salt, h.params.n, h.params.r, h.params.p, h.params.keyLen)
 	if err != nil {
 		return nil, err
 	}
 
 	return []byte(fmt.Sprintf(
-		"scrypt$%d$%d$%d$%s$%s",
+		"%s$%d$%d$%d$%s$%s",
+		scryptPrefix,
 		h.params.n,
 		h.params.r,
 		h.params.p,
-		base64.StdEncoding.EncodeToString(h.salt),
+		base64.StdEncoding.EncodeToString(salt),
 		base64.StdEncoding.EncodeToString(dk),
 	)), nil
 }
+
+func (h *ScryptHasher) Verify(hash, s []byte) (bool, error) {
+	params, salt, dk, err := parseScryptHash(string(hash))
+	if err != nil {
+		return false, err
+	}
+
+	actual, err := scrypt.Key(s, salt, params.n, params.r, params.p, params.keyLen)
+	if err != nil {
+		return false, err
+	}
+
+	return subtle.ConstantTimeCompare(dk, actual) == 1, nil
+}
+
+func (h *ScryptHasher) NeedsRehash(hash []byte) bool {
+	params, _, _, err := parseScryptHash(string(hash))
+	if err != nil {
+		return true
+	}
+
+	return params.n < h.params.n ||
+		params.r < h.params.r ||
+		params.p < h.params.p ||
+		params.keyLen < h.params.keyLen
+}
+
+// parseScryptHash parses a hash in the form of `scrypt$N$r$p$salt$dk`.
+func parseScryptHash(hash string) (scryptParams, []byte, []byte, error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[0] != scryptPrefix {
+		return scryptParams{}, nil, nil, ErrMalformedHash
+	}
+
+	var nums [3]int
+	for i, part := range parts[1:4] {
+		num, err := strconv.Atoi(part)
+		if err != nil {
+			return scryptParams{}, nil, nil, ErrMalformedHash
+		}
+		nums[i] = num
+	}
+
+	salt, err := base64.StdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return scryptParams{}, nil, nil, ErrMalformedHash
+	}
+
+	dk, err := base64.StdEncoding.DecodeString(parts[5])
+	if err != nil || len(dk) == 0 {
+		return scryptParams{}, nil, nil, ErrMalformedHash
+	}
+
+	return scryptParams{n: nums[0], r: nums[1], p: nums[2], keyLen: len(dk)}, salt, dk, nil
+}
@@ -1,6 +1,7 @@
 package crypto
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -30,7 +31,7 @@ func TestScryptHasher_Hash(t *testing.T) {
 
 		t.Run(string(tc.given), func(t *testing.T) {
 			hasher := ScryptHasher{
-				salt:   []byte{0x72, 0x2c, 0x99, 0x5e, 0x3c, 0x65, 0xf8, 0x1c, 0x80, 0xd2, 0x3c, 0x7b, 0xd0, 0xe8, 0x4a, 0xfd},
+				rand:   bytes.NewReader([]byte{0x72, 0x2c, 0x99, 0x5e, 0x3c, 0x65, 0xf8, 0x1c, 0x80, 0xd2, 0x3c, 0x7b, 0xd0, 0xe8, 0x4a, 0xfd}),
 				params: scryptParams{n: 1 << 15, r: 8, p: 1, keyLen: 32},
 			}
 
@@ -42,3 +43,141 @@ func TestScryptHasher_Hash(t *testing.T) {
 		})
 	}
 }
+
+func TestScryptHasher_Hash_RandomSalt(t *testing.T) {
+	hasher := NewScryptHasher(1 << 15)
+
+	hash1, err := hasher.Hash([]byte("P@ssw0rd"))
+	assert.NoError(t, err)
+
+	hash2, err := hasher.Hash([]byte("P@ssw0rd"))
+	assert.NoError(t, err)
+
+	assert.NotEqual(t, string(hash1), string(hash2))
+}
+
+func TestScryptHasher_Verify(t *testing.T) {
+	cases := []struct {
+		name  string
+		hash  string
+		given []byte
+
+		expected    bool
+		expectedErr string
+	}{
+		{
+			name:     "match",
+			hash:     "scrypt$32768$8$1$ciyZXjxl+ByA0jx70OhK/Q==$LEWwE8woAWO5fCb5JjoSZN/0TJP6nHAWa45Jm0jXBM8=",
+			given:    []byte("P@ssw0rd"),
+			expected: true,
+		},
+		{
+			name:     "match with global salt",
+			hash:     "scrypt$32768$8$1$DdUvj62VZaFEJkHOQkxT1A==$Gylx1rTDUt0pSSpd3jycFaIi2V4HgDJKXyi/qpkssiM=",
+			given:    []byte("P@ssw0rd"),
+			expected: true,
+		},
+		{
+			name:     "mismatch",
+			hash:     "scrypt$32768$8$1$ciyZXjxl+ByA0jx70OhK/Q==$LEWwE8woAWO5fCb5JjoSZN/0TJP6nHAWa45Jm0jXBM8=",
+			given:    []byte("qwerty123"),
+			expected: false,
+		},
+		{
+			name:        "unknown algorithm",
+			hash:        "bcrypt$32768$8$1$ciyZXjxl+ByA0jx70OhK/Q==$LEWwE8woAWO5fCb5JjoSZN/0TJP6nHAWa45Jm0jXBM8=",
+			given:       []byte("P@ssw0rd"),
+			expectedErr: "malformed hash",
+		},
+		{
+			name:        "invalid params",
+			hash:        "scrypt$N$8$1$ciyZXjxl+ByA0jx70OhK/Q==$LEWwE8woAWO5fCb5JjoSZN/0TJP6nHAWa45Jm0jXBM8=",
+			given:       []byte("P@ssw0rd"),
+			expectedErr: "malformed hash",
+		},
+		{
+			name:        "invalid salt",
+			hash:        "scrypt$32768$8$1$!!$LEWwE8woAWO5fCb5JjoSZN/0TJP6nHAWa45Jm0jXBM8=",
+			given:       []byte("P@ssw0rd"),
+			expectedErr: "malformed hash",
+		},
+		{
+			name:        "missing parts",
+			hash:        "scrypt$32768$8$1$LEWwE8woAWO5fCb5JjoSZN/0TJP6nHAWa45Jm0jXBM8=",
+			given:       []byte("P@ssw0rd"),
+			expectedErr: "malformed hash",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			hasher := NewScryptHasher(1 << 15)
+
+			ok, err := hasher.Verify([]byte(tc.hash), tc.given)
+
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expected, ok)
+		})
+	}
+}
+
+func TestScryptHasher_HashAndVerify(t *testing.T) {
+	hasher := NewScryptHasher(1 << 15)
+
+	hash, err := hasher.Hash([]byte("P@ssw0rd"))
+	assert.NoError(t, err)
+	assert.Len(t, string(hash), 86)
+
+	ok, err := hasher.Verify(hash, []byte("P@ssw0rd"))
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = hasher.Verify(hash, []byte("qwerty123"))
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestScryptHasher_NeedsRehash(t *testing.T) {
+	cases := []struct {
+		name     string
+		hash     string
+		expected bool
+	}{
+		{
+			name:     "same params",
+			hash:     "scrypt$32768$8$1$ciyZXjxl+ByA0jx70OhK/Q==$LEWwE8woAWO5fCb5JjoSZN/0TJP6nHAWa45Jm0jXBM8=",
+			expected: false,
+		},
+		{
+			name:     "stronger params",
+			hash:     "scrypt$65536$8$1$ciyZXjxl+ByA0jx70OhK/Q==$LEWwE8woAWO5fCb5JjoSZN/0TJP6nHAWa45Jm0jXBM8=",
+			expected: false,
+		},
+		{
+			name:     "weaker params",
+			hash:     "scrypt$16384$8$1$ciyZXjxl+ByA0jx70OhK/Q==$LEWwE8woAWO5fCb5JjoSZN/0TJP6nHAWa45Jm0jXBM8=",
+			expected: true,
+		},
+		{
+			name:     "malformed",
+			hash:     "scrypt$32768$8$1$LEWwE8woAWO5fCb5JjoSZN/0TJP6nHAWa45Jm0jXBM8=",
+			expected: true,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			hasher := NewScryptHasher(1 << 15)
+
+			assert.Equal(t, tc.expected, hasher.NeedsRehash([]byte(tc.hash)))
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package crypto
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// rfc6238Secret is the SHA1 seed used in the test vectors of RFC 6238.
+var rfc6238Secret = []byte("12345678901234567890")
+
+func TestTOTPCode(t *testing.T) {
+	// See https://datatracker.ietf.org/doc/html/rfc6238#appendix-B
+	cases := []struct {
+		given    time.Time
+		expected string
+	}{
+		{given: time.Unix(59, 0), expected: "287082"},
+		{given: time.Unix(1111111109, 0), expected: "081804"},
+		{given: time.Unix(1111111111, 0), expected: "050471"},
+		{given: time.Unix(1234567890, 0), expected: "005924"},
+		{given: time.Unix(2000000000, 0), expected: "279037"},
+		{given: time.Unix(20000000000, 0), expected: "353130"},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.expected, func(t *testing.T) {
+			assert.Equal(t, tc.expected, TOTPCode(rfc6238Secret, TOTPStep(tc.given)))
+		})
+	}
+}
+
+func TestVerifyTOTP(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+
+	cases := []struct {
+		name string
+		code string
+
+		expectedStep int64
+		expectedOK   bool
+	}{
+		{
+			name:         "current step",
+			code:         TOTPCode(rfc6238Secret, TOTPStep(now)),
+			expectedStep: TOTPStep(now),
+			expectedOK:   true,
+		},
+		{
+			name:         "previous step",
+			code:         TOTPCode(rfc6238Secret, TOTPStep(now)-1),
+			expectedStep: TOTPStep(now) - 1,
+			expectedOK:   true,
+		},
+		{
+			name:         "next step",
+			code:         TOTPCode(rfc6238Secret, TOTPStep(now)+1),
+			expectedStep: TOTPStep(now) + 1,
+			expectedOK:   true,
+		},
+		{
+			name: "too old",
+			code: TOTPCode(rfc6238Secret, TOTPStep(now)-2),
+		},
+		{
+			name: "wrong length",
+			code: "05924",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			step, ok := VerifyTOTP(rfc6238Secret, tc.code, now)
+
+			assert.Equal(t, tc.expectedOK, ok)
+			assert.Equal(t, tc.expectedStep, step)
+		})
+	}
+}
+
+func TestTOTPURI(t *testing.T) {
+	assert.Equal(t,
+		"otpauth://totp/User:john.doe@example.com?algorithm=SHA1&digits=6&issuer=User&period=30&secret=GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ",
+		TOTPURI("User", "john.doe@example.com", rfc6238Secret),
+	)
+}
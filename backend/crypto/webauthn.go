@@ -0,0 +1,327 @@
+package crypto
+
+import (
+	"bytes"
+	stdcrypto "crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"math/big"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// WebAuthn ceremonies are verified as described in https://www.w3.org/TR/webauthn-2/#sctn-rp-operations.
+const (
+	WebAuthnTypeCreate = "webauthn.create"
+	WebAuthnTypeGet    = "webauthn.get"
+
+	// webAuthnAttestationNone is the only attestation format accepted, since authenticators are not restricted by
+	// their make and model. Clients return it when the attestation conveyance preference is `none`.
+	webAuthnAttestationNone = "none"
+
+	webAuthnFlagUserPresent            = 0x01
+	webAuthnFlagUserVerified           = 0x04
+	webAuthnFlagAttestedCredentialData = 0x40
+
+	webAuthnRPIDHashLen = 32
+	webAuthnAAGUIDLen   = 16
+)
+
+// COSE key parameters of the algorithms we support.
+// See https://www.iana.org/assignments/cose/cose.xhtml
+const (
+	COSEAlgorithmES256 = -7
+	COSEAlgorithmEdDSA = -8
+	COSEAlgorithmRS256 = -257
+
+	coseKeyTypeOKP = 1
+	coseKeyTypeEC2 = 2
+	coseKeyTypeRSA = 3
+
+	coseCurveP256    = 1
+	coseCurveEd25519 = 6
+
+	coseKeyLabelKeyType   = 1
+	coseKeyLabelAlgorithm = 3
+	coseKeyLabelCurve     = -1 // n for RSA
+	coseKeyLabelX         = -2 // e for RSA
+	coseKeyLabelY         = -3
+)
+
+var (
+	ErrInvalidWebAuthnResponse        = errors.New("invalid webauthn response")
+	ErrUnsupportedWebAuthnAttestation = errors.New("unsupported webauthn attestation")
+	ErrUnsupportedCOSEKey             = errors.New("unsupported cose key")
+)
+
+// WebAuthnRelyingParty verifies the responses of authenticators for the relying party ID, which is the domain of the
+// web origins the ceremonies are allowed to run on.
+type WebAuthnRelyingParty struct {
+	ID      string
+	Origins []string
+}
+
+// WebAuthnCredential is what is registered for the user.
+type WebAuthnCredential struct {
+	ID []byte
+	// PublicKey is COSE_Key encoded
+	PublicKey []byte
+	SignCount uint32
+}
+
+type webAuthnClientData struct {
+	Type      string `json:"type"`
+	Challenge string `json:"challenge"`
+	Origin    string `json:"origin"`
+}
+
+type webAuthnAttestationObject struct {
+	Format   string          `cbor:"fmt"`
+	AttStmt  cbor.RawMessage `cbor:"attStmt"`
+	AuthData []byte          `cbor:"authData"`
+}
+
+type webAuthnAuthenticatorData struct {
+	rpIDHash  []byte
+	flags     byte
+	signCount uint32
+
+	// set only if the attested credential data is included
+	credentialID []byte
+	publicKey    []byte
+}
+
+// WebAuthnChallenge returns the challenge in the client data, so that the ceremony it belongs to can be looked up
+// before the client data is verified.
+func WebAuthnChallenge(clientDataJSON []byte) (string, error) {
+	var c webAuthnClientData
+	if err := json.Unmarshal(clientDataJSON, &c); err != nil {
+		return "", ErrInvalidWebAuthnResponse
+	}
+	if c.Challenge == "" {
+		return "", ErrInvalidWebAuthnResponse
+	}
+	return c.Challenge, nil
+}
+
+// VerifyRegistration verifies the response to navigator.credentials.create() and returns the credential to register.
+// The challenge is base64url encoded without padding, as it appears in the client data.
+func (rp *WebAuthnRelyingParty) VerifyRegistration(challenge string, clientDataJSON, attestationObject []byte) (*WebAuthnCredential, error) {
+	if err := rp.verifyClientData(clientDataJSON, WebAuthnTypeCreate, challenge); err != nil {
+		return nil, err
+	}
+
+	var att webAuthnAttestationObject
+	if err := cbor.Unmarshal(attestationObject, &att); err != nil {
+		return nil, ErrInvalidWebAuthnResponse
+	}
+	if att.Format != webAuthnAttestationNone {
+		return nil, ErrUnsupportedWebAuthnAttestation
+	}
+
+	authData, err := rp.verifyAuthenticatorData(att.AuthData)
+	if err != nil {
+		return nil, err
+	}
+	if authData.flags&webAuthnFlagAttestedCredentialData == 0 {
+		return nil, ErrInvalidWebAuthnResponse
+	}
+
+	if _, _, err := parseCOSEKey(authData.publicKey); err != nil {
+		return nil, err
+	}
+
+	return &WebAuthnCredential{
+		ID:        authData.credentialID,
+		PublicKey: authData.publicKey,
+		SignCount: authData.signCount,
+	}, nil
+}
+
+// VerifyAssertion verifies the response to navigator.credentials.get() with the public key of the credential, and
+// returns the signature counter of the authenticator.
+func (rp *WebAuthnRelyingParty) VerifyAssertion(challenge string, publicKey, clientDataJSON, authenticatorData, signature []byte) (uint32, error) {
+	if err := rp.verifyClientData(clientDataJSON, WebAuthnTypeGet, challenge); err != nil {
+		return 0, err
+	}
+
+	authData, err := rp.verifyAuthenticatorData(authenticatorData)
+	if err != nil {
+		return 0, err
+	}
+
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	signed := append(append([]byte{}, authenticatorData...), clientDataHash[:]...)
+	if err := verifyCOSESignature(publicKey, signed, signature); err != nil {
+		return 0, err
+	}
+
+	return authData.signCount, nil
+}
+
+func (rp *WebAuthnRelyingParty) verifyClientData(clientDataJSON []byte, typ, challenge string) error {
+	var c webAuthnClientData
+	if err := json.Unmarshal(clientDataJSON, &c); err != nil {
+		return ErrInvalidWebAuthnResponse
+	}
+	if c.Type != typ {
+		return ErrInvalidWebAuthnResponse
+	}
+	if subtle.ConstantTimeCompare([]byte(c.Challenge), []byte(challenge)) != 1 {
+		return ErrInvalidWebAuthnResponse
+	}
+
+	for _, origin := range rp.Origins {
+		if c.Origin == origin {
+			return nil
+		}
+	}
+	return ErrInvalidWebAuthnResponse
+}
+
+// verifyAuthenticatorData requires the user to be verified by the authenticator, e.g. with biometrics or a PIN, since
+// the credential replaces the password rather than being a second factor.
+func (rp *WebAuthnRelyingParty) verifyAuthenticatorData(b []byte) (*webAuthnAuthenticatorData, error) {
+	authData, err := parseWebAuthnAuthenticatorData(b)
+	if err != nil {
+		return nil, err
+	}
+
+	rpIDHash := sha256.Sum256([]byte(rp.ID))
+	if subtle.ConstantTimeCompare(authData.rpIDHash, rpIDHash[:]) != 1 {
+		return nil, ErrInvalidWebAuthnResponse
+	}
+	if authData.flags&webAuthnFlagUserPresent == 0 || authData.flags&webAuthnFlagUserVerified == 0 {
+		return nil, ErrInvalidWebAuthnResponse
+	}
+	return authData, nil
+}
+
+// parseWebAuthnAuthenticatorData parses the authenticator data.
+// See https://www.w3.org/TR/webauthn-2/#sctn-authenticator-data
+func parseWebAuthnAuthenticatorData(b []byte) (*webAuthnAuthenticatorData, error) {
+	if len(b) < webAuthnRPIDHashLen+1+4 {
+		return nil, ErrInvalidWebAuthnResponse
+	}
+
+	d := &webAuthnAuthenticatorData{
+		rpIDHash:  b[:webAuthnRPIDHashLen],
+		flags:     b[webAuthnRPIDHashLen],
+		signCount: binary.BigEndian.Uint32(b[webAuthnRPIDHashLen+1:]),
+	}
+	if d.flags&webAuthnFlagAttestedCredentialData == 0 {
+		return d, nil
+	}
+
+	rest := b[webAuthnRPIDHashLen+1+4:]
+	if len(rest) < webAuthnAAGUIDLen+2 {
+		return nil, ErrInvalidWebAuthnResponse
+	}
+	n := int(binary.BigEndian.Uint16(rest[webAuthnAAGUIDLen:]))
+	rest = rest[webAuthnAAGUIDLen+2:]
+	if len(rest) < n {
+		return nil, ErrInvalidWebAuthnResponse
+	}
+	d.credentialID = rest[:n]
+	rest = rest[n:]
+
+	// the public key is followed by extensions, if any, so its length is known only after decoding it
+	var publicKey cbor.RawMessage
+	if err := cbor.NewDecoder(bytes.NewReader(rest)).Decode(&publicKey); err != nil {
+		return nil, ErrInvalidWebAuthnResponse
+	}
+	d.publicKey = publicKey
+
+	return d, nil
+}
+
+// parseCOSEKey returns the public key and the algorithm it is used with.
+// See https://datatracker.ietf.org/doc/html/rfc8152#section-13
+func parseCOSEKey(b []byte) (interface{}, int, error) {
+	var params map[int]cbor.RawMessage
+	if err := cbor.Unmarshal(b, &params); err != nil {
+		return nil, 0, ErrUnsupportedCOSEKey
+	}
+
+	var kty, alg int
+	if err := cbor.Unmarshal(params[coseKeyLabelKeyType], &kty); err != nil {
+		return nil, 0, ErrUnsupportedCOSEKey
+	}
+	if err := cbor.Unmarshal(params[coseKeyLabelAlgorithm], &alg); err != nil {
+		return nil, 0, ErrUnsupportedCOSEKey
+	}
+
+	switch {
+	case kty == coseKeyTypeEC2 && alg == COSEAlgorithmES256:
+		var (
+			crv  int
+			x, y []byte
+		)
+		if cbor.Unmarshal(params[coseKeyLabelCurve], &crv) != nil || crv != coseCurveP256 ||
+			cbor.Unmarshal(params[coseKeyLabelX], &x) != nil || cbor.Unmarshal(params[coseKeyLabelY], &y) != nil {
+			return nil, 0, ErrUnsupportedCOSEKey
+		}
+
+		pub := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !pub.Curve.IsOnCurve(pub.X, pub.Y) {
+			return nil, 0, ErrUnsupportedCOSEKey
+		}
+		return pub, alg, nil
+	case kty == coseKeyTypeOKP && alg == COSEAlgorithmEdDSA:
+		var (
+			crv int
+			x   []byte
+		)
+		if cbor.Unmarshal(params[coseKeyLabelCurve], &crv) != nil || crv != coseCurveEd25519 ||
+			cbor.Unmarshal(params[coseKeyLabelX], &x) != nil || len(x) != ed25519.PublicKeySize {
+			return nil, 0, ErrUnsupportedCOSEKey
+		}
+		return ed25519.PublicKey(x), alg, nil
+	case kty == coseKeyTypeRSA && alg == COSEAlgorithmRS256:
+		var n, e []byte
+		if cbor.Unmarshal(params[coseKeyLabelCurve], &n) != nil || cbor.Unmarshal(params[coseKeyLabelX], &e) != nil ||
+			len(e) == 0 || len(e) > 4 {
+			return nil, 0, ErrUnsupportedCOSEKey
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, alg, nil
+	default:
+		return nil, 0, ErrUnsupportedCOSEKey
+	}
+}
+
+func verifyCOSESignature(publicKey, signed, signature []byte) error {
+	pub, alg, err := parseCOSEKey(publicKey)
+	if err != nil {
+		return err
+	}
+
+	digest := sha256.Sum256(signed)
+
+	var ok bool
+	switch alg {
+	case COSEAlgorithmES256:
+		ok = ecdsa.VerifyASN1(pub.(*ecdsa.PublicKey), digest[:], signature)
+	case COSEAlgorithmEdDSA:
+		ok = ed25519.Verify(pub.(ed25519.PublicKey), signed, signature)
+	case COSEAlgorithmRS256:
+		ok = rsa.VerifyPKCS1v15(pub.(*rsa.PublicKey), stdcrypto.SHA256, digest[:], signature) == nil
+	}
+	if !ok {
+		return ErrInvalidWebAuthnResponse
+	}
+	return nil
+}
+
+// EncodeWebAuthnID encodes credential IDs and challenges the way the client data and the JSON serialization of
+// credentials do.
+func EncodeWebAuthnID(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
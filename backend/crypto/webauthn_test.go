@@ -0,0 +1,283 @@
+package crypto
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sean-ahn/user/backend/test"
+)
+
+const (
+	testRPID      = "example.com"
+	testOrigin    = "https://example.com"
+	testChallenge = "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA"
+)
+
+var testRP = &WebAuthnRelyingParty{ID: testRPID, Origins: []string{testOrigin}}
+
+func TestWebAuthnRelyingParty_VerifyRegistration(t *testing.T) {
+	cases := []struct {
+		name      string
+		rpID      string
+		origin    string
+		challenge string
+		skipUV    bool
+		tamper    func(clientDataJSON, attestationObject []byte) ([]byte, []byte)
+
+		expectedErr error
+	}{
+		{
+			name:      "valid",
+			rpID:      testRPID,
+			origin:    testOrigin,
+			challenge: testChallenge,
+		},
+		{
+			name:        "different challenge",
+			rpID:        testRPID,
+			origin:      testOrigin,
+			challenge:   "BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB",
+			expectedErr: ErrInvalidWebAuthnResponse,
+		},
+		{
+			name:        "different origin",
+			rpID:        testRPID,
+			origin:      "https://evil.example.com",
+			challenge:   testChallenge,
+			expectedErr: ErrInvalidWebAuthnResponse,
+		},
+		{
+			name:        "different rp id",
+			rpID:        "evil.example.com",
+			origin:      testOrigin,
+			challenge:   testChallenge,
+			expectedErr: ErrInvalidWebAuthnResponse,
+		},
+		{
+			name:        "user not verified",
+			rpID:        testRPID,
+			origin:      testOrigin,
+			challenge:   testChallenge,
+			skipUV:      true,
+			expectedErr: ErrInvalidWebAuthnResponse,
+		},
+		{
+			name:      "assertion instead of attestation",
+			rpID:      testRPID,
+			origin:    testOrigin,
+			challenge: testChallenge,
+			tamper: func(clientDataJSON, attestationObject []byte) ([]byte, []byte) {
+				return []byte(`{"type":"webauthn.get","challenge":"` + testChallenge + `","origin":"` + testOrigin + `"}`), attestationObject
+			},
+			expectedErr: ErrInvalidWebAuthnResponse,
+		},
+		{
+			name:      "packed attestation",
+			rpID:      testRPID,
+			origin:    testOrigin,
+			challenge: testChallenge,
+			tamper: func(clientDataJSON, attestationObject []byte) ([]byte, []byte) {
+				var att map[string]interface{}
+				assert.NoError(t, cbor.Unmarshal(attestationObject, &att))
+				att["fmt"] = "packed"
+				b, err := cbor.Marshal(att)
+				assert.NoError(t, err)
+				return clientDataJSON, b
+			},
+			expectedErr: ErrUnsupportedWebAuthnAttestation,
+		},
+		{
+			name:      "malformed attestation object",
+			rpID:      testRPID,
+			origin:    testOrigin,
+			challenge: testChallenge,
+			tamper: func(clientDataJSON, attestationObject []byte) ([]byte, []byte) {
+				return clientDataJSON, attestationObject[:len(attestationObject)/2]
+			},
+			expectedErr: ErrInvalidWebAuthnResponse,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			authenticator, err := test.NewSoftwareAuthenticator()
+			if err != nil {
+				t.Fatal(err)
+			}
+			authenticator.SkipUserVerification = tc.skipUV
+
+			clientDataJSON, attestationObject := authenticator.Create(tc.rpID, tc.origin, tc.challenge)
+			if tc.tamper != nil {
+				clientDataJSON, attestationObject = tc.tamper(clientDataJSON, attestationObject)
+			}
+
+			cred, err := testRP.VerifyRegistration(testChallenge, clientDataJSON, attestationObject)
+
+			if tc.expectedErr != nil {
+				assert.Equal(t, tc.expectedErr, err)
+				assert.Nil(t, cred)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, authenticator.CredentialID, cred.ID)
+			assert.Equal(t, authenticator.PublicKey(), cred.PublicKey)
+			assert.Equal(t, uint32(0), cred.SignCount)
+		})
+	}
+}
+
+func TestWebAuthnRelyingParty_VerifyAssertion(t *testing.T) {
+	cases := []struct {
+		name      string
+		rpID      string
+		origin    string
+		challenge string
+		skipUV    bool
+		tamper    func(clientDataJSON, authenticatorData, signature []byte) ([]byte, []byte, []byte)
+
+		expectedErr error
+	}{
+		{
+			name:      "valid",
+			rpID:      testRPID,
+			origin:    testOrigin,
+			challenge: testChallenge,
+		},
+		{
+			name:        "different challenge",
+			rpID:        testRPID,
+			origin:      testOrigin,
+			challenge:   "BBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBBB",
+			expectedErr: ErrInvalidWebAuthnResponse,
+		},
+		{
+			name:        "different origin",
+			rpID:        testRPID,
+			origin:      "https://evil.example.com",
+			challenge:   testChallenge,
+			expectedErr: ErrInvalidWebAuthnResponse,
+		},
+		{
+			name:        "different rp id",
+			rpID:        "evil.example.com",
+			origin:      testOrigin,
+			challenge:   testChallenge,
+			expectedErr: ErrInvalidWebAuthnResponse,
+		},
+		{
+			name:        "user not verified",
+			rpID:        testRPID,
+			origin:      testOrigin,
+			challenge:   testChallenge,
+			skipUV:      true,
+			expectedErr: ErrInvalidWebAuthnResponse,
+		},
+		{
+			name:      "tampered authenticator data",
+			rpID:      testRPID,
+			origin:    testOrigin,
+			challenge: testChallenge,
+			tamper: func(clientDataJSON, authenticatorData, signature []byte) ([]byte, []byte, []byte) {
+				authenticatorData[len(authenticatorData)-1]++ // sign count
+				return clientDataJSON, authenticatorData, signature
+			},
+			expectedErr: ErrInvalidWebAuthnResponse,
+		},
+		{
+			name:      "malformed signature",
+			rpID:      testRPID,
+			origin:    testOrigin,
+			challenge: testChallenge,
+			tamper: func(clientDataJSON, authenticatorData, signature []byte) ([]byte, []byte, []byte) {
+				return clientDataJSON, authenticatorData, signature[1:]
+			},
+			expectedErr: ErrInvalidWebAuthnResponse,
+		},
+		{
+			name:      "truncated authenticator data",
+			rpID:      testRPID,
+			origin:    testOrigin,
+			challenge: testChallenge,
+			tamper: func(clientDataJSON, authenticatorData, signature []byte) ([]byte, []byte, []byte) {
+				return clientDataJSON, authenticatorData[:32], signature
+			},
+			expectedErr: ErrInvalidWebAuthnResponse,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			authenticator, err := test.NewSoftwareAuthenticator()
+			if err != nil {
+				t.Fatal(err)
+			}
+			authenticator.SkipUserVerification = tc.skipUV
+
+			clientDataJSON, authenticatorData, signature := authenticator.Get(tc.rpID, tc.origin, tc.challenge)
+			if tc.tamper != nil {
+				clientDataJSON, authenticatorData, signature = tc.tamper(clientDataJSON, authenticatorData, signature)
+			}
+
+			signCount, err := testRP.VerifyAssertion(testChallenge, authenticator.PublicKey(), clientDataJSON, authenticatorData, signature)
+
+			if tc.expectedErr != nil {
+				assert.Equal(t, tc.expectedErr, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, uint32(1), signCount)
+		})
+	}
+}
+
+func TestWebAuthnRelyingParty_VerifyAssertion_EdDSA(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	publicKey, err := cbor.Marshal(map[int]interface{}{
+		coseKeyLabelKeyType:   coseKeyTypeOKP,
+		coseKeyLabelAlgorithm: COSEAlgorithmEdDSA,
+		coseKeyLabelCurve:     coseCurveEd25519,
+		coseKeyLabelX:         []byte(pub),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clientDataJSON := []byte(`{"type":"webauthn.get","challenge":"` + testChallenge + `","origin":"` + testOrigin + `"}`)
+	rpIDHash := sha256.Sum256([]byte(testRPID))
+	authenticatorData := append(rpIDHash[:], webAuthnFlagUserPresent|webAuthnFlagUserVerified, 0, 0, 0, 7)
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	signature := ed25519.Sign(priv, append(append([]byte{}, authenticatorData...), clientDataHash[:]...))
+
+	signCount, err := testRP.VerifyAssertion(testChallenge, publicKey, clientDataJSON, authenticatorData, signature)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(7), signCount)
+
+	signature[0]++
+	_, err = testRP.VerifyAssertion(testChallenge, publicKey, clientDataJSON, authenticatorData, signature)
+	assert.Equal(t, ErrInvalidWebAuthnResponse, err)
+}
+
+func TestWebAuthnChallenge(t *testing.T) {
+	challenge, err := WebAuthnChallenge([]byte(`{"type":"webauthn.get","challenge":"` + testChallenge + `","origin":"` + testOrigin + `"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, testChallenge, challenge)
+
+	_, err = WebAuthnChallenge([]byte(`{"type":"webauthn.get"}`))
+	assert.Equal(t, ErrInvalidWebAuthnResponse, err)
+
+	_, err = WebAuthnChallenge([]byte(`not json`))
+	assert.Equal(t, ErrInvalidWebAuthnResponse, err)
+}
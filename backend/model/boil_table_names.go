@@ -4,13 +4,43 @@
 package model
 
 var TableNames = struct {
+	AccountNotice      string
+	EmailConfirmation  string
 	JWTAudienceSecret  string
 	JWTDenylist        string
+	JWTSigningKey      string
+	MfaChallenge       string
+	MfaRecoveryCode    string
+	PasswordHistory    string
+	RefreshTokenFamily string
+	SecurityEvent      string
+	SignInThrottle     string
 	SMSOtpVerification string
+	SMSOutbox          string
 	User               string
+	UserSession        string
+	UserTotp           string
+	WebauthnChallenge  string
+	WebauthnCredential string
+	WebauthnUser       string
 }{
+	AccountNotice:      "account_notice",
+	EmailConfirmation:  "email_confirmation",
 	JWTAudienceSecret:  "jwt_audience_secret",
 	JWTDenylist:        "jwt_denylist",
+	JWTSigningKey:      "jwt_signing_key",
+	MfaChallenge:       "mfa_challenge",
+	MfaRecoveryCode:    "mfa_recovery_code",
+	PasswordHistory:    "password_history",
+	RefreshTokenFamily: "refresh_token_family",
+	SecurityEvent:      "security_event",
+	SignInThrottle:     "sign_in_throttle",
 	SMSOtpVerification: "sms_otp_verification",
+	SMSOutbox:          "sms_outbox",
 	User:               "user",
+	UserSession:        "user_session",
+	UserTotp:           "user_totp",
+	WebauthnChallenge:  "webauthn_challenge",
+	WebauthnCredential: "webauthn_credential",
+	WebauthnUser:       "webauthn_user",
 }
@@ -29,16 +29,24 @@ type SMSOtpVerification struct { // SMS OTP 인증 아이디
 	VerificationToken string `boil:"verification_token" json:"verification_token" toml:"verification_token" yaml:"verification_token"`
 	// 핸드폰 번호
 	PhoneNumber string `boil:"phone_number" json:"phone_number" toml:"phone_number" yaml:"phone_number"`
+	// 요청 IP 주소
+	IPAddress string `boil:"ip_address" json:"ip_address" toml:"ip_address" yaml:"ip_address"`
+	// 인증 목적
+	Purpose string `boil:"purpose" json:"purpose" toml:"purpose" yaml:"purpose"`
 	// 인증 코드
-	OtpCode string `boil:"otp_code" json:"otp_code" toml:"otp_code" yaml:"otp_code"`
+	OtpCode null.String `boil:"otp_code" json:"otp_code,omitempty" toml:"otp_code" yaml:"otp_code,omitempty"`
+	// 인증 코드 해시
+	OtpCodeHash null.String `boil:"otp_code_hash" json:"otp_code_hash,omitempty" toml:"otp_code_hash" yaml:"otp_code_hash,omitempty"`
 	// 만료 일시
 	ExpiresAt time.Time `boil:"expires_at" json:"expires_at" toml:"expires_at" yaml:"expires_at"`
 	// 검증 시도 횟수
 	VerificationTrials int `boil:"verification_trials" json:"verification_trials" toml:"verification_trials" yaml:"verification_trials"`
 	// 검증 유효 일시
 	VerificationValidUntil null.Time `boil:"verification_valid_until" json:"verification_valid_until,omitempty" toml:"verification_valid_until" yaml:"verification_valid_until,omitempty"`
-	CreatedAt              time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
-	UpdatedAt              time.Time `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	// 사용 일시
+	ConsumedAt null.Time `boil:"consumed_at" json:"consumed_at,omitempty" toml:"consumed_at" yaml:"consumed_at,omitempty"`
+	CreatedAt  time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt  time.Time `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
 
 	R *smsOtpVerificationR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L smsOtpVerificationL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -48,20 +56,28 @@ var SMSOtpVerificationColumns = struct {
 	SMSOtpVerificationID   string
 	VerificationToken      string
 	PhoneNumber            string
+	IPAddress              string
+	Purpose                string
 	OtpCode                string
+	OtpCodeHash            string
 	ExpiresAt              string
 	VerificationTrials     string
 	VerificationValidUntil string
+	ConsumedAt             string
 	CreatedAt              string
 	UpdatedAt              string
 }{
 	SMSOtpVerificationID:   "sms_otp_verification_id",
 	VerificationToken:      "verification_token",
 	PhoneNumber:            "phone_number",
+	IPAddress:              "ip_address",
+	Purpose:                "purpose",
 	OtpCode:                "otp_code",
+	OtpCodeHash:            "otp_code_hash",
 	ExpiresAt:              "expires_at",
 	VerificationTrials:     "verification_trials",
 	VerificationValidUntil: "verification_valid_until",
+	ConsumedAt:             "consumed_at",
 	CreatedAt:              "created_at",
 	UpdatedAt:              "updated_at",
 }
@@ -70,68 +86,84 @@ var SMSOtpVerificationTableColumns = struct {
 	SMSOtpVerificationID   string
 	VerificationToken      string
 	PhoneNumber            string
+	IPAddress              string
+	Purpose                string
 	OtpCode                string
+	OtpCodeHash            string
 	ExpiresAt              string
 	VerificationTrials     string
 	VerificationValidUntil string
+	ConsumedAt             string
 	CreatedAt              string
 	UpdatedAt              string
 }{
 	SMSOtpVerificationID:   "sms_otp_verification.sms_otp_verification_id",
 	VerificationToken:      "sms_otp_verification.verification_token",
 	PhoneNumber:            "sms_otp_verification.phone_number",
+	IPAddress:              "sms_otp_verification.ip_address",
+	Purpose:                "sms_otp_verification.purpose",
 	OtpCode:                "sms_otp_verification.otp_code",
+	OtpCodeHash:            "sms_otp_verification.otp_code_hash",
 	ExpiresAt:              "sms_otp_verification.expires_at",
 	VerificationTrials:     "sms_otp_verification.verification_trials",
 	VerificationValidUntil: "sms_otp_verification.verification_valid_until",
+	ConsumedAt:             "sms_otp_verification.consumed_at",
 	CreatedAt:              "sms_otp_verification.created_at",
 	UpdatedAt:              "sms_otp_verification.updated_at",
 }
 
 // Generated where
 
-type whereHelpernull_Time struct{ field string }
+type whereHelpernull_String struct{ field string }
 
-func (w whereHelpernull_Time) EQ(x null.Time) qm.QueryMod {
+func (w whereHelpernull_String) EQ(x null.String) qm.QueryMod {
 	return qmhelper.WhereNullEQ(w.field, false, x)
 }
-func (w whereHelpernull_Time) NEQ(x null.Time) qm.QueryMod {
+func (w whereHelpernull_String) NEQ(x null.String) qm.QueryMod {
 	return qmhelper.WhereNullEQ(w.field, true, x)
 }
-func (w whereHelpernull_Time) LT(x null.Time) qm.QueryMod {
+func (w whereHelpernull_String) LT(x null.String) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.LT, x)
 }
-func (w whereHelpernull_Time) LTE(x null.Time) qm.QueryMod {
+func (w whereHelpernull_String) LTE(x null.String) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.LTE, x)
 }
-func (w whereHelpernull_Time) GT(x null.Time) qm.QueryMod {
+func (w whereHelpernull_String) GT(x null.String) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.GT, x)
 }
-func (w whereHelpernull_Time) GTE(x null.Time) qm.QueryMod {
+func (w whereHelpernull_String) GTE(x null.String) qm.QueryMod {
 	return qmhelper.Where(w.field, qmhelper.GTE, x)
 }
 
-func (w whereHelpernull_Time) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
-func (w whereHelpernull_Time) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+func (w whereHelpernull_String) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_String) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
 
 var SMSOtpVerificationWhere = struct {
 	SMSOtpVerificationID   whereHelperint
 	VerificationToken      whereHelperstring
 	PhoneNumber            whereHelperstring
-	OtpCode                whereHelperstring
+	IPAddress              whereHelperstring
+	Purpose                whereHelperstring
+	OtpCode                whereHelpernull_String
+	OtpCodeHash            whereHelpernull_String
 	ExpiresAt              whereHelpertime_Time
 	VerificationTrials     whereHelperint
 	VerificationValidUntil whereHelpernull_Time
+	ConsumedAt             whereHelpernull_Time
 	CreatedAt              whereHelpertime_Time
 	UpdatedAt              whereHelpertime_Time
 }{
 	SMSOtpVerificationID:   whereHelperint{field: "`sms_otp_verification`.`sms_otp_verification_id`"},
 	VerificationToken:      whereHelperstring{field: "`sms_otp_verification`.`verification_token`"},
 	PhoneNumber:            whereHelperstring{field: "`sms_otp_verification`.`phone_number`"},
-	OtpCode:                whereHelperstring{field: "`sms_otp_verification`.`otp_code`"},
+	IPAddress:              whereHelperstring{field: "`sms_otp_verification`.`ip_address`"},
+	Purpose:                whereHelperstring{field: "`sms_otp_verification`.`purpose`"},
+	OtpCode:                whereHelpernull_String{field: "`sms_otp_verification`.`otp_code`"},
+	OtpCodeHash:            whereHelpernull_String{field: "`sms_otp_verification`.`otp_code_hash`"},
 	ExpiresAt:              whereHelpertime_Time{field: "`sms_otp_verification`.`expires_at`"},
 	VerificationTrials:     whereHelperint{field: "`sms_otp_verification`.`verification_trials`"},
 	VerificationValidUntil: whereHelpernull_Time{field: "`sms_otp_verification`.`verification_valid_until`"},
+	ConsumedAt:             whereHelpernull_Time{field: "`sms_otp_verification`.`consumed_at`"},
 	CreatedAt:              whereHelpertime_Time{field: "`sms_otp_verification`.`created_at`"},
 	UpdatedAt:              whereHelpertime_Time{field: "`sms_otp_verification`.`updated_at`"},
 }
@@ -153,8 +185,8 @@ func (*smsOtpVerificationR) NewStruct() *smsOtpVerificationR {
 type smsOtpVerificationL struct{}
 
 var (
-	smsOtpVerificationAllColumns            = []string{"sms_otp_verification_id", "verification_token", "phone_number", "otp_code", "expires_at", "verification_trials", "verification_valid_until", "created_at", "updated_at"}
-	smsOtpVerificationColumnsWithoutDefault = []string{"verification_token", "phone_number", "otp_code", "expires_at", "verification_trials", "verification_valid_until"}
+	smsOtpVerificationAllColumns            = []string{"sms_otp_verification_id", "verification_token", "phone_number", "ip_address", "purpose", "otp_code", "otp_code_hash", "expires_at", "verification_trials", "verification_valid_until", "consumed_at", "created_at", "updated_at"}
+	smsOtpVerificationColumnsWithoutDefault = []string{"verification_token", "phone_number", "ip_address", "purpose", "otp_code", "otp_code_hash", "expires_at", "verification_trials", "verification_valid_until", "consumed_at"}
 	smsOtpVerificationColumnsWithDefault    = []string{"sms_otp_verification_id", "created_at", "updated_at"}
 	smsOtpVerificationPrimaryKeyColumns     = []string{"sms_otp_verification_id"}
 )
@@ -0,0 +1,28 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/friendsofgo/errors"
+)
+
+// GetLock takes the named lock of MySQL, waiting up to timeoutSec for it, and returns false if another session holds it.
+// The lock belongs to the session, so it should be released with ReleaseLock on the same conn.
+func GetLock(ctx context.Context, conn *sql.Conn, name string, timeoutSec int) (bool, error) {
+	var locked sql.NullInt64
+	if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", name, timeoutSec).Scan(&locked); err != nil {
+		return false, errors.WithStack(err)
+	}
+	if !locked.Valid {
+		return false, errors.Errorf("failed to get lock: %s", name)
+	}
+	return locked.Int64 == 1, nil
+}
+
+func ReleaseLock(ctx context.Context, conn *sql.Conn, name string) error {
+	if _, err := conn.ExecContext(ctx, "SELECT RELEASE_LOCK(?)", name); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
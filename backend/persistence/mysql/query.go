@@ -2,9 +2,12 @@ package mysql
 
 import (
 	"context"
+	"time"
 
 	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
 
 	"github.com/sean-ahn/user/backend/model"
 )
@@ -17,6 +20,15 @@ func GetUser(ctx context.Context, exec boil.ContextExecutor, id int) (*model.Use
 	return u, nil
 }
 
+// GetUserForUpdate is the same as GetUser, but locks the row until the transaction ends.
+func GetUserForUpdate(ctx context.Context, exec boil.ContextExecutor, id int) (*model.User, error) {
+	u, err := model.Users(model.UserWhere.UserID.EQ(id), qm.For("UPDATE")).One(ctx, exec)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return u, nil
+}
+
 func FindUserByEmail(ctx context.Context, exec boil.ContextExecutor, email string) (*model.User, error) {
 	u, err := model.Users(model.UserWhere.Email.EQ(email)).One(ctx, exec)
 	if err != nil {
@@ -41,6 +53,148 @@ func FindJWTDenylistByJTI(ctx context.Context, exec boil.ContextExecutor, jti st
 	return d, nil
 }
 
+func FindRefreshTokenFamilyByFamilyID(ctx context.Context, exec boil.ContextExecutor, familyID string) (*model.RefreshTokenFamily, error) {
+	f, err := model.RefreshTokenFamilies(model.RefreshTokenFamilyWhere.FamilyID.EQ(familyID)).One(ctx, exec)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return f, nil
+}
+
+func FindUserSessionByFamilyID(ctx context.Context, exec boil.ContextExecutor, familyID string) (*model.UserSession, error) {
+	s, err := model.UserSessions(model.UserSessionWhere.FamilyID.EQ(familyID)).One(ctx, exec)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return s, nil
+}
+
+// ListActiveUserSessions returns sessions used after usedAfter whose refresh token family has not been revoked.
+func ListActiveUserSessions(ctx context.Context, exec boil.ContextExecutor, userID int, usedAfter time.Time) (model.UserSessionSlice, error) {
+	ss, err := model.UserSessions(
+		qm.Select(model.TableNames.UserSession+".*"),
+		qm.InnerJoin(model.TableNames.RefreshTokenFamily+" ON "+model.TableNames.RefreshTokenFamily+"."+model.RefreshTokenFamilyColumns.FamilyID+" = "+model.TableNames.UserSession+"."+model.UserSessionColumns.FamilyID),
+		model.UserSessionWhere.UserID.EQ(userID),
+		model.UserSessionWhere.LastUsedAt.GT(usedAfter),
+		model.RefreshTokenFamilyWhere.RevokedAt.IsNull(),
+		qm.OrderBy(model.UserSessionColumns.LastUsedAt+" DESC"),
+	).All(ctx, exec)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return ss, nil
+}
+
+func FindEmailConfirmationByCodeHash(ctx context.Context, exec boil.ContextExecutor, codeHash string) (*model.EmailConfirmation, error) {
+	c, err := model.EmailConfirmations(model.EmailConfirmationWhere.CodeHash.EQ(codeHash)).One(ctx, exec)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return c, nil
+}
+
+func FindLatestEmailConfirmationByUserID(ctx context.Context, exec boil.ContextExecutor, userID int) (*model.EmailConfirmation, error) {
+	c, err := model.EmailConfirmations(
+		model.EmailConfirmationWhere.UserID.EQ(userID),
+		qm.OrderBy(model.EmailConfirmationColumns.CreatedAt+" DESC"),
+	).One(ctx, exec)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return c, nil
+}
+
+// ListRecentPasswordHistoriesByUserID returns at most limit passwords the user has replaced, the latest first.
+func ListRecentPasswordHistoriesByUserID(ctx context.Context, exec boil.ContextExecutor, userID int, limit int) (model.PasswordHistorySlice, error) {
+	hs, err := model.PasswordHistories(
+		model.PasswordHistoryWhere.UserID.EQ(userID),
+		qm.OrderBy(model.PasswordHistoryColumns.PasswordHistoryID+" DESC"),
+		qm.Limit(limit),
+	).All(ctx, exec)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return hs, nil
+}
+
+func FindUserTOTPByUserID(ctx context.Context, exec boil.ContextExecutor, userID int) (*model.UserTotp, error) {
+	t, err := model.UserTotps(model.UserTotpWhere.UserID.EQ(userID)).One(ctx, exec)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return t, nil
+}
+
+func CountUnconsumedMFARecoveryCodesByUserID(ctx context.Context, exec boil.ContextExecutor, userID int) (int64, error) {
+	n, err := model.MfaRecoveryCodes(
+		model.MfaRecoveryCodeWhere.UserID.EQ(userID),
+		model.MfaRecoveryCodeWhere.ConsumedAt.IsNull(),
+	).Count(ctx, exec)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	return n, nil
+}
+
+func FindMFAChallengeByChallengeToken(ctx context.Context, exec boil.ContextExecutor, token string) (*model.MfaChallenge, error) {
+	c, err := model.MfaChallenges(model.MfaChallengeWhere.ChallengeToken.EQ(token)).One(ctx, exec)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return c, nil
+}
+
+func FindWebAuthnCredentialByCredentialID(ctx context.Context, exec boil.ContextExecutor, credentialID string) (*model.WebauthnCredential, error) {
+	c, err := model.WebauthnCredentials(model.WebauthnCredentialWhere.CredentialID.EQ(credentialID)).One(ctx, exec)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return c, nil
+}
+
+func ListWebAuthnCredentialsByUserID(ctx context.Context, exec boil.ContextExecutor, userID int) (model.WebauthnCredentialSlice, error) {
+	cs, err := model.WebauthnCredentials(
+		model.WebauthnCredentialWhere.UserID.EQ(userID),
+		qm.OrderBy(model.WebauthnCredentialColumns.WebauthnCredentialID),
+	).All(ctx, exec)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return cs, nil
+}
+
+func FindWebAuthnChallengeByChallenge(ctx context.Context, exec boil.ContextExecutor, challenge string) (*model.WebauthnChallenge, error) {
+	c, err := model.WebauthnChallenges(model.WebauthnChallengeWhere.Challenge.EQ(challenge)).One(ctx, exec)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return c, nil
+}
+
+// ListPendingWebAuthnChallengesByIPAddress returns the unexpired challenges of the ceremony created from the IP
+// address and not used yet, the earliest to expire first.
+func ListPendingWebAuthnChallengesByIPAddress(ctx context.Context, exec boil.ContextExecutor, ceremony, ipAddress string, now time.Time) (model.WebauthnChallengeSlice, error) {
+	cs, err := model.WebauthnChallenges(
+		model.WebauthnChallengeWhere.IPAddress.EQ(ipAddress),
+		model.WebauthnChallengeWhere.ExpiresAt.GT(now),
+		model.WebauthnChallengeWhere.Ceremony.EQ(ceremony),
+		model.WebauthnChallengeWhere.ConsumedAt.IsNull(),
+		qm.OrderBy(model.WebauthnChallengeColumns.ExpiresAt),
+	).All(ctx, exec)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return cs, nil
+}
+
+func FindWebAuthnUserByUserID(ctx context.Context, exec boil.ContextExecutor, userID int) (*model.WebauthnUser, error) {
+	u, err := model.WebauthnUsers(model.WebauthnUserWhere.UserID.EQ(userID)).One(ctx, exec)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return u, nil
+}
+
 func FindSMSOTPVerificationByVerificationToken(ctx context.Context, exec boil.ContextExecutor, token string) (*model.SMSOtpVerification, error) {
 	v, err := model.SMSOtpVerifications(model.SMSOtpVerificationWhere.VerificationToken.EQ(token)).One(ctx, exec)
 	if err != nil {
@@ -48,3 +202,108 @@ func FindSMSOTPVerificationByVerificationToken(ctx context.Context, exec boil.Co
 	}
 	return v, nil
 }
+
+// ListRecentSMSOTPVerificationsByPhoneNumber returns at most limit verifications created since the time, the latest
+// first.
+func ListRecentSMSOTPVerificationsByPhoneNumber(ctx context.Context, exec boil.ContextExecutor, phoneNumber string, since time.Time, limit int) (model.SMSOtpVerificationSlice, error) {
+	vs, err := model.SMSOtpVerifications(
+		model.SMSOtpVerificationWhere.PhoneNumber.EQ(phoneNumber),
+		model.SMSOtpVerificationWhere.CreatedAt.GTE(since),
+		qm.OrderBy(model.SMSOtpVerificationColumns.CreatedAt+" DESC"),
+		qm.Limit(limit),
+	).All(ctx, exec)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return vs, nil
+}
+
+// ListRecentSMSOTPVerificationsByIPAddress returns at most limit verifications created since the time, the latest
+// first.
+func ListRecentSMSOTPVerificationsByIPAddress(ctx context.Context, exec boil.ContextExecutor, ipAddress string, since time.Time, limit int) (model.SMSOtpVerificationSlice, error) {
+	vs, err := model.SMSOtpVerifications(
+		model.SMSOtpVerificationWhere.IPAddress.EQ(ipAddress),
+		model.SMSOtpVerificationWhere.CreatedAt.GTE(since),
+		qm.OrderBy(model.SMSOtpVerificationColumns.CreatedAt+" DESC"),
+		qm.Limit(limit),
+	).All(ctx, exec)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return vs, nil
+}
+
+// ListDueSMSOutboxes returns at most limit messages of the status whose next attempt is due, the earliest first.
+func ListDueSMSOutboxes(ctx context.Context, exec boil.ContextExecutor, status string, now time.Time, limit int) (model.SMSOutboxSlice, error) {
+	outboxes, err := model.SMSOutboxes(
+		model.SMSOutboxWhere.Status.EQ(status),
+		model.SMSOutboxWhere.NextAttemptAt.LTE(now),
+		qm.OrderBy(model.SMSOutboxColumns.NextAttemptAt),
+		qm.Limit(limit),
+	).All(ctx, exec)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return outboxes, nil
+}
+
+func FindLatestSMSOutboxBySMSOTPVerificationID(ctx context.Context, exec boil.ContextExecutor, verificationID int) (*model.SMSOutbox, error) {
+	o, err := model.SMSOutboxes(
+		model.SMSOutboxWhere.SMSOtpVerificationID.EQ(null.IntFrom(verificationID)),
+		qm.OrderBy(model.SMSOutboxColumns.SMSOutboxID+" DESC"),
+	).One(ctx, exec)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return o, nil
+}
+
+func FindSignInThrottle(ctx context.Context, exec boil.ContextExecutor, scope, key string) (*model.SignInThrottle, error) {
+	t, err := model.SignInThrottles(
+		model.SignInThrottleWhere.Scope.EQ(scope),
+		model.SignInThrottleWhere.ScopeKey.EQ(key),
+	).One(ctx, exec)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return t, nil
+}
+
+// FindSignInThrottleForUpdate is the same as FindSignInThrottle, but locks the row until the transaction ends.
+func FindSignInThrottleForUpdate(ctx context.Context, exec boil.ContextExecutor, scope, key string) (*model.SignInThrottle, error) {
+	t, err := model.SignInThrottles(
+		model.SignInThrottleWhere.Scope.EQ(scope),
+		model.SignInThrottleWhere.ScopeKey.EQ(key),
+		qm.For("UPDATE"),
+	).One(ctx, exec)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return t, nil
+}
+
+// ListBlockedSignInThrottles returns the throttles blocking sign-ins at the time, the latest to be unblocked first.
+func ListBlockedSignInThrottles(ctx context.Context, exec boil.ContextExecutor, now time.Time) (model.SignInThrottleSlice, error) {
+	ts, err := model.SignInThrottles(
+		model.SignInThrottleWhere.BlockedUntil.GT(null.TimeFrom(now)),
+		qm.OrderBy(model.SignInThrottleColumns.BlockedUntil+" DESC"),
+	).All(ctx, exec)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return ts, nil
+}
+
+func ListUnretiredJWTSigningKeys(ctx context.Context, exec boil.ContextExecutor, now time.Time) (model.JWTSigningKeySlice, error) {
+	ks, err := model.JWTSigningKeys(
+		qm.Expr(
+			model.JWTSigningKeyWhere.RetiresAt.IsNull(),
+			qm.Or2(model.JWTSigningKeyWhere.RetiresAt.GT(null.TimeFrom(now))),
+		),
+		qm.OrderBy(model.JWTSigningKeyColumns.ActivatesAt+" DESC, "+model.JWTSigningKeyColumns.JWTSigningKeyID+" DESC"),
+	).All(ctx, exec)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return ks, nil
+}
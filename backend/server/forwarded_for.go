@@ -0,0 +1,83 @@
+package server
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const headerKeyForwardedFor = "X-Forwarded-For"
+
+// forwardedForInterceptor replaces X-Forwarded-For with the single address of the client, so that the handlers don't
+// have to trust what the client gives. The addresses are walked from the peer back to the client as long as the one
+// which appended the next address is a trusted proxy, and the first untrusted one is the client.
+func forwardedForInterceptor(trustedProxies []*net.IPNet) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		md = md.Copy()
+
+		md.Delete(headerKeyForwardedFor)
+		if addr := resolveClientAddr(ctx, trustedProxies); addr != nil {
+			md.Set(headerKeyForwardedFor, addr.String())
+		}
+
+		return handler(metadata.NewIncomingContext(ctx, md), req)
+	}
+}
+
+func resolveClientAddr(ctx context.Context, trustedProxies []*net.IPNet) net.IP {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return nil
+	}
+	addr := net.ParseIP(host)
+	if addr == nil {
+		return nil
+	}
+
+	var hops []string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for _, v := range md.Get(headerKeyForwardedFor) {
+			hops = append(hops, strings.Split(v, ",")...)
+		}
+	}
+
+	for i := len(hops) - 1; i >= 0 && containsIP(trustedProxies, addr); i-- {
+		hop := net.ParseIP(strings.TrimSpace(hops[i]))
+		if hop == nil {
+			break
+		}
+		addr = hop
+	}
+	return addr
+}
+
+func containsIP(nets []*net.IPNet, ip net.IP) bool {
+	for _, n := range nets {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseCIDRs(ss []string) ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, 0, len(ss))
+	for _, s := range ss {
+		_, n, err := net.ParseCIDR(strings.TrimSpace(s))
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		nets = append(nets, n)
+	}
+	return nets, nil
+}
@@ -0,0 +1,90 @@
+package server
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func Test_forwardedForInterceptor(t *testing.T) {
+	trustedProxies, err := parseCIDRs([]string{"127.0.0.1/32", "10.0.0.0/8"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name         string
+		peerAddr     string
+		forwardedFor []string
+
+		expected []string
+	}{
+		{
+			name:         "through gateway",
+			peerAddr:     "127.0.0.1:52814",
+			forwardedFor: []string{"203.0.113.1"},
+			expected:     []string{"203.0.113.1"},
+		},
+		{
+			name:         "through trusted proxies",
+			peerAddr:     "127.0.0.1:52814",
+			forwardedFor: []string{"203.0.113.1, 10.0.0.7", "10.0.0.8"},
+			expected:     []string{"203.0.113.1"},
+		},
+		{
+			name:         "spoofed by client through gateway",
+			peerAddr:     "127.0.0.1:52814",
+			forwardedFor: []string{"198.51.100.7, 203.0.113.1"},
+			expected:     []string{"203.0.113.1"},
+		},
+		{
+			name:         "spoofed by client directly",
+			peerAddr:     "203.0.113.1:52814",
+			forwardedFor: []string{"198.51.100.7"},
+			expected:     []string{"203.0.113.1"},
+		},
+		{
+			name:         "malformed",
+			peerAddr:     "127.0.0.1:52814",
+			forwardedFor: []string{"unknown"},
+			expected:     []string{"127.0.0.1"},
+		},
+		{
+			name:     "no forwarded for",
+			peerAddr: "[2001:db8::1]:52814",
+			expected: []string{"2001:db8::1"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			addr, err := net.ResolveTCPAddr("tcp", tc.peerAddr)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			md := metadata.MD{}
+			for _, v := range tc.forwardedFor {
+				md.Append(headerKeyForwardedFor, v)
+			}
+			ctx := peer.NewContext(metadata.NewIncomingContext(context.Background(), md), &peer.Peer{Addr: addr})
+
+			var got []string
+			_, err = forwardedForInterceptor(trustedProxies)(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+				md, _ := metadata.FromIncomingContext(ctx)
+				got = md.Get(headerKeyForwardedFor)
+				return nil, nil
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
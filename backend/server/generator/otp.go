@@ -1,20 +1,55 @@
 package generator
 
 import (
-	"math/rand"
-	"strconv"
+	"crypto/rand"
+	"io"
 )
 
+const (
+	DigitAlphabet = "0123456789"
+	// AlphanumericAlphabet leaves out I and O, which are easily mistaken for 1 and 0.
+	AlphanumericAlphabet = "0123456789ABCDEFGHJKLMNPQRSTUVWXYZ"
+)
+
+// OTPGenerator generates codes of Len characters picked uniformly at random from Alphabet, which is DigitAlphabet if
+// empty. The alphabet must consist of distinct ASCII characters.
 type OTPGenerator struct {
-	Len int
+	Len      int
+	Alphabet string
+
+	// rand is crypto/rand.Reader if nil
+	rand io.Reader
 }
 
 var _ Generator = (*OTPGenerator)(nil)
 
+// Generate panics if the random source fails, as uuid.New does.
 func (g *OTPGenerator) Generate() string {
-	var code string
-	for i := 0; i < g.Len; i++ {
-		code += strconv.Itoa(rand.Intn(10))
+	alphabet := g.Alphabet
+	if alphabet == "" {
+		alphabet = DigitAlphabet
+	}
+	r := g.rand
+	if r == nil {
+		r = rand.Reader
+	}
+
+	// bytes not less than the largest multiple of the alphabet size are rejected, so that every character is
+	// equally likely
+	n := len(alphabet)
+	limit := 256 - 256%n
+
+	code := make([]byte, 0, g.Len)
+	buf := make([]byte, g.Len)
+	for len(code) < g.Len {
+		if _, err := io.ReadFull(r, buf[:g.Len-len(code)]); err != nil {
+			panic(err)
+		}
+		for _, b := range buf[:g.Len-len(code)] {
+			if int(b) < limit {
+				code = append(code, alphabet[int(b)%n])
+			}
+		}
 	}
-	return code
+	return string(code)
 }
@@ -0,0 +1,126 @@
+package generator
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// chiSquareCritical approximates the value the chi-square statistic with df degrees of freedom exceeds with
+// probability 1e-6, by the Wilson-Hilferty transformation. Uniform generators fail the tests about once in a million
+// runs.
+func chiSquareCritical(df int) float64 {
+	const z = 4.753 // upper 1e-6 quantile of the standard normal distribution
+
+	k := float64(df)
+	return k * math.Pow(1-2/(9*k)+z*math.Sqrt(2/(9*k)), 3)
+}
+
+// assertUniform generates n codes and tests that every character of the alphabet is equally likely at every
+// position.
+func assertUniform(t *testing.T, g Generator, alphabet string, length, n int) {
+	counts := make([]map[rune]int, length)
+	for i := range counts {
+		counts[i] = make(map[rune]int)
+	}
+
+	for i := 0; i < n; i++ {
+		code := g.Generate()
+		if !assert.Len(t, code, length) {
+			return
+		}
+		for pos, c := range code {
+			if !assert.Contains(t, alphabet, string(c)) {
+				return
+			}
+			counts[pos][c]++
+		}
+	}
+
+	expected := float64(n) / float64(len(alphabet))
+	critical := chiSquareCritical(len(alphabet) - 1)
+	for pos := range counts {
+		var stat float64
+		for _, c := range alphabet {
+			d := float64(counts[pos][c]) - expected
+			stat += d * d / expected
+		}
+		assert.Lessf(t, stat, critical, "not uniform at %d", pos)
+	}
+}
+
+func TestOTPGenerator_Generate_Uniform(t *testing.T) {
+	cases := []struct {
+		name     string
+		g        *OTPGenerator
+		alphabet string
+	}{
+		{
+			name:     "digits by default",
+			g:        &OTPGenerator{Len: 6},
+			alphabet: DigitAlphabet,
+		},
+		{
+			name:     "alphanumeric",
+			g:        &OTPGenerator{Len: 8, Alphabet: AlphanumericAlphabet},
+			alphabet: AlphanumericAlphabet,
+		},
+		{
+			name:     "alphabet of 3",
+			g:        &OTPGenerator{Len: 4, Alphabet: "abc"},
+			alphabet: "abc",
+		},
+		{
+			name:     "printable ascii",
+			g:        &OTPGenerator{Len: 2, Alphabet: printableASCII()},
+			alphabet: printableASCII(),
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			assertUniform(t, tc.g, tc.alphabet, tc.g.Len, 2000*len(tc.alphabet))
+		})
+	}
+}
+
+func TestOTPGenerator_Generate_Unpredictable(t *testing.T) {
+	// there are 10^16 codes, so that a collision in a hundred thousand is all but impossible for random codes
+	g := &OTPGenerator{Len: 16}
+
+	seen := make(map[string]struct{})
+	for i := 0; i < 100000; i++ {
+		code := g.Generate()
+		if _, ok := seen[code]; ok {
+			t.Fatalf("%s is generated twice", code)
+		}
+		seen[code] = struct{}{}
+	}
+}
+
+func TestOTPGenerator_Generate_Rejection(t *testing.T) {
+	// 250 and above are rejected for the digits, since 256 is not a multiple of 10
+	g := &OTPGenerator{
+		Len:  6,
+		rand: bytes.NewReader([]byte{250, 0, 255, 19, 249, 252, 253, 37, 100, 6}),
+	}
+
+	assert.Equal(t, "099706", g.Generate())
+}
+
+func TestOTPGenerator_Generate_Empty(t *testing.T) {
+	assert.Equal(t, "", (&OTPGenerator{}).Generate())
+}
+
+func printableASCII() string {
+	var b strings.Builder
+	for c := byte(' '); c <= '~'; c++ {
+		b.WriteByte(c)
+	}
+	return b.String()
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/sean-ahn/user/backend/config"
 	"github.com/sean-ahn/user/backend/server/generator"
 	"github.com/sean-ahn/user/backend/server/handler"
+	"github.com/sean-ahn/user/backend/server/message"
 	userv1 "github.com/sean-ahn/user/proto/gen/go/user/v1"
 )
 
@@ -32,23 +33,38 @@ func NewUserServer(cfg config.Config) (*UserServer, error) {
 }
 
 func (s *UserServer) RequestSmsOtp(ctx context.Context, req *userv1.RequestSmsOtpRequest) (*userv1.RequestSmsOtpResponse, error) {
-	return handler.RequestSmsOtp(s.cfg.Clock(), s.cfg.DB(), &generator.UUIDGenerator{}, &generator.OTPGenerator{Len: s.cfg.Setting().SMSOTPCodeLength}, s.cfg.SmsV1Client())(ctx, req)
+	return handler.RequestSmsOtp(s.cfg.Clock(), s.cfg.DB(), &generator.UUIDGenerator{}, &generator.OTPGenerator{Len: s.cfg.Setting().SMSOTPCodeLength}, s.cfg.SMSOTPHasher(), s.cfg.SMSOTPLimiter(), s.cfg.SMSTemplates(), message.OTPAutofill{
+		WebOTPDomain:   s.cfg.Setting().SMSWebOTPDomain,
+		AndroidAppHash: s.cfg.Setting().SMSAndroidAppHash,
+	})(ctx, req)
 }
 
 func (s *UserServer) VerifySmsOtp(ctx context.Context, req *userv1.VerifySmsOtpRequest) (*userv1.VerifySmsOtpResponse, error) {
-	return handler.VerifySmsOtp(s.cfg.Clock(), s.cfg.DB())(ctx, req)
+	return handler.VerifySmsOtp(s.cfg.Clock(), s.cfg.DB(), s.cfg.SMSOTPHasher())(ctx, req)
+}
+
+func (s *UserServer) GetSmsOtpDeliveryStatus(ctx context.Context, req *userv1.GetSmsOtpDeliveryStatusRequest) (*userv1.GetSmsOtpDeliveryStatusResponse, error) {
+	return handler.GetSmsOtpDeliveryStatus(s.cfg.DB())(ctx, req)
 }
 
 func (s *UserServer) ConfirmEmail(ctx context.Context, req *userv1.ConfirmEmailRequest) (*userv1.ConfirmEmailResponse, error) {
-	return handler.ConfirmEmail(s.cfg.DB())(ctx, req)
+	return handler.ConfirmEmail(s.cfg.EmailConfirmationService())(ctx, req)
+}
+
+func (s *UserServer) ResendConfirmationEmail(ctx context.Context, req *userv1.ResendConfirmationEmailRequest) (*userv1.ResendConfirmationEmailResponse, error) {
+	return handler.ResendConfirmationEmail(s.cfg.DB(), s.cfg.EmailConfirmationService(), s.cfg.AccountNoticeService(), s.cfg.Setting().EnumerationResistant)(ctx, req)
 }
 
 func (s *UserServer) Register(ctx context.Context, req *userv1.RegisterRequest) (*userv1.RegisterResponse, error) {
-	return handler.Register(s.cfg.Clock(), s.cfg.DB(), s.cfg.PasswordHasher())(ctx, req)
+	return handler.Register(s.cfg.Clock(), s.cfg.DB(), s.cfg.PasswordHasher(), s.cfg.PasswordChecker(), s.cfg.EmailConfirmationService(), s.cfg.AccountNoticeService(), s.cfg.Setting().EnumerationResistant)(ctx, req)
 }
 
 func (s *UserServer) SignIn(ctx context.Context, req *userv1.SignInRequest) (*userv1.SignInResponse, error) {
-	return handler.SignIn(s.cfg.PasswordHasher(), s.cfg.DB(), s.cfg.UserTokenService())(ctx, req)
+	return handler.SignIn(s.cfg.PasswordHasher(), s.cfg.DB(), s.cfg.UserTokenService(), s.cfg.MFAService(), s.cfg.SignInThrottle(), s.cfg.Setting().DefaultPhoneRegion, s.cfg.Setting().EnumerationResistant, s.cfg.DummyPasswordHash())(ctx, req)
+}
+
+func (s *UserServer) CompleteMfaChallenge(ctx context.Context, req *userv1.CompleteMfaChallengeRequest) (*userv1.CompleteMfaChallengeResponse, error) {
+	return handler.CompleteMfaChallenge(s.cfg.MFAService(), s.cfg.UserTokenService())(ctx, req)
 }
 
 func (s *UserServer) SignOut(ctx context.Context, req *userv1.SignOutRequest) (*userv1.SignOutResponse, error) {
@@ -59,26 +75,97 @@ func (s *UserServer) RefreshToken(ctx context.Context, req *userv1.RefreshTokenR
 	return handler.RefreshToken(s.cfg.UserTokenService())(ctx, req)
 }
 
+func (s *UserServer) IntrospectToken(ctx context.Context, req *userv1.IntrospectTokenRequest) (*userv1.IntrospectTokenResponse, error) {
+	return handler.IntrospectToken(s.cfg.IntrospectionClients(), s.cfg.UserTokenService())(ctx, req)
+}
+
 func (s *UserServer) ResetPassword(ctx context.Context, req *userv1.ResetPasswordRequest) (*userv1.ResetPasswordResponse, error) {
-	return handler.ResetPassword(s.cfg.Clock(), s.cfg.DB(), s.cfg.PasswordHasher(), s.cfg.UserTokenService())(ctx, req)
+	return handler.ResetPassword(s.cfg.Clock(), s.cfg.DB(), s.cfg.PasswordHasher(), s.cfg.PasswordChecker(), s.cfg.UserTokenService(), s.cfg.PasswordHistoryService(), s.cfg.AccountNoticeService(), s.cfg.SMSTemplates(), s.cfg.Setting().EnumerationResistant)(ctx, req)
+}
+
+func (s *UserServer) UnlockAccount(ctx context.Context, req *userv1.UnlockAccountRequest) (*userv1.UnlockAccountResponse, error) {
+	return handler.UnlockAccount(s.cfg.Clock(), s.cfg.DB(), s.cfg.SignInThrottle(), s.cfg.Setting().EnumerationResistant)(ctx, req)
 }
 
 func (s *UserServer) GetMyPersonalInfo(ctx context.Context, req *userv1.GetMyPersonalInfoRequest) (*userv1.GetMyPersonalInfoResponse, error) {
 	return handler.GetMyPersonalInfo(s.cfg.UserTokenService())(ctx, req)
 }
 
+func (s *UserServer) ListMySessions(ctx context.Context, req *userv1.ListMySessionsRequest) (*userv1.ListMySessionsResponse, error) {
+	return handler.ListMySessions(s.cfg.UserTokenService())(ctx, req)
+}
+
+func (s *UserServer) RevokeSession(ctx context.Context, req *userv1.RevokeSessionRequest) (*userv1.RevokeSessionResponse, error) {
+	return handler.RevokeSession(s.cfg.UserTokenService())(ctx, req)
+}
+
+func (s *UserServer) RevokeOtherSessions(ctx context.Context, req *userv1.RevokeOtherSessionsRequest) (*userv1.RevokeOtherSessionsResponse, error) {
+	return handler.RevokeOtherSessions(s.cfg.UserTokenService())(ctx, req)
+}
+
+func (s *UserServer) ChangePassword(ctx context.Context, req *userv1.ChangePasswordRequest) (*userv1.ChangePasswordResponse, error) {
+	return handler.ChangePassword(s.cfg.DB(), s.cfg.PasswordHasher(), s.cfg.PasswordChecker(), s.cfg.UserTokenService(), s.cfg.SignInThrottle(), s.cfg.PasswordHistoryService())(ctx, req)
+}
+
+func (s *UserServer) BeginTotpEnrollment(ctx context.Context, req *userv1.BeginTotpEnrollmentRequest) (*userv1.BeginTotpEnrollmentResponse, error) {
+	return handler.BeginTotpEnrollment(s.cfg.UserTokenService(), s.cfg.MFAService())(ctx, req)
+}
+
+func (s *UserServer) ConfirmTotpEnrollment(ctx context.Context, req *userv1.ConfirmTotpEnrollmentRequest) (*userv1.ConfirmTotpEnrollmentResponse, error) {
+	return handler.ConfirmTotpEnrollment(s.cfg.UserTokenService(), s.cfg.MFAService())(ctx, req)
+}
+
+func (s *UserServer) RegenerateRecoveryCodes(ctx context.Context, req *userv1.RegenerateRecoveryCodesRequest) (*userv1.RegenerateRecoveryCodesResponse, error) {
+	return handler.RegenerateRecoveryCodes(s.cfg.UserTokenService(), s.cfg.MFAService())(ctx, req)
+}
+
+func (s *UserServer) BeginWebauthnRegistration(ctx context.Context, req *userv1.BeginWebauthnRegistrationRequest) (*userv1.BeginWebauthnRegistrationResponse, error) {
+	return handler.BeginWebauthnRegistration(s.cfg.UserTokenService(), s.cfg.WebAuthnService())(ctx, req)
+}
+
+func (s *UserServer) FinishWebauthnRegistration(ctx context.Context, req *userv1.FinishWebauthnRegistrationRequest) (*userv1.FinishWebauthnRegistrationResponse, error) {
+	return handler.FinishWebauthnRegistration(s.cfg.UserTokenService(), s.cfg.WebAuthnService())(ctx, req)
+}
+
+func (s *UserServer) BeginWebauthnSignIn(ctx context.Context, req *userv1.BeginWebauthnSignInRequest) (*userv1.BeginWebauthnSignInResponse, error) {
+	return handler.BeginWebauthnSignIn(s.cfg.WebAuthnService())(ctx, req)
+}
+
+func (s *UserServer) FinishWebauthnSignIn(ctx context.Context, req *userv1.FinishWebauthnSignInRequest) (*userv1.FinishWebauthnSignInResponse, error) {
+	return handler.FinishWebauthnSignIn(s.cfg.WebAuthnService(), s.cfg.UserTokenService(), s.cfg.SignInThrottle())(ctx, req)
+}
+
+type UserAdminServer struct {
+	userv1.UnimplementedUserAdminServiceServer
+
+	cfg config.Config
+}
+
+func NewUserAdminServer(cfg config.Config) (*UserAdminServer, error) {
+	return &UserAdminServer{
+		cfg: cfg,
+	}, nil
+}
+
+func (s *UserAdminServer) RotateSigningKey(ctx context.Context, req *userv1.RotateSigningKeyRequest) (*userv1.RotateSigningKeyResponse, error) {
+	return handler.RotateSigningKey(s.cfg.SigningKeyRotator())(ctx, req)
+}
+
+func (s *UserAdminServer) ListSigningKeys(ctx context.Context, req *userv1.ListSigningKeysRequest) (*userv1.ListSigningKeysResponse, error) {
+	return handler.ListSigningKeys(s.cfg.SigningKeyStore())(ctx, req)
+}
+
+func (s *UserAdminServer) ListSignInLockouts(ctx context.Context, req *userv1.ListSignInLockoutsRequest) (*userv1.ListSignInLockoutsResponse, error) {
+	return handler.ListSignInLockouts(s.cfg.SignInThrottle())(ctx, req)
+}
+
 func NewGRPCServer(cfg config.Config) (*grpc.Server, error) {
-	logrus.ErrorKey = "grpc.error"
-	log := logrus.New()
-	log.SetFormatter(&logrus.JSONFormatter{})
+	trustedProxies, err := parseCIDRs(cfg.Setting().TrustedProxyCIDRs)
+	if err != nil {
+		return nil, err
+	}
 
-	srv := grpc.NewServer(
-		grpc_middleware.WithUnaryServerChain(
-			grpc_recovery.UnaryServerInterceptor(grpc_recovery.WithRecoveryHandler(func(p interface{}) (err error) {
-				return status.Errorf(codes.Unknown, "panic triggered: %v", p)
-			})),
-		),
-	)
+	srv := newGRPCServer(forwardedForInterceptor(trustedProxies))
 
 	userServer, err := NewUserServer(cfg)
 	if err != nil {
@@ -94,3 +181,37 @@ func NewGRPCServer(cfg config.Config) (*grpc.Server, error) {
 
 	return srv, nil
 }
+
+// NewAdminGRPCServer serves UserAdminService, which has no authentication of its own. It is listened on a separate
+// port, which must be reachable only from the internal network.
+func NewAdminGRPCServer(cfg config.Config) (*grpc.Server, error) {
+	srv := newGRPCServer()
+
+	userAdminServer, err := NewUserAdminServer(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	userv1.RegisterUserAdminServiceServer(srv, userAdminServer)
+
+	healthServer := health.NewServer()
+	grpc_health_v1.RegisterHealthServer(srv, healthServer)
+
+	reflection.Register(srv)
+
+	return srv, nil
+}
+
+func newGRPCServer(interceptors ...grpc.UnaryServerInterceptor) *grpc.Server {
+	logrus.ErrorKey = "grpc.error"
+	log := logrus.New()
+	log.SetFormatter(&logrus.JSONFormatter{})
+
+	return grpc.NewServer(
+		grpc_middleware.WithUnaryServerChain(append([]grpc.UnaryServerInterceptor{
+			grpc_recovery.UnaryServerInterceptor(grpc_recovery.WithRecoveryHandler(func(p interface{}) (err error) {
+				return status.Errorf(codes.Unknown, "panic triggered: %v", p)
+			})),
+		}, interceptors...)...),
+	)
+}
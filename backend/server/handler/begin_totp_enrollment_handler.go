@@ -0,0 +1,40 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/sean-ahn/user/backend/server/service"
+	userv1 "github.com/sean-ahn/user/proto/gen/go/user/v1"
+)
+
+type BeginTotpEnrollmentHandlerFunc func(ctx context.Context, req *userv1.BeginTotpEnrollmentRequest) (*userv1.BeginTotpEnrollmentResponse, error)
+
+func BeginTotpEnrollment(userTokenService service.UserTokenService, mfaService service.MFAService) BeginTotpEnrollmentHandlerFunc {
+	return func(ctx context.Context, req *userv1.BeginTotpEnrollmentRequest) (*userv1.BeginTotpEnrollmentResponse, error) {
+		token := extractToken(ctx)
+		if token == "" {
+			return nil, status.Error(codes.Unauthenticated, "no token")
+		}
+
+		user, err := userTokenService.GetUser(ctx, token)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		}
+
+		enrollment, err := mfaService.BeginTOTPEnrollment(ctx, user)
+		if err != nil {
+			switch errors.Cause(err) {
+			case service.ErrTOTPAlreadyEnabled:
+				return nil, status.Error(codes.FailedPrecondition, errors.Cause(err).Error())
+			default:
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+		}
+
+		return &userv1.BeginTotpEnrollmentResponse{Secret: enrollment.Secret, OtpauthUri: enrollment.URI}, nil
+	}
+}
@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/sean-ahn/user/backend/model"
+	"github.com/sean-ahn/user/backend/server/service"
+	userv1 "github.com/sean-ahn/user/proto/gen/go/user/v1"
+)
+
+func TestBeginTotpEnrollment(t *testing.T) {
+	cases := []struct {
+		name string
+		req  *userv1.BeginTotpEnrollmentRequest
+
+		userTokenServiceExpectFunc func(context.Context) func(*service.MockUserTokenService)
+		mfaServiceExpectFunc       func(context.Context) func(*service.MockMFAService)
+
+		expectedCode codes.Code
+		expectedResp *userv1.BeginTotpEnrollmentResponse
+		expectedErr  string
+	}{
+		{
+			name: "success",
+			req:  &userv1.BeginTotpEnrollmentRequest{},
+			userTokenServiceExpectFunc: func(ctx context.Context) func(*service.MockUserTokenService) {
+				return func(mock *service.MockUserTokenService) {
+					mock.EXPECT().
+						GetUser(ctx, "access_token").
+						Return(&model.User{UserID: 1}, nil)
+				}
+			},
+			mfaServiceExpectFunc: func(ctx context.Context) func(*service.MockMFAService) {
+				return func(mock *service.MockMFAService) {
+					mock.EXPECT().
+						BeginTOTPEnrollment(ctx, &model.User{UserID: 1}).
+						Return(&service.TOTPEnrollment{Secret: "JBSWY3DPEHPK3PXP", URI: "otpauth://totp/user:john.doe@example.com?secret=JBSWY3DPEHPK3PXP"}, nil)
+				}
+			},
+			expectedCode: codes.OK,
+			expectedResp: &userv1.BeginTotpEnrollmentResponse{Secret: "JBSWY3DPEHPK3PXP", OtpauthUri: "otpauth://totp/user:john.doe@example.com?secret=JBSWY3DPEHPK3PXP"},
+		},
+		{
+			name: "unauthorized",
+			req:  &userv1.BeginTotpEnrollmentRequest{},
+			userTokenServiceExpectFunc: func(ctx context.Context) func(*service.MockUserTokenService) {
+				return func(mock *service.MockUserTokenService) {
+					mock.EXPECT().
+						GetUser(ctx, "access_token").
+						Return(nil, errors.New("invalid token"))
+				}
+			},
+			expectedCode: codes.Unauthenticated,
+			expectedErr:  "rpc error: code = Unauthenticated desc = invalid token",
+		},
+		{
+			name: "already enabled",
+			req:  &userv1.BeginTotpEnrollmentRequest{},
+			userTokenServiceExpectFunc: func(ctx context.Context) func(*service.MockUserTokenService) {
+				return func(mock *service.MockUserTokenService) {
+					mock.EXPECT().
+						GetUser(ctx, "access_token").
+						Return(&model.User{UserID: 1}, nil)
+				}
+			},
+			mfaServiceExpectFunc: func(ctx context.Context) func(*service.MockMFAService) {
+				return func(mock *service.MockMFAService) {
+					mock.EXPECT().
+						BeginTOTPEnrollment(ctx, &model.User{UserID: 1}).
+						Return(nil, errors.WithStack(service.ErrTOTPAlreadyEnabled))
+				}
+			},
+			expectedCode: codes.FailedPrecondition,
+			expectedErr:  "rpc error: code = FailedPrecondition desc = totp already enabled",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{
+				headerKeyAuthorization: "Bearer access_token",
+			}))
+
+			ctrl := gomock.NewController(t)
+
+			mockUserTokenService := service.NewMockUserTokenService(ctrl)
+			if tc.userTokenServiceExpectFunc != nil {
+				tc.userTokenServiceExpectFunc(ctx)(mockUserTokenService)
+			}
+
+			mockMFAService := service.NewMockMFAService(ctrl)
+			if tc.mfaServiceExpectFunc != nil {
+				tc.mfaServiceExpectFunc(ctx)(mockMFAService)
+			}
+
+			handler := BeginTotpEnrollment(mockUserTokenService, mockMFAService)
+
+			resp, err := handler(ctx, tc.req)
+
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedCode, status.Code(err))
+			assert.Empty(t, cmp.Diff(resp, tc.expectedResp, protocmp.Transform()))
+		})
+	}
+}
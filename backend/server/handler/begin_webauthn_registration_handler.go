@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/sean-ahn/user/backend/server/service"
+	userv1 "github.com/sean-ahn/user/proto/gen/go/user/v1"
+)
+
+type BeginWebauthnRegistrationHandlerFunc func(ctx context.Context, req *userv1.BeginWebauthnRegistrationRequest) (*userv1.BeginWebauthnRegistrationResponse, error)
+
+func BeginWebauthnRegistration(userTokenService service.UserTokenService, webAuthnService service.WebAuthnService) BeginWebauthnRegistrationHandlerFunc {
+	return func(ctx context.Context, req *userv1.BeginWebauthnRegistrationRequest) (*userv1.BeginWebauthnRegistrationResponse, error) {
+		token := extractToken(ctx)
+		if token == "" {
+			return nil, status.Error(codes.Unauthenticated, "no token")
+		}
+
+		user, err := userTokenService.GetUser(ctx, token)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		}
+
+		opts, err := webAuthnService.BeginRegistration(ctx, user)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+
+		return &userv1.BeginWebauthnRegistrationResponse{
+			Challenge:            opts.Challenge,
+			RpId:                 opts.RPID,
+			RpName:               opts.RPName,
+			UserHandle:           opts.UserHandle,
+			UserName:             opts.UserName,
+			UserDisplayName:      opts.UserDisplayName,
+			PubKeyCredAlgs:       opts.PubKeyCredAlgs,
+			ExcludeCredentialIds: opts.ExcludeCredentialIDs,
+			TimeoutMs:            opts.Timeout.Milliseconds(),
+		}, nil
+	}
+}
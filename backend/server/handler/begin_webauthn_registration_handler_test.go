@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/sean-ahn/user/backend/model"
+	"github.com/sean-ahn/user/backend/server/service"
+	userv1 "github.com/sean-ahn/user/proto/gen/go/user/v1"
+)
+
+func TestBeginWebauthnRegistration(t *testing.T) {
+	cases := []struct {
+		name string
+		req  *userv1.BeginWebauthnRegistrationRequest
+
+		userTokenServiceExpectFunc func(context.Context) func(*service.MockUserTokenService)
+		webAuthnServiceExpectFunc  func(context.Context) func(*service.MockWebAuthnService)
+
+		expectedCode codes.Code
+		expectedResp *userv1.BeginWebauthnRegistrationResponse
+		expectedErr  string
+	}{
+		{
+			name: "success",
+			req:  &userv1.BeginWebauthnRegistrationRequest{},
+			userTokenServiceExpectFunc: func(ctx context.Context) func(*service.MockUserTokenService) {
+				return func(mock *service.MockUserTokenService) {
+					mock.EXPECT().
+						GetUser(ctx, "access_token").
+						Return(&model.User{UserID: 1}, nil)
+				}
+			},
+			webAuthnServiceExpectFunc: func(ctx context.Context) func(*service.MockWebAuthnService) {
+				return func(mock *service.MockWebAuthnService) {
+					mock.EXPECT().
+						BeginRegistration(ctx, &model.User{UserID: 1}).
+						Return(&service.WebAuthnRegistrationOptions{
+							Challenge:            "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA",
+							RPID:                 "example.com",
+							RPName:               "user",
+							UserHandle:           "MQ",
+							UserName:             "john.doe@example.com",
+							UserDisplayName:      "John Doe",
+							PubKeyCredAlgs:       []int32{-7, -8, -257},
+							ExcludeCredentialIDs: []string{"credential_id"},
+							Timeout:              5 * time.Minute,
+						}, nil)
+				}
+			},
+			expectedCode: codes.OK,
+			expectedResp: &userv1.BeginWebauthnRegistrationResponse{
+				Challenge:            "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA",
+				RpId:                 "example.com",
+				RpName:               "user",
+				UserHandle:           "MQ",
+				UserName:             "john.doe@example.com",
+				UserDisplayName:      "John Doe",
+				PubKeyCredAlgs:       []int32{-7, -8, -257},
+				ExcludeCredentialIds: []string{"credential_id"},
+				TimeoutMs:            300000,
+			},
+		},
+		{
+			name: "unauthorized",
+			req:  &userv1.BeginWebauthnRegistrationRequest{},
+			userTokenServiceExpectFunc: func(ctx context.Context) func(*service.MockUserTokenService) {
+				return func(mock *service.MockUserTokenService) {
+					mock.EXPECT().
+						GetUser(ctx, "access_token").
+						Return(nil, errors.New("invalid token"))
+				}
+			},
+			expectedCode: codes.Unauthenticated,
+			expectedErr:  "rpc error: code = Unauthenticated desc = invalid token",
+		},
+		{
+			name: "unexpected error",
+			req:  &userv1.BeginWebauthnRegistrationRequest{},
+			userTokenServiceExpectFunc: func(ctx context.Context) func(*service.MockUserTokenService) {
+				return func(mock *service.MockUserTokenService) {
+					mock.EXPECT().
+						GetUser(ctx, "access_token").
+						Return(&model.User{UserID: 1}, nil)
+				}
+			},
+			webAuthnServiceExpectFunc: func(ctx context.Context) func(*service.MockWebAuthnService) {
+				return func(mock *service.MockWebAuthnService) {
+					mock.EXPECT().
+						BeginRegistration(ctx, &model.User{UserID: 1}).
+						Return(nil, errors.New("unexpected error"))
+				}
+			},
+			expectedCode: codes.Internal,
+			expectedErr:  "rpc error: code = Internal desc = unexpected error",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{
+				headerKeyAuthorization: "Bearer access_token",
+			}))
+
+			ctrl := gomock.NewController(t)
+
+			mockUserTokenService := service.NewMockUserTokenService(ctrl)
+			if tc.userTokenServiceExpectFunc != nil {
+				tc.userTokenServiceExpectFunc(ctx)(mockUserTokenService)
+			}
+
+			mockWebAuthnService := service.NewMockWebAuthnService(ctrl)
+			if tc.webAuthnServiceExpectFunc != nil {
+				tc.webAuthnServiceExpectFunc(ctx)(mockWebAuthnService)
+			}
+
+			handler := BeginWebauthnRegistration(mockUserTokenService, mockWebAuthnService)
+
+			resp, err := handler(ctx, tc.req)
+
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedCode, status.Code(err))
+			assert.Empty(t, cmp.Diff(resp, tc.expectedResp, protocmp.Transform()))
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"context"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/sean-ahn/user/backend/server/service"
+	userv1 "github.com/sean-ahn/user/proto/gen/go/user/v1"
+)
+
+type BeginWebauthnSignInHandlerFunc func(ctx context.Context, req *userv1.BeginWebauthnSignInRequest) (*userv1.BeginWebauthnSignInResponse, error)
+
+func BeginWebauthnSignIn(webAuthnService service.WebAuthnService) BeginWebauthnSignInHandlerFunc {
+	return func(ctx context.Context, req *userv1.BeginWebauthnSignInRequest) (*userv1.BeginWebauthnSignInResponse, error) {
+		if utf8.RuneCountInString(req.DeviceLabel) > maxDeviceLabelLen {
+			return nil, status.Error(codes.InvalidArgument, "invalid device_label")
+		}
+
+		opts, err := webAuthnService.BeginSignIn(ctx, req.DeviceLabel, extractClientInfo(ctx).IPAddress)
+		if err != nil {
+			if rateLimitedErr, ok := errors.Cause(err).(*service.RateLimitedError); ok {
+				return nil, rateLimitedStatus(rateLimitedErr).Err()
+			}
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+
+		return &userv1.BeginWebauthnSignInResponse{
+			Challenge: opts.Challenge,
+			RpId:      opts.RPID,
+			TimeoutMs: opts.Timeout.Milliseconds(),
+		}, nil
+	}
+}
@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/sean-ahn/user/backend/server/service"
+	userv1 "github.com/sean-ahn/user/proto/gen/go/user/v1"
+)
+
+func TestBeginWebauthnSignIn(t *testing.T) {
+	cases := []struct {
+		name string
+		req  *userv1.BeginWebauthnSignInRequest
+
+		webAuthnServiceExpectFunc func(context.Context) func(*service.MockWebAuthnService)
+
+		expectedCode codes.Code
+		expectedResp *userv1.BeginWebauthnSignInResponse
+		expectedErr  string
+	}{
+		{
+			name: "success",
+			req:  &userv1.BeginWebauthnSignInRequest{DeviceLabel: "Chrome on macOS"},
+			webAuthnServiceExpectFunc: func(ctx context.Context) func(*service.MockWebAuthnService) {
+				return func(mock *service.MockWebAuthnService) {
+					mock.EXPECT().
+						BeginSignIn(ctx, "Chrome on macOS", "198.51.100.7").
+						Return(&service.WebAuthnSignInOptions{
+							Challenge: "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA",
+							RPID:      "example.com",
+							Timeout:   5 * time.Minute,
+						}, nil)
+				}
+			},
+			expectedCode: codes.OK,
+			expectedResp: &userv1.BeginWebauthnSignInResponse{
+				Challenge: "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA",
+				RpId:      "example.com",
+				TimeoutMs: 300000,
+			},
+		},
+		{
+			name:         "too long device_label",
+			req:          &userv1.BeginWebauthnSignInRequest{DeviceLabel: strings.Repeat("a", maxDeviceLabelLen+1)},
+			expectedCode: codes.InvalidArgument,
+			expectedErr:  "rpc error: code = InvalidArgument desc = invalid device_label",
+		},
+		{
+			name: "too many pending challenges",
+			req:  &userv1.BeginWebauthnSignInRequest{},
+			webAuthnServiceExpectFunc: func(ctx context.Context) func(*service.MockWebAuthnService) {
+				return func(mock *service.MockWebAuthnService) {
+					mock.EXPECT().
+						BeginSignIn(ctx, "", "198.51.100.7").
+						Return(nil, errors.WithStack(&service.RateLimitedError{Limit: service.WebAuthnLimitPendingSignInChallenges, RetryAfter: time.Minute}))
+				}
+			},
+			expectedCode: codes.ResourceExhausted,
+			expectedErr:  "rpc error: code = ResourceExhausted desc = rate limited by webauthn_pending_sign_in_challenges",
+		},
+		{
+			name: "unexpected error",
+			req:  &userv1.BeginWebauthnSignInRequest{},
+			webAuthnServiceExpectFunc: func(ctx context.Context) func(*service.MockWebAuthnService) {
+				return func(mock *service.MockWebAuthnService) {
+					mock.EXPECT().
+						BeginSignIn(ctx, "", "198.51.100.7").
+						Return(nil, errors.New("unexpected error"))
+				}
+			},
+			expectedCode: codes.Internal,
+			expectedErr:  "rpc error: code = Internal desc = unexpected error",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{
+				headerKeyForwardedFor: "203.0.113.1, 198.51.100.7",
+			}))
+
+			ctrl := gomock.NewController(t)
+
+			mockWebAuthnService := service.NewMockWebAuthnService(ctrl)
+			if tc.webAuthnServiceExpectFunc != nil {
+				tc.webAuthnServiceExpectFunc(ctx)(mockWebAuthnService)
+			}
+
+			handler := BeginWebauthnSignIn(mockWebAuthnService)
+
+			resp, err := handler(ctx, tc.req)
+
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedCode, status.Code(err))
+			assert.Empty(t, cmp.Diff(resp, tc.expectedResp, protocmp.Transform()))
+		})
+	}
+}
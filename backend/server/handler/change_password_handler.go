@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/sean-ahn/user/backend/crypto"
+	"github.com/sean-ahn/user/backend/model"
+	"github.com/sean-ahn/user/backend/persistence/mysql"
+	"github.com/sean-ahn/user/backend/server/service"
+	userv1 "github.com/sean-ahn/user/proto/gen/go/user/v1"
+)
+
+type ChangePasswordHandlerFunc func(ctx context.Context, req *userv1.ChangePasswordRequest) (*userv1.ChangePasswordResponse, error)
+
+func ChangePassword(db *sql.DB, hasher crypto.Hasher, passwordChecker *service.PasswordChecker, userTokenService service.UserTokenService, signInThrottle service.SignInThrottle, passwordHistoryService service.PasswordHistoryService) ChangePasswordHandlerFunc {
+	return func(ctx context.Context, req *userv1.ChangePasswordRequest) (*userv1.ChangePasswordResponse, error) {
+		token := extractToken(ctx)
+		if token == "" {
+			return nil, status.Error(codes.Unauthenticated, "no token")
+		}
+
+		if req.CurrentPassword == "" {
+			return nil, status.Error(codes.InvalidArgument, "no current_password")
+		}
+		if req.NewPassword == "" {
+			return nil, status.Error(codes.InvalidArgument, "no new_password")
+		}
+
+		user, sessionID, err := userTokenService.GetUserSession(ctx, token)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		}
+
+		if violations := passwordChecker.Check(req.NewPassword, user.Name, user.Email, user.Nickname, user.PhoneNumber); len(violations) > 0 {
+			return nil, invalidPasswordStatus("new_password", violations).Err()
+		}
+
+		// the current password is throttled as of sign-ins, or it could be guessed with a stolen access token
+		accountKey := strconv.Itoa(user.UserID)
+		if err := checkSignInThrottle(ctx, signInThrottle, service.SignInThrottleScopeAccount, accountKey); err != nil {
+			return nil, err
+		}
+
+		ok, err := hasher.Verify([]byte(user.PasswordHash), []byte(req.CurrentPassword))
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		if !ok {
+			recordSignInFailure(ctx, signInThrottle, "", accountKey)
+			return nil, status.Error(codes.InvalidArgument, "incorrect current_password")
+		}
+
+		if err := passwordHistoryService.CheckMinAge(ctx, user); err != nil {
+			if rateLimitedErr, ok := errors.Cause(err).(*service.RateLimitedError); ok {
+				return nil, rateLimitedStatus(rateLimitedErr).Err()
+			}
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+
+		if err := passwordHistoryService.CheckReuse(ctx, user, req.NewPassword); err != nil {
+			switch errors.Cause(err) {
+			case service.ErrPasswordReused:
+				return nil, status.Error(codes.InvalidArgument, errors.Cause(err).Error())
+			default:
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+		}
+
+		newPasswordHash, err := hasher.Hash([]byte(req.NewPassword))
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+
+		replacedHash := user.PasswordHash
+		user.PasswordHash = string(newPasswordHash)
+
+		client := extractClientInfo(ctx)
+
+		if err := changeUserPassword(ctx, db, userTokenService, passwordHistoryService, user, replacedHash, req.RevokeAllSessions, client); err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+
+		if !req.RevokeAllSessions {
+			return &userv1.ChangePasswordResponse{}, nil
+		}
+
+		// the current session is revoked as well, so it is replaced by a new one with the same device label
+		if sessionID != "" {
+			s, err := mysql.FindUserSessionByFamilyID(ctx, db, sessionID)
+			if err != nil && errors.Cause(err) != sql.ErrNoRows {
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+			if s != nil {
+				client.DeviceLabel = s.DeviceLabel
+			}
+		}
+
+		accessToken, refreshToken, err := userTokenService.Issue(ctx, user, client)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+
+		return &userv1.ChangePasswordResponse{
+			AccessToken:  accessToken,
+			RefreshToken: refreshToken,
+		}, nil
+	}
+}
+
+func changeUserPassword(ctx context.Context, db *sql.DB, userTokenService service.UserTokenService, passwordHistoryService service.PasswordHistoryService, user *model.User, replacedHash string, revokeAllSessions bool, client service.ClientInfo) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			logrus.WithError(err).Error()
+		}
+	}()
+
+	if _, err := user.Update(ctx, tx, boil.Whitelist(model.UserColumns.PasswordHash)); err != nil {
+		return errors.WithStack(err)
+	}
+
+	if err := passwordHistoryService.Record(ctx, tx, user, replacedHash); err != nil {
+		return err
+	}
+
+	if revokeAllSessions {
+		if err := userTokenService.RevokeAll(ctx, user, tx); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	if err := service.RecordSecurityEvent(ctx, tx, user.UserID, service.SecurityEventTypePasswordChanged, map[string]string{
+		"revoke_all_sessions": strconv.FormatBool(revokeAllSessions),
+		"ip_address":          client.IPAddress,
+		"user_agent":          client.UserAgent,
+	}); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
@@ -0,0 +1,354 @@
+package handler
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/sean-ahn/user/backend/model"
+	"github.com/sean-ahn/user/backend/server/service"
+	"github.com/sean-ahn/user/backend/test"
+	userv1 "github.com/sean-ahn/user/proto/gen/go/user/v1"
+)
+
+func TestChangePassword(t *testing.T) {
+	now := time.Date(2021, 10, 25, 16, 37, 55, 509012743, time.UTC)
+
+	getUser := func(ctx context.Context, mock *service.MockUserTokenService) {
+		mock.EXPECT().
+			GetUserSession(ctx, "access_token").
+			Return(&model.User{UserID: 1, PasswordHash: "P@ssw0rd_hash"}, "c4a1b5e6-1f57-4a0b-9d63-0b6f1f1c9f7a", nil)
+	}
+	notLockedOut := func(ctx context.Context, mock *service.MockSignInThrottle) {
+		mock.EXPECT().Check(ctx, service.SignInThrottleScopeAccount, "1").Return(nil)
+	}
+	notReused := func(ctx context.Context, mock *service.MockPasswordHistoryService) {
+		mock.EXPECT().CheckMinAge(ctx, &userMatcher{id: 1}).Return(nil)
+		mock.EXPECT().CheckReuse(ctx, &userMatcher{id: 1}, "N3w_P@ssw0rd").Return(nil)
+	}
+	expectSecurityEvent := func(mock sqlmock.Sqlmock, detail string) {
+		mock.ExpectExec(regexp.QuoteMeta(
+			"INSERT INTO `security_event` (`user_id`,`event_type`,`detail`) VALUES (?,?,?)",
+		)).WithArgs(
+			1, service.SecurityEventTypePasswordChanged, detail,
+		).WillReturnResult(
+			sqlmock.NewResult(5, 1),
+		)
+
+		mock.ExpectQuery(regexp.QuoteMeta(
+			"SELECT `security_event_id`,`created_at`,`updated_at` FROM `security_event` WHERE `security_event_id`=?",
+		)).WithArgs(
+			5,
+		).WillReturnRows(sqlmock.NewRows([]string{"security_event_id", "created_at", "updated_at"}).
+			AddRow(5, now, now),
+		)
+	}
+
+	cases := []struct {
+		name string
+		req  *userv1.ChangePasswordRequest
+
+		dbExpectFunc               func(sqlmock.Sqlmock)
+		userTokenServiceExpectFunc func(context.Context) func(*service.MockUserTokenService)
+		signInThrottleExpectFunc   func(context.Context) func(*service.MockSignInThrottle)
+		passwordHistoryExpectFunc  func(context.Context) func(*service.MockPasswordHistoryService)
+
+		expectedCode codes.Code
+		expectedResp *userv1.ChangePasswordResponse
+		expectedErr  string
+	}{
+		{
+			name: "success",
+			req:  &userv1.ChangePasswordRequest{CurrentPassword: "P@ssw0rd", NewPassword: "N3w_P@ssw0rd"},
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"UPDATE `user` SET `password_hash`=? WHERE `user_id`=?",
+				)).WithArgs(
+					"N3w_P@ssw0rd_hash", 1,
+				).WillReturnResult(sqlmock.NewResult(0, 1))
+
+				expectSecurityEvent(mock, `{"ip_address":"198.51.100.7","revoke_all_sessions":"false","user_agent":"Mozilla/5.0"}`)
+
+				mock.ExpectCommit()
+			},
+			userTokenServiceExpectFunc: func(ctx context.Context) func(*service.MockUserTokenService) {
+				return func(mock *service.MockUserTokenService) {
+					getUser(ctx, mock)
+				}
+			},
+			signInThrottleExpectFunc: func(ctx context.Context) func(*service.MockSignInThrottle) {
+				return func(mock *service.MockSignInThrottle) {
+					notLockedOut(ctx, mock)
+				}
+			},
+			passwordHistoryExpectFunc: func(ctx context.Context) func(*service.MockPasswordHistoryService) {
+				return func(mock *service.MockPasswordHistoryService) {
+					notReused(ctx, mock)
+					mock.EXPECT().Record(ctx, gomock.Any(), &userMatcher{id: 1}, "P@ssw0rd_hash").Return(nil)
+				}
+			},
+			expectedCode: codes.OK,
+			expectedResp: &userv1.ChangePasswordResponse{},
+		},
+		{
+			name: "success with all sessions revoked",
+			req:  &userv1.ChangePasswordRequest{CurrentPassword: "P@ssw0rd", NewPassword: "N3w_P@ssw0rd", RevokeAllSessions: true},
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"UPDATE `user` SET `password_hash`=? WHERE `user_id`=?",
+				)).WithArgs(
+					"N3w_P@ssw0rd_hash", 1,
+				).WillReturnResult(sqlmock.NewResult(0, 1))
+
+				expectSecurityEvent(mock, `{"ip_address":"198.51.100.7","revoke_all_sessions":"true","user_agent":"Mozilla/5.0"}`)
+
+				mock.ExpectCommit()
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user_session` WHERE (`user_session`.`family_id` = ?) LIMIT 1;",
+				)).WithArgs(
+					"c4a1b5e6-1f57-4a0b-9d63-0b6f1f1c9f7a",
+				).WillReturnRows(test.NewUserSessionRows([]*model.UserSession{
+					{UserSessionID: 1, FamilyID: "c4a1b5e6-1f57-4a0b-9d63-0b6f1f1c9f7a", UserID: 1, DeviceLabel: "Chrome on macOS"},
+				}))
+			},
+			userTokenServiceExpectFunc: func(ctx context.Context) func(*service.MockUserTokenService) {
+				return func(mock *service.MockUserTokenService) {
+					getUser(ctx, mock)
+					mock.EXPECT().RevokeAll(ctx, &userMatcher{id: 1}, gomock.Any()).Return(nil)
+					mock.EXPECT().
+						Issue(ctx, &userMatcher{id: 1}, service.ClientInfo{DeviceLabel: "Chrome on macOS", UserAgent: "Mozilla/5.0", IPAddress: "198.51.100.7"}).
+						Return("new_access_token", "new_refresh_token", nil)
+				}
+			},
+			signInThrottleExpectFunc: func(ctx context.Context) func(*service.MockSignInThrottle) {
+				return func(mock *service.MockSignInThrottle) {
+					notLockedOut(ctx, mock)
+				}
+			},
+			passwordHistoryExpectFunc: func(ctx context.Context) func(*service.MockPasswordHistoryService) {
+				return func(mock *service.MockPasswordHistoryService) {
+					notReused(ctx, mock)
+					mock.EXPECT().Record(ctx, gomock.Any(), &userMatcher{id: 1}, "P@ssw0rd_hash").Return(nil)
+				}
+			},
+			expectedCode: codes.OK,
+			expectedResp: &userv1.ChangePasswordResponse{AccessToken: "new_access_token", RefreshToken: "new_refresh_token"},
+		},
+		{
+			name: "fail to revoke sessions",
+			req:  &userv1.ChangePasswordRequest{CurrentPassword: "P@ssw0rd", NewPassword: "N3w_P@ssw0rd", RevokeAllSessions: true},
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"UPDATE `user` SET `password_hash`=? WHERE `user_id`=?",
+				)).WithArgs(
+					"N3w_P@ssw0rd_hash", 1,
+				).WillReturnResult(sqlmock.NewResult(0, 1))
+
+				mock.ExpectRollback()
+			},
+			userTokenServiceExpectFunc: func(ctx context.Context) func(*service.MockUserTokenService) {
+				return func(mock *service.MockUserTokenService) {
+					getUser(ctx, mock)
+					mock.EXPECT().RevokeAll(ctx, &userMatcher{id: 1}, gomock.Any()).Return(errors.New("unexpected error"))
+				}
+			},
+			signInThrottleExpectFunc: func(ctx context.Context) func(*service.MockSignInThrottle) {
+				return func(mock *service.MockSignInThrottle) {
+					notLockedOut(ctx, mock)
+				}
+			},
+			passwordHistoryExpectFunc: func(ctx context.Context) func(*service.MockPasswordHistoryService) {
+				return func(mock *service.MockPasswordHistoryService) {
+					notReused(ctx, mock)
+					mock.EXPECT().Record(ctx, gomock.Any(), &userMatcher{id: 1}, "P@ssw0rd_hash").Return(nil)
+				}
+			},
+			expectedCode: codes.Internal,
+			expectedErr:  "rpc error: code = Internal desc = unexpected error",
+		},
+		{
+			name: "recently used password",
+			req:  &userv1.ChangePasswordRequest{CurrentPassword: "P@ssw0rd", NewPassword: "N3w_P@ssw0rd"},
+			userTokenServiceExpectFunc: func(ctx context.Context) func(*service.MockUserTokenService) {
+				return func(mock *service.MockUserTokenService) {
+					getUser(ctx, mock)
+				}
+			},
+			signInThrottleExpectFunc: func(ctx context.Context) func(*service.MockSignInThrottle) {
+				return func(mock *service.MockSignInThrottle) {
+					notLockedOut(ctx, mock)
+				}
+			},
+			passwordHistoryExpectFunc: func(ctx context.Context) func(*service.MockPasswordHistoryService) {
+				return func(mock *service.MockPasswordHistoryService) {
+					mock.EXPECT().CheckMinAge(ctx, &userMatcher{id: 1}).Return(nil)
+					mock.EXPECT().CheckReuse(ctx, &userMatcher{id: 1}, "N3w_P@ssw0rd").Return(errors.WithStack(service.ErrPasswordReused))
+				}
+			},
+			expectedCode: codes.InvalidArgument,
+			expectedErr:  "rpc error: code = InvalidArgument desc = recently used password",
+		},
+		{
+			name: "password changed too recently",
+			req:  &userv1.ChangePasswordRequest{CurrentPassword: "P@ssw0rd", NewPassword: "N3w_P@ssw0rd"},
+			userTokenServiceExpectFunc: func(ctx context.Context) func(*service.MockUserTokenService) {
+				return func(mock *service.MockUserTokenService) {
+					getUser(ctx, mock)
+				}
+			},
+			signInThrottleExpectFunc: func(ctx context.Context) func(*service.MockSignInThrottle) {
+				return func(mock *service.MockSignInThrottle) {
+					notLockedOut(ctx, mock)
+				}
+			},
+			passwordHistoryExpectFunc: func(ctx context.Context) func(*service.MockPasswordHistoryService) {
+				return func(mock *service.MockPasswordHistoryService) {
+					mock.EXPECT().
+						CheckMinAge(ctx, &userMatcher{id: 1}).
+						Return(errors.WithStack(&service.RateLimitedError{Limit: service.PasswordLimitMinAge, RetryAfter: time.Hour}))
+				}
+			},
+			expectedCode: codes.ResourceExhausted,
+			expectedErr:  "rpc error: code = ResourceExhausted desc = rate limited by password_min_age",
+		},
+		{
+			name: "incorrect current password",
+			req:  &userv1.ChangePasswordRequest{CurrentPassword: "qwerty123", NewPassword: "N3w_P@ssw0rd"},
+			userTokenServiceExpectFunc: func(ctx context.Context) func(*service.MockUserTokenService) {
+				return func(mock *service.MockUserTokenService) {
+					getUser(ctx, mock)
+				}
+			},
+			signInThrottleExpectFunc: func(ctx context.Context) func(*service.MockSignInThrottle) {
+				return func(mock *service.MockSignInThrottle) {
+					notLockedOut(ctx, mock)
+					mock.EXPECT().Fail(ctx, service.SignInThrottleScopeAccount, "1").Return(nil)
+				}
+			},
+			expectedCode: codes.InvalidArgument,
+			expectedErr:  "rpc error: code = InvalidArgument desc = incorrect current_password",
+		},
+		{
+			name: "locked out",
+			req:  &userv1.ChangePasswordRequest{CurrentPassword: "P@ssw0rd", NewPassword: "N3w_P@ssw0rd"},
+			userTokenServiceExpectFunc: func(ctx context.Context) func(*service.MockUserTokenService) {
+				return func(mock *service.MockUserTokenService) {
+					getUser(ctx, mock)
+				}
+			},
+			signInThrottleExpectFunc: func(ctx context.Context) func(*service.MockSignInThrottle) {
+				return func(mock *service.MockSignInThrottle) {
+					mock.EXPECT().
+						Check(ctx, service.SignInThrottleScopeAccount, "1").
+						Return(errors.WithStack(&service.RateLimitedError{Limit: service.SignInLimitLockout, RetryAfter: time.Hour}))
+				}
+			},
+			expectedCode: codes.ResourceExhausted,
+			expectedErr:  "rpc error: code = ResourceExhausted desc = rate limited by sign_in_lockout",
+		},
+		{
+			name: "invalid token",
+			req:  &userv1.ChangePasswordRequest{CurrentPassword: "P@ssw0rd", NewPassword: "N3w_P@ssw0rd"},
+			userTokenServiceExpectFunc: func(ctx context.Context) func(*service.MockUserTokenService) {
+				return func(mock *service.MockUserTokenService) {
+					mock.EXPECT().
+						GetUserSession(ctx, "access_token").
+						Return(nil, "", errors.New("invalid token"))
+				}
+			},
+			expectedCode: codes.Unauthenticated,
+			expectedErr:  "rpc error: code = Unauthenticated desc = invalid token",
+		},
+		{
+			name:         "no current password",
+			req:          &userv1.ChangePasswordRequest{NewPassword: "N3w_P@ssw0rd"},
+			expectedCode: codes.InvalidArgument,
+			expectedErr:  "rpc error: code = InvalidArgument desc = no current_password",
+		},
+		{
+			name:         "no new password",
+			req:          &userv1.ChangePasswordRequest{CurrentPassword: "P@ssw0rd"},
+			expectedCode: codes.InvalidArgument,
+			expectedErr:  "rpc error: code = InvalidArgument desc = no new_password",
+		},
+		{
+			name: "invalid new password",
+			req:  &userv1.ChangePasswordRequest{CurrentPassword: "P@ssw0rd", NewPassword: "qwerty123"},
+			userTokenServiceExpectFunc: func(ctx context.Context) func(*service.MockUserTokenService) {
+				return func(mock *service.MockUserTokenService) {
+					getUser(ctx, mock)
+				}
+			},
+			expectedCode: codes.InvalidArgument,
+			expectedErr:  "rpc error: code = InvalidArgument desc = invalid password",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{
+				headerKeyAuthorization:    "Bearer access_token",
+				headerKeyGatewayUserAgent: "Mozilla/5.0",
+				headerKeyForwardedFor:     "203.0.113.1, 198.51.100.7",
+			}))
+
+			ctrl := gomock.NewController(t)
+
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fail()
+			}
+			if tc.dbExpectFunc != nil {
+				tc.dbExpectFunc(mock)
+			}
+			defer test.CloseSqlmock(t, db, mock)
+
+			mockUserTokenService := service.NewMockUserTokenService(ctrl)
+			if tc.userTokenServiceExpectFunc != nil {
+				tc.userTokenServiceExpectFunc(ctx)(mockUserTokenService)
+			}
+
+			mockSignInThrottle := service.NewMockSignInThrottle(ctrl)
+			if tc.signInThrottleExpectFunc != nil {
+				tc.signInThrottleExpectFunc(ctx)(mockSignInThrottle)
+			}
+
+			mockPasswordHistoryService := service.NewMockPasswordHistoryService(ctrl)
+			if tc.passwordHistoryExpectFunc != nil {
+				tc.passwordHistoryExpectFunc(ctx)(mockPasswordHistoryService)
+			}
+
+			handler := ChangePassword(db, &testHasher{}, testPasswordChecker, mockUserTokenService, mockSignInThrottle, mockPasswordHistoryService)
+
+			resp, err := handler(ctx, tc.req)
+
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedCode, status.Code(err))
+			assert.Empty(t, cmp.Diff(resp, tc.expectedResp, protocmp.Transform()))
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/sean-ahn/user/backend/server/service"
+	userv1 "github.com/sean-ahn/user/proto/gen/go/user/v1"
+)
+
+type CompleteMfaChallengeHandlerFunc func(ctx context.Context, req *userv1.CompleteMfaChallengeRequest) (*userv1.CompleteMfaChallengeResponse, error)
+
+func CompleteMfaChallenge(mfaService service.MFAService, userTokenService service.UserTokenService) CompleteMfaChallengeHandlerFunc {
+	return func(ctx context.Context, req *userv1.CompleteMfaChallengeRequest) (*userv1.CompleteMfaChallengeResponse, error) {
+		if req.MfaChallengeToken == "" {
+			return nil, status.Error(codes.InvalidArgument, "no mfa_challenge_token")
+		}
+
+		var method, code string
+		switch {
+		case req.TotpCode != "" && req.RecoveryCode != "":
+			return nil, status.Error(codes.InvalidArgument, "both totp_code and recovery_code")
+		case req.TotpCode != "":
+			method, code = service.MFAMethodTOTP, req.TotpCode
+		case req.RecoveryCode != "":
+			method, code = service.MFAMethodRecoveryCode, req.RecoveryCode
+		default:
+			return nil, status.Error(codes.InvalidArgument, "no totp_code or recovery_code")
+		}
+
+		user, deviceLabel, err := mfaService.CompleteChallenge(ctx, req.MfaChallengeToken, method, code)
+		if err != nil {
+			if rateLimitedErr, ok := errors.Cause(err).(*service.RateLimitedError); ok {
+				return nil, rateLimitedStatus(rateLimitedErr).Err()
+			}
+			switch errors.Cause(err) {
+			case service.ErrInvalidMFAChallenge, service.ErrInvalidMFACode:
+				return nil, status.Error(codes.Unauthenticated, errors.Cause(err).Error())
+			default:
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+		}
+
+		client := extractClientInfo(ctx)
+		client.DeviceLabel = deviceLabel
+
+		accessToken, refreshToken, err := userTokenService.Issue(ctx, user, client)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+
+		return &userv1.CompleteMfaChallengeResponse{AccessToken: accessToken, RefreshToken: refreshToken}, nil
+	}
+}
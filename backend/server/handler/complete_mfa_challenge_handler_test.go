@@ -0,0 +1,180 @@
+package handler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/sean-ahn/user/backend/model"
+	"github.com/sean-ahn/user/backend/server/service"
+	userv1 "github.com/sean-ahn/user/proto/gen/go/user/v1"
+)
+
+func TestCompleteMfaChallenge(t *testing.T) {
+	cases := []struct {
+		name string
+		req  *userv1.CompleteMfaChallengeRequest
+
+		mfaServiceExpectFunc       func(context.Context) func(*service.MockMFAService)
+		userTokenServiceExpectFunc func(context.Context) func(*service.MockUserTokenService)
+
+		expectedCode codes.Code
+		expectedResp *userv1.CompleteMfaChallengeResponse
+		expectedErr  string
+	}{
+		{
+			name: "success",
+			req:  &userv1.CompleteMfaChallengeRequest{MfaChallengeToken: "mfa_challenge_token", TotpCode: "123456"},
+			mfaServiceExpectFunc: func(ctx context.Context) func(*service.MockMFAService) {
+				return func(mock *service.MockMFAService) {
+					mock.EXPECT().
+						CompleteChallenge(ctx, "mfa_challenge_token", service.MFAMethodTOTP, "123456").
+						Return(&model.User{UserID: 1}, "Chrome on macOS", nil)
+				}
+			},
+			userTokenServiceExpectFunc: func(ctx context.Context) func(*service.MockUserTokenService) {
+				return func(mock *service.MockUserTokenService) {
+					mock.EXPECT().
+						Issue(ctx, &model.User{UserID: 1}, service.ClientInfo{DeviceLabel: "Chrome on macOS", UserAgent: "Mozilla/5.0", IPAddress: "198.51.100.7"}).
+						Return("access_token", "refresh_token", nil)
+				}
+			},
+			expectedCode: codes.OK,
+			expectedResp: &userv1.CompleteMfaChallengeResponse{AccessToken: "access_token", RefreshToken: "refresh_token"},
+		},
+		{
+			name: "success with recovery code",
+			req:  &userv1.CompleteMfaChallengeRequest{MfaChallengeToken: "mfa_challenge_token", RecoveryCode: "abcd-efgh-ijkl-mnop"},
+			mfaServiceExpectFunc: func(ctx context.Context) func(*service.MockMFAService) {
+				return func(mock *service.MockMFAService) {
+					mock.EXPECT().
+						CompleteChallenge(ctx, "mfa_challenge_token", service.MFAMethodRecoveryCode, "abcd-efgh-ijkl-mnop").
+						Return(&model.User{UserID: 1}, "Chrome on macOS", nil)
+				}
+			},
+			userTokenServiceExpectFunc: func(ctx context.Context) func(*service.MockUserTokenService) {
+				return func(mock *service.MockUserTokenService) {
+					mock.EXPECT().
+						Issue(ctx, &model.User{UserID: 1}, service.ClientInfo{DeviceLabel: "Chrome on macOS", UserAgent: "Mozilla/5.0", IPAddress: "198.51.100.7"}).
+						Return("access_token", "refresh_token", nil)
+				}
+			},
+			expectedCode: codes.OK,
+			expectedResp: &userv1.CompleteMfaChallengeResponse{AccessToken: "access_token", RefreshToken: "refresh_token"},
+		},
+		{
+			name:         "no mfa_challenge_token",
+			req:          &userv1.CompleteMfaChallengeRequest{TotpCode: "123456"},
+			expectedCode: codes.InvalidArgument,
+			expectedErr:  "rpc error: code = InvalidArgument desc = no mfa_challenge_token",
+		},
+		{
+			name:         "no code",
+			req:          &userv1.CompleteMfaChallengeRequest{MfaChallengeToken: "mfa_challenge_token"},
+			expectedCode: codes.InvalidArgument,
+			expectedErr:  "rpc error: code = InvalidArgument desc = no totp_code or recovery_code",
+		},
+		{
+			name:         "both totp_code and recovery_code",
+			req:          &userv1.CompleteMfaChallengeRequest{MfaChallengeToken: "mfa_challenge_token", TotpCode: "123456", RecoveryCode: "abcd-efgh-ijkl-mnop"},
+			expectedCode: codes.InvalidArgument,
+			expectedErr:  "rpc error: code = InvalidArgument desc = both totp_code and recovery_code",
+		},
+		{
+			name: "invalid challenge",
+			req:  &userv1.CompleteMfaChallengeRequest{MfaChallengeToken: "mfa_challenge_token", TotpCode: "123456"},
+			mfaServiceExpectFunc: func(ctx context.Context) func(*service.MockMFAService) {
+				return func(mock *service.MockMFAService) {
+					mock.EXPECT().
+						CompleteChallenge(ctx, "mfa_challenge_token", service.MFAMethodTOTP, "123456").
+						Return(nil, "", errors.WithStack(service.ErrInvalidMFAChallenge))
+				}
+			},
+			expectedCode: codes.Unauthenticated,
+			expectedErr:  "rpc error: code = Unauthenticated desc = invalid mfa challenge",
+		},
+		{
+			name: "invalid code",
+			req:  &userv1.CompleteMfaChallengeRequest{MfaChallengeToken: "mfa_challenge_token", TotpCode: "123456"},
+			mfaServiceExpectFunc: func(ctx context.Context) func(*service.MockMFAService) {
+				return func(mock *service.MockMFAService) {
+					mock.EXPECT().
+						CompleteChallenge(ctx, "mfa_challenge_token", service.MFAMethodTOTP, "123456").
+						Return(nil, "", errors.WithStack(service.ErrInvalidMFACode))
+				}
+			},
+			expectedCode: codes.Unauthenticated,
+			expectedErr:  "rpc error: code = Unauthenticated desc = invalid mfa code",
+		},
+		{
+			name: "locked out",
+			req:  &userv1.CompleteMfaChallengeRequest{MfaChallengeToken: "mfa_challenge_token", TotpCode: "123456"},
+			mfaServiceExpectFunc: func(ctx context.Context) func(*service.MockMFAService) {
+				return func(mock *service.MockMFAService) {
+					mock.EXPECT().
+						CompleteChallenge(ctx, "mfa_challenge_token", service.MFAMethodTOTP, "123456").
+						Return(nil, "", errors.WithStack(&service.RateLimitedError{Limit: service.SignInLimitLockout, RetryAfter: time.Hour}))
+				}
+			},
+			expectedCode: codes.ResourceExhausted,
+			expectedErr:  "rpc error: code = ResourceExhausted desc = rate limited by sign_in_lockout",
+		},
+		{
+			name: "unexpected error",
+			req:  &userv1.CompleteMfaChallengeRequest{MfaChallengeToken: "mfa_challenge_token", TotpCode: "123456"},
+			mfaServiceExpectFunc: func(ctx context.Context) func(*service.MockMFAService) {
+				return func(mock *service.MockMFAService) {
+					mock.EXPECT().
+						CompleteChallenge(ctx, "mfa_challenge_token", service.MFAMethodTOTP, "123456").
+						Return(nil, "", errors.New("unexpected error"))
+				}
+			},
+			expectedCode: codes.Internal,
+			expectedErr:  "rpc error: code = Internal desc = unexpected error",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{
+				headerKeyGatewayUserAgent: "Mozilla/5.0",
+				headerKeyForwardedFor:     "203.0.113.1, 198.51.100.7",
+			}))
+
+			ctrl := gomock.NewController(t)
+
+			mockMFAService := service.NewMockMFAService(ctrl)
+			if tc.mfaServiceExpectFunc != nil {
+				tc.mfaServiceExpectFunc(ctx)(mockMFAService)
+			}
+
+			mockUserTokenService := service.NewMockUserTokenService(ctrl)
+			if tc.userTokenServiceExpectFunc != nil {
+				tc.userTokenServiceExpectFunc(ctx)(mockUserTokenService)
+			}
+
+			handler := CompleteMfaChallenge(mockMFAService, mockUserTokenService)
+
+			resp, err := handler(ctx, tc.req)
+
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedCode, status.Code(err))
+			assert.Empty(t, cmp.Diff(resp, tc.expectedResp, protocmp.Transform()))
+		})
+	}
+}
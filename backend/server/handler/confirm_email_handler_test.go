@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/sean-ahn/user/backend/server/service"
+	userv1 "github.com/sean-ahn/user/proto/gen/go/user/v1"
+)
+
+func TestConfirmEmail(t *testing.T) {
+	cases := []struct {
+		name string
+		req  *userv1.ConfirmEmailRequest
+
+		emailConfirmationServiceExpectFunc func(context.Context) func(*service.MockEmailConfirmationService)
+
+		expectedCode codes.Code
+		expectedResp *userv1.ConfirmEmailResponse
+		expectedErr  string
+	}{
+		{
+			name: "success",
+			req:  &userv1.ConfirmEmailRequest{ConfirmationCode: "confirmation_code"},
+			emailConfirmationServiceExpectFunc: func(ctx context.Context) func(*service.MockEmailConfirmationService) {
+				return func(mock *service.MockEmailConfirmationService) {
+					mock.EXPECT().
+						Confirm(ctx, "confirmation_code").
+						Return(nil)
+				}
+			},
+			expectedCode: codes.OK,
+			expectedResp: &userv1.ConfirmEmailResponse{},
+		},
+		{
+			name:         "no confirmation_code",
+			req:          &userv1.ConfirmEmailRequest{},
+			expectedCode: codes.InvalidArgument,
+			expectedErr:  "rpc error: code = InvalidArgument desc = no confirmation_code",
+		},
+		{
+			name: "invalid confirmation code",
+			req:  &userv1.ConfirmEmailRequest{ConfirmationCode: "confirmation_code"},
+			emailConfirmationServiceExpectFunc: func(ctx context.Context) func(*service.MockEmailConfirmationService) {
+				return func(mock *service.MockEmailConfirmationService) {
+					mock.EXPECT().
+						Confirm(ctx, "confirmation_code").
+						Return(errors.WithStack(service.ErrInvalidConfirmationCode))
+				}
+			},
+			expectedCode: codes.InvalidArgument,
+			expectedErr:  "rpc error: code = InvalidArgument desc = invalid confirmation code",
+		},
+		{
+			name: "already confirmed email",
+			req:  &userv1.ConfirmEmailRequest{ConfirmationCode: "confirmation_code"},
+			emailConfirmationServiceExpectFunc: func(ctx context.Context) func(*service.MockEmailConfirmationService) {
+				return func(mock *service.MockEmailConfirmationService) {
+					mock.EXPECT().
+						Confirm(ctx, "confirmation_code").
+						Return(errors.WithStack(service.ErrEmailAlreadyConfirmed))
+				}
+			},
+			expectedCode: codes.InvalidArgument,
+			expectedErr:  "rpc error: code = InvalidArgument desc = already confirmed email",
+		},
+		{
+			name: "unexpected error",
+			req:  &userv1.ConfirmEmailRequest{ConfirmationCode: "confirmation_code"},
+			emailConfirmationServiceExpectFunc: func(ctx context.Context) func(*service.MockEmailConfirmationService) {
+				return func(mock *service.MockEmailConfirmationService) {
+					mock.EXPECT().
+						Confirm(ctx, "confirmation_code").
+						Return(errors.New("unexpected error"))
+				}
+			},
+			expectedCode: codes.Internal,
+			expectedErr:  "rpc error: code = Internal desc = unexpected error",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+
+			mockEmailConfirmationService := service.NewMockEmailConfirmationService(ctrl)
+			if tc.emailConfirmationServiceExpectFunc != nil {
+				tc.emailConfirmationServiceExpectFunc(ctx)(mockEmailConfirmationService)
+			}
+
+			handler := ConfirmEmail(mockEmailConfirmationService)
+
+			resp, err := handler(ctx, tc.req)
+
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedCode, status.Code(err))
+			assert.Empty(t, cmp.Diff(resp, tc.expectedResp, protocmp.Transform()))
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/sean-ahn/user/backend/server/service"
+	userv1 "github.com/sean-ahn/user/proto/gen/go/user/v1"
+)
+
+type ConfirmTotpEnrollmentHandlerFunc func(ctx context.Context, req *userv1.ConfirmTotpEnrollmentRequest) (*userv1.ConfirmTotpEnrollmentResponse, error)
+
+func ConfirmTotpEnrollment(userTokenService service.UserTokenService, mfaService service.MFAService) ConfirmTotpEnrollmentHandlerFunc {
+	return func(ctx context.Context, req *userv1.ConfirmTotpEnrollmentRequest) (*userv1.ConfirmTotpEnrollmentResponse, error) {
+		token := extractToken(ctx)
+		if token == "" {
+			return nil, status.Error(codes.Unauthenticated, "no token")
+		}
+
+		user, err := userTokenService.GetUser(ctx, token)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		}
+
+		if req.TotpCode == "" {
+			return nil, status.Error(codes.InvalidArgument, "no totp_code")
+		}
+
+		recoveryCodes, err := mfaService.ConfirmTOTPEnrollment(ctx, user, req.TotpCode)
+		if err != nil {
+			switch errors.Cause(err) {
+			case service.ErrInvalidMFACode:
+				return nil, status.Error(codes.InvalidArgument, errors.Cause(err).Error())
+			case service.ErrTOTPAlreadyEnabled, service.ErrTOTPEnrollmentNotFound:
+				return nil, status.Error(codes.FailedPrecondition, errors.Cause(err).Error())
+			default:
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+		}
+
+		return &userv1.ConfirmTotpEnrollmentResponse{RecoveryCodes: recoveryCodes}, nil
+	}
+}
@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/sean-ahn/user/backend/model"
+	"github.com/sean-ahn/user/backend/server/service"
+	userv1 "github.com/sean-ahn/user/proto/gen/go/user/v1"
+)
+
+func TestConfirmTotpEnrollment(t *testing.T) {
+	cases := []struct {
+		name string
+		req  *userv1.ConfirmTotpEnrollmentRequest
+
+		userTokenServiceExpectFunc func(context.Context) func(*service.MockUserTokenService)
+		mfaServiceExpectFunc       func(context.Context) func(*service.MockMFAService)
+
+		expectedCode codes.Code
+		expectedResp *userv1.ConfirmTotpEnrollmentResponse
+		expectedErr  string
+	}{
+		{
+			name: "success",
+			req:  &userv1.ConfirmTotpEnrollmentRequest{TotpCode: "123456"},
+			userTokenServiceExpectFunc: func(ctx context.Context) func(*service.MockUserTokenService) {
+				return func(mock *service.MockUserTokenService) {
+					mock.EXPECT().
+						GetUser(ctx, "access_token").
+						Return(&model.User{UserID: 1}, nil)
+				}
+			},
+			mfaServiceExpectFunc: func(ctx context.Context) func(*service.MockMFAService) {
+				return func(mock *service.MockMFAService) {
+					mock.EXPECT().
+						ConfirmTOTPEnrollment(ctx, &model.User{UserID: 1}, "123456").
+						Return([]string{"abcd-efgh-ijkl-mnop", "qrst-uvwx-yz23-4567"}, nil)
+				}
+			},
+			expectedCode: codes.OK,
+			expectedResp: &userv1.ConfirmTotpEnrollmentResponse{RecoveryCodes: []string{"abcd-efgh-ijkl-mnop", "qrst-uvwx-yz23-4567"}},
+		},
+		{
+			name: "no totp_code",
+			req:  &userv1.ConfirmTotpEnrollmentRequest{},
+			userTokenServiceExpectFunc: func(ctx context.Context) func(*service.MockUserTokenService) {
+				return func(mock *service.MockUserTokenService) {
+					mock.EXPECT().
+						GetUser(ctx, "access_token").
+						Return(&model.User{UserID: 1}, nil)
+				}
+			},
+			expectedCode: codes.InvalidArgument,
+			expectedErr:  "rpc error: code = InvalidArgument desc = no totp_code",
+		},
+		{
+			name: "invalid code",
+			req:  &userv1.ConfirmTotpEnrollmentRequest{TotpCode: "123456"},
+			userTokenServiceExpectFunc: func(ctx context.Context) func(*service.MockUserTokenService) {
+				return func(mock *service.MockUserTokenService) {
+					mock.EXPECT().
+						GetUser(ctx, "access_token").
+						Return(&model.User{UserID: 1}, nil)
+				}
+			},
+			mfaServiceExpectFunc: func(ctx context.Context) func(*service.MockMFAService) {
+				return func(mock *service.MockMFAService) {
+					mock.EXPECT().
+						ConfirmTOTPEnrollment(ctx, &model.User{UserID: 1}, "123456").
+						Return(nil, errors.WithStack(service.ErrInvalidMFACode))
+				}
+			},
+			expectedCode: codes.InvalidArgument,
+			expectedErr:  "rpc error: code = InvalidArgument desc = invalid mfa code",
+		},
+		{
+			name: "not enrolled",
+			req:  &userv1.ConfirmTotpEnrollmentRequest{TotpCode: "123456"},
+			userTokenServiceExpectFunc: func(ctx context.Context) func(*service.MockUserTokenService) {
+				return func(mock *service.MockUserTokenService) {
+					mock.EXPECT().
+						GetUser(ctx, "access_token").
+						Return(&model.User{UserID: 1}, nil)
+				}
+			},
+			mfaServiceExpectFunc: func(ctx context.Context) func(*service.MockMFAService) {
+				return func(mock *service.MockMFAService) {
+					mock.EXPECT().
+						ConfirmTOTPEnrollment(ctx, &model.User{UserID: 1}, "123456").
+						Return(nil, errors.WithStack(service.ErrTOTPEnrollmentNotFound))
+				}
+			},
+			expectedCode: codes.FailedPrecondition,
+			expectedErr:  "rpc error: code = FailedPrecondition desc = totp enrollment not found",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{
+				headerKeyAuthorization: "Bearer access_token",
+			}))
+
+			ctrl := gomock.NewController(t)
+
+			mockUserTokenService := service.NewMockUserTokenService(ctrl)
+			if tc.userTokenServiceExpectFunc != nil {
+				tc.userTokenServiceExpectFunc(ctx)(mockUserTokenService)
+			}
+
+			mockMFAService := service.NewMockMFAService(ctrl)
+			if tc.mfaServiceExpectFunc != nil {
+				tc.mfaServiceExpectFunc(ctx)(mockMFAService)
+			}
+
+			handler := ConfirmTotpEnrollment(mockUserTokenService, mockMFAService)
+
+			resp, err := handler(ctx, tc.req)
+
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedCode, status.Code(err))
+			assert.Empty(t, cmp.Diff(resp, tc.expectedResp, protocmp.Transform()))
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/sean-ahn/user/backend/crypto"
+	"github.com/sean-ahn/user/backend/server/service"
+	userv1 "github.com/sean-ahn/user/proto/gen/go/user/v1"
+)
+
+type FinishWebauthnRegistrationHandlerFunc func(ctx context.Context, req *userv1.FinishWebauthnRegistrationRequest) (*userv1.FinishWebauthnRegistrationResponse, error)
+
+func FinishWebauthnRegistration(userTokenService service.UserTokenService, webAuthnService service.WebAuthnService) FinishWebauthnRegistrationHandlerFunc {
+	return func(ctx context.Context, req *userv1.FinishWebauthnRegistrationRequest) (*userv1.FinishWebauthnRegistrationResponse, error) {
+		token := extractToken(ctx)
+		if token == "" {
+			return nil, status.Error(codes.Unauthenticated, "no token")
+		}
+
+		user, err := userTokenService.GetUser(ctx, token)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		}
+
+		if req.CredentialId == "" {
+			return nil, status.Error(codes.InvalidArgument, "no credential_id")
+		}
+		if len(req.ClientDataJson) == 0 {
+			return nil, status.Error(codes.InvalidArgument, "no client_data_json")
+		}
+		if len(req.AttestationObject) == 0 {
+			return nil, status.Error(codes.InvalidArgument, "no attestation_object")
+		}
+
+		if err := webAuthnService.FinishRegistration(ctx, user, req.CredentialId, req.ClientDataJson, req.AttestationObject); err != nil {
+			switch errors.Cause(err) {
+			case service.ErrInvalidWebAuthnChallenge, crypto.ErrInvalidWebAuthnResponse, crypto.ErrUnsupportedWebAuthnAttestation, crypto.ErrUnsupportedCOSEKey:
+				return nil, status.Error(codes.InvalidArgument, errors.Cause(err).Error())
+			case service.ErrWebAuthnCredentialAlreadyRegistered:
+				return nil, status.Error(codes.AlreadyExists, errors.Cause(err).Error())
+			default:
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+		}
+
+		return &userv1.FinishWebauthnRegistrationResponse{}, nil
+	}
+}
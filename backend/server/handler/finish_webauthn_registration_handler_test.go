@@ -0,0 +1,207 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/sean-ahn/user/backend/crypto"
+	"github.com/sean-ahn/user/backend/model"
+	"github.com/sean-ahn/user/backend/server/service"
+	userv1 "github.com/sean-ahn/user/proto/gen/go/user/v1"
+)
+
+func TestFinishWebauthnRegistration(t *testing.T) {
+	validReq := &userv1.FinishWebauthnRegistrationRequest{
+		CredentialId:      "credential_id",
+		ClientDataJson:    []byte("client_data_json"),
+		AttestationObject: []byte("attestation_object"),
+	}
+
+	cases := []struct {
+		name string
+		req  *userv1.FinishWebauthnRegistrationRequest
+
+		userTokenServiceExpectFunc func(context.Context) func(*service.MockUserTokenService)
+		webAuthnServiceExpectFunc  func(context.Context) func(*service.MockWebAuthnService)
+
+		expectedCode codes.Code
+		expectedResp *userv1.FinishWebauthnRegistrationResponse
+		expectedErr  string
+	}{
+		{
+			name: "success",
+			req:  validReq,
+			userTokenServiceExpectFunc: func(ctx context.Context) func(*service.MockUserTokenService) {
+				return func(mock *service.MockUserTokenService) {
+					mock.EXPECT().
+						GetUser(ctx, "access_token").
+						Return(&model.User{UserID: 1}, nil)
+				}
+			},
+			webAuthnServiceExpectFunc: func(ctx context.Context) func(*service.MockWebAuthnService) {
+				return func(mock *service.MockWebAuthnService) {
+					mock.EXPECT().
+						FinishRegistration(ctx, &model.User{UserID: 1}, "credential_id", []byte("client_data_json"), []byte("attestation_object")).
+						Return(nil)
+				}
+			},
+			expectedCode: codes.OK,
+			expectedResp: &userv1.FinishWebauthnRegistrationResponse{},
+		},
+		{
+			name: "no credential_id",
+			req:  &userv1.FinishWebauthnRegistrationRequest{ClientDataJson: []byte("client_data_json"), AttestationObject: []byte("attestation_object")},
+			userTokenServiceExpectFunc: func(ctx context.Context) func(*service.MockUserTokenService) {
+				return func(mock *service.MockUserTokenService) {
+					mock.EXPECT().
+						GetUser(ctx, "access_token").
+						Return(&model.User{UserID: 1}, nil)
+				}
+			},
+			expectedCode: codes.InvalidArgument,
+			expectedErr:  "rpc error: code = InvalidArgument desc = no credential_id",
+		},
+		{
+			name: "no client_data_json",
+			req:  &userv1.FinishWebauthnRegistrationRequest{CredentialId: "credential_id", AttestationObject: []byte("attestation_object")},
+			userTokenServiceExpectFunc: func(ctx context.Context) func(*service.MockUserTokenService) {
+				return func(mock *service.MockUserTokenService) {
+					mock.EXPECT().
+						GetUser(ctx, "access_token").
+						Return(&model.User{UserID: 1}, nil)
+				}
+			},
+			expectedCode: codes.InvalidArgument,
+			expectedErr:  "rpc error: code = InvalidArgument desc = no client_data_json",
+		},
+		{
+			name: "no attestation_object",
+			req:  &userv1.FinishWebauthnRegistrationRequest{CredentialId: "credential_id", ClientDataJson: []byte("client_data_json")},
+			userTokenServiceExpectFunc: func(ctx context.Context) func(*service.MockUserTokenService) {
+				return func(mock *service.MockUserTokenService) {
+					mock.EXPECT().
+						GetUser(ctx, "access_token").
+						Return(&model.User{UserID: 1}, nil)
+				}
+			},
+			expectedCode: codes.InvalidArgument,
+			expectedErr:  "rpc error: code = InvalidArgument desc = no attestation_object",
+		},
+		{
+			name: "unauthorized",
+			req:  validReq,
+			userTokenServiceExpectFunc: func(ctx context.Context) func(*service.MockUserTokenService) {
+				return func(mock *service.MockUserTokenService) {
+					mock.EXPECT().
+						GetUser(ctx, "access_token").
+						Return(nil, errors.New("invalid token"))
+				}
+			},
+			expectedCode: codes.Unauthenticated,
+			expectedErr:  "rpc error: code = Unauthenticated desc = invalid token",
+		},
+		{
+			name: "invalid challenge",
+			req:  validReq,
+			userTokenServiceExpectFunc: func(ctx context.Context) func(*service.MockUserTokenService) {
+				return func(mock *service.MockUserTokenService) {
+					mock.EXPECT().
+						GetUser(ctx, "access_token").
+						Return(&model.User{UserID: 1}, nil)
+				}
+			},
+			webAuthnServiceExpectFunc: func(ctx context.Context) func(*service.MockWebAuthnService) {
+				return func(mock *service.MockWebAuthnService) {
+					mock.EXPECT().
+						FinishRegistration(ctx, &model.User{UserID: 1}, "credential_id", []byte("client_data_json"), []byte("attestation_object")).
+						Return(errors.WithStack(service.ErrInvalidWebAuthnChallenge))
+				}
+			},
+			expectedCode: codes.InvalidArgument,
+			expectedErr:  "rpc error: code = InvalidArgument desc = invalid webauthn challenge",
+		},
+		{
+			name: "unsupported attestation",
+			req:  validReq,
+			userTokenServiceExpectFunc: func(ctx context.Context) func(*service.MockUserTokenService) {
+				return func(mock *service.MockUserTokenService) {
+					mock.EXPECT().
+						GetUser(ctx, "access_token").
+						Return(&model.User{UserID: 1}, nil)
+				}
+			},
+			webAuthnServiceExpectFunc: func(ctx context.Context) func(*service.MockWebAuthnService) {
+				return func(mock *service.MockWebAuthnService) {
+					mock.EXPECT().
+						FinishRegistration(ctx, &model.User{UserID: 1}, "credential_id", []byte("client_data_json"), []byte("attestation_object")).
+						Return(errors.WithStack(crypto.ErrUnsupportedWebAuthnAttestation))
+				}
+			},
+			expectedCode: codes.InvalidArgument,
+			expectedErr:  "rpc error: code = InvalidArgument desc = unsupported webauthn attestation",
+		},
+		{
+			name: "already registered",
+			req:  validReq,
+			userTokenServiceExpectFunc: func(ctx context.Context) func(*service.MockUserTokenService) {
+				return func(mock *service.MockUserTokenService) {
+					mock.EXPECT().
+						GetUser(ctx, "access_token").
+						Return(&model.User{UserID: 1}, nil)
+				}
+			},
+			webAuthnServiceExpectFunc: func(ctx context.Context) func(*service.MockWebAuthnService) {
+				return func(mock *service.MockWebAuthnService) {
+					mock.EXPECT().
+						FinishRegistration(ctx, &model.User{UserID: 1}, "credential_id", []byte("client_data_json"), []byte("attestation_object")).
+						Return(errors.WithStack(service.ErrWebAuthnCredentialAlreadyRegistered))
+				}
+			},
+			expectedCode: codes.AlreadyExists,
+			expectedErr:  "rpc error: code = AlreadyExists desc = webauthn credential already registered",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{
+				headerKeyAuthorization: "Bearer access_token",
+			}))
+
+			ctrl := gomock.NewController(t)
+
+			mockUserTokenService := service.NewMockUserTokenService(ctrl)
+			if tc.userTokenServiceExpectFunc != nil {
+				tc.userTokenServiceExpectFunc(ctx)(mockUserTokenService)
+			}
+
+			mockWebAuthnService := service.NewMockWebAuthnService(ctrl)
+			if tc.webAuthnServiceExpectFunc != nil {
+				tc.webAuthnServiceExpectFunc(ctx)(mockWebAuthnService)
+			}
+
+			handler := FinishWebauthnRegistration(mockUserTokenService, mockWebAuthnService)
+
+			resp, err := handler(ctx, tc.req)
+
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedCode, status.Code(err))
+			assert.Empty(t, cmp.Diff(resp, tc.expectedResp, protocmp.Transform()))
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/sean-ahn/user/backend/crypto"
+	"github.com/sean-ahn/user/backend/server/service"
+	userv1 "github.com/sean-ahn/user/proto/gen/go/user/v1"
+)
+
+type FinishWebauthnSignInHandlerFunc func(ctx context.Context, req *userv1.FinishWebauthnSignInRequest) (*userv1.FinishWebauthnSignInResponse, error)
+
+func FinishWebauthnSignIn(webAuthnService service.WebAuthnService, userTokenService service.UserTokenService, signInThrottle service.SignInThrottle) FinishWebauthnSignInHandlerFunc {
+	return func(ctx context.Context, req *userv1.FinishWebauthnSignInRequest) (*userv1.FinishWebauthnSignInResponse, error) {
+		if req.CredentialId == "" {
+			return nil, status.Error(codes.InvalidArgument, "no credential_id")
+		}
+		if len(req.ClientDataJson) == 0 {
+			return nil, status.Error(codes.InvalidArgument, "no client_data_json")
+		}
+		if len(req.AuthenticatorData) == 0 {
+			return nil, status.Error(codes.InvalidArgument, "no authenticator_data")
+		}
+		if len(req.Signature) == 0 {
+			return nil, status.Error(codes.InvalidArgument, "no signature")
+		}
+		if len(req.UserHandle) == 0 {
+			return nil, status.Error(codes.InvalidArgument, "no user_handle")
+		}
+
+		client := extractClientInfo(ctx)
+
+		// throttled as password sign-ins are, so that neither can be used to get around the other
+		if client.IPAddress != "" {
+			if err := checkSignInThrottle(ctx, signInThrottle, service.SignInThrottleScopeIPAddress, client.IPAddress); err != nil {
+				return nil, err
+			}
+		}
+
+		user, deviceLabel, err := webAuthnService.FinishSignIn(ctx, req.CredentialId, req.ClientDataJson, req.AuthenticatorData, req.Signature, req.UserHandle)
+		if err != nil {
+			switch errors.Cause(err) {
+			case service.ErrInvalidWebAuthnChallenge, service.ErrWebAuthnCredentialNotFound, crypto.ErrInvalidWebAuthnResponse, crypto.ErrUnsupportedCOSEKey:
+				recordSignInFailure(ctx, signInThrottle, client.IPAddress, "")
+				return nil, status.Error(codes.Unauthenticated, errors.Cause(err).Error())
+			default:
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+		}
+
+		client.DeviceLabel = deviceLabel
+
+		accessToken, refreshToken, err := userTokenService.Issue(ctx, user, client)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+
+		return &userv1.FinishWebauthnSignInResponse{AccessToken: accessToken, RefreshToken: refreshToken}, nil
+	}
+}
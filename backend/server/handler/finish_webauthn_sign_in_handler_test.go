@@ -0,0 +1,217 @@
+package handler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/sean-ahn/user/backend/crypto"
+	"github.com/sean-ahn/user/backend/model"
+	"github.com/sean-ahn/user/backend/server/service"
+	userv1 "github.com/sean-ahn/user/proto/gen/go/user/v1"
+)
+
+func TestFinishWebauthnSignIn(t *testing.T) {
+	validReq := &userv1.FinishWebauthnSignInRequest{
+		CredentialId:      "credential_id",
+		ClientDataJson:    []byte("client_data_json"),
+		AuthenticatorData: []byte("authenticator_data"),
+		Signature:         []byte("signature"),
+		UserHandle:        []byte("user_handle"),
+	}
+
+	signInThrottleNotBlocked := func(ctx context.Context) func(*service.MockSignInThrottle) {
+		return func(mock *service.MockSignInThrottle) {
+			mock.EXPECT().Check(ctx, service.SignInThrottleScopeIPAddress, "198.51.100.7").Return(nil)
+		}
+	}
+	signInThrottleFailed := func(ctx context.Context) func(*service.MockSignInThrottle) {
+		return func(mock *service.MockSignInThrottle) {
+			mock.EXPECT().Check(ctx, service.SignInThrottleScopeIPAddress, "198.51.100.7").Return(nil)
+			mock.EXPECT().Fail(ctx, service.SignInThrottleScopeIPAddress, "198.51.100.7").Return(nil)
+		}
+	}
+
+	cases := []struct {
+		name string
+		req  *userv1.FinishWebauthnSignInRequest
+
+		webAuthnServiceExpectFunc  func(context.Context) func(*service.MockWebAuthnService)
+		userTokenServiceExpectFunc func(context.Context) func(*service.MockUserTokenService)
+		signInThrottleExpectFunc   func(context.Context) func(*service.MockSignInThrottle)
+
+		expectedCode codes.Code
+		expectedResp *userv1.FinishWebauthnSignInResponse
+		expectedErr  string
+	}{
+		{
+			name: "success",
+			req:  validReq,
+			webAuthnServiceExpectFunc: func(ctx context.Context) func(*service.MockWebAuthnService) {
+				return func(mock *service.MockWebAuthnService) {
+					mock.EXPECT().
+						FinishSignIn(ctx, "credential_id", []byte("client_data_json"), []byte("authenticator_data"), []byte("signature"), []byte("user_handle")).
+						Return(&model.User{UserID: 1}, "Chrome on macOS", nil)
+				}
+			},
+			userTokenServiceExpectFunc: func(ctx context.Context) func(*service.MockUserTokenService) {
+				return func(mock *service.MockUserTokenService) {
+					mock.EXPECT().
+						Issue(ctx, &model.User{UserID: 1}, service.ClientInfo{DeviceLabel: "Chrome on macOS", UserAgent: "Mozilla/5.0", IPAddress: "198.51.100.7"}).
+						Return("access_token", "refresh_token", nil)
+				}
+			},
+			signInThrottleExpectFunc: signInThrottleNotBlocked,
+			expectedCode:             codes.OK,
+			expectedResp:             &userv1.FinishWebauthnSignInResponse{AccessToken: "access_token", RefreshToken: "refresh_token"},
+		},
+		{
+			name:         "no credential_id",
+			req:          &userv1.FinishWebauthnSignInRequest{ClientDataJson: []byte("client_data_json"), AuthenticatorData: []byte("authenticator_data"), Signature: []byte("signature")},
+			expectedCode: codes.InvalidArgument,
+			expectedErr:  "rpc error: code = InvalidArgument desc = no credential_id",
+		},
+		{
+			name:         "no client_data_json",
+			req:          &userv1.FinishWebauthnSignInRequest{CredentialId: "credential_id", AuthenticatorData: []byte("authenticator_data"), Signature: []byte("signature")},
+			expectedCode: codes.InvalidArgument,
+			expectedErr:  "rpc error: code = InvalidArgument desc = no client_data_json",
+		},
+		{
+			name:         "no authenticator_data",
+			req:          &userv1.FinishWebauthnSignInRequest{CredentialId: "credential_id", ClientDataJson: []byte("client_data_json"), Signature: []byte("signature")},
+			expectedCode: codes.InvalidArgument,
+			expectedErr:  "rpc error: code = InvalidArgument desc = no authenticator_data",
+		},
+		{
+			name:         "no signature",
+			req:          &userv1.FinishWebauthnSignInRequest{CredentialId: "credential_id", ClientDataJson: []byte("client_data_json"), AuthenticatorData: []byte("authenticator_data")},
+			expectedCode: codes.InvalidArgument,
+			expectedErr:  "rpc error: code = InvalidArgument desc = no signature",
+		},
+		{
+			name:         "no user_handle",
+			req:          &userv1.FinishWebauthnSignInRequest{CredentialId: "credential_id", ClientDataJson: []byte("client_data_json"), AuthenticatorData: []byte("authenticator_data"), Signature: []byte("signature")},
+			expectedCode: codes.InvalidArgument,
+			expectedErr:  "rpc error: code = InvalidArgument desc = no user_handle",
+		},
+		{
+			name: "sign in from blocked ip address",
+			req:  validReq,
+			signInThrottleExpectFunc: func(ctx context.Context) func(*service.MockSignInThrottle) {
+				return func(mock *service.MockSignInThrottle) {
+					mock.EXPECT().
+						Check(ctx, service.SignInThrottleScopeIPAddress, "198.51.100.7").
+						Return(errors.WithStack(&service.RateLimitedError{Limit: service.SignInLimitDelay, RetryAfter: 4 * time.Second}))
+				}
+			},
+			expectedCode: codes.ResourceExhausted,
+			expectedErr:  "rpc error: code = ResourceExhausted desc = rate limited by sign_in_delay",
+		},
+		{
+			name: "invalid challenge",
+			req:  validReq,
+			webAuthnServiceExpectFunc: func(ctx context.Context) func(*service.MockWebAuthnService) {
+				return func(mock *service.MockWebAuthnService) {
+					mock.EXPECT().
+						FinishSignIn(ctx, "credential_id", []byte("client_data_json"), []byte("authenticator_data"), []byte("signature"), []byte("user_handle")).
+						Return(nil, "", errors.WithStack(service.ErrInvalidWebAuthnChallenge))
+				}
+			},
+			signInThrottleExpectFunc: signInThrottleFailed,
+			expectedCode:             codes.Unauthenticated,
+			expectedErr:              "rpc error: code = Unauthenticated desc = invalid webauthn challenge",
+		},
+		{
+			name: "credential not found",
+			req:  validReq,
+			webAuthnServiceExpectFunc: func(ctx context.Context) func(*service.MockWebAuthnService) {
+				return func(mock *service.MockWebAuthnService) {
+					mock.EXPECT().
+						FinishSignIn(ctx, "credential_id", []byte("client_data_json"), []byte("authenticator_data"), []byte("signature"), []byte("user_handle")).
+						Return(nil, "", errors.WithStack(service.ErrWebAuthnCredentialNotFound))
+				}
+			},
+			signInThrottleExpectFunc: signInThrottleFailed,
+			expectedCode:             codes.Unauthenticated,
+			expectedErr:              "rpc error: code = Unauthenticated desc = webauthn credential not found",
+		},
+		{
+			name: "invalid signature",
+			req:  validReq,
+			webAuthnServiceExpectFunc: func(ctx context.Context) func(*service.MockWebAuthnService) {
+				return func(mock *service.MockWebAuthnService) {
+					mock.EXPECT().
+						FinishSignIn(ctx, "credential_id", []byte("client_data_json"), []byte("authenticator_data"), []byte("signature"), []byte("user_handle")).
+						Return(nil, "", errors.WithStack(crypto.ErrInvalidWebAuthnResponse))
+				}
+			},
+			signInThrottleExpectFunc: signInThrottleFailed,
+			expectedCode:             codes.Unauthenticated,
+			expectedErr:              "rpc error: code = Unauthenticated desc = invalid webauthn response",
+		},
+		{
+			name: "unexpected error",
+			req:  validReq,
+			webAuthnServiceExpectFunc: func(ctx context.Context) func(*service.MockWebAuthnService) {
+				return func(mock *service.MockWebAuthnService) {
+					mock.EXPECT().
+						FinishSignIn(ctx, "credential_id", []byte("client_data_json"), []byte("authenticator_data"), []byte("signature"), []byte("user_handle")).
+						Return(nil, "", errors.New("unexpected error"))
+				}
+			},
+			signInThrottleExpectFunc: signInThrottleNotBlocked,
+			expectedCode:             codes.Internal,
+			expectedErr:              "rpc error: code = Internal desc = unexpected error",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{
+				headerKeyGatewayUserAgent: "Mozilla/5.0",
+				headerKeyForwardedFor:     "203.0.113.1, 198.51.100.7",
+			}))
+
+			ctrl := gomock.NewController(t)
+
+			mockWebAuthnService := service.NewMockWebAuthnService(ctrl)
+			if tc.webAuthnServiceExpectFunc != nil {
+				tc.webAuthnServiceExpectFunc(ctx)(mockWebAuthnService)
+			}
+
+			mockUserTokenService := service.NewMockUserTokenService(ctrl)
+			if tc.userTokenServiceExpectFunc != nil {
+				tc.userTokenServiceExpectFunc(ctx)(mockUserTokenService)
+			}
+
+			mockSignInThrottle := service.NewMockSignInThrottle(ctrl)
+			if tc.signInThrottleExpectFunc != nil {
+				tc.signInThrottleExpectFunc(ctx)(mockSignInThrottle)
+			}
+
+			handler := FinishWebauthnSignIn(mockWebAuthnService, mockUserTokenService, mockSignInThrottle)
+
+			resp, err := handler(ctx, tc.req)
+
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedCode, status.Code(err))
+			assert.Empty(t, cmp.Diff(resp, tc.expectedResp, protocmp.Transform()))
+		})
+	}
+}
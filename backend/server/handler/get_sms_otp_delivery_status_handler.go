@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/friendsofgo/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/sean-ahn/user/backend/persistence/mysql"
+	userv1 "github.com/sean-ahn/user/proto/gen/go/user/v1"
+)
+
+type GetSmsOtpDeliveryStatusHandlerFunc func(ctx context.Context, req *userv1.GetSmsOtpDeliveryStatusRequest) (*userv1.GetSmsOtpDeliveryStatusResponse, error)
+
+func GetSmsOtpDeliveryStatus(db *sql.DB) GetSmsOtpDeliveryStatusHandlerFunc {
+	return func(ctx context.Context, req *userv1.GetSmsOtpDeliveryStatusRequest) (*userv1.GetSmsOtpDeliveryStatusResponse, error) {
+		if req.VerificationToken == "" {
+			return nil, status.Error(codes.InvalidArgument, "no verification_token")
+		}
+
+		verification, err := mysql.FindSMSOTPVerificationByVerificationToken(ctx, db, req.VerificationToken)
+		if err != nil && errors.Cause(err) == sql.ErrNoRows {
+			return nil, status.Error(codes.NotFound, "verification not found")
+		}
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+
+		// verifications requested before the outbox was introduced have none
+		outbox, err := mysql.FindLatestSMSOutboxBySMSOTPVerificationID(ctx, db, verification.SMSOtpVerificationID)
+		if err != nil && errors.Cause(err) == sql.ErrNoRows {
+			return nil, status.Error(codes.NotFound, "delivery not found")
+		}
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+
+		return &userv1.GetSmsOtpDeliveryStatusResponse{
+			Status: outbox.Status,
+		}, nil
+	}
+}
@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/volatiletech/null/v8"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/sean-ahn/user/backend/model"
+	"github.com/sean-ahn/user/backend/test"
+	userv1 "github.com/sean-ahn/user/proto/gen/go/user/v1"
+)
+
+func TestGetSmsOtpDeliveryStatus(t *testing.T) {
+	cases := []struct {
+		name string
+		req  *userv1.GetSmsOtpDeliveryStatusRequest
+
+		dbExpectFunc func(sqlmock.Sqlmock)
+
+		expectedCode codes.Code
+		expectedResp *userv1.GetSmsOtpDeliveryStatusResponse
+		expectedErr  string
+	}{
+		{
+			name: "success",
+			req:  &userv1.GetSmsOtpDeliveryStatusRequest{VerificationToken: "verification_token"},
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `sms_otp_verification` WHERE (`sms_otp_verification`.`verification_token` = ?) LIMIT 1;",
+				)).WithArgs(
+					"verification_token",
+				).WillReturnRows(test.NewSMSOtpVerificationRows([]*model.SMSOtpVerification{
+					{SMSOtpVerificationID: 2, VerificationToken: "verification_token"},
+				}))
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `sms_outbox` WHERE (`sms_outbox`.`sms_otp_verification_id` = ?) ORDER BY sms_outbox_id DESC LIMIT 1;",
+				)).WithArgs(
+					2,
+				).WillReturnRows(test.NewSMSOutboxRows([]*model.SMSOutbox{
+					{SMSOutboxID: 3, SMSOtpVerificationID: null.IntFrom(2), Status: "sent"},
+				}))
+			},
+			expectedCode: codes.OK,
+			expectedResp: &userv1.GetSmsOtpDeliveryStatusResponse{Status: "sent"},
+		},
+		{
+			name:         "no verification token",
+			req:          &userv1.GetSmsOtpDeliveryStatusRequest{},
+			expectedCode: codes.InvalidArgument,
+			expectedErr:  "rpc error: code = InvalidArgument desc = no verification_token",
+		},
+		{
+			name: "verification not found",
+			req:  &userv1.GetSmsOtpDeliveryStatusRequest{VerificationToken: "verification_token"},
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `sms_otp_verification` WHERE (`sms_otp_verification`.`verification_token` = ?) LIMIT 1;",
+				)).WithArgs(
+					"verification_token",
+				).WillReturnError(
+					sql.ErrNoRows,
+				)
+			},
+			expectedCode: codes.NotFound,
+			expectedErr:  "rpc error: code = NotFound desc = verification not found",
+		},
+		{
+			name: "delivery not found",
+			req:  &userv1.GetSmsOtpDeliveryStatusRequest{VerificationToken: "verification_token"},
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `sms_otp_verification` WHERE (`sms_otp_verification`.`verification_token` = ?) LIMIT 1;",
+				)).WithArgs(
+					"verification_token",
+				).WillReturnRows(test.NewSMSOtpVerificationRows([]*model.SMSOtpVerification{
+					{SMSOtpVerificationID: 2, VerificationToken: "verification_token"},
+				}))
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `sms_outbox` WHERE (`sms_outbox`.`sms_otp_verification_id` = ?) ORDER BY sms_outbox_id DESC LIMIT 1;",
+				)).WithArgs(
+					2,
+				).WillReturnRows(test.NewSMSOutboxRows(nil))
+			},
+			expectedCode: codes.NotFound,
+			expectedErr:  "rpc error: code = NotFound desc = delivery not found",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fail()
+			}
+			if tc.dbExpectFunc != nil {
+				tc.dbExpectFunc(mock)
+			}
+			defer test.CloseSqlmock(t, db, mock)
+
+			handler := GetSmsOtpDeliveryStatus(db)
+
+			resp, err := handler(ctx, tc.req)
+
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedCode, status.Code(err))
+			assert.Empty(t, cmp.Diff(resp, tc.expectedResp, protocmp.Transform()))
+		})
+	}
+}
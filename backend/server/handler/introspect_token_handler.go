@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/base64"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/sean-ahn/user/backend/server/service"
+	userv1 "github.com/sean-ahn/user/proto/gen/go/user/v1"
+)
+
+type IntrospectTokenHandlerFunc func(ctx context.Context, req *userv1.IntrospectTokenRequest) (*userv1.IntrospectTokenResponse, error)
+
+func IntrospectToken(introspectionClients map[string]string, userTokenService service.UserTokenService) IntrospectTokenHandlerFunc {
+	return func(ctx context.Context, req *userv1.IntrospectTokenRequest) (*userv1.IntrospectTokenResponse, error) {
+		// only the resource servers are allowed, or anyone could check whether a token they got hold of is active
+		clientID, secret, ok := extractBasicAuth(ctx)
+		if !ok {
+			return nil, status.Error(codes.Unauthenticated, "no client credentials")
+		}
+		expectedSecret, ok := introspectionClients[clientID]
+		if !ok || subtle.ConstantTimeCompare([]byte(secret), []byte(expectedSecret)) != 1 {
+			return nil, status.Error(codes.Unauthenticated, "invalid client credentials")
+		}
+
+		if req.Token == "" {
+			return nil, status.Error(codes.InvalidArgument, "no token")
+		}
+
+		introspection, err := userTokenService.Introspect(ctx, req.Token)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+
+		if !introspection.Active {
+			return &userv1.IntrospectTokenResponse{Active: false}, nil
+		}
+
+		return &userv1.IntrospectTokenResponse{
+			Active:    true,
+			Sub:       introspection.Subject,
+			Exp:       introspection.ExpiresAt.Unix(),
+			Iat:       introspection.IssuedAt.Unix(),
+			Jti:       introspection.JTI,
+			TokenType: introspection.TokenType,
+			Scopes:    introspection.Scopes,
+		}, nil
+	}
+}
+
+// extractBasicAuth returns the credentials of `Authorization: Basic <base64 of client_id:secret>`.
+func extractBasicAuth(ctx context.Context) (string, string, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", "", false
+	}
+	authorization := md.Get(headerKeyAuthorization)
+	if len(authorization) != 1 {
+		return "", "", false
+	}
+
+	split := strings.Split(authorization[0], " ")
+	if len(split) != 2 || split[0] != "Basic" {
+		return "", "", false
+	}
+	decoded, err := base64.StdEncoding.DecodeString(split[1])
+	if err != nil {
+		return "", "", false
+	}
+
+	i := strings.Index(string(decoded), ":")
+	if i < 0 {
+		return "", "", false
+	}
+	return string(decoded[:i]), string(decoded[i+1:]), true
+}
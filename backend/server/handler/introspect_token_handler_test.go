@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/sean-ahn/user/backend/server/service"
+	userv1 "github.com/sean-ahn/user/proto/gen/go/user/v1"
+)
+
+func TestIntrospectToken(t *testing.T) {
+	now := time.Date(2021, 10, 24, 7, 39, 46, 0, time.UTC)
+
+	introspectionClients := map[string]string{"api": "s3cr3t"}
+
+	cases := []struct {
+		name          string
+		authorization string
+		req           *userv1.IntrospectTokenRequest
+
+		userTokenServiceExpectFunc func(context.Context) func(*service.MockUserTokenService)
+
+		expectedCode codes.Code
+		expectedResp *userv1.IntrospectTokenResponse
+		expectedErr  string
+	}{
+		{
+			name:          "active",
+			authorization: "Basic YXBpOnMzY3IzdA==", // api:s3cr3t
+			req:           &userv1.IntrospectTokenRequest{Token: "refresh_token"},
+			userTokenServiceExpectFunc: func(ctx context.Context) func(*service.MockUserTokenService) {
+				return func(mock *service.MockUserTokenService) {
+					mock.EXPECT().
+						Introspect(ctx, "refresh_token").
+						Return(&service.TokenIntrospection{
+							Active:    true,
+							Subject:   "1",
+							ExpiresAt: now.Add(14 * 24 * time.Hour),
+							IssuedAt:  now,
+							JTI:       "d391416c-c2d2-44d5-b3ec-147a7713606d",
+							TokenType: service.TokenTypeRefresh,
+							Scopes:    []string{service.ScopeUser},
+						}, nil)
+				}
+			},
+			expectedCode: codes.OK,
+			expectedResp: &userv1.IntrospectTokenResponse{
+				Active:    true,
+				Sub:       "1",
+				Exp:       now.Add(14 * 24 * time.Hour).Unix(),
+				Iat:       now.Unix(),
+				Jti:       "d391416c-c2d2-44d5-b3ec-147a7713606d",
+				TokenType: service.TokenTypeRefresh,
+				Scopes:    []string{service.ScopeUser},
+			},
+		},
+		{
+			name:          "inactive",
+			authorization: "Basic YXBpOnMzY3IzdA==", // api:s3cr3t
+			req:           &userv1.IntrospectTokenRequest{Token: "refresh_token"},
+			userTokenServiceExpectFunc: func(ctx context.Context) func(*service.MockUserTokenService) {
+				return func(mock *service.MockUserTokenService) {
+					mock.EXPECT().
+						Introspect(ctx, "refresh_token").
+						Return(&service.TokenIntrospection{Active: false}, nil)
+				}
+			},
+			expectedCode: codes.OK,
+			expectedResp: &userv1.IntrospectTokenResponse{Active: false},
+		},
+		{
+			name:          "no token",
+			authorization: "Basic YXBpOnMzY3IzdA==",
+			req:           &userv1.IntrospectTokenRequest{},
+			expectedCode:  codes.InvalidArgument,
+			expectedErr:   "rpc error: code = InvalidArgument desc = no token",
+		},
+		{
+			name:         "no client credentials",
+			req:          &userv1.IntrospectTokenRequest{Token: "refresh_token"},
+			expectedCode: codes.Unauthenticated,
+			expectedErr:  "rpc error: code = Unauthenticated desc = no client credentials",
+		},
+		{
+			name:          "bearer token instead of client credentials",
+			authorization: "Bearer access_token",
+			req:           &userv1.IntrospectTokenRequest{Token: "refresh_token"},
+			expectedCode:  codes.Unauthenticated,
+			expectedErr:   "rpc error: code = Unauthenticated desc = no client credentials",
+		},
+		{
+			name:          "wrong secret",
+			authorization: "Basic YXBpOndyb25n", // api:wrong
+			req:           &userv1.IntrospectTokenRequest{Token: "refresh_token"},
+			expectedCode:  codes.Unauthenticated,
+			expectedErr:   "rpc error: code = Unauthenticated desc = invalid client credentials",
+		},
+		{
+			name:          "unknown client",
+			authorization: "Basic d2ViOnMzY3IzdA==", // web:s3cr3t
+			req:           &userv1.IntrospectTokenRequest{Token: "refresh_token"},
+			expectedCode:  codes.Unauthenticated,
+			expectedErr:   "rpc error: code = Unauthenticated desc = invalid client credentials",
+		},
+		{
+			name:          "unexpected error",
+			authorization: "Basic YXBpOnMzY3IzdA==", // api:s3cr3t
+			req:           &userv1.IntrospectTokenRequest{Token: "refresh_token"},
+			userTokenServiceExpectFunc: func(ctx context.Context) func(*service.MockUserTokenService) {
+				return func(mock *service.MockUserTokenService) {
+					mock.EXPECT().
+						Introspect(ctx, "refresh_token").
+						Return(nil, errors.New("unexpected error"))
+				}
+			},
+			expectedCode: codes.Internal,
+			expectedErr:  "rpc error: code = Internal desc = unexpected error",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.authorization != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(headerKeyAuthorization, tc.authorization))
+			}
+
+			ctrl := gomock.NewController(t)
+
+			mockUserTokenService := service.NewMockUserTokenService(ctrl)
+			if tc.userTokenServiceExpectFunc != nil {
+				tc.userTokenServiceExpectFunc(ctx)(mockUserTokenService)
+			}
+
+			handler := IntrospectToken(introspectionClients, mockUserTokenService)
+
+			resp, err := handler(ctx, tc.req)
+
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedCode, status.Code(err))
+			assert.Empty(t, cmp.Diff(resp, tc.expectedResp, protocmp.Transform()))
+		})
+	}
+}
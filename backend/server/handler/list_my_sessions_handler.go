@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/sean-ahn/user/backend/model"
+	"github.com/sean-ahn/user/backend/server/service"
+	userv1 "github.com/sean-ahn/user/proto/gen/go/user/v1"
+)
+
+type ListMySessionsHandlerFunc func(ctx context.Context, req *userv1.ListMySessionsRequest) (*userv1.ListMySessionsResponse, error)
+
+func ListMySessions(userTokenService service.UserTokenService) ListMySessionsHandlerFunc {
+	return func(ctx context.Context, req *userv1.ListMySessionsRequest) (*userv1.ListMySessionsResponse, error) {
+		token := extractToken(ctx)
+		if token == "" {
+			return nil, status.Error(codes.Unauthenticated, "no token")
+		}
+
+		user, sessionID, err := userTokenService.GetUserSession(ctx, token)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		}
+
+		sessions, err := userTokenService.ListSessions(ctx, user)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+
+		resp := &userv1.ListMySessionsResponse{Sessions: make([]*userv1.Session, 0, len(sessions))}
+		for _, s := range sessions {
+			resp.Sessions = append(resp.Sessions, convertToSession(s, sessionID))
+		}
+		return resp, nil
+	}
+}
+
+func convertToSession(s *model.UserSession, currentSessionID string) *userv1.Session {
+	return &userv1.Session{
+		SessionId:   s.FamilyID,
+		DeviceLabel: s.DeviceLabel,
+		UserAgent:   s.UserAgent,
+		IpAddress:   s.IPAddress,
+		CreatedAt:   timestamppb.New(s.CreatedAt),
+		LastUsedAt:  timestamppb.New(s.LastUsedAt),
+		Current:     s.FamilyID == currentSessionID,
+	}
+}
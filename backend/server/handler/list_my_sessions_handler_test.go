@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/sean-ahn/user/backend/model"
+	"github.com/sean-ahn/user/backend/server/service"
+	userv1 "github.com/sean-ahn/user/proto/gen/go/user/v1"
+)
+
+func TestListMySessions(t *testing.T) {
+	now := time.Date(2021, 10, 24, 7, 39, 46, 127956672, time.UTC)
+
+	cases := []struct {
+		name string
+		req  *userv1.ListMySessionsRequest
+
+		userTokenServiceExpectFunc func(context.Context) func(*service.MockUserTokenService)
+
+		expectedCode codes.Code
+		expectedResp *userv1.ListMySessionsResponse
+		expectedErr  string
+	}{
+		{
+			name: "success",
+			req:  &userv1.ListMySessionsRequest{},
+			userTokenServiceExpectFunc: func(ctx context.Context) func(*service.MockUserTokenService) {
+				return func(mock *service.MockUserTokenService) {
+					user := &model.User{UserID: 1}
+
+					mock.EXPECT().
+						GetUserSession(ctx, "access_token").
+						Return(user, "c4a1b5e6-1f57-4a0b-9d63-0b6f1f1c9f7a", nil)
+
+					mock.EXPECT().
+						ListSessions(ctx, user).
+						Return(model.UserSessionSlice{
+							{FamilyID: "c4a1b5e6-1f57-4a0b-9d63-0b6f1f1c9f7a", UserID: 1, DeviceLabel: "Chrome on macOS", UserAgent: "Mozilla/5.0", IPAddress: "198.51.100.7", LastUsedAt: now, CreatedAt: now.Add(-time.Hour)},
+							{FamilyID: "5d0a3e4c-8f2b-4b8e-a1f7-3c9e2d6b7a10", UserID: 1, UserAgent: "okhttp/4.9.1", IPAddress: "203.0.113.1", LastUsedAt: now.Add(-24 * time.Hour), CreatedAt: now.Add(-48 * time.Hour)},
+						}, nil)
+				}
+			},
+			expectedCode: codes.OK,
+			expectedResp: &userv1.ListMySessionsResponse{
+				Sessions: []*userv1.Session{
+					{
+						SessionId:   "c4a1b5e6-1f57-4a0b-9d63-0b6f1f1c9f7a",
+						DeviceLabel: "Chrome on macOS",
+						UserAgent:   "Mozilla/5.0",
+						IpAddress:   "198.51.100.7",
+						CreatedAt:   timestamppb.New(now.Add(-time.Hour)),
+						LastUsedAt:  timestamppb.New(now),
+						Current:     true,
+					},
+					{
+						SessionId:  "5d0a3e4c-8f2b-4b8e-a1f7-3c9e2d6b7a10",
+						UserAgent:  "okhttp/4.9.1",
+						IpAddress:  "203.0.113.1",
+						CreatedAt:  timestamppb.New(now.Add(-48 * time.Hour)),
+						LastUsedAt: timestamppb.New(now.Add(-24 * time.Hour)),
+					},
+				},
+			},
+		},
+		{
+			name: "unauthorized",
+			req:  &userv1.ListMySessionsRequest{},
+			userTokenServiceExpectFunc: func(ctx context.Context) func(*service.MockUserTokenService) {
+				return func(mock *service.MockUserTokenService) {
+					mock.EXPECT().
+						GetUserSession(ctx, "access_token").
+						Return(nil, "", errors.New("invalid token"))
+				}
+			},
+			expectedCode: codes.Unauthenticated,
+			expectedErr:  "rpc error: code = Unauthenticated desc = invalid token",
+		},
+		{
+			name: "unexpected error",
+			req:  &userv1.ListMySessionsRequest{},
+			userTokenServiceExpectFunc: func(ctx context.Context) func(*service.MockUserTokenService) {
+				return func(mock *service.MockUserTokenService) {
+					user := &model.User{UserID: 1}
+
+					mock.EXPECT().
+						GetUserSession(ctx, "access_token").
+						Return(user, "c4a1b5e6-1f57-4a0b-9d63-0b6f1f1c9f7a", nil)
+
+					mock.EXPECT().
+						ListSessions(ctx, user).
+						Return(nil, errors.New("unexpected error"))
+				}
+			},
+			expectedCode: codes.Internal,
+			expectedErr:  "rpc error: code = Internal desc = unexpected error",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{
+				headerKeyAuthorization: "Bearer access_token",
+			}))
+
+			ctrl := gomock.NewController(t)
+
+			mockUserTokenService := service.NewMockUserTokenService(ctrl)
+			if tc.userTokenServiceExpectFunc != nil {
+				tc.userTokenServiceExpectFunc(ctx)(mockUserTokenService)
+			}
+
+			handler := ListMySessions(mockUserTokenService)
+
+			resp, err := handler(ctx, tc.req)
+
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedCode, status.Code(err))
+			assert.Empty(t, cmp.Diff(resp, tc.expectedResp, protocmp.Transform()))
+		})
+	}
+}
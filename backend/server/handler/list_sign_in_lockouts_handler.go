@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/sean-ahn/user/backend/model"
+	"github.com/sean-ahn/user/backend/server/service"
+	userv1 "github.com/sean-ahn/user/proto/gen/go/user/v1"
+)
+
+type ListSignInLockoutsHandlerFunc func(ctx context.Context, req *userv1.ListSignInLockoutsRequest) (*userv1.ListSignInLockoutsResponse, error)
+
+func ListSignInLockouts(signInThrottle service.SignInThrottle) ListSignInLockoutsHandlerFunc {
+	return func(ctx context.Context, req *userv1.ListSignInLockoutsRequest) (*userv1.ListSignInLockoutsResponse, error) {
+		throttles, err := signInThrottle.ListBlocked(ctx)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+
+		resp := &userv1.ListSignInLockoutsResponse{SignInLockouts: make([]*userv1.SignInLockout, 0, len(throttles))}
+		for _, t := range throttles {
+			resp.SignInLockouts = append(resp.SignInLockouts, convertToSignInLockout(t))
+		}
+		return resp, nil
+	}
+}
+
+func convertToSignInLockout(t *model.SignInThrottle) *userv1.SignInLockout {
+	return &userv1.SignInLockout{
+		Scope:        t.Scope,
+		Key:          t.ScopeKey,
+		Failures:     int32(t.Failures), // safe
+		LastFailedAt: timestamppb.New(t.LastFailedAt),
+		BlockedUntil: timestamppb.New(t.BlockedUntil.Time),
+		Locked:       t.LockedAt.Valid,
+	}
+}
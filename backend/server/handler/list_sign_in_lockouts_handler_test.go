@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/volatiletech/null/v8"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/sean-ahn/user/backend/model"
+	"github.com/sean-ahn/user/backend/server/service"
+	userv1 "github.com/sean-ahn/user/proto/gen/go/user/v1"
+)
+
+func TestListSignInLockouts(t *testing.T) {
+	now := time.Date(2021, 10, 24, 7, 39, 46, 0, time.UTC)
+
+	cases := []struct {
+		name string
+		req  *userv1.ListSignInLockoutsRequest
+
+		signInThrottleExpectFunc func(context.Context) func(*service.MockSignInThrottle)
+
+		expectedCode codes.Code
+		expectedResp *userv1.ListSignInLockoutsResponse
+		expectedErr  string
+	}{
+		{
+			name: "success",
+			req:  &userv1.ListSignInLockoutsRequest{},
+			signInThrottleExpectFunc: func(ctx context.Context) func(*service.MockSignInThrottle) {
+				return func(mock *service.MockSignInThrottle) {
+					mock.EXPECT().
+						ListBlocked(ctx).
+						Return(model.SignInThrottleSlice{
+							{Scope: service.SignInThrottleScopeAccount, ScopeKey: "1", Failures: 10, LastFailedAt: now, BlockedUntil: null.TimeFrom(now.Add(time.Hour)), LockedAt: null.TimeFrom(now)},
+							{Scope: service.SignInThrottleScopeIPAddress, ScopeKey: "198.51.100.7", Failures: 12, LastFailedAt: now, BlockedUntil: null.TimeFrom(now.Add(4 * time.Second))},
+						}, nil)
+				}
+			},
+			expectedCode: codes.OK,
+			expectedResp: &userv1.ListSignInLockoutsResponse{
+				SignInLockouts: []*userv1.SignInLockout{
+					{
+						Scope:        "account",
+						Key:          "1",
+						Failures:     10,
+						LastFailedAt: timestamppb.New(now),
+						BlockedUntil: timestamppb.New(now.Add(time.Hour)),
+						Locked:       true,
+					},
+					{
+						Scope:        "ip_address",
+						Key:          "198.51.100.7",
+						Failures:     12,
+						LastFailedAt: timestamppb.New(now),
+						BlockedUntil: timestamppb.New(now.Add(4 * time.Second)),
+					},
+				},
+			},
+		},
+		{
+			name: "unexpected error",
+			req:  &userv1.ListSignInLockoutsRequest{},
+			signInThrottleExpectFunc: func(ctx context.Context) func(*service.MockSignInThrottle) {
+				return func(mock *service.MockSignInThrottle) {
+					mock.EXPECT().
+						ListBlocked(ctx).
+						Return(nil, errors.New("unexpected error"))
+				}
+			},
+			expectedCode: codes.Internal,
+			expectedErr:  "rpc error: code = Internal desc = unexpected error",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+
+			mockSignInThrottle := service.NewMockSignInThrottle(ctrl)
+			if tc.signInThrottleExpectFunc != nil {
+				tc.signInThrottleExpectFunc(ctx)(mockSignInThrottle)
+			}
+
+			handler := ListSignInLockouts(mockSignInThrottle)
+
+			resp, err := handler(ctx, tc.req)
+
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedCode, status.Code(err))
+			assert.Empty(t, cmp.Diff(resp, tc.expectedResp, protocmp.Transform()))
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/sean-ahn/user/backend/server/service"
+	userv1 "github.com/sean-ahn/user/proto/gen/go/user/v1"
+)
+
+type ListSigningKeysHandlerFunc func(ctx context.Context, req *userv1.ListSigningKeysRequest) (*userv1.ListSigningKeysResponse, error)
+
+func ListSigningKeys(signingKeyStore service.SigningKeyStore) ListSigningKeysHandlerFunc {
+	return func(ctx context.Context, req *userv1.ListSigningKeysRequest) (*userv1.ListSigningKeysResponse, error) {
+		keys, err := signingKeyStore.ListVerificationKeys(ctx)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+
+		resp := &userv1.ListSigningKeysResponse{SigningKeys: make([]*userv1.SigningKey, 0, len(keys))}
+		for _, k := range keys {
+			resp.SigningKeys = append(resp.SigningKeys, convertToSigningKey(k))
+		}
+		return resp, nil
+	}
+}
@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/sean-ahn/user/backend/server/service"
+	userv1 "github.com/sean-ahn/user/proto/gen/go/user/v1"
+)
+
+func TestListSigningKeys(t *testing.T) {
+	now := time.Date(2021, 10, 24, 7, 39, 46, 0, time.UTC)
+
+	cases := []struct {
+		name string
+		req  *userv1.ListSigningKeysRequest
+
+		signingKeyStoreExpectFunc func(context.Context) func(*service.MockSigningKeyStore)
+
+		expectedCode codes.Code
+		expectedResp *userv1.ListSigningKeysResponse
+		expectedErr  string
+	}{
+		{
+			name: "success",
+			req:  &userv1.ListSigningKeysRequest{},
+			signingKeyStoreExpectFunc: func(ctx context.Context) func(*service.MockSigningKeyStore) {
+				return func(mock *service.MockSigningKeyStore) {
+					mock.EXPECT().
+						ListVerificationKeys(ctx).
+						Return([]*service.SigningKey{
+							{ID: "kid2", Algorithm: service.SigningAlgorithmES256, ActivatesAt: now.Add(10 * time.Minute)},
+							{ID: "kid1", Algorithm: service.SigningAlgorithmES256, ActivatesAt: now.Add(-24 * time.Hour), RetiresAt: now.Add(14 * 24 * time.Hour)},
+						}, nil)
+				}
+			},
+			expectedCode: codes.OK,
+			expectedResp: &userv1.ListSigningKeysResponse{
+				SigningKeys: []*userv1.SigningKey{
+					{
+						Kid:         "kid2",
+						Algorithm:   service.SigningAlgorithmES256,
+						ActivatesAt: timestamppb.New(now.Add(10 * time.Minute)),
+					},
+					{
+						Kid:         "kid1",
+						Algorithm:   service.SigningAlgorithmES256,
+						ActivatesAt: timestamppb.New(now.Add(-24 * time.Hour)),
+						RetiresAt:   timestamppb.New(now.Add(14 * 24 * time.Hour)),
+					},
+				},
+			},
+		},
+		{
+			name: "unexpected error",
+			req:  &userv1.ListSigningKeysRequest{},
+			signingKeyStoreExpectFunc: func(ctx context.Context) func(*service.MockSigningKeyStore) {
+				return func(mock *service.MockSigningKeyStore) {
+					mock.EXPECT().
+						ListVerificationKeys(ctx).
+						Return(nil, errors.New("unexpected error"))
+				}
+			},
+			expectedCode: codes.Internal,
+			expectedErr:  "rpc error: code = Internal desc = unexpected error",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+
+			mockSigningKeyStore := service.NewMockSigningKeyStore(ctrl)
+			if tc.signingKeyStoreExpectFunc != nil {
+				tc.signingKeyStoreExpectFunc(ctx)(mockSigningKeyStore)
+			}
+
+			handler := ListSigningKeys(mockSigningKeyStore)
+
+			resp, err := handler(ctx, tc.req)
+
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedCode, status.Code(err))
+			assert.Empty(t, cmp.Diff(resp, tc.expectedResp, protocmp.Transform()))
+		})
+	}
+}
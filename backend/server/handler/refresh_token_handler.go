@@ -18,7 +18,7 @@ func RefreshToken(userTokenService service.UserTokenService) RefreshTokenHandler
 			return nil, status.Error(codes.InvalidArgument, "no refresh_token")
 		}
 
-		accessToken, refreshToken, err := userTokenService.Refresh(ctx, req.RefreshToken)
+		accessToken, refreshToken, err := userTokenService.Refresh(ctx, req.RefreshToken, extractClientInfo(ctx))
 		if err != nil {
 			return nil, status.Error(codes.Unauthenticated, err.Error())
 		}
@@ -33,7 +33,7 @@ func TestRefreshToken(t *testing.T) {
 			userTokenServiceExpectFunc: func(ctx context.Context) func(*service.MockUserTokenService) {
 				return func(mock *service.MockUserTokenService) {
 					mock.EXPECT().
-						Refresh(ctx, "old_refresh_token").
+						Refresh(ctx, "old_refresh_token", service.ClientInfo{}).
 						Return("new_access_token", "new_refresh_token", nil)
 				}
 			},
@@ -55,7 +55,7 @@ func TestRefreshToken(t *testing.T) {
 			userTokenServiceExpectFunc: func(ctx context.Context) func(*service.MockUserTokenService) {
 				return func(mock *service.MockUserTokenService) {
 					mock.EXPECT().
-						Refresh(ctx, "expired_refresh_token").
+						Refresh(ctx, "expired_refresh_token", service.ClientInfo{}).
 						Return("", "", errors.New("token expired"))
 				}
 			},
@@ -68,7 +68,7 @@ func TestRefreshToken(t *testing.T) {
 			userTokenServiceExpectFunc: func(ctx context.Context) func(*service.MockUserTokenService) {
 				return func(mock *service.MockUserTokenService) {
 					mock.EXPECT().
-						Refresh(ctx, "1234").
+						Refresh(ctx, "1234", service.ClientInfo{}).
 						Return("", "", errors.New("invalid token"))
 				}
 			},
@@ -5,17 +5,20 @@ import (
 	"database/sql"
 	"net/mail"
 	"regexp"
-	"unicode"
+	"time"
 
 	"github.com/jonboulle/clockwork"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 	"github.com/volatiletech/sqlboiler/v4/boil"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"github.com/sean-ahn/user/backend/crypto"
 	"github.com/sean-ahn/user/backend/model"
 	"github.com/sean-ahn/user/backend/persistence/mysql"
+	"github.com/sean-ahn/user/backend/server/service"
 	userv1 "github.com/sean-ahn/user/proto/gen/go/user/v1"
 )
 
@@ -25,7 +28,7 @@ var (
 
 type RegisterHandlerFunc func(ctx context.Context, req *userv1.RegisterRequest) (*userv1.RegisterResponse, error)
 
-func Register(clock clockwork.Clock, db *sql.DB, hasher crypto.Hasher) RegisterHandlerFunc {
+func Register(clock clockwork.Clock, db *sql.DB, hasher crypto.Hasher, passwordChecker *service.PasswordChecker, emailConfirmationService service.EmailConfirmationService, accountNoticeService service.AccountNoticeService, enumerationResistant bool) RegisterHandlerFunc {
 	return func(ctx context.Context, req *userv1.RegisterRequest) (*userv1.RegisterResponse, error) {
 		now := clock.Now()
 
@@ -41,27 +44,68 @@ func Register(clock clockwork.Clock, db *sql.DB, hasher crypto.Hasher) RegisterH
 			return nil, status.Error(codes.Internal, err.Error())
 		}
 
-		phoneNumber, err := normalizePhoneNumber(verification.PhoneNumber)
+		phoneNumber, err := normalizePhoneNumber(verification.PhoneNumber, "") // stored in E.164
 		if err != nil {
 			return nil, status.Error(codes.Internal, "invalid phone_number")
 		}
 
-		if !verification.VerificationValidUntil.Valid || verification.VerificationValidUntil.Time.Before(now) {
-			return nil, status.Error(codes.InvalidArgument, "invalid verification")
+		if !isUsableSMSOTPVerification(verification, smsOTPPurposeRegister, now) {
+			return nil, status.Error(codes.InvalidArgument, errInvalidVerification.Error())
 		}
 
-		if _, err := mysql.FindUserByPhoneNumber(ctx, db, phoneNumber); errors.Cause(err) != sql.ErrNoRows {
-			if err == nil {
+		if violations := passwordChecker.Check(req.Password, req.Name, req.Email, req.GetNickname(), phoneNumber); len(violations) > 0 {
+			return nil, invalidPasswordStatus("password", violations).Err()
+		}
+
+		// hashed before the lookups so that the response takes as long whether or not they are already used
+		passwordHash, err := hasher.Hash([]byte(req.Password))
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+
+		var existingUsers []*model.User
+
+		if u, err := mysql.FindUserByPhoneNumber(ctx, db, phoneNumber); errors.Cause(err) != sql.ErrNoRows {
+			if err != nil {
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+			if !enumerationResistant {
 				return nil, status.Error(codes.InvalidArgument, "already used phone number")
 			}
-			return nil, status.Error(codes.Internal, err.Error())
+			existingUsers = append(existingUsers, u)
 		}
 
-		if _, err := mysql.FindUserByEmail(ctx, db, req.Email); errors.Cause(err) != sql.ErrNoRows {
-			if err == nil {
+		if u, err := mysql.FindUserByEmail(ctx, db, req.Email); errors.Cause(err) != sql.ErrNoRows {
+			if err != nil {
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+			if !enumerationResistant {
 				return nil, status.Error(codes.InvalidArgument, "already used email")
 			}
-			return nil, status.Error(codes.Internal, err.Error())
+			if len(existingUsers) == 0 || existingUsers[0].UserID != u.UserID {
+				existingUsers = append(existingUsers, u)
+			}
+		}
+
+		// answered as if registered when enumerationResistant, and the users having them are noticed instead
+		if len(existingUsers) > 0 {
+			// consumed as if registered, or whether the registration has failed could be told by reusing it
+			if err := consumeSMSOTPVerification(ctx, db, verification, now); err != nil {
+				switch errors.Cause(err) {
+				case errInvalidVerification:
+					return nil, status.Error(codes.InvalidArgument, errors.Cause(err).Error())
+				default:
+					return nil, status.Error(codes.Internal, err.Error())
+				}
+			}
+
+			for _, u := range existingUsers {
+				if err := accountNoticeService.NoticeRegistrationAttempt(ctx, u); err != nil {
+					logrus.WithError(err).Warn("failed to notice registration attempt")
+				}
+			}
+
+			return &userv1.RegisterResponse{}, nil
 		}
 
 		nickname := req.Name
@@ -69,11 +113,6 @@ func Register(clock clockwork.Clock, db *sql.DB, hasher crypto.Hasher) RegisterH
 			nickname = *req.Nickname
 		}
 
-		passwordHash, err := hasher.Hash([]byte(req.Password))
-		if err != nil {
-			return nil, status.Error(codes.Internal, err.Error())
-		}
-
 		user := &model.User{
 			Name:             req.Name,
 			Email:            req.Email,
@@ -82,14 +121,49 @@ func Register(clock clockwork.Clock, db *sql.DB, hasher crypto.Hasher) RegisterH
 			Nickname:         nickname,
 			PasswordHash:     string(passwordHash),
 		}
-		if err := user.Insert(ctx, db, boil.Infer()); err != nil {
-			return nil, status.Error(codes.Internal, err.Error())
+		if err := registerUser(ctx, db, verification, user, now); err != nil {
+			switch errors.Cause(err) {
+			case errInvalidVerification:
+				return nil, status.Error(codes.InvalidArgument, errors.Cause(err).Error())
+			default:
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+		}
+
+		// the user can ask for another one with ResendConfirmationEmail
+		if err := emailConfirmationService.Send(ctx, user); err != nil {
+			logrus.WithError(err).Warn("failed to send confirmation email")
 		}
 
 		return &userv1.RegisterResponse{}, nil
 	}
 }
 
+func registerUser(ctx context.Context, db *sql.DB, verification *model.SMSOtpVerification, user *model.User, now time.Time) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			logrus.WithError(err).Error()
+		}
+	}()
+
+	if err := consumeSMSOTPVerification(ctx, tx, verification, now); err != nil {
+		return err
+	}
+
+	if err := user.Insert(ctx, tx, boil.Infer()); err != nil {
+		return errors.WithStack(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
 func validateRegisterRequest(req *userv1.RegisterRequest) error {
 	if req.VerificationToken == "" {
 		return errors.New("no verification_token")
@@ -106,9 +180,6 @@ func validateRegisterRequest(req *userv1.RegisterRequest) error {
 	if req.Password == "" {
 		return errors.New("no password")
 	}
-	if !isValidPassword(req.Password) {
-		return errors.New("invalid password")
-	}
 	if req.Nickname != nil && *req.Nickname == "" {
 		return errors.New("empty nickname")
 	}
@@ -125,39 +196,20 @@ func isValidEmail(email string) bool {
 	return true
 }
 
-func isValidPassword(pw string) bool {
-	const (
-		minLen, maxLen = 8, 20
-	)
-
-	rr := []rune(pw)
-	if len(rr) < minLen || len(rr) > maxLen {
-		return false
+// invalidPasswordStatus tells the client every password rule violated with BadRequest, see
+// https://cloud.google.com/apis/design/errors#error_payloads
+func invalidPasswordStatus(field string, violations []string) *status.Status {
+	badRequest := &errdetails.BadRequest{}
+	for _, v := range violations {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       field,
+			Description: v,
+		})
 	}
 
-	var hasAlpha, hasDigit, hasAllowedSpecial bool
-	for _, r := range rr {
-		switch {
-		case isAlpha(r):
-			hasAlpha = true
-		case isAllowedSpecial(r):
-			hasAllowedSpecial = true
-		case unicode.IsDigit(r):
-			hasDigit = true
-		default:
-			return false
-		}
+	st := status.New(codes.InvalidArgument, "invalid password")
+	if withDetails, detailErr := st.WithDetails(badRequest); detailErr == nil {
+		st = withDetails
 	}
-
-	return hasAlpha && hasDigit && hasAllowedSpecial
-}
-
-func isAlpha(c rune) bool {
-	return ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
-}
-
-// isAllowedSpecial returns whether c is valid special character for password.
-// See https://en.wikipedia.org/wiki/List_of_Special_Characters_for_Passwords
-func isAllowedSpecial(c rune) bool {
-	return unicode.IsSymbol(c) || unicode.IsPunct(c)
+	return st
 }
@@ -8,20 +8,31 @@ import (
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
 	"github.com/jonboulle/clockwork"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/volatiletech/null/v8"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/testing/protocmp"
 
 	"github.com/sean-ahn/user/backend/model"
+	"github.com/sean-ahn/user/backend/server/service"
 	"github.com/sean-ahn/user/backend/test"
 	userv1 "github.com/sean-ahn/user/proto/gen/go/user/v1"
 )
 
+var testPasswordChecker, _ = service.NewPasswordChecker(service.PasswordPolicy{
+	MinLength:          8,
+	MaxLength:          64,
+	MinEntropyBits:     40,
+	RejectPersonalInfo: true,
+})
+
 func TestRegister(t *testing.T) {
 	now := time.Date(2021, 10, 25, 9, 40, 47, 42395845, time.UTC)
 
@@ -29,7 +40,10 @@ func TestRegister(t *testing.T) {
 		name string
 		req  *userv1.RegisterRequest
 
-		dbExpectFunc func(sqlmock.Sqlmock)
+		dbExpectFunc                       func(sqlmock.Sqlmock)
+		emailConfirmationServiceExpectFunc func(context.Context) func(*service.MockEmailConfirmationService)
+		accountNoticeServiceExpectFunc     func(context.Context) func(*service.MockAccountNoticeService)
+		enumerationResistant               bool
 
 		expectedCode codes.Code
 		expectedResp *userv1.RegisterResponse
@@ -50,7 +64,7 @@ func TestRegister(t *testing.T) {
 				)).WithArgs(
 					"verification_token",
 				).WillReturnRows(test.NewSMSOtpVerificationRows([]*model.SMSOtpVerification{
-					{PhoneNumber: "+821012345678", VerificationValidUntil: null.TimeFrom(now.Add(defaultSMSOTPValidity))},
+					{SMSOtpVerificationID: 1, PhoneNumber: "+821012345678", Purpose: "register", VerificationValidUntil: null.TimeFrom(now.Add(defaultSMSOTPValidity))},
 				}))
 
 				mock.ExpectQuery(regexp.QuoteMeta(
@@ -69,6 +83,14 @@ func TestRegister(t *testing.T) {
 					sql.ErrNoRows,
 				)
 
+				mock.ExpectBegin()
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"UPDATE `sms_otp_verification` SET `consumed_at` = ? WHERE (`sms_otp_verification`.`sms_otp_verification_id` = ?) AND (`sms_otp_verification`.`consumed_at` is null);",
+				)).WithArgs(
+					now, 1,
+				).WillReturnResult(sqlmock.NewResult(0, 1))
+
 				mock.ExpectExec(regexp.QuoteMeta(
 					"INSERT INTO `user` (`name`,`email`,`is_email_confirmed`,`phone_number`,`nickname`,`password_hash`) VALUES (?,?,?,?,?,?)",
 				)).WithArgs(
@@ -84,6 +106,85 @@ func TestRegister(t *testing.T) {
 				).WillReturnRows(sqlmock.NewRows([]string{"user_id", "created_at", "updated_at"}).AddRow(
 					2, now, now,
 				))
+
+				mock.ExpectCommit()
+			},
+			emailConfirmationServiceExpectFunc: func(ctx context.Context) func(*service.MockEmailConfirmationService) {
+				return func(mock *service.MockEmailConfirmationService) {
+					mock.EXPECT().
+						Send(ctx, gomock.Any()).
+						Return(nil)
+				}
+			},
+			expectedCode: codes.OK,
+			expectedResp: &userv1.RegisterResponse{},
+		},
+		{
+			name: "success even if confirmation email not sent",
+			req: &userv1.RegisterRequest{
+				VerificationToken: "verification_token",
+				Name:              "name",
+				Email:             "john.doe@example.com",
+				Password:          "P@ssw0rd",
+				Nickname:          proto.String("nickname"),
+			},
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `sms_otp_verification` WHERE (`sms_otp_verification`.`verification_token` = ?) LIMIT 1;",
+				)).WithArgs(
+					"verification_token",
+				).WillReturnRows(test.NewSMSOtpVerificationRows([]*model.SMSOtpVerification{
+					{SMSOtpVerificationID: 1, PhoneNumber: "+821012345678", Purpose: "register", VerificationValidUntil: null.TimeFrom(now.Add(defaultSMSOTPValidity))},
+				}))
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user` WHERE (`user`.`phone_number` = ?) LIMIT 1;",
+				)).WithArgs(
+					"+821012345678",
+				).WillReturnError(
+					sql.ErrNoRows,
+				)
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user` WHERE (`user`.`email` = ?) LIMIT 1;",
+				)).WithArgs(
+					"john.doe@example.com",
+				).WillReturnError(
+					sql.ErrNoRows,
+				)
+
+				mock.ExpectBegin()
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"UPDATE `sms_otp_verification` SET `consumed_at` = ? WHERE (`sms_otp_verification`.`sms_otp_verification_id` = ?) AND (`sms_otp_verification`.`consumed_at` is null);",
+				)).WithArgs(
+					now, 1,
+				).WillReturnResult(sqlmock.NewResult(0, 1))
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"INSERT INTO `user` (`name`,`email`,`is_email_confirmed`,`phone_number`,`nickname`,`password_hash`) VALUES (?,?,?,?,?,?)",
+				)).WithArgs(
+					"name", "john.doe@example.com", false, "+821012345678", "nickname", "P@ssw0rd_hash",
+				).WillReturnResult(
+					sqlmock.NewResult(2, 1),
+				)
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT `user_id`,`created_at`,`updated_at` FROM `user` WHERE `user_id`=?",
+				)).WithArgs(
+					2,
+				).WillReturnRows(sqlmock.NewRows([]string{"user_id", "created_at", "updated_at"}).AddRow(
+					2, now, now,
+				))
+
+				mock.ExpectCommit()
+			},
+			emailConfirmationServiceExpectFunc: func(ctx context.Context) func(*service.MockEmailConfirmationService) {
+				return func(mock *service.MockEmailConfirmationService) {
+					mock.EXPECT().
+						Send(ctx, gomock.Any()).
+						Return(errors.New("unexpected error"))
+				}
 			},
 			expectedCode: codes.OK,
 			expectedResp: &userv1.RegisterResponse{},
@@ -103,7 +204,7 @@ func TestRegister(t *testing.T) {
 				)).WithArgs(
 					"verification_token",
 				).WillReturnRows(test.NewSMSOtpVerificationRows([]*model.SMSOtpVerification{
-					{PhoneNumber: "+821012345678", VerificationValidUntil: null.TimeFrom(now.Add(defaultSMSOTPValidity))},
+					{SMSOtpVerificationID: 1, PhoneNumber: "+821012345678", Purpose: "register", VerificationValidUntil: null.TimeFrom(now.Add(defaultSMSOTPValidity))},
 				}))
 
 				mock.ExpectQuery(regexp.QuoteMeta(
@@ -125,6 +226,104 @@ func TestRegister(t *testing.T) {
 			expectedCode: codes.InvalidArgument,
 			expectedErr:  "rpc error: code = InvalidArgument desc = already used email",
 		},
+		{
+			name: "already used phone number noticed when enumeration resistant",
+			req: &userv1.RegisterRequest{
+				VerificationToken: "verification_token",
+				Name:              "name",
+				Email:             "john.doe@example.com",
+				Password:          "P@ssw0rd",
+				Nickname:          proto.String("nickname"),
+			},
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `sms_otp_verification` WHERE (`sms_otp_verification`.`verification_token` = ?) LIMIT 1;",
+				)).WithArgs(
+					"verification_token",
+				).WillReturnRows(test.NewSMSOtpVerificationRows([]*model.SMSOtpVerification{
+					{SMSOtpVerificationID: 1, PhoneNumber: "+821012345678", Purpose: "register", VerificationValidUntil: null.TimeFrom(now.Add(defaultSMSOTPValidity))},
+				}))
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user` WHERE (`user`.`phone_number` = ?) LIMIT 1;",
+				)).WithArgs(
+					"+821012345678",
+				).WillReturnRows(test.NewUserRows([]*model.User{
+					{UserID: 2, Email: "john.doe@example.com", PhoneNumber: "+821012345678"},
+				}))
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user` WHERE (`user`.`email` = ?) LIMIT 1;",
+				)).WithArgs(
+					"john.doe@example.com",
+				).WillReturnRows(test.NewUserRows([]*model.User{
+					{UserID: 2, Email: "john.doe@example.com", PhoneNumber: "+821012345678"},
+				}))
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"UPDATE `sms_otp_verification` SET `consumed_at` = ? WHERE (`sms_otp_verification`.`sms_otp_verification_id` = ?) AND (`sms_otp_verification`.`consumed_at` is null);",
+				)).WithArgs(
+					now, 1,
+				).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			accountNoticeServiceExpectFunc: func(ctx context.Context) func(*service.MockAccountNoticeService) {
+				return func(mock *service.MockAccountNoticeService) {
+					mock.EXPECT().NoticeRegistrationAttempt(ctx, &userMatcher{id: 2}).Return(nil).Times(1)
+				}
+			},
+			enumerationResistant: true,
+			expectedCode:         codes.OK,
+			expectedResp:         &userv1.RegisterResponse{},
+		},
+		{
+			name: "already used email noticed when enumeration resistant",
+			req: &userv1.RegisterRequest{
+				VerificationToken: "verification_token",
+				Name:              "name",
+				Email:             "john.doe@example.com",
+				Password:          "P@ssw0rd",
+				Nickname:          proto.String("nickname"),
+			},
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `sms_otp_verification` WHERE (`sms_otp_verification`.`verification_token` = ?) LIMIT 1;",
+				)).WithArgs(
+					"verification_token",
+				).WillReturnRows(test.NewSMSOtpVerificationRows([]*model.SMSOtpVerification{
+					{SMSOtpVerificationID: 1, PhoneNumber: "+821012345678", Purpose: "register", VerificationValidUntil: null.TimeFrom(now.Add(defaultSMSOTPValidity))},
+				}))
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user` WHERE (`user`.`phone_number` = ?) LIMIT 1;",
+				)).WithArgs(
+					"+821012345678",
+				).WillReturnError(
+					sql.ErrNoRows,
+				)
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user` WHERE (`user`.`email` = ?) LIMIT 1;",
+				)).WithArgs(
+					"john.doe@example.com",
+				).WillReturnRows(test.NewUserRows([]*model.User{
+					{UserID: 3, Email: "john.doe@example.com"},
+				}))
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"UPDATE `sms_otp_verification` SET `consumed_at` = ? WHERE (`sms_otp_verification`.`sms_otp_verification_id` = ?) AND (`sms_otp_verification`.`consumed_at` is null);",
+				)).WithArgs(
+					now, 1,
+				).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			accountNoticeServiceExpectFunc: func(ctx context.Context) func(*service.MockAccountNoticeService) {
+				return func(mock *service.MockAccountNoticeService) {
+					mock.EXPECT().NoticeRegistrationAttempt(ctx, &userMatcher{id: 3}).Return(errors.New("unexpected error"))
+				}
+			},
+			enumerationResistant: true,
+			expectedCode:         codes.OK,
+			expectedResp:         &userv1.RegisterResponse{},
+		},
 		{
 			name: "verification not found",
 			req: &userv1.RegisterRequest{
@@ -161,12 +360,122 @@ func TestRegister(t *testing.T) {
 				)).WithArgs(
 					"verification_token",
 				).WillReturnRows(test.NewSMSOtpVerificationRows([]*model.SMSOtpVerification{
-					{PhoneNumber: "+821012345678", VerificationValidUntil: null.TimeFrom(now.Add(-1 * time.Minute))},
+					{SMSOtpVerificationID: 1, PhoneNumber: "+821012345678", Purpose: "register", VerificationValidUntil: null.TimeFrom(now.Add(-1 * time.Minute))},
+				}))
+			},
+			expectedCode: codes.InvalidArgument,
+			expectedErr:  "rpc error: code = InvalidArgument desc = invalid verification",
+		},
+		{
+			name: "verification for other purpose",
+			req: &userv1.RegisterRequest{
+				VerificationToken: "verification_token",
+				Name:              "name",
+				Email:             "john.doe@example.com",
+				Password:          "P@ssw0rd",
+				Nickname:          proto.String("nickname"),
+			},
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `sms_otp_verification` WHERE (`sms_otp_verification`.`verification_token` = ?) LIMIT 1;",
+				)).WithArgs(
+					"verification_token",
+				).WillReturnRows(test.NewSMSOtpVerificationRows([]*model.SMSOtpVerification{
+					{SMSOtpVerificationID: 1, PhoneNumber: "+821012345678", Purpose: "reset_password", VerificationValidUntil: null.TimeFrom(now.Add(defaultSMSOTPValidity))},
+				}))
+			},
+			expectedCode: codes.InvalidArgument,
+			expectedErr:  "rpc error: code = InvalidArgument desc = invalid verification",
+		},
+		{
+			name: "verification already consumed",
+			req: &userv1.RegisterRequest{
+				VerificationToken: "verification_token",
+				Name:              "name",
+				Email:             "john.doe@example.com",
+				Password:          "P@ssw0rd",
+				Nickname:          proto.String("nickname"),
+			},
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `sms_otp_verification` WHERE (`sms_otp_verification`.`verification_token` = ?) LIMIT 1;",
+				)).WithArgs(
+					"verification_token",
+				).WillReturnRows(test.NewSMSOtpVerificationRows([]*model.SMSOtpVerification{
+					{SMSOtpVerificationID: 1, PhoneNumber: "+821012345678", Purpose: "register", VerificationValidUntil: null.TimeFrom(now.Add(defaultSMSOTPValidity)), ConsumedAt: null.TimeFrom(now.Add(-1 * time.Minute))},
+				}))
+			},
+			expectedCode: codes.InvalidArgument,
+			expectedErr:  "rpc error: code = InvalidArgument desc = invalid verification",
+		},
+		{
+			name: "verification consumed concurrently",
+			req: &userv1.RegisterRequest{
+				VerificationToken: "verification_token",
+				Name:              "name",
+				Email:             "john.doe@example.com",
+				Password:          "P@ssw0rd",
+				Nickname:          proto.String("nickname"),
+			},
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `sms_otp_verification` WHERE (`sms_otp_verification`.`verification_token` = ?) LIMIT 1;",
+				)).WithArgs(
+					"verification_token",
+				).WillReturnRows(test.NewSMSOtpVerificationRows([]*model.SMSOtpVerification{
+					{SMSOtpVerificationID: 1, PhoneNumber: "+821012345678", Purpose: "register", VerificationValidUntil: null.TimeFrom(now.Add(defaultSMSOTPValidity))},
 				}))
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user` WHERE (`user`.`phone_number` = ?) LIMIT 1;",
+				)).WithArgs(
+					"+821012345678",
+				).WillReturnError(
+					sql.ErrNoRows,
+				)
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user` WHERE (`user`.`email` = ?) LIMIT 1;",
+				)).WithArgs(
+					"john.doe@example.com",
+				).WillReturnError(
+					sql.ErrNoRows,
+				)
+
+				mock.ExpectBegin()
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"UPDATE `sms_otp_verification` SET `consumed_at` = ? WHERE (`sms_otp_verification`.`sms_otp_verification_id` = ?) AND (`sms_otp_verification`.`consumed_at` is null);",
+				)).WithArgs(
+					now, 1,
+				).WillReturnResult(sqlmock.NewResult(0, 0))
+
+				mock.ExpectRollback()
 			},
 			expectedCode: codes.InvalidArgument,
 			expectedErr:  "rpc error: code = InvalidArgument desc = invalid verification",
 		},
+		{
+			name: "password containing phone number",
+			req: &userv1.RegisterRequest{
+				VerificationToken: "verification_token",
+				Name:              "name",
+				Email:             "john.doe@example.com",
+				Password:          "P@ss12345678",
+				Nickname:          proto.String("nickname"),
+			},
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `sms_otp_verification` WHERE (`sms_otp_verification`.`verification_token` = ?) LIMIT 1;",
+				)).WithArgs(
+					"verification_token",
+				).WillReturnRows(test.NewSMSOtpVerificationRows([]*model.SMSOtpVerification{
+					{SMSOtpVerificationID: 1, PhoneNumber: "+821012345678", Purpose: "register", VerificationValidUntil: null.TimeFrom(now.Add(defaultSMSOTPValidity))},
+				}))
+			},
+			expectedCode: codes.InvalidArgument,
+			expectedErr:  "rpc error: code = InvalidArgument desc = invalid password",
+		},
 	}
 
 	for _, tc := range cases {
@@ -186,7 +495,19 @@ func TestRegister(t *testing.T) {
 			}
 			defer test.CloseSqlmock(t, db, mock)
 
-			handler := Register(clock, db, &testHasher{})
+			ctrl := gomock.NewController(t)
+
+			mockEmailConfirmationService := service.NewMockEmailConfirmationService(ctrl)
+			if tc.emailConfirmationServiceExpectFunc != nil {
+				tc.emailConfirmationServiceExpectFunc(ctx)(mockEmailConfirmationService)
+			}
+
+			mockAccountNoticeService := service.NewMockAccountNoticeService(ctrl)
+			if tc.accountNoticeServiceExpectFunc != nil {
+				tc.accountNoticeServiceExpectFunc(ctx)(mockAccountNoticeService)
+			}
+
+			handler := Register(clock, db, &testHasher{}, testPasswordChecker, mockEmailConfirmationService, mockAccountNoticeService, tc.enumerationResistant)
 
 			resp, err := handler(ctx, tc.req)
 
@@ -201,24 +522,17 @@ func TestRegister(t *testing.T) {
 	}
 }
 
-func Test_isValidPassword(t *testing.T) {
-	cases := []struct {
-		given    string
-		expected bool
-	}{
-		{given: "p@assw0rd", expected: true},
-		{given: "P@assw0rd", expected: true},
-		{given: "p@assw rd", expected: false},
-		{given: "qwerty123", expected: false},
-		{given: "비밀번호486!", expected: false},
-	}
+func Test_invalidPasswordStatus(t *testing.T) {
+	st := invalidPasswordStatus("password", []string{"must be at least 8 characters", "must contain a digit"})
 
-	for _, tc := range cases {
-		tc := tc
-		t.Run(tc.given, func(t *testing.T) {
-			got := isValidPassword(tc.given)
-
-			assert.Equal(t, tc.expected, got)
-		})
-	}
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	assert.Equal(t, "invalid password", st.Message())
+	assert.Empty(t, cmp.Diff(st.Details(), []interface{}{
+		&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: "password", Description: "must be at least 8 characters"},
+				{Field: "password", Description: "must contain a digit"},
+			},
+		},
+	}, protocmp.Transform()))
 }
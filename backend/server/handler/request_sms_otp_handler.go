@@ -3,19 +3,25 @@ package handler
 import (
 	"context"
 	"database/sql"
-	"fmt"
+	"strings"
 	"time"
 
 	"github.com/jonboulle/clockwork"
 	"github.com/nyaruka/phonenumbers"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 
+	"github.com/sean-ahn/user/backend/crypto"
 	"github.com/sean-ahn/user/backend/model"
 	"github.com/sean-ahn/user/backend/server/generator"
-	smsv1 "github.com/sean-ahn/user/proto/gen/go/sms/v1"
+	"github.com/sean-ahn/user/backend/server/message"
+	"github.com/sean-ahn/user/backend/server/service"
 	userv1 "github.com/sean-ahn/user/proto/gen/go/user/v1"
 )
 
@@ -23,31 +29,82 @@ const (
 	defaultSMSOTPExpiration = 3 * time.Minute
 )
 
+var (
+	errInvalidPhoneNumber = errors.New("invalid phone number")
+	errInvalidRegionCode  = errors.New("invalid region code")
+)
+
+// purposes of SMS OTP verifications, each of which is accepted only by the RPC of it
+const (
+	smsOTPPurposeRegister      = "register"
+	smsOTPPurposeResetPassword = "reset_password"
+	smsOTPPurposeChangePhone   = "change_phone"
+	smsOTPPurposeSignIn        = "sign_in"
+	smsOTPPurposeUnlockAccount = "unlock_account"
+)
+
 type RequestSmsOtpHandlerFunc func(ctx context.Context, req *userv1.RequestSmsOtpRequest) (*userv1.RequestSmsOtpResponse, error)
 
-func RequestSmsOtp(clock clockwork.Clock, db *sql.DB, idGenerator, otpGenerator generator.Generator, smsv1ServiceCli smsv1.SmsServiceClient) RequestSmsOtpHandlerFunc {
+func RequestSmsOtp(clock clockwork.Clock, db *sql.DB, idGenerator, otpGenerator generator.Generator, otpHasher *crypto.OTPHasher, limiter service.SMSOTPLimiter, smsTemplates *message.Registry, otpAutofill message.OTPAutofill) RequestSmsOtpHandlerFunc {
 	return func(ctx context.Context, req *userv1.RequestSmsOtpRequest) (*userv1.RequestSmsOtpResponse, error) {
 		if req.PhoneNumber == "" {
 			return nil, status.Error(codes.InvalidArgument, "no phone_number")
 		}
 
-		normalizedPhoneNumber, err := normalizePhoneNumber(req.PhoneNumber)
-		if err != nil || normalizedPhoneNumber != req.PhoneNumber {
+		// without region_code, only E.164 is accepted so that the number is not guessed to be of the default region
+		region, err := resolvePhoneRegion(req.RegionCode, "")
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid region_code")
+		}
+
+		normalizedPhoneNumber, err := normalizePhoneNumber(req.PhoneNumber, region)
+		if err != nil || (region == "" && normalizedPhoneNumber != req.PhoneNumber) {
 			return nil, status.Error(codes.InvalidArgument, "invalid phone_number")
 		}
+		if req.Purpose == "" {
+			return nil, status.Error(codes.InvalidArgument, "no purpose")
+		}
+		if !isValidSMSOTPPurpose(req.Purpose) {
+			return nil, status.Error(codes.InvalidArgument, "invalid purpose")
+		}
 
-		verification := model.SMSOtpVerification{
-			VerificationToken: idGenerator.Generate(),
-			PhoneNumber:       normalizedPhoneNumber,
-			OtpCode:           otpGenerator.Generate(),
-			ExpiresAt:         clock.Now().Add(defaultSMSOTPExpiration),
+		client := extractClientInfo(ctx)
+
+		locale := req.Locale
+		if locale == "" {
+			locale = extractAcceptLanguage(ctx)
 		}
-		if err := verification.Insert(ctx, db, boil.Infer()); err != nil {
+
+		token, code := idGenerator.Generate(), otpGenerator.Generate()
+
+		text, err := smsTemplates.Render(message.KindSMSOTP, locale, message.SMSOTP{Code: code})
+		if err != nil {
 			return nil, status.Error(codes.Internal, err.Error())
 		}
 
-		if err := sendOTPVerificationSMS(ctx, smsv1ServiceCli, normalizedPhoneNumber, verification.OtpCode); err != nil {
-			return nil, status.Error(codes.Internal, err.Error())
+		now := clock.Now()
+		verification := model.SMSOtpVerification{
+			VerificationToken: token,
+			PhoneNumber:       normalizedPhoneNumber,
+			IPAddress:         client.IPAddress,
+			Purpose:           req.Purpose,
+			OtpCodeHash:       null.StringFrom(otpHasher.Hash(token, code)),
+			ExpiresAt:         now.Add(defaultSMSOTPExpiration),
+			CreatedAt:         now, // compared with the clock by the limiter
+		}
+		// inserted while the limiter holds the limits of the phone number and the IP address
+		if err := limiter.Allow(ctx, normalizedPhoneNumber, client.IPAddress, func(ctx context.Context) error {
+			return createSMSOTPVerification(ctx, db, &verification, otpAutofill.Append(text, code), now)
+		}); err != nil {
+			if rateLimitedErr, ok := errors.Cause(err).(*service.RateLimitedError); ok {
+				return nil, rateLimitedStatus(rateLimitedErr).Err()
+			}
+			switch errors.Cause(err) {
+			case service.ErrSMSOTPRegionNotAllowed:
+				return nil, status.Error(codes.InvalidArgument, errors.Cause(err).Error())
+			default:
+				return nil, status.Error(codes.Internal, err.Error())
+			}
 		}
 
 		return &userv1.RequestSmsOtpResponse{
@@ -57,21 +114,77 @@ func RequestSmsOtp(clock clockwork.Clock, db *sql.DB, idGenerator, otpGenerator
 	}
 }
 
-func normalizePhoneNumber(s string) (string, error) {
-	p, err := phonenumbers.Parse(s, "KR")
+func isValidSMSOTPPurpose(purpose string) bool {
+	switch purpose {
+	case smsOTPPurposeRegister, smsOTPPurposeResetPassword, smsOTPPurposeChangePhone, smsOTPPurposeSignIn, smsOTPPurposeUnlockAccount:
+		return true
+	default:
+		return false
+	}
+}
+
+// rateLimitedStatus tells the client when to retry with RetryInfo, see
+// https://cloud.google.com/apis/design/errors#error_payloads
+func rateLimitedStatus(err *service.RateLimitedError) *status.Status {
+	st := status.New(codes.ResourceExhausted, err.Error())
+	if withDetails, detailErr := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(err.RetryAfter)}); detailErr == nil {
+		st = withDetails
+	}
+	return st
+}
+
+// normalizePhoneNumber formats the phone number in E.164. The region is used only if the number is given without the
+// country calling code, and can be empty if it is known to be in E.164.
+func normalizePhoneNumber(s, region string) (string, error) {
+	p, err := phonenumbers.Parse(s, region)
 	if err != nil {
 		return "", errors.WithStack(err)
 	}
+	if !phonenumbers.IsValidNumber(p) {
+		return "", errors.WithStack(errInvalidPhoneNumber)
+	}
 	return phonenumbers.Format(p, phonenumbers.E164), nil
 }
 
-func sendOTPVerificationSMS(ctx context.Context, cli smsv1.SmsServiceClient, phoneNumber, code string) error {
-	_, err := cli.Send(ctx, &smsv1.SendRequest{
-		To:      phoneNumber,
-		Message: fmt.Sprintf("%s is your authentication code.", code),
-	})
+// resolvePhoneRegion returns the region given in the request if any, or the default one.
+func resolvePhoneRegion(regionCode, defaultRegion string) (string, error) {
+	if regionCode == "" {
+		return defaultRegion, nil
+	}
+	region := strings.ToUpper(regionCode)
+	if phonenumbers.GetCountryCodeForRegion(region) == 0 {
+		return "", errors.WithStack(errInvalidRegionCode)
+	}
+	return region, nil
+}
+
+// createSMSOTPVerification inserts the verification together with the SMS of it, which is sent in the background.
+func createSMSOTPVerification(ctx context.Context, db *sql.DB, verification *model.SMSOtpVerification, text string, now time.Time) error {
+	tx, err := db.BeginTx(ctx, nil)
 	if err != nil {
 		return errors.WithStack(err)
 	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			logrus.WithError(err).Error()
+		}
+	}()
+
+	if err := verification.Insert(ctx, tx, boil.Infer()); err != nil {
+		return errors.WithStack(err)
+	}
+
+	if _, err := service.EnqueueSMS(ctx, tx, service.OutboxSMS{
+		PhoneNumber:          verification.PhoneNumber,
+		Message:              text,
+		SMSOTPVerificationID: null.IntFrom(verification.SMSOtpVerificationID),
+		ExpiresAt:            verification.ExpiresAt,
+	}, now); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.WithStack(err)
+	}
 	return nil
 }
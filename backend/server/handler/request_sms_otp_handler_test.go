@@ -12,16 +12,25 @@ import (
 	"github.com/jonboulle/clockwork"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/durationpb"
 
-	"github.com/sean-ahn/user/backend/client"
+	"github.com/sean-ahn/user/backend/crypto"
+	"github.com/sean-ahn/user/backend/server/message"
+	"github.com/sean-ahn/user/backend/server/service"
 	"github.com/sean-ahn/user/backend/test"
-	smsv1 "github.com/sean-ahn/user/proto/gen/go/sms/v1"
 	userv1 "github.com/sean-ahn/user/proto/gen/go/user/v1"
 )
 
+var (
+	testSMSOTPHasher, _ = crypto.NewOTPHasher([]byte("sms_otp_hmac_secret_of_32_bytes!"))
+	testSMSTemplates, _ = message.LoadRegistry(message.DefaultTemplates(), "en")
+)
+
 type testGenerator struct {
 	mock string
 }
@@ -30,6 +39,11 @@ func (g *testGenerator) Generate() string {
 	return g.mock
 }
 
+// allowSMSOTP stubs SMSOTPLimiter.Allow allowing the request.
+func allowSMSOTP(ctx context.Context, _, _ string, send func(context.Context) error) error {
+	return send(ctx)
+}
+
 func TestRequestSmsOtp(t *testing.T) {
 	now := time.Date(2021, 10, 24, 17, 18, 16, 304850171, time.UTC)
 
@@ -37,45 +51,118 @@ func TestRequestSmsOtp(t *testing.T) {
 		name string
 		req  *userv1.RequestSmsOtpRequest
 
-		dbExpectFunc                   func(sqlmock.Sqlmock)
-		mockSmsServiceClientExpectFunc func(context.Context) func(*client.MockSmsServiceClient)
+		acceptLanguage string
+		otpAutofill    message.OTPAutofill
+
+		smsOTPLimiterExpectFunc func(context.Context) func(*service.MockSMSOTPLimiter)
+		dbExpectFunc            func(sqlmock.Sqlmock)
 
-		expectedCode codes.Code
-		expectedResp *userv1.RequestSmsOtpResponse
-		expectedErr  string
+		expectedCode    codes.Code
+		expectedResp    *userv1.RequestSmsOtpResponse
+		expectedErr     string
+		expectedDetails []interface{}
 	}{
 		{
 			name: "success",
-			req:  &userv1.RequestSmsOtpRequest{PhoneNumber: "+821012345678"},
+			req:  &userv1.RequestSmsOtpRequest{PhoneNumber: "+821012345678", Purpose: "register"},
+			smsOTPLimiterExpectFunc: func(ctx context.Context) func(*service.MockSMSOTPLimiter) {
+				return func(mock *service.MockSMSOTPLimiter) {
+					mock.EXPECT().
+						Allow(ctx, "+821012345678", "198.51.100.7", gomock.Any()).
+						DoAndReturn(allowSMSOTP)
+				}
+			},
 			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+
 				mock.ExpectExec(regexp.QuoteMeta(
-					"INSERT INTO `sms_otp_verification` (`verification_token`,`phone_number`,`otp_code`,`expires_at`,`verification_trials`,`verification_valid_until`) VALUES (?,?,?,?,?,?)",
+					"INSERT INTO `sms_otp_verification` (`verification_token`,`phone_number`,`ip_address`,`purpose`,`otp_code`,`otp_code_hash`,`expires_at`,`verification_trials`,`verification_valid_until`,`consumed_at`,`created_at`) VALUES (?,?,?,?,?,?,?,?,?,?,?)",
 				)).WithArgs(
-					"verification_token", "+821012345678", "123456", now.Add(defaultSMSOTPExpiration), 0, nil,
+					"verification_token", "+821012345678", "198.51.100.7", "register", nil, testSMSOTPHasher.Hash("verification_token", "123456"), now.Add(defaultSMSOTPExpiration), 0, nil, nil, now,
 				).WillReturnResult(
 					sqlmock.NewResult(1, 1),
 				)
 
 				mock.ExpectQuery(regexp.QuoteMeta(
-					"SELECT `sms_otp_verification_id`,`created_at`,`updated_at` FROM `sms_otp_verification` WHERE `sms_otp_verification_id`=?",
+					"SELECT `sms_otp_verification_id`,`updated_at` FROM `sms_otp_verification` WHERE `sms_otp_verification_id`=?",
 				)).WithArgs(
 					1,
-				).WillReturnRows(sqlmock.NewRows([]string{"sms_otp_verification_id", "created_at", "updated_at"}).AddRow(
+				).WillReturnRows(sqlmock.NewRows([]string{"sms_otp_verification_id", "updated_at"}).AddRow(
+					1, now,
+				))
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"INSERT INTO `sms_outbox` (`sms_otp_verification_id`,`phone_number`,`message`,`status`,`attempts`,`next_attempt_at`,`expires_at`,`sent_at`,`last_error`) VALUES (?,?,?,?,?,?,?,?,?)",
+				)).WithArgs(
+					1, "+821012345678", "123456 is your authentication code.", "pending", 0, now, now.Add(defaultSMSOTPExpiration), nil, nil,
+				).WillReturnResult(
+					sqlmock.NewResult(1, 1),
+				)
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT `sms_outbox_id`,`created_at`,`updated_at` FROM `sms_outbox` WHERE `sms_outbox_id`=?",
+				)).WithArgs(
+					1,
+				).WillReturnRows(sqlmock.NewRows([]string{"sms_outbox_id", "created_at", "updated_at"}).AddRow(
 					1, now, now,
 				))
+
+				mock.ExpectCommit()
 			},
-			mockSmsServiceClientExpectFunc: func(ctx context.Context) func(*client.MockSmsServiceClient) {
-				return func(mock *client.MockSmsServiceClient) {
+			expectedCode: codes.OK,
+			expectedResp: &userv1.RequestSmsOtpResponse{
+				VerificationToken: "verification_token",
+				ExpiresInMs:       180000,
+			},
+		},
+		{
+			name:           "success in locale of accept-language",
+			req:            &userv1.RequestSmsOtpRequest{PhoneNumber: "+821012345678", Purpose: "register"},
+			acceptLanguage: "ja,en;q=0.8",
+			smsOTPLimiterExpectFunc: func(ctx context.Context) func(*service.MockSMSOTPLimiter) {
+				return func(mock *service.MockSMSOTPLimiter) {
 					mock.EXPECT().
-						Send(ctx, &smsv1.SendRequest{
-							To:      "+821012345678",
-							Message: "123456 is your authentication code.",
-						}).
-						Return(
-							&smsv1.SendResponse{}, nil,
-						)
+						Allow(ctx, "+821012345678", "198.51.100.7", gomock.Any()).
+						DoAndReturn(allowSMSOTP)
 				}
 			},
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"INSERT INTO `sms_otp_verification` (`verification_token`,`phone_number`,`ip_address`,`purpose`,`otp_code`,`otp_code_hash`,`expires_at`,`verification_trials`,`verification_valid_until`,`consumed_at`,`created_at`) VALUES (?,?,?,?,?,?,?,?,?,?,?)",
+				)).WithArgs(
+					"verification_token", "+821012345678", "198.51.100.7", "register", nil, testSMSOTPHasher.Hash("verification_token", "123456"), now.Add(defaultSMSOTPExpiration), 0, nil, nil, now,
+				).WillReturnResult(
+					sqlmock.NewResult(1, 1),
+				)
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT `sms_otp_verification_id`,`updated_at` FROM `sms_otp_verification` WHERE `sms_otp_verification_id`=?",
+				)).WithArgs(
+					1,
+				).WillReturnRows(sqlmock.NewRows([]string{"sms_otp_verification_id", "updated_at"}).AddRow(
+					1, now,
+				))
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"INSERT INTO `sms_outbox` (`sms_otp_verification_id`,`phone_number`,`message`,`status`,`attempts`,`next_attempt_at`,`expires_at`,`sent_at`,`last_error`) VALUES (?,?,?,?,?,?,?,?,?)",
+				)).WithArgs(
+					1, "+821012345678", "認証コードは 123456 です。", "pending", 0, now, now.Add(defaultSMSOTPExpiration), nil, nil,
+				).WillReturnResult(
+					sqlmock.NewResult(1, 1),
+				)
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT `sms_outbox_id`,`created_at`,`updated_at` FROM `sms_outbox` WHERE `sms_outbox_id`=?",
+				)).WithArgs(
+					1,
+				).WillReturnRows(sqlmock.NewRows([]string{"sms_outbox_id", "created_at", "updated_at"}).AddRow(
+					1, now, now,
+				))
+
+				mock.ExpectCommit()
+			},
 			expectedCode: codes.OK,
 			expectedResp: &userv1.RequestSmsOtpResponse{
 				VerificationToken: "verification_token",
@@ -83,39 +170,153 @@ func TestRequestSmsOtp(t *testing.T) {
 			},
 		},
 		{
-			name: "fail to sms send",
-			req:  &userv1.RequestSmsOtpRequest{PhoneNumber: "+821012345678"},
+			name:        "success with locale and autofill",
+			req:         &userv1.RequestSmsOtpRequest{PhoneNumber: "+821012345678", Purpose: "register", Locale: "ko-KR"},
+			otpAutofill: message.OTPAutofill{WebOTPDomain: "example.com", AndroidAppHash: "FA+9qCX9VSu"},
+			smsOTPLimiterExpectFunc: func(ctx context.Context) func(*service.MockSMSOTPLimiter) {
+				return func(mock *service.MockSMSOTPLimiter) {
+					mock.EXPECT().
+						Allow(ctx, "+821012345678", "198.51.100.7", gomock.Any()).
+						DoAndReturn(allowSMSOTP)
+				}
+			},
 			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"INSERT INTO `sms_otp_verification` (`verification_token`,`phone_number`,`ip_address`,`purpose`,`otp_code`,`otp_code_hash`,`expires_at`,`verification_trials`,`verification_valid_until`,`consumed_at`,`created_at`) VALUES (?,?,?,?,?,?,?,?,?,?,?)",
+				)).WithArgs(
+					"verification_token", "+821012345678", "198.51.100.7", "register", nil, testSMSOTPHasher.Hash("verification_token", "123456"), now.Add(defaultSMSOTPExpiration), 0, nil, nil, now,
+				).WillReturnResult(
+					sqlmock.NewResult(1, 1),
+				)
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT `sms_otp_verification_id`,`updated_at` FROM `sms_otp_verification` WHERE `sms_otp_verification_id`=?",
+				)).WithArgs(
+					1,
+				).WillReturnRows(sqlmock.NewRows([]string{"sms_otp_verification_id", "updated_at"}).AddRow(
+					1, now,
+				))
+
 				mock.ExpectExec(regexp.QuoteMeta(
-					"INSERT INTO `sms_otp_verification` (`verification_token`,`phone_number`,`otp_code`,`expires_at`,`verification_trials`,`verification_valid_until`) VALUES (?,?,?,?,?,?)",
+					"INSERT INTO `sms_outbox` (`sms_otp_verification_id`,`phone_number`,`message`,`status`,`attempts`,`next_attempt_at`,`expires_at`,`sent_at`,`last_error`) VALUES (?,?,?,?,?,?,?,?,?)",
 				)).WithArgs(
-					"verification_token", "+821012345678", "123456", now.Add(defaultSMSOTPExpiration), 0, nil,
+					1, "+821012345678", "인증번호는 123456 입니다.\n\nFA+9qCX9VSu\n@example.com #123456", "pending", 0, now, now.Add(defaultSMSOTPExpiration), nil, nil,
 				).WillReturnResult(
 					sqlmock.NewResult(1, 1),
 				)
 
 				mock.ExpectQuery(regexp.QuoteMeta(
-					"SELECT `sms_otp_verification_id`,`created_at`,`updated_at` FROM `sms_otp_verification` WHERE `sms_otp_verification_id`=?",
+					"SELECT `sms_outbox_id`,`created_at`,`updated_at` FROM `sms_outbox` WHERE `sms_outbox_id`=?",
 				)).WithArgs(
 					1,
-				).WillReturnRows(sqlmock.NewRows([]string{"sms_otp_verification_id", "created_at", "updated_at"}).AddRow(
+				).WillReturnRows(sqlmock.NewRows([]string{"sms_outbox_id", "created_at", "updated_at"}).AddRow(
 					1, now, now,
 				))
+
+				mock.ExpectCommit()
+			},
+			expectedCode: codes.OK,
+			expectedResp: &userv1.RequestSmsOtpResponse{
+				VerificationToken: "verification_token",
+				ExpiresInMs:       180000,
 			},
-			mockSmsServiceClientExpectFunc: func(ctx context.Context) func(*client.MockSmsServiceClient) {
-				return func(mock *client.MockSmsServiceClient) {
+		},
+		{
+			name: "success with national format of region",
+			req:  &userv1.RequestSmsOtpRequest{PhoneNumber: "010-1234-5678", Purpose: "register", RegionCode: "kr"},
+			smsOTPLimiterExpectFunc: func(ctx context.Context) func(*service.MockSMSOTPLimiter) {
+				return func(mock *service.MockSMSOTPLimiter) {
 					mock.EXPECT().
-						Send(ctx, &smsv1.SendRequest{
-							To:      "+821012345678",
-							Message: "123456 is your authentication code.",
-						}).
-						Return(
-							nil, errors.New("unexpected error"),
-						)
+						Allow(ctx, "+821012345678", "198.51.100.7", gomock.Any()).
+						DoAndReturn(allowSMSOTP)
 				}
 			},
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"INSERT INTO `sms_otp_verification` (`verification_token`,`phone_number`,`ip_address`,`purpose`,`otp_code`,`otp_code_hash`,`expires_at`,`verification_trials`,`verification_valid_until`,`consumed_at`,`created_at`) VALUES (?,?,?,?,?,?,?,?,?,?,?)",
+				)).WithArgs(
+					"verification_token", "+821012345678", "198.51.100.7", "register", nil, testSMSOTPHasher.Hash("verification_token", "123456"), now.Add(defaultSMSOTPExpiration), 0, nil, nil, now,
+				).WillReturnResult(
+					sqlmock.NewResult(1, 1),
+				)
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT `sms_otp_verification_id`,`updated_at` FROM `sms_otp_verification` WHERE `sms_otp_verification_id`=?",
+				)).WithArgs(
+					1,
+				).WillReturnRows(sqlmock.NewRows([]string{"sms_otp_verification_id", "updated_at"}).AddRow(
+					1, now,
+				))
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"INSERT INTO `sms_outbox` (`sms_otp_verification_id`,`phone_number`,`message`,`status`,`attempts`,`next_attempt_at`,`expires_at`,`sent_at`,`last_error`) VALUES (?,?,?,?,?,?,?,?,?)",
+				)).WithArgs(
+					1, "+821012345678", "123456 is your authentication code.", "pending", 0, now, now.Add(defaultSMSOTPExpiration), nil, nil,
+				).WillReturnResult(
+					sqlmock.NewResult(1, 1),
+				)
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT `sms_outbox_id`,`created_at`,`updated_at` FROM `sms_outbox` WHERE `sms_outbox_id`=?",
+				)).WithArgs(
+					1,
+				).WillReturnRows(sqlmock.NewRows([]string{"sms_outbox_id", "created_at", "updated_at"}).AddRow(
+					1, now, now,
+				))
+
+				mock.ExpectCommit()
+			},
+			expectedCode: codes.OK,
+			expectedResp: &userv1.RequestSmsOtpResponse{
+				VerificationToken: "verification_token",
+				ExpiresInMs:       180000,
+			},
+		},
+		{
+			name: "fail to enqueue sms",
+			req:  &userv1.RequestSmsOtpRequest{PhoneNumber: "+821012345678", Purpose: "register"},
+			smsOTPLimiterExpectFunc: func(ctx context.Context) func(*service.MockSMSOTPLimiter) {
+				return func(mock *service.MockSMSOTPLimiter) {
+					mock.EXPECT().
+						Allow(ctx, "+821012345678", "198.51.100.7", gomock.Any()).
+						DoAndReturn(allowSMSOTP)
+				}
+			},
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"INSERT INTO `sms_otp_verification` (`verification_token`,`phone_number`,`ip_address`,`purpose`,`otp_code`,`otp_code_hash`,`expires_at`,`verification_trials`,`verification_valid_until`,`consumed_at`,`created_at`) VALUES (?,?,?,?,?,?,?,?,?,?,?)",
+				)).WithArgs(
+					"verification_token", "+821012345678", "198.51.100.7", "register", nil, testSMSOTPHasher.Hash("verification_token", "123456"), now.Add(defaultSMSOTPExpiration), 0, nil, nil, now,
+				).WillReturnResult(
+					sqlmock.NewResult(1, 1),
+				)
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT `sms_otp_verification_id`,`updated_at` FROM `sms_otp_verification` WHERE `sms_otp_verification_id`=?",
+				)).WithArgs(
+					1,
+				).WillReturnRows(sqlmock.NewRows([]string{"sms_otp_verification_id", "updated_at"}).AddRow(
+					1, now,
+				))
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"INSERT INTO `sms_outbox` (`sms_otp_verification_id`,`phone_number`,`message`,`status`,`attempts`,`next_attempt_at`,`expires_at`,`sent_at`,`last_error`) VALUES (?,?,?,?,?,?,?,?,?)",
+				)).WithArgs(
+					1, "+821012345678", "123456 is your authentication code.", "pending", 0, now, now.Add(defaultSMSOTPExpiration), nil, nil,
+				).WillReturnError(
+					errors.New("unexpected error"),
+				)
+
+				mock.ExpectRollback()
+			},
 			expectedCode: codes.Internal,
-			expectedErr:  "rpc error: code = Internal desc = unexpected error",
+			expectedErr:  "rpc error: code = Internal desc = model: unable to insert into sms_outbox: unexpected error",
 		},
 		{
 			name:         "invalid E.167 phone number",
@@ -123,13 +324,77 @@ func TestRequestSmsOtp(t *testing.T) {
 			expectedCode: codes.InvalidArgument,
 			expectedErr:  "rpc error: code = InvalidArgument desc = invalid phone_number",
 		},
+		{
+			name:         "invalid region code",
+			req:          &userv1.RequestSmsOtpRequest{PhoneNumber: "01012345678", Purpose: "register", RegionCode: "XX"},
+			expectedCode: codes.InvalidArgument,
+			expectedErr:  "rpc error: code = InvalidArgument desc = invalid region_code",
+		},
+		{
+			name:         "no purpose",
+			req:          &userv1.RequestSmsOtpRequest{PhoneNumber: "+821012345678"},
+			expectedCode: codes.InvalidArgument,
+			expectedErr:  "rpc error: code = InvalidArgument desc = no purpose",
+		},
+		{
+			name:         "invalid purpose",
+			req:          &userv1.RequestSmsOtpRequest{PhoneNumber: "+821012345678", Purpose: "change_email"},
+			expectedCode: codes.InvalidArgument,
+			expectedErr:  "rpc error: code = InvalidArgument desc = invalid purpose",
+		},
+		{
+			name: "rate limited",
+			req:  &userv1.RequestSmsOtpRequest{PhoneNumber: "+821012345678", Purpose: "register"},
+			smsOTPLimiterExpectFunc: func(ctx context.Context) func(*service.MockSMSOTPLimiter) {
+				return func(mock *service.MockSMSOTPLimiter) {
+					mock.EXPECT().
+						Allow(ctx, "+821012345678", "198.51.100.7", gomock.Any()).
+						Return(errors.WithStack(&service.RateLimitedError{Limit: service.SMSOTPLimitResendCooldown, RetryAfter: 42 * time.Second}))
+				}
+			},
+			expectedCode:    codes.ResourceExhausted,
+			expectedErr:     "rpc error: code = ResourceExhausted desc = rate limited by resend_cooldown",
+			expectedDetails: []interface{}{&errdetails.RetryInfo{RetryDelay: durationpb.New(42 * time.Second)}},
+		},
+		{
+			name: "region not allowed",
+			req:  &userv1.RequestSmsOtpRequest{PhoneNumber: "+14155550100", Purpose: "register"},
+			smsOTPLimiterExpectFunc: func(ctx context.Context) func(*service.MockSMSOTPLimiter) {
+				return func(mock *service.MockSMSOTPLimiter) {
+					mock.EXPECT().
+						Allow(ctx, "+14155550100", "198.51.100.7", gomock.Any()).
+						Return(errors.WithStack(service.ErrSMSOTPRegionNotAllowed))
+				}
+			},
+			expectedCode: codes.InvalidArgument,
+			expectedErr:  "rpc error: code = InvalidArgument desc = sms otp region not allowed",
+		},
+		{
+			name: "fail to check limits",
+			req:  &userv1.RequestSmsOtpRequest{PhoneNumber: "+821012345678", Purpose: "register"},
+			smsOTPLimiterExpectFunc: func(ctx context.Context) func(*service.MockSMSOTPLimiter) {
+				return func(mock *service.MockSMSOTPLimiter) {
+					mock.EXPECT().
+						Allow(ctx, "+821012345678", "198.51.100.7", gomock.Any()).
+						Return(errors.New("unexpected error"))
+				}
+			},
+			expectedCode: codes.Internal,
+			expectedErr:  "rpc error: code = Internal desc = unexpected error",
+		},
 	}
 
 	for _, tc := range cases {
 		tc := tc
 
 		t.Run(tc.name, func(t *testing.T) {
-			ctx := context.Background()
+			md := metadata.New(map[string]string{
+				headerKeyForwardedFor: "203.0.113.1, 198.51.100.7",
+			})
+			if tc.acceptLanguage != "" {
+				md.Set(headerKeyGatewayAcceptLanguage, tc.acceptLanguage)
+			}
+			ctx := metadata.NewIncomingContext(context.Background(), md)
 
 			ctrl := gomock.NewController(t)
 
@@ -144,12 +409,12 @@ func TestRequestSmsOtp(t *testing.T) {
 			}
 			defer test.CloseSqlmock(t, db, mock)
 
-			mockSmsServiceCli := client.NewMockSmsServiceClient(ctrl)
-			if tc.mockSmsServiceClientExpectFunc != nil {
-				tc.mockSmsServiceClientExpectFunc(ctx)(mockSmsServiceCli)
+			mockSMSOTPLimiter := service.NewMockSMSOTPLimiter(ctrl)
+			if tc.smsOTPLimiterExpectFunc != nil {
+				tc.smsOTPLimiterExpectFunc(ctx)(mockSMSOTPLimiter)
 			}
 
-			handler := RequestSmsOtp(clock, db, &testGenerator{mock: "verification_token"}, &testGenerator{mock: "123456"}, mockSmsServiceCli)
+			handler := RequestSmsOtp(clock, db, &testGenerator{mock: "verification_token"}, &testGenerator{mock: "123456"}, testSMSOTPHasher, mockSMSOTPLimiter, testSMSTemplates, tc.otpAutofill)
 
 			resp, err := handler(ctx, tc.req)
 
@@ -160,6 +425,9 @@ func TestRequestSmsOtp(t *testing.T) {
 			}
 			assert.Equal(t, tc.expectedCode, status.Code(err))
 			assert.Empty(t, cmp.Diff(resp, tc.expectedResp, protocmp.Transform()))
+			if tc.expectedDetails != nil {
+				assert.Empty(t, cmp.Diff(status.Convert(err).Details(), tc.expectedDetails, protocmp.Transform()))
+			}
 		})
 	}
 
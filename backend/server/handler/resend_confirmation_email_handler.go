@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/sean-ahn/user/backend/persistence/mysql"
+	"github.com/sean-ahn/user/backend/server/service"
+	userv1 "github.com/sean-ahn/user/proto/gen/go/user/v1"
+)
+
+type ResendConfirmationEmailHandlerFunc func(ctx context.Context, req *userv1.ResendConfirmationEmailRequest) (*userv1.ResendConfirmationEmailResponse, error)
+
+func ResendConfirmationEmail(db *sql.DB, emailConfirmationService service.EmailConfirmationService, accountNoticeService service.AccountNoticeService, enumerationResistant bool) ResendConfirmationEmailHandlerFunc {
+	return func(ctx context.Context, req *userv1.ResendConfirmationEmailRequest) (*userv1.ResendConfirmationEmailResponse, error) {
+		if req.Email == "" {
+			return nil, status.Error(codes.InvalidArgument, "no email")
+		}
+
+		user, err := mysql.FindUserByEmail(ctx, db, req.Email)
+		if errors.Cause(err) == sql.ErrNoRows {
+			// answered as if sent when enumerationResistant
+			if enumerationResistant {
+				return &userv1.ResendConfirmationEmailResponse{}, nil
+			}
+			return nil, status.Error(codes.NotFound, "no user with given email")
+		}
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+
+		if err := emailConfirmationService.Resend(ctx, user); err != nil {
+			switch errors.Cause(err) {
+			case service.ErrEmailAlreadyConfirmed:
+				// answered as if sent when enumerationResistant, and the user is noticed instead
+				if enumerationResistant {
+					if err := accountNoticeService.NoticeConfirmationResendAttempt(ctx, user); err != nil {
+						logrus.WithError(err).Warn("failed to notice confirmation resend attempt")
+					}
+					return &userv1.ResendConfirmationEmailResponse{}, nil
+				}
+				return nil, status.Error(codes.InvalidArgument, errors.Cause(err).Error())
+			case service.ErrConfirmationEmailCooldown:
+				// only the existing users with unconfirmed emails are in cooldown, so it is told as if sent as well
+				if enumerationResistant {
+					return &userv1.ResendConfirmationEmailResponse{}, nil
+				}
+				return nil, status.Error(codes.ResourceExhausted, errors.Cause(err).Error())
+			default:
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+		}
+
+		return &userv1.ResendConfirmationEmailResponse{}, nil
+	}
+}
@@ -0,0 +1,236 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/sean-ahn/user/backend/model"
+	"github.com/sean-ahn/user/backend/server/service"
+	"github.com/sean-ahn/user/backend/test"
+	userv1 "github.com/sean-ahn/user/proto/gen/go/user/v1"
+)
+
+func TestResendConfirmationEmail(t *testing.T) {
+	cases := []struct {
+		name                 string
+		req                  *userv1.ResendConfirmationEmailRequest
+		enumerationResistant bool
+
+		dbExpectFunc                       func(sqlmock.Sqlmock)
+		emailConfirmationServiceExpectFunc func(context.Context) func(*service.MockEmailConfirmationService)
+		accountNoticeServiceExpectFunc     func(context.Context) func(*service.MockAccountNoticeService)
+
+		expectedCode codes.Code
+		expectedResp *userv1.ResendConfirmationEmailResponse
+		expectedErr  string
+	}{
+		{
+			name: "success",
+			req:  &userv1.ResendConfirmationEmailRequest{Email: "john.doe@example.com"},
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user` WHERE (`user`.`email` = ?) LIMIT 1;",
+				)).WithArgs(
+					"john.doe@example.com",
+				).WillReturnRows(test.NewUserRows([]*model.User{
+					{UserID: 1, Email: "john.doe@example.com", IsEmailConfirmed: false},
+				}))
+			},
+			emailConfirmationServiceExpectFunc: func(ctx context.Context) func(*service.MockEmailConfirmationService) {
+				return func(mock *service.MockEmailConfirmationService) {
+					mock.EXPECT().
+						Resend(ctx, &model.User{UserID: 1, Email: "john.doe@example.com", IsEmailConfirmed: false}).
+						Return(nil)
+				}
+			},
+			expectedCode: codes.OK,
+			expectedResp: &userv1.ResendConfirmationEmailResponse{},
+		},
+		{
+			name:         "no email",
+			req:          &userv1.ResendConfirmationEmailRequest{},
+			expectedCode: codes.InvalidArgument,
+			expectedErr:  "rpc error: code = InvalidArgument desc = no email",
+		},
+		{
+			name: "user not found",
+			req:  &userv1.ResendConfirmationEmailRequest{Email: "john.doe@example.com"},
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user` WHERE (`user`.`email` = ?) LIMIT 1;",
+				)).WithArgs(
+					"john.doe@example.com",
+				).WillReturnError(
+					sql.ErrNoRows,
+				)
+			},
+			expectedCode: codes.NotFound,
+			expectedErr:  "rpc error: code = NotFound desc = no user with given email",
+		},
+		{
+			name: "already confirmed email",
+			req:  &userv1.ResendConfirmationEmailRequest{Email: "john.doe@example.com"},
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user` WHERE (`user`.`email` = ?) LIMIT 1;",
+				)).WithArgs(
+					"john.doe@example.com",
+				).WillReturnRows(test.NewUserRows([]*model.User{
+					{UserID: 1, Email: "john.doe@example.com", IsEmailConfirmed: true},
+				}))
+			},
+			emailConfirmationServiceExpectFunc: func(ctx context.Context) func(*service.MockEmailConfirmationService) {
+				return func(mock *service.MockEmailConfirmationService) {
+					mock.EXPECT().
+						Resend(ctx, gomock.Any()).
+						Return(errors.WithStack(service.ErrEmailAlreadyConfirmed))
+				}
+			},
+			expectedCode: codes.InvalidArgument,
+			expectedErr:  "rpc error: code = InvalidArgument desc = already confirmed email",
+		},
+		{
+			name: "cooldown",
+			req:  &userv1.ResendConfirmationEmailRequest{Email: "john.doe@example.com"},
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user` WHERE (`user`.`email` = ?) LIMIT 1;",
+				)).WithArgs(
+					"john.doe@example.com",
+				).WillReturnRows(test.NewUserRows([]*model.User{
+					{UserID: 1, Email: "john.doe@example.com", IsEmailConfirmed: false},
+				}))
+			},
+			emailConfirmationServiceExpectFunc: func(ctx context.Context) func(*service.MockEmailConfirmationService) {
+				return func(mock *service.MockEmailConfirmationService) {
+					mock.EXPECT().
+						Resend(ctx, gomock.Any()).
+						Return(errors.WithStack(service.ErrConfirmationEmailCooldown))
+				}
+			},
+			expectedCode: codes.ResourceExhausted,
+			expectedErr:  "rpc error: code = ResourceExhausted desc = confirmation email recently sent",
+		},
+		{
+			name:                 "user not found when enumeration resistant",
+			req:                  &userv1.ResendConfirmationEmailRequest{Email: "john.doe@example.com"},
+			enumerationResistant: true,
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user` WHERE (`user`.`email` = ?) LIMIT 1;",
+				)).WithArgs(
+					"john.doe@example.com",
+				).WillReturnError(
+					sql.ErrNoRows,
+				)
+			},
+			expectedCode: codes.OK,
+			expectedResp: &userv1.ResendConfirmationEmailResponse{},
+		},
+		{
+			name:                 "already confirmed email when enumeration resistant",
+			req:                  &userv1.ResendConfirmationEmailRequest{Email: "john.doe@example.com"},
+			enumerationResistant: true,
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user` WHERE (`user`.`email` = ?) LIMIT 1;",
+				)).WithArgs(
+					"john.doe@example.com",
+				).WillReturnRows(test.NewUserRows([]*model.User{
+					{UserID: 1, Email: "john.doe@example.com", IsEmailConfirmed: true},
+				}))
+			},
+			emailConfirmationServiceExpectFunc: func(ctx context.Context) func(*service.MockEmailConfirmationService) {
+				return func(mock *service.MockEmailConfirmationService) {
+					mock.EXPECT().
+						Resend(ctx, gomock.Any()).
+						Return(errors.WithStack(service.ErrEmailAlreadyConfirmed))
+				}
+			},
+			accountNoticeServiceExpectFunc: func(ctx context.Context) func(*service.MockAccountNoticeService) {
+				return func(mock *service.MockAccountNoticeService) {
+					mock.EXPECT().
+						NoticeConfirmationResendAttempt(ctx, &model.User{UserID: 1, Email: "john.doe@example.com", IsEmailConfirmed: true}).
+						Return(nil)
+				}
+			},
+			expectedCode: codes.OK,
+			expectedResp: &userv1.ResendConfirmationEmailResponse{},
+		},
+		{
+			name:                 "cooldown when enumeration resistant",
+			req:                  &userv1.ResendConfirmationEmailRequest{Email: "john.doe@example.com"},
+			enumerationResistant: true,
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user` WHERE (`user`.`email` = ?) LIMIT 1;",
+				)).WithArgs(
+					"john.doe@example.com",
+				).WillReturnRows(test.NewUserRows([]*model.User{
+					{UserID: 1, Email: "john.doe@example.com", IsEmailConfirmed: false},
+				}))
+			},
+			emailConfirmationServiceExpectFunc: func(ctx context.Context) func(*service.MockEmailConfirmationService) {
+				return func(mock *service.MockEmailConfirmationService) {
+					mock.EXPECT().
+						Resend(ctx, gomock.Any()).
+						Return(errors.WithStack(service.ErrConfirmationEmailCooldown))
+				}
+			},
+			expectedCode: codes.OK,
+			expectedResp: &userv1.ResendConfirmationEmailResponse{},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fail()
+			}
+			if tc.dbExpectFunc != nil {
+				tc.dbExpectFunc(mock)
+			}
+			defer test.CloseSqlmock(t, db, mock)
+
+			mockEmailConfirmationService := service.NewMockEmailConfirmationService(ctrl)
+			if tc.emailConfirmationServiceExpectFunc != nil {
+				tc.emailConfirmationServiceExpectFunc(ctx)(mockEmailConfirmationService)
+			}
+
+			mockAccountNoticeService := service.NewMockAccountNoticeService(ctrl)
+			if tc.accountNoticeServiceExpectFunc != nil {
+				tc.accountNoticeServiceExpectFunc(ctx)(mockAccountNoticeService)
+			}
+
+			handler := ResendConfirmationEmail(db, mockEmailConfirmationService, mockAccountNoticeService, tc.enumerationResistant)
+
+			resp, err := handler(ctx, tc.req)
+
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedCode, status.Code(err))
+			assert.Empty(t, cmp.Diff(resp, tc.expectedResp, protocmp.Transform()))
+		})
+	}
+}
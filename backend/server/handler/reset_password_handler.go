@@ -3,6 +3,7 @@ package handler
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/jonboulle/clockwork"
 	"github.com/pkg/errors"
@@ -14,13 +15,18 @@ import (
 	"github.com/sean-ahn/user/backend/crypto"
 	"github.com/sean-ahn/user/backend/model"
 	"github.com/sean-ahn/user/backend/persistence/mysql"
+	"github.com/sean-ahn/user/backend/server/message"
 	"github.com/sean-ahn/user/backend/server/service"
 	userv1 "github.com/sean-ahn/user/proto/gen/go/user/v1"
 )
 
+const (
+	noAccountSMSExpiration = 1 * time.Hour
+)
+
 type ResetPasswordHandlerFunc func(ctx context.Context, req *userv1.ResetPasswordRequest) (*userv1.ResetPasswordResponse, error)
 
-func ResetPassword(clock clockwork.Clock, db *sql.DB, hasher crypto.Hasher, userTokenService service.UserTokenService) ResetPasswordHandlerFunc {
+func ResetPassword(clock clockwork.Clock, db *sql.DB, hasher crypto.Hasher, passwordChecker *service.PasswordChecker, userTokenService service.UserTokenService, passwordHistoryService service.PasswordHistoryService, accountNoticeService service.AccountNoticeService, smsTemplates *message.Registry, enumerationResistant bool) ResetPasswordHandlerFunc {
 	return func(ctx context.Context, req *userv1.ResetPasswordRequest) (*userv1.ResetPasswordResponse, error) {
 		now := clock.Now()
 
@@ -30,10 +36,6 @@ func ResetPassword(clock clockwork.Clock, db *sql.DB, hasher crypto.Hasher, user
 		if req.NewPassword == "" {
 			return nil, status.Error(codes.InvalidArgument, "no new_password")
 		}
-		if !isValidPassword(req.NewPassword) {
-			return nil, status.Error(codes.InvalidArgument, "invalid password")
-		}
-
 		newPasswordHash, err := hasher.Hash([]byte(req.NewPassword))
 		if err != nil {
 			return nil, status.Error(codes.Internal, err.Error())
@@ -47,16 +49,32 @@ func ResetPassword(clock clockwork.Clock, db *sql.DB, hasher crypto.Hasher, user
 			return nil, status.Error(codes.Internal, err.Error())
 		}
 
-		phoneNumber, err := normalizePhoneNumber(verification.PhoneNumber)
+		phoneNumber, err := normalizePhoneNumber(verification.PhoneNumber, "") // stored in E.164
 		if err != nil {
 			return nil, status.Error(codes.Internal, "invalid phone_number")
 		}
 
-		if !verification.VerificationValidUntil.Valid || verification.VerificationValidUntil.Time.Before(now) {
-			return nil, status.Error(codes.InvalidArgument, "invalid verification")
+		if !isUsableSMSOTPVerification(verification, smsOTPPurposeResetPassword, now) {
+			return nil, status.Error(codes.InvalidArgument, errInvalidVerification.Error())
 		}
 
 		user, err := mysql.FindUserByPhoneNumber(ctx, db, phoneNumber)
+		if errors.Cause(err) == sql.ErrNoRows && enumerationResistant {
+			if violations := passwordChecker.Check(req.NewPassword, phoneNumber); len(violations) > 0 {
+				return nil, invalidPasswordStatus("new_password", violations).Err()
+			}
+
+			// answered as if reset, and the phone number, which the requester has verified, is noticed instead
+			if err := noticeNoAccount(ctx, db, accountNoticeService, smsTemplates, verification, extractAcceptLanguage(ctx), now); err != nil {
+				switch errors.Cause(err) {
+				case errInvalidVerification:
+					return nil, status.Error(codes.InvalidArgument, errors.Cause(err).Error())
+				default:
+					return nil, status.Error(codes.Internal, err.Error())
+				}
+			}
+			return &userv1.ResetPasswordResponse{}, nil
+		}
 		if errors.Cause(err) == sql.ErrNoRows {
 			return nil, status.Error(codes.NotFound, "no user with given phone number")
 		}
@@ -64,17 +82,36 @@ func ResetPassword(clock clockwork.Clock, db *sql.DB, hasher crypto.Hasher, user
 			return nil, status.Error(codes.Internal, err.Error())
 		}
 
+		if violations := passwordChecker.Check(req.NewPassword, user.Name, user.Email, user.Nickname, user.PhoneNumber); len(violations) > 0 {
+			return nil, invalidPasswordStatus("new_password", violations).Err()
+		}
+
+		if err := passwordHistoryService.CheckReuse(ctx, user, req.NewPassword); err != nil {
+			switch errors.Cause(err) {
+			case service.ErrPasswordReused:
+				return nil, status.Error(codes.InvalidArgument, errors.Cause(err).Error())
+			default:
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+		}
+
+		replacedHash := user.PasswordHash
 		user.PasswordHash = string(newPasswordHash)
 
-		if err := resetUserPassword(ctx, db, userTokenService, user); err != nil {
-			return nil, status.Error(codes.Internal, err.Error())
+		if err := resetUserPassword(ctx, db, userTokenService, passwordHistoryService, verification, user, replacedHash, now); err != nil {
+			switch errors.Cause(err) {
+			case errInvalidVerification:
+				return nil, status.Error(codes.InvalidArgument, errors.Cause(err).Error())
+			default:
+				return nil, status.Error(codes.Internal, err.Error())
+			}
 		}
 
 		return &userv1.ResetPasswordResponse{}, nil
 	}
 }
 
-func resetUserPassword(ctx context.Context, db *sql.DB, userTokenService service.UserTokenService, user *model.User) error {
+func resetUserPassword(ctx context.Context, db *sql.DB, userTokenService service.UserTokenService, passwordHistoryService service.PasswordHistoryService, verification *model.SMSOtpVerification, user *model.User, replacedHash string, now time.Time) error {
 	tx, err := db.BeginTx(ctx, nil)
 	if err != nil {
 		return errors.WithStack(err)
@@ -85,10 +122,18 @@ func resetUserPassword(ctx context.Context, db *sql.DB, userTokenService service
 		}
 	}()
 
+	if err := consumeSMSOTPVerification(ctx, tx, verification, now); err != nil {
+		return err
+	}
+
 	if _, err := user.Update(ctx, tx, boil.Whitelist(model.UserColumns.PasswordHash)); err != nil {
 		return errors.WithStack(err)
 	}
 
+	if err := passwordHistoryService.Record(ctx, tx, user, replacedHash); err != nil {
+		return err
+	}
+
 	if err := userTokenService.RevokeAll(ctx, user, tx); err != nil {
 		return errors.WithStack(err)
 	}
@@ -98,3 +143,45 @@ func resetUserPassword(ctx context.Context, db *sql.DB, userTokenService service
 	}
 	return nil
 }
+
+// noticeNoAccount consumes the verification as if the password were reset, and texts the phone number that no user has
+// it unless the phone number has been noticed within the cooldown.
+func noticeNoAccount(ctx context.Context, db *sql.DB, accountNoticeService service.AccountNoticeService, smsTemplates *message.Registry, verification *model.SMSOtpVerification, locale string, now time.Time) error {
+	text, err := smsTemplates.Render(message.KindSMSNoAccount, locale, nil)
+	if err != nil {
+		return err
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			logrus.WithError(err).Error()
+		}
+	}()
+
+	if err := consumeSMSOTPVerification(ctx, tx, verification, now); err != nil {
+		return err
+	}
+
+	ok, err := accountNoticeService.AllowNotice(ctx, tx, verification.PhoneNumber)
+	if err != nil {
+		return err
+	}
+	if ok {
+		if _, err := service.EnqueueSMS(ctx, tx, service.OutboxSMS{
+			PhoneNumber: verification.PhoneNumber,
+			Message:     text,
+			ExpiresAt:   now.Add(noAccountSMSExpiration),
+		}, now); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"regexp"
 	"testing"
@@ -11,6 +12,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
 	"github.com/jonboulle/clockwork"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/volatiletech/null/v8"
 	"google.golang.org/grpc/codes"
@@ -47,6 +49,9 @@ func TestResetPassword(t *testing.T) {
 
 		dbExpectFunc               func(sqlmock.Sqlmock)
 		userTokenServiceExpectFunc func(context.Context) func(*service.MockUserTokenService)
+		passwordHistoryExpectFunc  func(context.Context) func(*service.MockPasswordHistoryService)
+		accountNoticeExpectFunc    func(context.Context) func(*service.MockAccountNoticeService)
+		enumerationResistant       bool
 
 		expectedCode codes.Code
 		expectedResp *userv1.ResetPasswordResponse
@@ -64,7 +69,7 @@ func TestResetPassword(t *testing.T) {
 				)).WithArgs(
 					"verification_token",
 				).WillReturnRows(test.NewSMSOtpVerificationRows([]*model.SMSOtpVerification{
-					{PhoneNumber: "+821012345678", VerificationValidUntil: null.TimeFrom(now.Add(defaultSMSOTPValidity))},
+					{SMSOtpVerificationID: 1, PhoneNumber: "+821012345678", Purpose: "reset_password", VerificationValidUntil: null.TimeFrom(now.Add(defaultSMSOTPValidity))},
 				}))
 
 				mock.ExpectQuery(regexp.QuoteMeta(
@@ -72,11 +77,17 @@ func TestResetPassword(t *testing.T) {
 				)).WithArgs(
 					"+821012345678",
 				).WillReturnRows(test.NewUserRows([]*model.User{
-					{UserID: 1},
+					{UserID: 1, PasswordHash: "old_hash"},
 				}))
 
 				mock.ExpectBegin()
 
+				mock.ExpectExec(regexp.QuoteMeta(
+					"UPDATE `sms_otp_verification` SET `consumed_at` = ? WHERE (`sms_otp_verification`.`sms_otp_verification_id` = ?) AND (`sms_otp_verification`.`consumed_at` is null);",
+				)).WithArgs(
+					now, 1,
+				).WillReturnResult(sqlmock.NewResult(0, 1))
+
 				mock.ExpectExec(regexp.QuoteMeta(
 					"UPDATE `user` SET `password_hash`=? WHERE `user_id`=?",
 				)).WithArgs(
@@ -90,9 +101,235 @@ func TestResetPassword(t *testing.T) {
 					mock.EXPECT().RevokeAll(ctx, &userMatcher{id: 1}, gomock.Any()).Return(nil)
 				}
 			},
+			passwordHistoryExpectFunc: func(ctx context.Context) func(*service.MockPasswordHistoryService) {
+				return func(mock *service.MockPasswordHistoryService) {
+					mock.EXPECT().CheckReuse(ctx, &userMatcher{id: 1}, "P@$$w0rd").Return(nil)
+					mock.EXPECT().Record(ctx, gomock.Any(), &userMatcher{id: 1}, "old_hash").Return(nil)
+				}
+			},
 			expectedCode: codes.OK,
 			expectedResp: &userv1.ResetPasswordResponse{},
 		},
+		{
+			name: "no user",
+			req: &userv1.ResetPasswordRequest{
+				VerificationToken: "verification_token",
+				NewPassword:       "P@$$w0rd",
+			},
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `sms_otp_verification` WHERE (`sms_otp_verification`.`verification_token` = ?) LIMIT 1;",
+				)).WithArgs(
+					"verification_token",
+				).WillReturnRows(test.NewSMSOtpVerificationRows([]*model.SMSOtpVerification{
+					{SMSOtpVerificationID: 1, PhoneNumber: "+821012345678", Purpose: "reset_password", VerificationValidUntil: null.TimeFrom(now.Add(defaultSMSOTPValidity))},
+				}))
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user` WHERE (`user`.`phone_number` = ?) LIMIT 1;",
+				)).WithArgs(
+					"+821012345678",
+				).WillReturnError(
+					sql.ErrNoRows,
+				)
+			},
+			expectedCode: codes.NotFound,
+			expectedErr:  "rpc error: code = NotFound desc = no user with given phone number",
+		},
+		{
+			name: "no user noticed when enumeration resistant",
+			req: &userv1.ResetPasswordRequest{
+				VerificationToken: "verification_token",
+				NewPassword:       "P@$$w0rd",
+			},
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `sms_otp_verification` WHERE (`sms_otp_verification`.`verification_token` = ?) LIMIT 1;",
+				)).WithArgs(
+					"verification_token",
+				).WillReturnRows(test.NewSMSOtpVerificationRows([]*model.SMSOtpVerification{
+					{SMSOtpVerificationID: 1, PhoneNumber: "+821012345678", Purpose: "reset_password", VerificationValidUntil: null.TimeFrom(now.Add(defaultSMSOTPValidity))},
+				}))
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user` WHERE (`user`.`phone_number` = ?) LIMIT 1;",
+				)).WithArgs(
+					"+821012345678",
+				).WillReturnError(
+					sql.ErrNoRows,
+				)
+
+				mock.ExpectBegin()
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"UPDATE `sms_otp_verification` SET `consumed_at` = ? WHERE (`sms_otp_verification`.`sms_otp_verification_id` = ?) AND (`sms_otp_verification`.`consumed_at` is null);",
+				)).WithArgs(
+					now, 1,
+				).WillReturnResult(sqlmock.NewResult(0, 1))
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"INSERT INTO `sms_outbox` (`sms_otp_verification_id`,`phone_number`,`message`,`status`,`attempts`,`next_attempt_at`,`expires_at`,`sent_at`,`last_error`) VALUES (?,?,?,?,?,?,?,?,?)",
+				)).WithArgs(
+					nil, "+821012345678", "A password reset was requested with this phone number, but no account is registered with it.", "pending", 0, now, now.Add(noAccountSMSExpiration), nil, nil,
+				).WillReturnResult(
+					sqlmock.NewResult(1, 1),
+				)
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT `sms_outbox_id`,`created_at`,`updated_at` FROM `sms_outbox` WHERE `sms_outbox_id`=?",
+				)).WithArgs(
+					1,
+				).WillReturnRows(sqlmock.NewRows([]string{"sms_outbox_id", "created_at", "updated_at"}).AddRow(
+					1, now, now,
+				))
+
+				mock.ExpectCommit()
+			},
+			accountNoticeExpectFunc: func(ctx context.Context) func(*service.MockAccountNoticeService) {
+				return func(mock *service.MockAccountNoticeService) {
+					mock.EXPECT().
+						AllowNotice(ctx, gomock.Any(), "+821012345678").
+						Return(true, nil)
+				}
+			},
+			enumerationResistant: true,
+			expectedCode:         codes.OK,
+			expectedResp:         &userv1.ResetPasswordResponse{},
+		},
+		{
+			name: "no user already noticed when enumeration resistant",
+			req: &userv1.ResetPasswordRequest{
+				VerificationToken: "verification_token",
+				NewPassword:       "P@$$w0rd",
+			},
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `sms_otp_verification` WHERE (`sms_otp_verification`.`verification_token` = ?) LIMIT 1;",
+				)).WithArgs(
+					"verification_token",
+				).WillReturnRows(test.NewSMSOtpVerificationRows([]*model.SMSOtpVerification{
+					{SMSOtpVerificationID: 1, PhoneNumber: "+821012345678", Purpose: "reset_password", VerificationValidUntil: null.TimeFrom(now.Add(defaultSMSOTPValidity))},
+				}))
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user` WHERE (`user`.`phone_number` = ?) LIMIT 1;",
+				)).WithArgs(
+					"+821012345678",
+				).WillReturnError(
+					sql.ErrNoRows,
+				)
+
+				mock.ExpectBegin()
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"UPDATE `sms_otp_verification` SET `consumed_at` = ? WHERE (`sms_otp_verification`.`sms_otp_verification_id` = ?) AND (`sms_otp_verification`.`consumed_at` is null);",
+				)).WithArgs(
+					now, 1,
+				).WillReturnResult(sqlmock.NewResult(0, 1))
+
+				mock.ExpectCommit()
+			},
+			accountNoticeExpectFunc: func(ctx context.Context) func(*service.MockAccountNoticeService) {
+				return func(mock *service.MockAccountNoticeService) {
+					mock.EXPECT().
+						AllowNotice(ctx, gomock.Any(), "+821012345678").
+						Return(false, nil)
+				}
+			},
+			enumerationResistant: true,
+			expectedCode:         codes.OK,
+			expectedResp:         &userv1.ResetPasswordResponse{},
+		},
+		{
+			name: "verification for other purpose",
+			req: &userv1.ResetPasswordRequest{
+				VerificationToken: "verification_token",
+				NewPassword:       "P@$$w0rd",
+			},
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `sms_otp_verification` WHERE (`sms_otp_verification`.`verification_token` = ?) LIMIT 1;",
+				)).WithArgs(
+					"verification_token",
+				).WillReturnRows(test.NewSMSOtpVerificationRows([]*model.SMSOtpVerification{
+					{SMSOtpVerificationID: 1, PhoneNumber: "+821012345678", Purpose: "register", VerificationValidUntil: null.TimeFrom(now.Add(defaultSMSOTPValidity))},
+				}))
+			},
+			expectedCode: codes.InvalidArgument,
+			expectedErr:  "rpc error: code = InvalidArgument desc = invalid verification",
+		},
+		{
+			name: "verification consumed concurrently",
+			req: &userv1.ResetPasswordRequest{
+				VerificationToken: "verification_token",
+				NewPassword:       "P@$$w0rd",
+			},
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `sms_otp_verification` WHERE (`sms_otp_verification`.`verification_token` = ?) LIMIT 1;",
+				)).WithArgs(
+					"verification_token",
+				).WillReturnRows(test.NewSMSOtpVerificationRows([]*model.SMSOtpVerification{
+					{SMSOtpVerificationID: 1, PhoneNumber: "+821012345678", Purpose: "reset_password", VerificationValidUntil: null.TimeFrom(now.Add(defaultSMSOTPValidity))},
+				}))
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user` WHERE (`user`.`phone_number` = ?) LIMIT 1;",
+				)).WithArgs(
+					"+821012345678",
+				).WillReturnRows(test.NewUserRows([]*model.User{
+					{UserID: 1},
+				}))
+
+				mock.ExpectBegin()
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"UPDATE `sms_otp_verification` SET `consumed_at` = ? WHERE (`sms_otp_verification`.`sms_otp_verification_id` = ?) AND (`sms_otp_verification`.`consumed_at` is null);",
+				)).WithArgs(
+					now, 1,
+				).WillReturnResult(sqlmock.NewResult(0, 0))
+
+				mock.ExpectRollback()
+			},
+			passwordHistoryExpectFunc: func(ctx context.Context) func(*service.MockPasswordHistoryService) {
+				return func(mock *service.MockPasswordHistoryService) {
+					mock.EXPECT().CheckReuse(ctx, &userMatcher{id: 1}, "P@$$w0rd").Return(nil)
+				}
+			},
+			expectedCode: codes.InvalidArgument,
+			expectedErr:  "rpc error: code = InvalidArgument desc = invalid verification",
+		},
+		{
+			name: "recently used password",
+			req: &userv1.ResetPasswordRequest{
+				VerificationToken: "verification_token",
+				NewPassword:       "P@$$w0rd",
+			},
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `sms_otp_verification` WHERE (`sms_otp_verification`.`verification_token` = ?) LIMIT 1;",
+				)).WithArgs(
+					"verification_token",
+				).WillReturnRows(test.NewSMSOtpVerificationRows([]*model.SMSOtpVerification{
+					{SMSOtpVerificationID: 1, PhoneNumber: "+821012345678", Purpose: "reset_password", VerificationValidUntil: null.TimeFrom(now.Add(defaultSMSOTPValidity))},
+				}))
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user` WHERE (`user`.`phone_number` = ?) LIMIT 1;",
+				)).WithArgs(
+					"+821012345678",
+				).WillReturnRows(test.NewUserRows([]*model.User{
+					{UserID: 1},
+				}))
+			},
+			passwordHistoryExpectFunc: func(ctx context.Context) func(*service.MockPasswordHistoryService) {
+				return func(mock *service.MockPasswordHistoryService) {
+					mock.EXPECT().CheckReuse(ctx, &userMatcher{id: 1}, "P@$$w0rd").Return(errors.WithStack(service.ErrPasswordReused))
+				}
+			},
+			expectedCode: codes.InvalidArgument,
+			expectedErr:  "rpc error: code = InvalidArgument desc = recently used password",
+		},
 	}
 
 	for _, tc := range cases {
@@ -118,7 +355,17 @@ func TestResetPassword(t *testing.T) {
 				tc.userTokenServiceExpectFunc(ctx)(mockUserTokenService)
 			}
 
-			handler := ResetPassword(clock, db, &testHasher{}, mockUserTokenService)
+			mockPasswordHistoryService := service.NewMockPasswordHistoryService(ctrl)
+			if tc.passwordHistoryExpectFunc != nil {
+				tc.passwordHistoryExpectFunc(ctx)(mockPasswordHistoryService)
+			}
+
+			mockAccountNoticeService := service.NewMockAccountNoticeService(ctrl)
+			if tc.accountNoticeExpectFunc != nil {
+				tc.accountNoticeExpectFunc(ctx)(mockAccountNoticeService)
+			}
+
+			handler := ResetPassword(clock, db, &testHasher{}, testPasswordChecker, mockUserTokenService, mockPasswordHistoryService, mockAccountNoticeService, testSMSTemplates, tc.enumerationResistant)
 
 			resp, err := handler(ctx, tc.req)
 
@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/sean-ahn/user/backend/server/service"
+	userv1 "github.com/sean-ahn/user/proto/gen/go/user/v1"
+)
+
+type RevokeOtherSessionsHandlerFunc func(ctx context.Context, req *userv1.RevokeOtherSessionsRequest) (*userv1.RevokeOtherSessionsResponse, error)
+
+func RevokeOtherSessions(userTokenService service.UserTokenService) RevokeOtherSessionsHandlerFunc {
+	return func(ctx context.Context, req *userv1.RevokeOtherSessionsRequest) (*userv1.RevokeOtherSessionsResponse, error) {
+		token := extractToken(ctx)
+		if token == "" {
+			return nil, status.Error(codes.Unauthenticated, "no token")
+		}
+
+		user, sessionID, err := userTokenService.GetUserSession(ctx, token)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		}
+		// tokens issued before sessions were introduced do not tell which session is the current one
+		if sessionID == "" {
+			return nil, status.Error(codes.FailedPrecondition, "current session unknown")
+		}
+
+		if err := userTokenService.RevokeOtherSessions(ctx, user, sessionID); err != nil {
+			switch errors.Cause(err) {
+			case service.ErrSessionNotFound:
+				return nil, status.Error(codes.FailedPrecondition, errors.Cause(err).Error())
+			default:
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+		}
+
+		return &userv1.RevokeOtherSessionsResponse{}, nil
+	}
+}
@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/friendsofgo/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/sean-ahn/user/backend/server/service"
+	userv1 "github.com/sean-ahn/user/proto/gen/go/user/v1"
+)
+
+type RevokeSessionHandlerFunc func(ctx context.Context, req *userv1.RevokeSessionRequest) (*userv1.RevokeSessionResponse, error)
+
+func RevokeSession(userTokenService service.UserTokenService) RevokeSessionHandlerFunc {
+	return func(ctx context.Context, req *userv1.RevokeSessionRequest) (*userv1.RevokeSessionResponse, error) {
+		token := extractToken(ctx)
+		if token == "" {
+			return nil, status.Error(codes.Unauthenticated, "no token")
+		}
+
+		user, err := userTokenService.GetUser(ctx, token)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		}
+
+		if req.SessionId == "" {
+			return nil, status.Error(codes.InvalidArgument, "no session_id")
+		}
+
+		if err := userTokenService.RevokeSession(ctx, user, req.SessionId); err != nil {
+			switch errors.Cause(err) {
+			case service.ErrSessionNotFound:
+				return nil, status.Error(codes.NotFound, err.Error())
+			default:
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+		}
+
+		return &userv1.RevokeSessionResponse{}, nil
+	}
+}
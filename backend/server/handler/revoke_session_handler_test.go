@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/sean-ahn/user/backend/model"
+	"github.com/sean-ahn/user/backend/server/service"
+	userv1 "github.com/sean-ahn/user/proto/gen/go/user/v1"
+)
+
+func TestRevokeSession(t *testing.T) {
+	cases := []struct {
+		name string
+		req  *userv1.RevokeSessionRequest
+
+		userTokenServiceExpectFunc func(context.Context) func(*service.MockUserTokenService)
+
+		expectedCode codes.Code
+		expectedResp *userv1.RevokeSessionResponse
+		expectedErr  string
+	}{
+		{
+			name: "success",
+			req:  &userv1.RevokeSessionRequest{SessionId: "c4a1b5e6-1f57-4a0b-9d63-0b6f1f1c9f7a"},
+			userTokenServiceExpectFunc: func(ctx context.Context) func(*service.MockUserTokenService) {
+				return func(mock *service.MockUserTokenService) {
+					user := &model.User{UserID: 1}
+
+					mock.EXPECT().
+						GetUser(ctx, "access_token").
+						Return(user, nil)
+
+					mock.EXPECT().
+						RevokeSession(ctx, user, "c4a1b5e6-1f57-4a0b-9d63-0b6f1f1c9f7a").
+						Return(nil)
+				}
+			},
+			expectedCode: codes.OK,
+			expectedResp: &userv1.RevokeSessionResponse{},
+		},
+		{
+			name: "unauthorized",
+			req:  &userv1.RevokeSessionRequest{SessionId: "c4a1b5e6-1f57-4a0b-9d63-0b6f1f1c9f7a"},
+			userTokenServiceExpectFunc: func(ctx context.Context) func(*service.MockUserTokenService) {
+				return func(mock *service.MockUserTokenService) {
+					mock.EXPECT().
+						GetUser(ctx, "access_token").
+						Return(nil, errors.New("invalid token"))
+				}
+			},
+			expectedCode: codes.Unauthenticated,
+			expectedErr:  "rpc error: code = Unauthenticated desc = invalid token",
+		},
+		{
+			name: "no session_id",
+			req:  &userv1.RevokeSessionRequest{},
+			userTokenServiceExpectFunc: func(ctx context.Context) func(*service.MockUserTokenService) {
+				return func(mock *service.MockUserTokenService) {
+					mock.EXPECT().
+						GetUser(ctx, "access_token").
+						Return(&model.User{UserID: 1}, nil)
+				}
+			},
+			expectedCode: codes.InvalidArgument,
+			expectedErr:  "rpc error: code = InvalidArgument desc = no session_id",
+		},
+		{
+			name: "session not found",
+			req:  &userv1.RevokeSessionRequest{SessionId: "c4a1b5e6-1f57-4a0b-9d63-0b6f1f1c9f7a"},
+			userTokenServiceExpectFunc: func(ctx context.Context) func(*service.MockUserTokenService) {
+				return func(mock *service.MockUserTokenService) {
+					user := &model.User{UserID: 1}
+
+					mock.EXPECT().
+						GetUser(ctx, "access_token").
+						Return(user, nil)
+
+					mock.EXPECT().
+						RevokeSession(ctx, user, "c4a1b5e6-1f57-4a0b-9d63-0b6f1f1c9f7a").
+						Return(service.ErrSessionNotFound)
+				}
+			},
+			expectedCode: codes.NotFound,
+			expectedErr:  "rpc error: code = NotFound desc = session not found",
+		},
+		{
+			name: "unexpected error",
+			req:  &userv1.RevokeSessionRequest{SessionId: "c4a1b5e6-1f57-4a0b-9d63-0b6f1f1c9f7a"},
+			userTokenServiceExpectFunc: func(ctx context.Context) func(*service.MockUserTokenService) {
+				return func(mock *service.MockUserTokenService) {
+					user := &model.User{UserID: 1}
+
+					mock.EXPECT().
+						GetUser(ctx, "access_token").
+						Return(user, nil)
+
+					mock.EXPECT().
+						RevokeSession(ctx, user, "c4a1b5e6-1f57-4a0b-9d63-0b6f1f1c9f7a").
+						Return(errors.New("unexpected error"))
+				}
+			},
+			expectedCode: codes.Internal,
+			expectedErr:  "rpc error: code = Internal desc = unexpected error",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{
+				headerKeyAuthorization: "Bearer access_token",
+			}))
+
+			ctrl := gomock.NewController(t)
+
+			mockUserTokenService := service.NewMockUserTokenService(ctrl)
+			if tc.userTokenServiceExpectFunc != nil {
+				tc.userTokenServiceExpectFunc(ctx)(mockUserTokenService)
+			}
+
+			handler := RevokeSession(mockUserTokenService)
+
+			resp, err := handler(ctx, tc.req)
+
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedCode, status.Code(err))
+			assert.Empty(t, cmp.Diff(resp, tc.expectedResp, protocmp.Transform()))
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package handler
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/sean-ahn/user/backend/server/service"
+	userv1 "github.com/sean-ahn/user/proto/gen/go/user/v1"
+)
+
+type RotateSigningKeyHandlerFunc func(ctx context.Context, req *userv1.RotateSigningKeyRequest) (*userv1.RotateSigningKeyResponse, error)
+
+func RotateSigningKey(signingKeyRotator service.SigningKeyRotator) RotateSigningKeyHandlerFunc {
+	return func(ctx context.Context, req *userv1.RotateSigningKeyRequest) (*userv1.RotateSigningKeyResponse, error) {
+		key, err := signingKeyRotator.Rotate(ctx)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+
+		return &userv1.RotateSigningKeyResponse{SigningKey: convertToSigningKey(key)}, nil
+	}
+}
+
+func convertToSigningKey(k *service.SigningKey) *userv1.SigningKey {
+	key := &userv1.SigningKey{
+		Kid:         k.ID,
+		Algorithm:   k.Algorithm,
+		ActivatesAt: timestamppb.New(k.ActivatesAt),
+	}
+	if !k.RetiresAt.IsZero() {
+		key.RetiresAt = timestamppb.New(k.RetiresAt)
+	}
+	return key
+}
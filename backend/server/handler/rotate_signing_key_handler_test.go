@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/sean-ahn/user/backend/server/service"
+	userv1 "github.com/sean-ahn/user/proto/gen/go/user/v1"
+)
+
+func TestRotateSigningKey(t *testing.T) {
+	activatesAt := time.Date(2021, 10, 24, 7, 49, 46, 0, time.UTC)
+
+	cases := []struct {
+		name string
+		req  *userv1.RotateSigningKeyRequest
+
+		signingKeyRotatorExpectFunc func(context.Context) func(*service.MockSigningKeyRotator)
+
+		expectedCode codes.Code
+		expectedResp *userv1.RotateSigningKeyResponse
+		expectedErr  string
+	}{
+		{
+			name: "success",
+			req:  &userv1.RotateSigningKeyRequest{},
+			signingKeyRotatorExpectFunc: func(ctx context.Context) func(*service.MockSigningKeyRotator) {
+				return func(mock *service.MockSigningKeyRotator) {
+					mock.EXPECT().
+						Rotate(ctx).
+						Return(&service.SigningKey{ID: "kid", Algorithm: service.SigningAlgorithmES256, ActivatesAt: activatesAt}, nil)
+				}
+			},
+			expectedCode: codes.OK,
+			expectedResp: &userv1.RotateSigningKeyResponse{
+				SigningKey: &userv1.SigningKey{
+					Kid:         "kid",
+					Algorithm:   service.SigningAlgorithmES256,
+					ActivatesAt: timestamppb.New(activatesAt),
+				},
+			},
+		},
+		{
+			name: "rotation failed",
+			req:  &userv1.RotateSigningKeyRequest{},
+			signingKeyRotatorExpectFunc: func(ctx context.Context) func(*service.MockSigningKeyRotator) {
+				return func(mock *service.MockSigningKeyRotator) {
+					mock.EXPECT().
+						Rotate(ctx).
+						Return(nil, errors.New("unexpected error"))
+				}
+			},
+			expectedCode: codes.Internal,
+			expectedErr:  "rpc error: code = Internal desc = unexpected error",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+
+			mockSigningKeyRotator := service.NewMockSigningKeyRotator(ctrl)
+			if tc.signingKeyRotatorExpectFunc != nil {
+				tc.signingKeyRotatorExpectFunc(ctx)(mockSigningKeyRotator)
+			}
+
+			handler := RotateSigningKey(mockSigningKeyRotator)
+
+			resp, err := handler(ctx, tc.req)
+
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedCode, status.Code(err))
+			assert.Empty(t, cmp.Diff(resp, tc.expectedResp, protocmp.Transform()))
+		})
+	}
+}
@@ -2,13 +2,21 @@ package handler
 
 import (
 	"context"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
+	"net"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/nyaruka/phonenumbers"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 
 	"github.com/sean-ahn/user/backend/crypto"
@@ -27,11 +35,23 @@ const (
 
 const (
 	signInFailureMessage = "id or password incorrect"
+
+	headerKeyUserAgent        = "User-Agent"
+	headerKeyGatewayUserAgent = "Grpcgateway-User-Agent"
+	headerKeyForwardedFor     = "X-Forwarded-For"
+
+	headerKeyAcceptLanguage        = "Accept-Language"
+	headerKeyGatewayAcceptLanguage = "Grpcgateway-Accept-Language"
+
+	maxDeviceLabelLen = 64
+	maxUserAgentLen   = 255
+
+	unknownSignInIDThrottleKeyLen = 45 // of sign_in_throttle.scope_key
 )
 
 type SignInHandlerFunc func(ctx context.Context, req *userv1.SignInRequest) (*userv1.SignInResponse, error)
 
-func SignIn(hasher crypto.Hasher, db *sql.DB, userTokenService service.UserTokenService) SignInHandlerFunc {
+func SignIn(hasher crypto.Hasher, db *sql.DB, userTokenService service.UserTokenService, mfaService service.MFAService, signInThrottle service.SignInThrottle, defaultRegion string, enumerationResistant bool, dummyPasswordHash []byte) SignInHandlerFunc {
 	return func(ctx context.Context, req *userv1.SignInRequest) (*userv1.SignInResponse, error) {
 		if req.Id == "" {
 			return nil, status.Error(codes.InvalidArgument, "no id")
@@ -39,12 +59,20 @@ func SignIn(hasher crypto.Hasher, db *sql.DB, userTokenService service.UserToken
 		if req.Password == "" {
 			return nil, status.Error(codes.InvalidArgument, "no password")
 		}
+		if utf8.RuneCountInString(req.DeviceLabel) > maxDeviceLabelLen {
+			return nil, status.Error(codes.InvalidArgument, "invalid device_label")
+		}
+
+		region, err := resolvePhoneRegion(req.RegionCode, defaultRegion)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid region_code")
+		}
 
 		var (
 			id       = req.Id
 			findByID func(context.Context, boil.ContextExecutor, string) (*model.User, error)
 		)
-		idType := detectIDType(req.Id)
+		idType := detectIDType(req.Id, region)
 		switch idType {
 		case IDTypeEmail:
 			findByID = mysql.FindUserByEmail
@@ -53,7 +81,7 @@ func SignIn(hasher crypto.Hasher, db *sql.DB, userTokenService service.UserToken
 			}
 		case IDTypePhoneNumber:
 			findByID = mysql.FindUserByPhoneNumber
-			norm, err := normalizePhoneNumber(id)
+			norm, err := normalizePhoneNumber(id, region)
 			if err != nil {
 				return nil, status.Error(codes.InvalidArgument, "invalid id format")
 			}
@@ -65,20 +93,48 @@ func SignIn(hasher crypto.Hasher, db *sql.DB, userTokenService service.UserToken
 			return nil, status.Error(codes.Unknown, "should not be here")
 		}
 
+		client := extractClientInfo(ctx)
+		client.DeviceLabel = req.DeviceLabel
+
+		if client.IPAddress != "" {
+			if err := checkSignInThrottle(ctx, signInThrottle, service.SignInThrottleScopeIPAddress, client.IPAddress); err != nil {
+				return nil, err
+			}
+		}
+
 		user, err := findByID(ctx, db, id)
+		if errors.Cause(err) == sql.ErrNoRows && enumerationResistant {
+			// throttled and verified as if the user existed, so that neither the responses nor the latency tell
+			accountKey := unknownSignInIDThrottleKey(id)
+			if err := checkSignInThrottle(ctx, signInThrottle, service.SignInThrottleScopeAccount, accountKey); err != nil {
+				return nil, err
+			}
+			if _, err := hasher.Verify(dummyPasswordHash, []byte(req.Password)); err != nil {
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+			recordSignInFailure(ctx, signInThrottle, client.IPAddress, accountKey)
+			return nil, status.Error(codes.Unauthenticated, signInFailureMessage)
+		}
 		if errors.Cause(err) == sql.ErrNoRows {
+			recordSignInFailure(ctx, signInThrottle, client.IPAddress, "")
 			return nil, status.Error(codes.Unauthenticated, signInFailureMessage) // for security reason
 		}
 		if err != nil {
 			return nil, status.Error(codes.Internal, err.Error())
 		}
 
-		passwordHash, err := hasher.Hash([]byte(req.Password))
+		// checked before the password so that a locked out account gives no hint whether a guess is right
+		if err := checkSignInThrottle(ctx, signInThrottle, service.SignInThrottleScopeAccount, strconv.Itoa(user.UserID)); err != nil {
+			return nil, err
+		}
+
+		ok, err := hasher.Verify([]byte(user.PasswordHash), []byte(req.Password))
 		if err != nil {
 			return nil, status.Error(codes.Internal, err.Error())
 		}
 
-		if user.PasswordHash != string(passwordHash) {
+		if !ok {
+			recordSignInFailure(ctx, signInThrottle, client.IPAddress, strconv.Itoa(user.UserID))
 			return nil, status.Error(codes.Unauthenticated, signInFailureMessage)
 		}
 
@@ -86,17 +142,87 @@ func SignIn(hasher crypto.Hasher, db *sql.DB, userTokenService service.UserToken
 			return nil, status.Error(codes.Unauthenticated, "email not verified yet")
 		}
 
-		accessToken, refreshToken, err := userTokenService.Issue(ctx, user)
+		if hasher.NeedsRehash([]byte(user.PasswordHash)) {
+			rehashPassword(ctx, db, hasher, user, req.Password)
+		}
+
+		methods, err := mfaService.Methods(ctx, user)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		if len(methods) > 0 {
+			challengeToken, err := mfaService.CreateChallenge(ctx, user, req.DeviceLabel)
+			if err != nil {
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+			// the account is reset once the second factor is verified as well
+			return &userv1.SignInResponse{MfaChallengeToken: challengeToken, MfaMethods: methods}, nil
+		}
+
+		// only the account is reset, or a client could keep guessing the others from the IP address with its own one
+		if err := signInThrottle.Reset(ctx, db, service.SignInThrottleScopeAccount, strconv.Itoa(user.UserID)); err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+
+		accessToken, refreshToken, err := userTokenService.Issue(ctx, user, client)
 		if err != nil {
 			return nil, status.Error(codes.Internal, err.Error())
 		}
 
-		return &userv1.SignInResponse{AccessToken: accessToken, RefreshToken: refreshToken}, nil // TODO: Add token
+		return &userv1.SignInResponse{AccessToken: accessToken, RefreshToken: refreshToken}, nil
+	}
+}
+
+// checkSignInThrottle returns the status to reject the sign-in with if it is blocked.
+func checkSignInThrottle(ctx context.Context, signInThrottle service.SignInThrottle, scope, key string) error {
+	if err := signInThrottle.Check(ctx, scope, key); err != nil {
+		if rateLimitedErr, ok := errors.Cause(err).(*service.RateLimitedError); ok {
+			return rateLimitedStatus(rateLimitedErr).Err()
+		}
+		return status.Error(codes.Internal, err.Error())
+	}
+	return nil
+}
+
+// recordSignInFailure counts the failure against the IP address if known, and the account if given.
+// Failures are only logged since the sign-in fails anyway.
+func recordSignInFailure(ctx context.Context, signInThrottle service.SignInThrottle, ipAddress, accountKey string) {
+	if ipAddress != "" {
+		if err := signInThrottle.Fail(ctx, service.SignInThrottleScopeIPAddress, ipAddress); err != nil {
+			logrus.WithError(err).Warn("failed to record sign-in failure")
+		}
 	}
+	if accountKey != "" {
+		if err := signInThrottle.Fail(ctx, service.SignInThrottleScopeAccount, accountKey); err != nil {
+			logrus.WithError(err).Warn("failed to record sign-in failure")
+		}
+	}
+}
+
+// unknownSignInIDThrottleKey returns the account key to throttle the sign-ins with the id no user has, which is a
+// digest of the id to fit in the key, prefixed not to be taken for a user ID.
+func unknownSignInIDThrottleKey(id string) string {
+	h := sha256.Sum256([]byte(id))
+	return "#" + hex.EncodeToString(h[:])[:unknownSignInIDThrottleKeyLen-1]
 }
 
-func detectIDType(id string) IDType {
-	if p, err := phonenumbers.Parse(id, "KR"); err == nil && phonenumbers.IsValidNumber(p) {
+// rehashPassword upgrades the password hash produced by an outdated algorithm or with weaker parameters.
+// Failures are only logged since the user can still sign in with the old hash.
+func rehashPassword(ctx context.Context, db *sql.DB, hasher crypto.Hasher, user *model.User, password string) {
+	passwordHash, err := hasher.Hash([]byte(password))
+	if err != nil {
+		logrus.WithError(err).Warn("failed to rehash password")
+		return
+	}
+
+	user.PasswordHash = string(passwordHash)
+	if _, err := user.Update(ctx, db, boil.Whitelist(model.UserColumns.PasswordHash)); err != nil {
+		logrus.WithError(err).Warn("failed to rehash password")
+	}
+}
+
+func detectIDType(id, region string) IDType {
+	if p, err := phonenumbers.Parse(id, region); err == nil && phonenumbers.IsValidNumber(p) {
 		return IDTypePhoneNumber
 	}
 	if strings.Contains(id, "@") { // TODO: Use regexp
@@ -104,3 +230,50 @@ func detectIDType(id string) IDType {
 	}
 	return 0
 }
+
+// extractAcceptLanguage returns the Accept-Language header of the request, which is empty if not given.
+func extractAcceptLanguage(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if acceptLanguage := md.Get(headerKeyGatewayAcceptLanguage); len(acceptLanguage) > 0 {
+		return acceptLanguage[0]
+	}
+	if acceptLanguage := md.Get(headerKeyAcceptLanguage); len(acceptLanguage) > 0 {
+		return acceptLanguage[0]
+	}
+	return ""
+}
+
+func extractClientInfo(ctx context.Context) service.ClientInfo {
+	var client service.ClientInfo
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if userAgent := md.Get(headerKeyGatewayUserAgent); len(userAgent) > 0 {
+			client.UserAgent = userAgent[0]
+		} else if userAgent := md.Get(headerKeyUserAgent); len(userAgent) > 0 {
+			client.UserAgent = userAgent[0]
+		}
+
+		// the interceptor has replaced it with the client address resolved through the trusted proxies.
+		if forwardedFor := md.Get(headerKeyForwardedFor); len(forwardedFor) > 0 {
+			addrs := strings.Split(forwardedFor[len(forwardedFor)-1], ",")
+			client.IPAddress = strings.TrimSpace(addrs[len(addrs)-1])
+		}
+	}
+
+	if client.IPAddress == "" {
+		if p, ok := peer.FromContext(ctx); ok {
+			if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+				client.IPAddress = host
+			}
+		}
+	}
+
+	if utf8.RuneCountInString(client.UserAgent) > maxUserAgentLen {
+		client.UserAgent = string([]rune(client.UserAgent)[:maxUserAgentLen])
+	}
+
+	return client
+}
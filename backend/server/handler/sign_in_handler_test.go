@@ -4,15 +4,21 @@ import (
 	"context"
 	"database/sql"
 	"regexp"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/durationpb"
 
 	"github.com/sean-ahn/user/backend/model"
 	"github.com/sean-ahn/user/backend/server/service"
@@ -26,21 +32,49 @@ func (h *testHasher) Hash(s []byte) ([]byte, error) {
 	return []byte(string(s) + "_hash"), nil
 }
 
+func (h *testHasher) Verify(hash, s []byte) (bool, error) {
+	return string(hash) == string(s)+"_hash" || string(hash) == string(s)+"_legacy_hash", nil
+}
+
+func (h *testHasher) NeedsRehash(hash []byte) bool {
+	return strings.HasSuffix(string(hash), "_legacy_hash")
+}
+
 func TestSignIn(t *testing.T) {
+	// the sign-in of the user 1 is not blocked, and resets the failures once the password is verified
+	signInThrottleNotBlocked := func(ctx context.Context) func(*service.MockSignInThrottle) {
+		return func(mock *service.MockSignInThrottle) {
+			mock.EXPECT().Check(ctx, service.SignInThrottleScopeIPAddress, "198.51.100.7").Return(nil)
+			mock.EXPECT().Check(ctx, service.SignInThrottleScopeAccount, "1").Return(nil)
+			mock.EXPECT().Reset(ctx, gomock.Any(), service.SignInThrottleScopeAccount, "1").Return(nil)
+		}
+	}
+	// the failures are kept until the sign-in completes, e.g. by the second factor
+	signInThrottleNotCompleted := func(ctx context.Context) func(*service.MockSignInThrottle) {
+		return func(mock *service.MockSignInThrottle) {
+			mock.EXPECT().Check(ctx, service.SignInThrottleScopeIPAddress, "198.51.100.7").Return(nil)
+			mock.EXPECT().Check(ctx, service.SignInThrottleScopeAccount, "1").Return(nil)
+		}
+	}
+
 	cases := []struct {
 		name string
 		req  *userv1.SignInRequest
 
 		dbExpectFunc               func(sqlmock.Sqlmock)
+		signInThrottleExpectFunc   func(context.Context) func(*service.MockSignInThrottle)
+		mfaServiceExpectFunc       func(context.Context) func(*service.MockMFAService)
 		userTokenServiceExpectFunc func(context.Context) func(*service.MockUserTokenService)
+		enumerationResistant       bool
 
-		expectedCode codes.Code
-		expectedResp *userv1.SignInResponse
-		expectedErr  string
+		expectedCode    codes.Code
+		expectedResp    *userv1.SignInResponse
+		expectedErr     string
+		expectedDetails []interface{}
 	}{
 		{
 			name: "sign in with phone number",
-			req:  &userv1.SignInRequest{Id: "821012345678", Password: "P@ssw0rd"},
+			req:  &userv1.SignInRequest{Id: "821012345678", Password: "P@ssw0rd", DeviceLabel: "Chrome on macOS"},
 			dbExpectFunc: func(m sqlmock.Sqlmock) {
 				m.ExpectQuery(regexp.QuoteMeta(
 					"SELECT * FROM `user` WHERE (`user`.`phone_number` = ?) LIMIT 1;",
@@ -50,10 +84,44 @@ func TestSignIn(t *testing.T) {
 					{UserID: 1, Email: "john.doe@example.com", IsEmailConfirmed: true, PhoneNumber: "+821012345678", PasswordHash: "P@ssw0rd_hash"},
 				}))
 			},
+			signInThrottleExpectFunc: signInThrottleNotBlocked,
+			mfaServiceExpectFunc: func(ctx context.Context) func(*service.MockMFAService) {
+				return func(mock *service.MockMFAService) {
+					mock.EXPECT().Methods(ctx, gomock.Any()).Return(nil, nil)
+				}
+			},
 			userTokenServiceExpectFunc: func(ctx context.Context) func(*service.MockUserTokenService) {
 				return func(mock *service.MockUserTokenService) {
 					mock.EXPECT().
-						Issue(ctx, &model.User{UserID: 1, Email: "john.doe@example.com", IsEmailConfirmed: true, PhoneNumber: "+821012345678", PasswordHash: "P@ssw0rd_hash"}).
+						Issue(ctx, &model.User{UserID: 1, Email: "john.doe@example.com", IsEmailConfirmed: true, PhoneNumber: "+821012345678", PasswordHash: "P@ssw0rd_hash"}, service.ClientInfo{DeviceLabel: "Chrome on macOS", UserAgent: "Mozilla/5.0", IPAddress: "198.51.100.7"}).
+						Return("access_token", "refresh_token", nil)
+				}
+			},
+			expectedCode: codes.OK,
+			expectedResp: &userv1.SignInResponse{AccessToken: "access_token", RefreshToken: "refresh_token"},
+		},
+		{
+			name: "sign in with phone number of region",
+			req:  &userv1.SignInRequest{Id: "09012345678", RegionCode: "JP", Password: "P@ssw0rd", DeviceLabel: "Chrome on macOS"},
+			dbExpectFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user` WHERE (`user`.`phone_number` = ?) LIMIT 1;",
+				)).WithArgs(
+					"+819012345678",
+				).WillReturnRows(test.NewUserRows([]*model.User{
+					{UserID: 1, Email: "john.doe@example.com", IsEmailConfirmed: true, PhoneNumber: "+819012345678", PasswordHash: "P@ssw0rd_hash"},
+				}))
+			},
+			signInThrottleExpectFunc: signInThrottleNotBlocked,
+			mfaServiceExpectFunc: func(ctx context.Context) func(*service.MockMFAService) {
+				return func(mock *service.MockMFAService) {
+					mock.EXPECT().Methods(ctx, gomock.Any()).Return(nil, nil)
+				}
+			},
+			userTokenServiceExpectFunc: func(ctx context.Context) func(*service.MockUserTokenService) {
+				return func(mock *service.MockUserTokenService) {
+					mock.EXPECT().
+						Issue(ctx, &model.User{UserID: 1, Email: "john.doe@example.com", IsEmailConfirmed: true, PhoneNumber: "+819012345678", PasswordHash: "P@ssw0rd_hash"}, service.ClientInfo{DeviceLabel: "Chrome on macOS", UserAgent: "Mozilla/5.0", IPAddress: "198.51.100.7"}).
 						Return("access_token", "refresh_token", nil)
 				}
 			},
@@ -72,10 +140,16 @@ func TestSignIn(t *testing.T) {
 					{UserID: 1, Email: "john.doe@example.com", IsEmailConfirmed: true, PhoneNumber: "+821012345678", PasswordHash: "P@ssw0rd_hash"},
 				}))
 			},
+			signInThrottleExpectFunc: signInThrottleNotBlocked,
+			mfaServiceExpectFunc: func(ctx context.Context) func(*service.MockMFAService) {
+				return func(mock *service.MockMFAService) {
+					mock.EXPECT().Methods(ctx, gomock.Any()).Return(nil, nil)
+				}
+			},
 			userTokenServiceExpectFunc: func(ctx context.Context) func(*service.MockUserTokenService) {
 				return func(mock *service.MockUserTokenService) {
 					mock.EXPECT().
-						Issue(ctx, &model.User{UserID: 1, Email: "john.doe@example.com", IsEmailConfirmed: true, PhoneNumber: "+821012345678", PasswordHash: "P@ssw0rd_hash"}).
+						Issue(ctx, &model.User{UserID: 1, Email: "john.doe@example.com", IsEmailConfirmed: true, PhoneNumber: "+821012345678", PasswordHash: "P@ssw0rd_hash"}, service.ClientInfo{UserAgent: "Mozilla/5.0", IPAddress: "198.51.100.7"}).
 						Return("access_token", "refresh_token", nil)
 				}
 			},
@@ -94,16 +168,117 @@ func TestSignIn(t *testing.T) {
 					{UserID: 1, Email: "john.doe@example.com", IsEmailConfirmed: false, PhoneNumber: "+821012345678", PasswordHash: "P@ssw0rd_hash"},
 				}))
 			},
+			signInThrottleExpectFunc: signInThrottleNotBlocked,
+			mfaServiceExpectFunc: func(ctx context.Context) func(*service.MockMFAService) {
+				return func(mock *service.MockMFAService) {
+					mock.EXPECT().Methods(ctx, gomock.Any()).Return(nil, nil)
+				}
+			},
 			userTokenServiceExpectFunc: func(ctx context.Context) func(*service.MockUserTokenService) {
 				return func(mock *service.MockUserTokenService) {
 					mock.EXPECT().
-						Issue(ctx, &model.User{UserID: 1, Email: "john.doe@example.com", IsEmailConfirmed: false, PhoneNumber: "+821012345678", PasswordHash: "P@ssw0rd_hash"}).
+						Issue(ctx, &model.User{UserID: 1, Email: "john.doe@example.com", IsEmailConfirmed: false, PhoneNumber: "+821012345678", PasswordHash: "P@ssw0rd_hash"}, service.ClientInfo{UserAgent: "Mozilla/5.0", IPAddress: "198.51.100.7"}).
 						Return("access_token", "refresh_token", nil)
 				}
 			},
 			expectedCode: codes.OK,
 			expectedResp: &userv1.SignInResponse{AccessToken: "access_token", RefreshToken: "refresh_token"},
 		},
+		{
+			name: "sign in with outdated password hash",
+			req:  &userv1.SignInRequest{Id: "+821012345678", Password: "P@ssw0rd"},
+			dbExpectFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user` WHERE (`user`.`phone_number` = ?) LIMIT 1;",
+				)).WithArgs(
+					"+821012345678",
+				).WillReturnRows(test.NewUserRows([]*model.User{
+					{UserID: 1, Email: "john.doe@example.com", IsEmailConfirmed: true, PhoneNumber: "+821012345678", PasswordHash: "P@ssw0rd_legacy_hash"},
+				}))
+
+				m.ExpectExec(regexp.QuoteMeta(
+					"UPDATE `user` SET `password_hash`=? WHERE `user_id`=?",
+				)).WithArgs(
+					"P@ssw0rd_hash", 1,
+				).WillReturnResult(
+					sqlmock.NewResult(0, 1),
+				)
+			},
+			signInThrottleExpectFunc: signInThrottleNotBlocked,
+			mfaServiceExpectFunc: func(ctx context.Context) func(*service.MockMFAService) {
+				return func(mock *service.MockMFAService) {
+					mock.EXPECT().Methods(ctx, gomock.Any()).Return(nil, nil)
+				}
+			},
+			userTokenServiceExpectFunc: func(ctx context.Context) func(*service.MockUserTokenService) {
+				return func(mock *service.MockUserTokenService) {
+					mock.EXPECT().
+						Issue(ctx, &model.User{UserID: 1, Email: "john.doe@example.com", IsEmailConfirmed: true, PhoneNumber: "+821012345678", PasswordHash: "P@ssw0rd_hash"}, service.ClientInfo{UserAgent: "Mozilla/5.0", IPAddress: "198.51.100.7"}).
+						Return("access_token", "refresh_token", nil)
+				}
+			},
+			expectedCode: codes.OK,
+			expectedResp: &userv1.SignInResponse{AccessToken: "access_token", RefreshToken: "refresh_token"},
+		},
+		{
+			name: "sign in with outdated password hash but rehash failed",
+			req:  &userv1.SignInRequest{Id: "+821012345678", Password: "P@ssw0rd"},
+			dbExpectFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user` WHERE (`user`.`phone_number` = ?) LIMIT 1;",
+				)).WithArgs(
+					"+821012345678",
+				).WillReturnRows(test.NewUserRows([]*model.User{
+					{UserID: 1, Email: "john.doe@example.com", IsEmailConfirmed: true, PhoneNumber: "+821012345678", PasswordHash: "P@ssw0rd_legacy_hash"},
+				}))
+
+				m.ExpectExec(regexp.QuoteMeta(
+					"UPDATE `user` SET `password_hash`=? WHERE `user_id`=?",
+				)).WithArgs(
+					"P@ssw0rd_hash", 1,
+				).WillReturnError(
+					errors.New("unexpected error"),
+				)
+			},
+			signInThrottleExpectFunc: signInThrottleNotBlocked,
+			mfaServiceExpectFunc: func(ctx context.Context) func(*service.MockMFAService) {
+				return func(mock *service.MockMFAService) {
+					mock.EXPECT().Methods(ctx, gomock.Any()).Return(nil, nil)
+				}
+			},
+			userTokenServiceExpectFunc: func(ctx context.Context) func(*service.MockUserTokenService) {
+				return func(mock *service.MockUserTokenService) {
+					mock.EXPECT().
+						Issue(ctx, gomock.Any(), service.ClientInfo{UserAgent: "Mozilla/5.0", IPAddress: "198.51.100.7"}).
+						Return("access_token", "refresh_token", nil)
+				}
+			},
+			expectedCode: codes.OK,
+			expectedResp: &userv1.SignInResponse{AccessToken: "access_token", RefreshToken: "refresh_token"},
+		},
+		{
+			name: "sign in with 2FA enabled",
+			req:  &userv1.SignInRequest{Id: "821012345678", Password: "P@ssw0rd", DeviceLabel: "Chrome on macOS"},
+			dbExpectFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user` WHERE (`user`.`phone_number` = ?) LIMIT 1;",
+				)).WithArgs(
+					"+821012345678",
+				).WillReturnRows(test.NewUserRows([]*model.User{
+					{UserID: 1, Email: "john.doe@example.com", IsEmailConfirmed: true, PhoneNumber: "+821012345678", PasswordHash: "P@ssw0rd_hash"},
+				}))
+			},
+			signInThrottleExpectFunc: signInThrottleNotCompleted,
+			mfaServiceExpectFunc: func(ctx context.Context) func(*service.MockMFAService) {
+				return func(mock *service.MockMFAService) {
+					user := &model.User{UserID: 1, Email: "john.doe@example.com", IsEmailConfirmed: true, PhoneNumber: "+821012345678", PasswordHash: "P@ssw0rd_hash"}
+					mock.EXPECT().Methods(ctx, user).Return([]string{service.MFAMethodTOTP}, nil)
+					mock.EXPECT().CreateChallenge(ctx, user, "Chrome on macOS").Return("mfa_challenge_token", nil)
+				}
+			},
+			expectedCode: codes.OK,
+			expectedResp: &userv1.SignInResponse{MfaChallengeToken: "mfa_challenge_token", MfaMethods: []string{"totp"}},
+		},
 		{
 			name: "sign in with unverified email",
 			req:  &userv1.SignInRequest{Id: "john.doe@example.com", Password: "P@ssw0rd"},
@@ -116,8 +291,9 @@ func TestSignIn(t *testing.T) {
 					{UserID: 1, Email: "john.doe@example.com", IsEmailConfirmed: false, PhoneNumber: "+821012345678", PasswordHash: "P@ssw0rd_hash"},
 				}))
 			},
-			expectedCode: codes.Unauthenticated,
-			expectedErr:  "rpc error: code = Unauthenticated desc = email not verified yet",
+			signInThrottleExpectFunc: signInThrottleNotCompleted,
+			expectedCode:             codes.Unauthenticated,
+			expectedErr:              "rpc error: code = Unauthenticated desc = email not verified yet",
 		},
 		{
 			name: "sign in with not existing email",
@@ -131,9 +307,64 @@ func TestSignIn(t *testing.T) {
 					sql.ErrNoRows,
 				)
 			},
+			signInThrottleExpectFunc: func(ctx context.Context) func(*service.MockSignInThrottle) {
+				return func(mock *service.MockSignInThrottle) {
+					mock.EXPECT().Check(ctx, service.SignInThrottleScopeIPAddress, "198.51.100.7").Return(nil)
+					mock.EXPECT().Fail(ctx, service.SignInThrottleScopeIPAddress, "198.51.100.7").Return(nil)
+				}
+			},
 			expectedCode: codes.Unauthenticated,
 			expectedErr:  "rpc error: code = Unauthenticated desc = id or password incorrect",
 		},
+		{
+			name: "sign in with not existing email when enumeration resistant",
+			req:  &userv1.SignInRequest{Id: "john.doe@example.com", Password: "P@ssw0rd"},
+			dbExpectFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user` WHERE (`user`.`email` = ?) LIMIT 1;",
+				)).WithArgs(
+					"john.doe@example.com",
+				).WillReturnError(
+					sql.ErrNoRows,
+				)
+			},
+			signInThrottleExpectFunc: func(ctx context.Context) func(*service.MockSignInThrottle) {
+				return func(mock *service.MockSignInThrottle) {
+					mock.EXPECT().Check(ctx, service.SignInThrottleScopeIPAddress, "198.51.100.7").Return(nil)
+					mock.EXPECT().Check(ctx, service.SignInThrottleScopeAccount, "#836f82db99121b3481011f16b49dfa5fbc714a0d1b1b").Return(nil)
+					mock.EXPECT().Fail(ctx, service.SignInThrottleScopeIPAddress, "198.51.100.7").Return(nil)
+					mock.EXPECT().Fail(ctx, service.SignInThrottleScopeAccount, "#836f82db99121b3481011f16b49dfa5fbc714a0d1b1b").Return(nil)
+				}
+			},
+			enumerationResistant: true,
+			expectedCode:         codes.Unauthenticated,
+			expectedErr:          "rpc error: code = Unauthenticated desc = id or password incorrect",
+		},
+		{
+			name: "sign in to locked out not existing email when enumeration resistant",
+			req:  &userv1.SignInRequest{Id: "john.doe@example.com", Password: "P@ssw0rd"},
+			dbExpectFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user` WHERE (`user`.`email` = ?) LIMIT 1;",
+				)).WithArgs(
+					"john.doe@example.com",
+				).WillReturnError(
+					sql.ErrNoRows,
+				)
+			},
+			signInThrottleExpectFunc: func(ctx context.Context) func(*service.MockSignInThrottle) {
+				return func(mock *service.MockSignInThrottle) {
+					mock.EXPECT().Check(ctx, service.SignInThrottleScopeIPAddress, "198.51.100.7").Return(nil)
+					mock.EXPECT().
+						Check(ctx, service.SignInThrottleScopeAccount, "#836f82db99121b3481011f16b49dfa5fbc714a0d1b1b").
+						Return(errors.WithStack(&service.RateLimitedError{Limit: service.SignInLimitLockout, RetryAfter: time.Hour}))
+				}
+			},
+			enumerationResistant: true,
+			expectedCode:         codes.ResourceExhausted,
+			expectedErr:          "rpc error: code = ResourceExhausted desc = rate limited by sign_in_lockout",
+			expectedDetails:      []interface{}{&errdetails.RetryInfo{RetryDelay: durationpb.New(time.Hour)}},
+		},
 		{
 			name: "sign in with incorrect password",
 			req:  &userv1.SignInRequest{Id: "+821012345678", Password: "qwerty123"},
@@ -146,9 +377,97 @@ func TestSignIn(t *testing.T) {
 					{UserID: 1, Email: "john.doe@example.com", IsEmailConfirmed: true, PhoneNumber: "+821012345678", PasswordHash: "P@ssw0rd_hash"},
 				}))
 			},
+			signInThrottleExpectFunc: func(ctx context.Context) func(*service.MockSignInThrottle) {
+				return func(mock *service.MockSignInThrottle) {
+					mock.EXPECT().Check(ctx, service.SignInThrottleScopeIPAddress, "198.51.100.7").Return(nil)
+					mock.EXPECT().Check(ctx, service.SignInThrottleScopeAccount, "1").Return(nil)
+					mock.EXPECT().Fail(ctx, service.SignInThrottleScopeIPAddress, "198.51.100.7").Return(nil)
+					mock.EXPECT().Fail(ctx, service.SignInThrottleScopeAccount, "1").Return(nil)
+				}
+			},
 			expectedCode: codes.Unauthenticated,
 			expectedErr:  "rpc error: code = Unauthenticated desc = id or password incorrect",
 		},
+		{
+			name: "sign in with incorrect password but failed to record",
+			req:  &userv1.SignInRequest{Id: "+821012345678", Password: "qwerty123"},
+			dbExpectFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user` WHERE (`user`.`phone_number` = ?) LIMIT 1;",
+				)).WithArgs(
+					"+821012345678",
+				).WillReturnRows(test.NewUserRows([]*model.User{
+					{UserID: 1, Email: "john.doe@example.com", IsEmailConfirmed: true, PhoneNumber: "+821012345678", PasswordHash: "P@ssw0rd_hash"},
+				}))
+			},
+			signInThrottleExpectFunc: func(ctx context.Context) func(*service.MockSignInThrottle) {
+				return func(mock *service.MockSignInThrottle) {
+					mock.EXPECT().Check(ctx, service.SignInThrottleScopeIPAddress, "198.51.100.7").Return(nil)
+					mock.EXPECT().Check(ctx, service.SignInThrottleScopeAccount, "1").Return(nil)
+					mock.EXPECT().Fail(ctx, service.SignInThrottleScopeIPAddress, "198.51.100.7").Return(errors.New("unexpected error"))
+					mock.EXPECT().Fail(ctx, service.SignInThrottleScopeAccount, "1").Return(errors.New("unexpected error"))
+				}
+			},
+			expectedCode: codes.Unauthenticated,
+			expectedErr:  "rpc error: code = Unauthenticated desc = id or password incorrect",
+		},
+		{
+			name: "sign in from blocked ip address",
+			req:  &userv1.SignInRequest{Id: "+821012345678", Password: "P@ssw0rd"},
+			signInThrottleExpectFunc: func(ctx context.Context) func(*service.MockSignInThrottle) {
+				return func(mock *service.MockSignInThrottle) {
+					mock.EXPECT().
+						Check(ctx, service.SignInThrottleScopeIPAddress, "198.51.100.7").
+						Return(errors.WithStack(&service.RateLimitedError{Limit: service.SignInLimitDelay, RetryAfter: 4 * time.Second}))
+				}
+			},
+			expectedCode:    codes.ResourceExhausted,
+			expectedErr:     "rpc error: code = ResourceExhausted desc = rate limited by sign_in_delay",
+			expectedDetails: []interface{}{&errdetails.RetryInfo{RetryDelay: durationpb.New(4 * time.Second)}},
+		},
+		{
+			name: "sign in to locked out account",
+			req:  &userv1.SignInRequest{Id: "+821012345678", Password: "P@ssw0rd"},
+			dbExpectFunc: func(m sqlmock.Sqlmock) {
+				m.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user` WHERE (`user`.`phone_number` = ?) LIMIT 1;",
+				)).WithArgs(
+					"+821012345678",
+				).WillReturnRows(test.NewUserRows([]*model.User{
+					{UserID: 1, Email: "john.doe@example.com", IsEmailConfirmed: true, PhoneNumber: "+821012345678", PasswordHash: "P@ssw0rd_hash"},
+				}))
+			},
+			signInThrottleExpectFunc: func(ctx context.Context) func(*service.MockSignInThrottle) {
+				return func(mock *service.MockSignInThrottle) {
+					mock.EXPECT().Check(ctx, service.SignInThrottleScopeIPAddress, "198.51.100.7").Return(nil)
+					mock.EXPECT().
+						Check(ctx, service.SignInThrottleScopeAccount, "1").
+						Return(errors.WithStack(&service.RateLimitedError{Limit: service.SignInLimitLockout, RetryAfter: time.Hour}))
+				}
+			},
+			expectedCode:    codes.ResourceExhausted,
+			expectedErr:     "rpc error: code = ResourceExhausted desc = rate limited by sign_in_lockout",
+			expectedDetails: []interface{}{&errdetails.RetryInfo{RetryDelay: durationpb.New(time.Hour)}},
+		},
+		{
+			name: "fail to check throttle",
+			req:  &userv1.SignInRequest{Id: "+821012345678", Password: "P@ssw0rd"},
+			signInThrottleExpectFunc: func(ctx context.Context) func(*service.MockSignInThrottle) {
+				return func(mock *service.MockSignInThrottle) {
+					mock.EXPECT().
+						Check(ctx, service.SignInThrottleScopeIPAddress, "198.51.100.7").
+						Return(errors.New("unexpected error"))
+				}
+			},
+			expectedCode: codes.Internal,
+			expectedErr:  "rpc error: code = Internal desc = unexpected error",
+		},
+		{
+			name:         "invalid region code",
+			req:          &userv1.SignInRequest{Id: "09012345678", Password: "P@ssw0rd", RegionCode: "XX"},
+			expectedCode: codes.InvalidArgument,
+			expectedErr:  "rpc error: code = InvalidArgument desc = invalid region_code",
+		},
 		{
 			name:         "no id",
 			req:          &userv1.SignInRequest{Password: "P@ssw0rd"},
@@ -161,13 +480,22 @@ func TestSignIn(t *testing.T) {
 			expectedCode: codes.InvalidArgument,
 			expectedErr:  "rpc error: code = InvalidArgument desc = no password",
 		},
+		{
+			name:         "too long device label",
+			req:          &userv1.SignInRequest{Id: "821012345678", Password: "P@ssw0rd", DeviceLabel: strings.Repeat("a", 65)},
+			expectedCode: codes.InvalidArgument,
+			expectedErr:  "rpc error: code = InvalidArgument desc = invalid device_label",
+		},
 	}
 
 	for _, tc := range cases {
 		tc := tc
 
 		t.Run(tc.name, func(t *testing.T) {
-			ctx := context.Background()
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{
+				headerKeyGatewayUserAgent: "Mozilla/5.0",
+				headerKeyForwardedFor:     "203.0.113.1, 198.51.100.7",
+			}))
 
 			ctrl := gomock.NewController(t)
 
@@ -185,7 +513,17 @@ func TestSignIn(t *testing.T) {
 				tc.userTokenServiceExpectFunc(ctx)(mockUserTokenService)
 			}
 
-			handler := SignIn(&testHasher{}, db, mockUserTokenService)
+			mockMFAService := service.NewMockMFAService(ctrl)
+			if tc.mfaServiceExpectFunc != nil {
+				tc.mfaServiceExpectFunc(ctx)(mockMFAService)
+			}
+
+			mockSignInThrottle := service.NewMockSignInThrottle(ctrl)
+			if tc.signInThrottleExpectFunc != nil {
+				tc.signInThrottleExpectFunc(ctx)(mockSignInThrottle)
+			}
+
+			handler := SignIn(&testHasher{}, db, mockUserTokenService, mockMFAService, mockSignInThrottle, "KR", tc.enumerationResistant, []byte("dummy_hash"))
 
 			resp, err := handler(ctx, tc.req)
 
@@ -196,6 +534,9 @@ func TestSignIn(t *testing.T) {
 			}
 			assert.Equal(t, tc.expectedCode, status.Code(err))
 			assert.Empty(t, cmp.Diff(resp, tc.expectedResp, protocmp.Transform()))
+			if tc.expectedDetails != nil {
+				assert.Empty(t, cmp.Diff(status.Convert(err).Details(), tc.expectedDetails, protocmp.Transform()))
+			}
 		})
 	}
 }
@@ -203,20 +544,23 @@ func TestSignIn(t *testing.T) {
 func Test_detectIDType(t *testing.T) {
 	cases := []struct {
 		given    string
+		region   string
 		expected IDType
 	}{
-		{given: "@", expected: IDTypeEmail},
-		{given: "821012345678", expected: IDTypePhoneNumber},
-		{given: "1012345678", expected: IDTypePhoneNumber},
-		{given: "abc123", expected: 0},
-		{given: "1234", expected: 0},
+		{given: "@", region: "KR", expected: IDTypeEmail},
+		{given: "821012345678", region: "KR", expected: IDTypePhoneNumber},
+		{given: "1012345678", region: "KR", expected: IDTypePhoneNumber},
+		{given: "09012345678", region: "JP", expected: IDTypePhoneNumber},
+		{given: "+819012345678", region: "KR", expected: IDTypePhoneNumber},
+		{given: "abc123", region: "KR", expected: 0},
+		{given: "1234", region: "KR", expected: 0},
 	}
 
 	for _, tc := range cases {
 		tc := tc
 
 		t.Run(tc.given, func(t *testing.T) {
-			got := detectIDType(tc.given)
+			got := detectIDType(tc.given, tc.region)
 
 			assert.Equal(t, tc.expected, got)
 		})
@@ -225,18 +569,30 @@ func Test_detectIDType(t *testing.T) {
 
 func Test_normalizePhoneNumber(t *testing.T) {
 	cases := []struct {
-		given, expected string
+		given, region string
+
+		expected    string
+		expectedErr bool
 	}{
-		{given: "1012345678", expected: "+821012345678"},
-		{given: "821012345678", expected: "+821012345678"},
+		{given: "1012345678", region: "KR", expected: "+821012345678"},
+		{given: "821012345678", region: "KR", expected: "+821012345678"},
+		{given: "09012345678", region: "JP", expected: "+819012345678"},
+		{given: "+819012345678", region: "KR", expected: "+819012345678"},
+		{given: "+819012345678", region: "", expected: "+819012345678"},
+		{given: "09012345678", region: "", expectedErr: true},
+		{given: "1234", region: "KR", expectedErr: true},
 	}
 
 	for _, tc := range cases {
 		tc := tc
 
-		t.Run(tc.given, func(t *testing.T) {
-			normalized, err := normalizePhoneNumber(tc.given)
+		t.Run(tc.given+"@"+tc.region, func(t *testing.T) {
+			normalized, err := normalizePhoneNumber(tc.given, tc.region)
 
+			if tc.expectedErr {
+				assert.Error(t, err)
+				return
+			}
 			assert.NoError(t, err)
 			assert.Equal(t, tc.expected, normalized)
 		})
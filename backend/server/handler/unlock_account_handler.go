@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/sean-ahn/user/backend/model"
+	"github.com/sean-ahn/user/backend/persistence/mysql"
+	"github.com/sean-ahn/user/backend/server/service"
+	userv1 "github.com/sean-ahn/user/proto/gen/go/user/v1"
+)
+
+type UnlockAccountHandlerFunc func(ctx context.Context, req *userv1.UnlockAccountRequest) (*userv1.UnlockAccountResponse, error)
+
+func UnlockAccount(clock clockwork.Clock, db *sql.DB, signInThrottle service.SignInThrottle, enumerationResistant bool) UnlockAccountHandlerFunc {
+	return func(ctx context.Context, req *userv1.UnlockAccountRequest) (*userv1.UnlockAccountResponse, error) {
+		now := clock.Now()
+
+		if req.VerificationToken == "" {
+			return nil, status.Error(codes.InvalidArgument, "no verification_token")
+		}
+
+		verification, err := mysql.FindSMSOTPVerificationByVerificationToken(ctx, db, req.VerificationToken)
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, status.Error(codes.InvalidArgument, "verification not found")
+		}
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+
+		if !isUsableSMSOTPVerification(verification, smsOTPPurposeUnlockAccount, now) {
+			return nil, status.Error(codes.InvalidArgument, errInvalidVerification.Error())
+		}
+
+		user, err := mysql.FindUserByPhoneNumber(ctx, db, verification.PhoneNumber) // stored in E.164
+		if errors.Cause(err) == sql.ErrNoRows {
+			if !enumerationResistant {
+				return nil, status.Error(codes.NotFound, "no user with given phone number")
+			}
+			// answered as if unlocked when enumerationResistant, and consumed as well so that it cannot be told
+			// apart by reusing it
+			if err := consumeSMSOTPVerification(ctx, db, verification, now); err != nil {
+				switch errors.Cause(err) {
+				case errInvalidVerification:
+					return nil, status.Error(codes.InvalidArgument, errors.Cause(err).Error())
+				default:
+					return nil, status.Error(codes.Internal, err.Error())
+				}
+			}
+			return &userv1.UnlockAccountResponse{}, nil
+		}
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+
+		if err := unlockAccount(ctx, db, signInThrottle, verification, user, now); err != nil {
+			switch errors.Cause(err) {
+			case errInvalidVerification:
+				return nil, status.Error(codes.InvalidArgument, errors.Cause(err).Error())
+			default:
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+		}
+
+		return &userv1.UnlockAccountResponse{}, nil
+	}
+}
+
+func unlockAccount(ctx context.Context, db *sql.DB, signInThrottle service.SignInThrottle, verification *model.SMSOtpVerification, user *model.User, now time.Time) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			logrus.WithError(err).Error()
+		}
+	}()
+
+	if err := consumeSMSOTPVerification(ctx, tx, verification, now); err != nil {
+		return err
+	}
+
+	if err := signInThrottle.Reset(ctx, tx, service.SignInThrottleScopeAccount, strconv.Itoa(user.UserID)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
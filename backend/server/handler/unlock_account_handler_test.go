@@ -0,0 +1,225 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/volatiletech/null/v8"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	"github.com/sean-ahn/user/backend/model"
+	"github.com/sean-ahn/user/backend/server/service"
+	"github.com/sean-ahn/user/backend/test"
+	userv1 "github.com/sean-ahn/user/proto/gen/go/user/v1"
+)
+
+func TestUnlockAccount(t *testing.T) {
+	now := time.Date(2021, 10, 25, 16, 37, 55, 509012743, time.UTC)
+
+	cases := []struct {
+		name                 string
+		req                  *userv1.UnlockAccountRequest
+		enumerationResistant bool
+
+		dbExpectFunc             func(sqlmock.Sqlmock)
+		signInThrottleExpectFunc func(context.Context) func(*service.MockSignInThrottle)
+
+		expectedCode codes.Code
+		expectedResp *userv1.UnlockAccountResponse
+		expectedErr  string
+	}{
+		{
+			name: "success",
+			req:  &userv1.UnlockAccountRequest{VerificationToken: "verification_token"},
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `sms_otp_verification` WHERE (`sms_otp_verification`.`verification_token` = ?) LIMIT 1;",
+				)).WithArgs(
+					"verification_token",
+				).WillReturnRows(test.NewSMSOtpVerificationRows([]*model.SMSOtpVerification{
+					{SMSOtpVerificationID: 1, PhoneNumber: "+821012345678", Purpose: "unlock_account", VerificationValidUntil: null.TimeFrom(now.Add(defaultSMSOTPValidity))},
+				}))
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user` WHERE (`user`.`phone_number` = ?) LIMIT 1;",
+				)).WithArgs(
+					"+821012345678",
+				).WillReturnRows(test.NewUserRows([]*model.User{
+					{UserID: 1},
+				}))
+
+				mock.ExpectBegin()
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"UPDATE `sms_otp_verification` SET `consumed_at` = ? WHERE (`sms_otp_verification`.`sms_otp_verification_id` = ?) AND (`sms_otp_verification`.`consumed_at` is null);",
+				)).WithArgs(
+					now, 1,
+				).WillReturnResult(sqlmock.NewResult(0, 1))
+
+				mock.ExpectCommit()
+			},
+			signInThrottleExpectFunc: func(ctx context.Context) func(*service.MockSignInThrottle) {
+				return func(mock *service.MockSignInThrottle) {
+					mock.EXPECT().Reset(ctx, gomock.Any(), service.SignInThrottleScopeAccount, "1").Return(nil)
+				}
+			},
+			expectedCode: codes.OK,
+			expectedResp: &userv1.UnlockAccountResponse{},
+		},
+		{
+			name: "verification for other purpose",
+			req:  &userv1.UnlockAccountRequest{VerificationToken: "verification_token"},
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `sms_otp_verification` WHERE (`sms_otp_verification`.`verification_token` = ?) LIMIT 1;",
+				)).WithArgs(
+					"verification_token",
+				).WillReturnRows(test.NewSMSOtpVerificationRows([]*model.SMSOtpVerification{
+					{SMSOtpVerificationID: 1, PhoneNumber: "+821012345678", Purpose: "reset_password", VerificationValidUntil: null.TimeFrom(now.Add(defaultSMSOTPValidity))},
+				}))
+			},
+			expectedCode: codes.InvalidArgument,
+			expectedErr:  "rpc error: code = InvalidArgument desc = invalid verification",
+		},
+		{
+			name: "verification already consumed",
+			req:  &userv1.UnlockAccountRequest{VerificationToken: "verification_token"},
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `sms_otp_verification` WHERE (`sms_otp_verification`.`verification_token` = ?) LIMIT 1;",
+				)).WithArgs(
+					"verification_token",
+				).WillReturnRows(test.NewSMSOtpVerificationRows([]*model.SMSOtpVerification{
+					{SMSOtpVerificationID: 1, PhoneNumber: "+821012345678", Purpose: "unlock_account", VerificationValidUntil: null.TimeFrom(now.Add(defaultSMSOTPValidity))},
+				}))
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user` WHERE (`user`.`phone_number` = ?) LIMIT 1;",
+				)).WithArgs(
+					"+821012345678",
+				).WillReturnRows(test.NewUserRows([]*model.User{
+					{UserID: 1},
+				}))
+
+				mock.ExpectBegin()
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"UPDATE `sms_otp_verification` SET `consumed_at` = ? WHERE (`sms_otp_verification`.`sms_otp_verification_id` = ?) AND (`sms_otp_verification`.`consumed_at` is null);",
+				)).WithArgs(
+					now, 1,
+				).WillReturnResult(sqlmock.NewResult(0, 0))
+
+				mock.ExpectRollback()
+			},
+			expectedCode: codes.InvalidArgument,
+			expectedErr:  "rpc error: code = InvalidArgument desc = invalid verification",
+		},
+		{
+			name: "no user with phone number",
+			req:  &userv1.UnlockAccountRequest{VerificationToken: "verification_token"},
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `sms_otp_verification` WHERE (`sms_otp_verification`.`verification_token` = ?) LIMIT 1;",
+				)).WithArgs(
+					"verification_token",
+				).WillReturnRows(test.NewSMSOtpVerificationRows([]*model.SMSOtpVerification{
+					{SMSOtpVerificationID: 1, PhoneNumber: "+821012345678", Purpose: "unlock_account", VerificationValidUntil: null.TimeFrom(now.Add(defaultSMSOTPValidity))},
+				}))
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user` WHERE (`user`.`phone_number` = ?) LIMIT 1;",
+				)).WithArgs(
+					"+821012345678",
+				).WillReturnError(
+					sql.ErrNoRows,
+				)
+			},
+			expectedCode: codes.NotFound,
+			expectedErr:  "rpc error: code = NotFound desc = no user with given phone number",
+		},
+		{
+			name:                 "no user with phone number when enumeration resistant",
+			req:                  &userv1.UnlockAccountRequest{VerificationToken: "verification_token"},
+			enumerationResistant: true,
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `sms_otp_verification` WHERE (`sms_otp_verification`.`verification_token` = ?) LIMIT 1;",
+				)).WithArgs(
+					"verification_token",
+				).WillReturnRows(test.NewSMSOtpVerificationRows([]*model.SMSOtpVerification{
+					{SMSOtpVerificationID: 1, PhoneNumber: "+821012345678", Purpose: "unlock_account", VerificationValidUntil: null.TimeFrom(now.Add(defaultSMSOTPValidity))},
+				}))
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user` WHERE (`user`.`phone_number` = ?) LIMIT 1;",
+				)).WithArgs(
+					"+821012345678",
+				).WillReturnError(
+					sql.ErrNoRows,
+				)
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"UPDATE `sms_otp_verification` SET `consumed_at` = ? WHERE (`sms_otp_verification`.`sms_otp_verification_id` = ?) AND (`sms_otp_verification`.`consumed_at` is null);",
+				)).WithArgs(
+					now, 1,
+				).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			expectedCode: codes.OK,
+			expectedResp: &userv1.UnlockAccountResponse{},
+		},
+		{
+			name:         "no verification token",
+			req:          &userv1.UnlockAccountRequest{},
+			expectedCode: codes.InvalidArgument,
+			expectedErr:  "rpc error: code = InvalidArgument desc = no verification_token",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			clock := clockwork.NewFakeClockAt(now)
+
+			ctrl := gomock.NewController(t)
+
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fail()
+			}
+			if tc.dbExpectFunc != nil {
+				tc.dbExpectFunc(mock)
+			}
+			defer test.CloseSqlmock(t, db, mock)
+
+			mockSignInThrottle := service.NewMockSignInThrottle(ctrl)
+			if tc.signInThrottleExpectFunc != nil {
+				tc.signInThrottleExpectFunc(ctx)(mockSignInThrottle)
+			}
+
+			handler := UnlockAccount(clock, db, mockSignInThrottle, tc.enumerationResistant)
+
+			resp, err := handler(ctx, tc.req)
+
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedCode, status.Code(err))
+			assert.Empty(t, cmp.Diff(resp, tc.expectedResp, protocmp.Transform()))
+		})
+	}
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"context"
+	"crypto/subtle"
 	"database/sql"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/sean-ahn/user/backend/crypto"
 	"github.com/sean-ahn/user/backend/model"
 	"github.com/sean-ahn/user/backend/persistence/mysql"
 	userv1 "github.com/sean-ahn/user/proto/gen/go/user/v1"
@@ -23,9 +25,11 @@ const (
 	defaultSMSOTPValidity = 5 * time.Minute
 )
 
+var errInvalidVerification = errors.New("invalid verification")
+
 type VerifySmsOtpHandlerFunc func(ctx context.Context, req *userv1.VerifySmsOtpRequest) (*userv1.VerifySmsOtpResponse, error)
 
-func VerifySmsOtp(clock clockwork.Clock, db *sql.DB) VerifySmsOtpHandlerFunc {
+func VerifySmsOtp(clock clockwork.Clock, db *sql.DB, otpHasher *crypto.OTPHasher) VerifySmsOtpHandlerFunc {
 	return func(ctx context.Context, req *userv1.VerifySmsOtpRequest) (*userv1.VerifySmsOtpResponse, error) {
 		now := clock.Now()
 
@@ -56,7 +60,7 @@ func VerifySmsOtp(clock clockwork.Clock, db *sql.DB) VerifySmsOtpHandlerFunc {
 			return nil, status.Error(codes.InvalidArgument, "verification maximum trials exceeded")
 		}
 
-		otpCodeMatched := verification.OtpCode == req.SmsOtpCode
+		otpCodeMatched := matchSMSOTPCode(otpHasher, verification, req.SmsOtpCode)
 
 		targets := []string{model.SMSOtpVerificationColumns.VerificationTrials}
 		verification.VerificationTrials += 1
@@ -75,3 +79,41 @@ func VerifySmsOtp(clock clockwork.Clock, db *sql.DB) VerifySmsOtpHandlerFunc {
 		return &userv1.VerifySmsOtpResponse{}, nil
 	}
 }
+
+// matchSMSOTPCode compares the code in constant time. Verifications created before the codes are hashed have the
+// plaintext code instead, which is accepted until they expire.
+func matchSMSOTPCode(otpHasher *crypto.OTPHasher, verification *model.SMSOtpVerification, code string) bool {
+	if verification.OtpCodeHash.Valid {
+		return otpHasher.Verify(verification.OtpCodeHash.String, verification.VerificationToken, code)
+	}
+	if verification.OtpCode.Valid {
+		return subtle.ConstantTimeCompare([]byte(verification.OtpCode.String), []byte(code)) == 1
+	}
+	return false
+}
+
+// isUsableSMSOTPVerification returns whether the verification has been verified for the purpose, and can be consumed.
+func isUsableSMSOTPVerification(verification *model.SMSOtpVerification, purpose string, now time.Time) bool {
+	if verification.Purpose != purpose || verification.ConsumedAt.Valid {
+		return false
+	}
+	return verification.VerificationValidUntil.Valid && !verification.VerificationValidUntil.Time.Before(now)
+}
+
+// consumeSMSOTPVerification marks the verification used, only if no one else has, so that the verification token
+// cannot be used twice. It should be called in the transaction of the action the verification is for.
+func consumeSMSOTPVerification(ctx context.Context, exec boil.ContextExecutor, verification *model.SMSOtpVerification, now time.Time) error {
+	n, err := model.SMSOtpVerifications(
+		model.SMSOtpVerificationWhere.SMSOtpVerificationID.EQ(verification.SMSOtpVerificationID),
+		model.SMSOtpVerificationWhere.ConsumedAt.IsNull(),
+	).UpdateAll(ctx, exec, model.M{
+		model.SMSOtpVerificationColumns.ConsumedAt: null.TimeFrom(now),
+	})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if n == 0 {
+		return errors.WithStack(errInvalidVerification)
+	}
+	return nil
+}
@@ -46,7 +46,7 @@ func TestVerifySmsOtp(t *testing.T) {
 				)).WithArgs(
 					"verification_token",
 				).WillReturnRows(test.NewSMSOtpVerificationRows([]*model.SMSOtpVerification{
-					{SMSOtpVerificationID: 2, VerificationToken: "verification_token", ExpiresAt: now.Add(defaultSMSOTPExpiration), OtpCode: "123456"},
+					{SMSOtpVerificationID: 2, VerificationToken: "verification_token", ExpiresAt: now.Add(defaultSMSOTPExpiration), OtpCodeHash: null.StringFrom(testSMSOTPHasher.Hash("verification_token", "123456"))},
 				}))
 
 				mock.ExpectExec(regexp.QuoteMeta(
@@ -72,7 +72,7 @@ func TestVerifySmsOtp(t *testing.T) {
 				)).WithArgs(
 					"verification_token",
 				).WillReturnRows(test.NewSMSOtpVerificationRows([]*model.SMSOtpVerification{
-					{SMSOtpVerificationID: 2, VerificationToken: "verification_token", ExpiresAt: now.Add(defaultSMSOTPExpiration), OtpCode: "123456", VerificationTrials: 2},
+					{SMSOtpVerificationID: 2, VerificationToken: "verification_token", ExpiresAt: now.Add(defaultSMSOTPExpiration), OtpCodeHash: null.StringFrom(testSMSOTPHasher.Hash("verification_token", "123456")), VerificationTrials: 2},
 				}))
 
 				mock.ExpectExec(regexp.QuoteMeta(
@@ -86,6 +86,84 @@ func TestVerifySmsOtp(t *testing.T) {
 			expectedCode: codes.InvalidArgument,
 			expectedErr:  "rpc error: code = InvalidArgument desc = otp code mismatch",
 		},
+		{
+			name: "success with plaintext code created before hashing",
+			req: &userv1.VerifySmsOtpRequest{
+				VerificationToken: "verification_token",
+				SmsOtpCode:        "123456",
+			},
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `sms_otp_verification` WHERE (`sms_otp_verification`.`verification_token` = ?) LIMIT 1;",
+				)).WithArgs(
+					"verification_token",
+				).WillReturnRows(test.NewSMSOtpVerificationRows([]*model.SMSOtpVerification{
+					{SMSOtpVerificationID: 2, VerificationToken: "verification_token", ExpiresAt: now.Add(defaultSMSOTPExpiration), OtpCode: null.StringFrom("123456")},
+				}))
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"UPDATE `sms_otp_verification` SET `verification_trials`=?,`verification_valid_until`=? WHERE `sms_otp_verification_id`=?",
+				)).WithArgs(
+					1, now.Add(defaultSMSOTPValidity), 2,
+				).WillReturnResult(
+					sqlmock.NewResult(0, 1),
+				)
+			},
+			expectedCode: codes.OK,
+			expectedResp: &userv1.VerifySmsOtpResponse{},
+		},
+		{
+			name: "plaintext code mismatch",
+			req: &userv1.VerifySmsOtpRequest{
+				VerificationToken: "verification_token",
+				SmsOtpCode:        "000000",
+			},
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `sms_otp_verification` WHERE (`sms_otp_verification`.`verification_token` = ?) LIMIT 1;",
+				)).WithArgs(
+					"verification_token",
+				).WillReturnRows(test.NewSMSOtpVerificationRows([]*model.SMSOtpVerification{
+					{SMSOtpVerificationID: 2, VerificationToken: "verification_token", ExpiresAt: now.Add(defaultSMSOTPExpiration), OtpCode: null.StringFrom("123456")},
+				}))
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"UPDATE `sms_otp_verification` SET `verification_trials`=? WHERE `sms_otp_verification_id`=?",
+				)).WithArgs(
+					1, 2,
+				).WillReturnResult(
+					sqlmock.NewResult(0, 1),
+				)
+			},
+			expectedCode: codes.InvalidArgument,
+			expectedErr:  "rpc error: code = InvalidArgument desc = otp code mismatch",
+		},
+		{
+			name: "hash of another verification",
+			req: &userv1.VerifySmsOtpRequest{
+				VerificationToken: "verification_token",
+				SmsOtpCode:        "123456",
+			},
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `sms_otp_verification` WHERE (`sms_otp_verification`.`verification_token` = ?) LIMIT 1;",
+				)).WithArgs(
+					"verification_token",
+				).WillReturnRows(test.NewSMSOtpVerificationRows([]*model.SMSOtpVerification{
+					{SMSOtpVerificationID: 2, VerificationToken: "verification_token", ExpiresAt: now.Add(defaultSMSOTPExpiration), OtpCodeHash: null.StringFrom(testSMSOTPHasher.Hash("another_verification_token", "123456"))},
+				}))
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"UPDATE `sms_otp_verification` SET `verification_trials`=? WHERE `sms_otp_verification_id`=?",
+				)).WithArgs(
+					1, 2,
+				).WillReturnResult(
+					sqlmock.NewResult(0, 1),
+				)
+			},
+			expectedCode: codes.InvalidArgument,
+			expectedErr:  "rpc error: code = InvalidArgument desc = otp code mismatch",
+		},
 		{
 			name: "already verified",
 			req: &userv1.VerifySmsOtpRequest{
@@ -134,7 +212,7 @@ func TestVerifySmsOtp(t *testing.T) {
 				)).WithArgs(
 					"verification_token",
 				).WillReturnRows(test.NewSMSOtpVerificationRows([]*model.SMSOtpVerification{
-					{VerificationToken: "verification_token", ExpiresAt: now.Add(defaultSMSOTPExpiration), VerificationTrials: defaultSMSOTPVerificationMaxTrials, OtpCode: "123456"},
+					{VerificationToken: "verification_token", ExpiresAt: now.Add(defaultSMSOTPExpiration), VerificationTrials: defaultSMSOTPVerificationMaxTrials, OtpCodeHash: null.StringFrom(testSMSOTPHasher.Hash("verification_token", "123456"))},
 				}))
 			},
 			expectedCode: codes.InvalidArgument,
@@ -177,7 +255,7 @@ func TestVerifySmsOtp(t *testing.T) {
 			}
 			defer test.CloseSqlmock(t, db, mock)
 
-			handler := VerifySmsOtp(clock, db)
+			handler := VerifySmsOtp(clock, db, testSMSOTPHasher)
 
 			resp, err := handler(ctx, tc.req)
 
@@ -2,17 +2,25 @@ package server
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"strconv"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 	"google.golang.org/protobuf/encoding/protojson"
 
 	"github.com/sean-ahn/user/backend/config"
+	"github.com/sean-ahn/user/backend/server/service"
 	userv1 "github.com/sean-ahn/user/proto/gen/go/user/v1"
 )
 
+const (
+	jwksPath         = "/.well-known/jwks.json"
+	jwksCacheControl = "public, max-age=300"
+)
+
 func NewHTTPServer(ctx context.Context, cfg config.Config) (*http.Server, error) {
 	mux := runtime.NewServeMux(
 		runtime.WithMarshalerOption(
@@ -41,6 +49,10 @@ func NewHTTPServer(ctx context.Context, cfg config.Config) (*http.Server, error)
 		return nil, err
 	}
 
+	if err := mux.HandlePath(http.MethodGet, jwksPath, handleJWKS(cfg.SigningKeyStore())); err != nil {
+		return nil, err
+	}
+
 	s := &http.Server{
 		Addr:    ":" + strconv.Itoa(cfg.Setting().HTTPServerPort),
 		Handler: mux,
@@ -48,3 +60,25 @@ func NewHTTPServer(ctx context.Context, cfg config.Config) (*http.Server, error)
 
 	return s, nil
 }
+
+func handleJWKS(keyStore service.SigningKeyStore) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, _ map[string]string) {
+		keys, err := keyStore.ListVerificationKeys(r.Context())
+		if err != nil {
+			logrus.WithError(err).Error("list verification keys")
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		set := service.JWKSet{Keys: make([]service.JWK, 0, len(keys))}
+		for _, k := range keys {
+			set.Keys = append(set.Keys, k.JWK())
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", jwksCacheControl)
+		if err := json.NewEncoder(w).Encode(set); err != nil {
+			logrus.WithError(err).Error("write jwks")
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package message
+
+import (
+	"strings"
+)
+
+// SMSOTP is the data of the KindSMSOTP templates.
+type SMSOTP struct {
+	Code string
+}
+
+// OTPAutofill has the platform-specific formats which let the OTP be filled in automatically. Each is appended to
+// the message if set.
+type OTPAutofill struct {
+	// WebOTPDomain is the domain of the site in the `@domain #code` line, which should be the last one.
+	// See https://wicg.github.io/web-otp/
+	WebOTPDomain string
+	// AndroidAppHash is the 11 characters hash of the app which reads the message with the SMS Retriever API.
+	// See https://developers.google.com/identity/sms-retriever/verify
+	AndroidAppHash string
+}
+
+// Append appends the lines of the formats after a blank line, keeping the WebOTP one the last.
+func (a OTPAutofill) Append(text, code string) string {
+	var lines []string
+	if a.AndroidAppHash != "" {
+		lines = append(lines, a.AndroidAppHash)
+	}
+	if a.WebOTPDomain != "" {
+		lines = append(lines, "@"+a.WebOTPDomain+" #"+code)
+	}
+	if len(lines) == 0 {
+		return text
+	}
+	return text + "\n\n" + strings.Join(lines, "\n")
+}
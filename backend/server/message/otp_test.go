@@ -0,0 +1,47 @@
+package message
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOTPAutofill_Append(t *testing.T) {
+	cases := []struct {
+		name     string
+		autofill OTPAutofill
+
+		expected string
+	}{
+		{
+			name:     "none",
+			autofill: OTPAutofill{},
+			expected: "123456 is your authentication code.",
+		},
+		{
+			name:     "webotp",
+			autofill: OTPAutofill{WebOTPDomain: "example.com"},
+			expected: "123456 is your authentication code.\n\n@example.com #123456",
+		},
+		{
+			name:     "android app hash",
+			autofill: OTPAutofill{AndroidAppHash: "FA+9qCX9VSu"},
+			expected: "123456 is your authentication code.\n\nFA+9qCX9VSu",
+		},
+		{
+			name:     "both",
+			autofill: OTPAutofill{WebOTPDomain: "example.com", AndroidAppHash: "FA+9qCX9VSu"},
+			expected: "123456 is your authentication code.\n\nFA+9qCX9VSu\n@example.com #123456",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.autofill.Append("123456 is your authentication code.", "123456")
+
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package message
+
+import (
+	"bytes"
+	"embed"
+	"io/fs"
+	"path"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+	"golang.org/x/text/language"
+)
+
+const (
+	KindSMSOTP = "sms_otp"
+	// KindSMSNoAccount is sent instead of an answer telling that no user has the phone number, and takes no data.
+	KindSMSNoAccount = "sms_no_account"
+
+	templateExt = ".tmpl"
+)
+
+var ErrUnknownKind = errors.New("unknown message kind")
+
+//go:embed templates
+var embedded embed.FS
+
+// DefaultTemplates returns the templates built into the binary.
+func DefaultTemplates() fs.FS {
+	fsys, err := fs.Sub(embedded, "templates")
+	if err != nil {
+		panic(err)
+	}
+	return fsys
+}
+
+// Registry has the templates of the messages per kind and locale.
+type Registry struct {
+	kinds map[string]*localizedTemplates
+}
+
+type localizedTemplates struct {
+	matcher language.Matcher
+	// in the order of the tags of matcher, the default locale first
+	templates []*template.Template
+}
+
+// LoadRegistry loads the templates from `<kind>/<locale>.tmpl` files, where locale is a BCP 47 language tag. Every kind
+// should have the template of the default locale, which is used if none matches the locale the message is asked in.
+func LoadRegistry(fsys fs.FS, defaultLocale string) (*Registry, error) {
+	defaultTag, err := language.Parse(defaultLocale)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid default locale: %s", defaultLocale)
+	}
+
+	dirs, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	r := &Registry{kinds: map[string]*localizedTemplates{}}
+	for _, dir := range dirs {
+		if !dir.IsDir() {
+			continue
+		}
+
+		lt, err := loadLocalizedTemplates(fsys, dir.Name(), defaultTag)
+		if err != nil {
+			return nil, err
+		}
+		r.kinds[dir.Name()] = lt
+	}
+	return r, nil
+}
+
+func loadLocalizedTemplates(fsys fs.FS, kind string, defaultTag language.Tag) (*localizedTemplates, error) {
+	files, err := fs.ReadDir(fsys, kind)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var (
+		tags      []language.Tag
+		templates []*template.Template
+	)
+	for _, f := range files {
+		if f.IsDir() || path.Ext(f.Name()) != templateExt {
+			continue
+		}
+
+		tag, err := language.Parse(strings.TrimSuffix(f.Name(), templateExt))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid locale of template: %s/%s", kind, f.Name())
+		}
+
+		b, err := fs.ReadFile(fsys, path.Join(kind, f.Name()))
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		// the trailing newline of the file is not a part of the message
+		tmpl, err := template.New(path.Join(kind, f.Name())).Option("missingkey=error").Parse(strings.TrimRight(string(b), "\n"))
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		if tag == defaultTag {
+			tags = append([]language.Tag{tag}, tags...)
+			templates = append([]*template.Template{tmpl}, templates...)
+		} else {
+			tags = append(tags, tag)
+			templates = append(templates, tmpl)
+		}
+	}
+	if len(tags) == 0 || tags[0] != defaultTag {
+		return nil, errors.Errorf("no template of default locale: %s/%s%s", kind, defaultTag, templateExt)
+	}
+
+	return &localizedTemplates{matcher: language.NewMatcher(tags), templates: templates}, nil
+}
+
+// Render renders the message of the kind in the locale which best matches the preference, which is a BCP 47 language
+// tag or an Accept-Language header value. The default locale is used if the preference is empty or matches none.
+func (r *Registry) Render(kind, preference string, data interface{}) (string, error) {
+	lt, ok := r.kinds[kind]
+	if !ok {
+		return "", errors.WithStack(ErrUnknownKind)
+	}
+
+	// a malformed preference is treated as no preference, as it comes from clients
+	tags, _, _ := language.ParseAcceptLanguage(preference)
+	_, index, _ := lt.matcher.Match(tags...)
+
+	var buf bytes.Buffer
+	if err := lt.templates[index].Execute(&buf, data); err != nil {
+		return "", errors.WithStack(err)
+	}
+	return buf.String(), nil
+}
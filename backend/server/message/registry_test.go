@@ -0,0 +1,134 @@
+package message
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry_Render(t *testing.T) {
+	r, err := LoadRegistry(fstest.MapFS{
+		"sms_otp/en.tmpl":    {Data: []byte("{{.Code}} is your authentication code.\n")},
+		"sms_otp/ko.tmpl":    {Data: []byte("인증번호는 {{.Code}} 입니다.\n")},
+		"sms_otp/pt-BR.tmpl": {Data: []byte("{{.Code}} é o seu código de autenticação.\n")},
+		"sms_otp/README.md":  {Data: []byte("not a template")},
+	}, "en")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name       string
+		kind       string
+		preference string
+
+		expected    string
+		expectedErr error
+	}{
+		{name: "default locale", kind: KindSMSOTP, preference: "", expected: "123456 is your authentication code."},
+		{name: "language tag", kind: KindSMSOTP, preference: "ko", expected: "인증번호는 123456 입니다."},
+		{name: "language tag with region", kind: KindSMSOTP, preference: "ko-KR", expected: "인증번호는 123456 입니다."},
+		{name: "accept-language", kind: KindSMSOTP, preference: "ja;q=0.9, ko;q=0.8, en;q=0.7", expected: "인증번호는 123456 입니다."},
+		{name: "region variant", kind: KindSMSOTP, preference: "pt-BR", expected: "123456 é o seu código de autenticação."},
+		{name: "no matching locale", kind: KindSMSOTP, preference: "ja", expected: "123456 is your authentication code."},
+		{name: "malformed preference", kind: KindSMSOTP, preference: "!!", expected: "123456 is your authentication code."},
+		{name: "unknown kind", kind: "unknown", preference: "ko", expectedErr: ErrUnknownKind},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := r.Render(tc.kind, tc.preference, SMSOTP{Code: "123456"})
+
+			if tc.expectedErr != nil {
+				assert.Equal(t, tc.expectedErr, errors.Cause(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func TestRegistry_Render_MissingKey(t *testing.T) {
+	r, err := LoadRegistry(fstest.MapFS{
+		"sms_otp/en.tmpl": {Data: []byte("{{.code}} is your authentication code.")},
+	}, "en")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = r.Render(KindSMSOTP, "", map[string]string{"Code": "123456"})
+	assert.Error(t, err)
+}
+
+func TestLoadRegistry(t *testing.T) {
+	cases := []struct {
+		name          string
+		fsys          fstest.MapFS
+		defaultLocale string
+
+		expectedErr bool
+	}{
+		{
+			name:          "default templates",
+			fsys:          nil,
+			defaultLocale: "en",
+		},
+		{
+			name: "no template of default locale",
+			fsys: fstest.MapFS{
+				"sms_otp/ko.tmpl": {Data: []byte("인증번호는 {{.Code}} 입니다.")},
+			},
+			defaultLocale: "en",
+			expectedErr:   true,
+		},
+		{
+			name: "invalid locale",
+			fsys: fstest.MapFS{
+				"sms_otp/en.tmpl":      {Data: []byte("{{.Code}} is your authentication code.")},
+				"sms_otp/english.tmpl": {Data: []byte("{{.Code}} is your authentication code.")},
+			},
+			defaultLocale: "en",
+			expectedErr:   true,
+		},
+		{
+			name: "invalid template",
+			fsys: fstest.MapFS{
+				"sms_otp/en.tmpl": {Data: []byte("{{.Code is your authentication code.")},
+			},
+			defaultLocale: "en",
+			expectedErr:   true,
+		},
+		{
+			name: "invalid default locale",
+			fsys: fstest.MapFS{
+				"sms_otp/en.tmpl": {Data: []byte("{{.Code}} is your authentication code.")},
+			},
+			defaultLocale: "english",
+			expectedErr:   true,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			var err error
+			if tc.fsys == nil {
+				_, err = LoadRegistry(DefaultTemplates(), tc.defaultLocale)
+			} else {
+				_, err = LoadRegistry(tc.fsys, tc.defaultLocale)
+			}
+
+			if tc.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
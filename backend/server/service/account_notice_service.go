@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"github.com/jonboulle/clockwork"
+	"github.com/pkg/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+
+	"github.com/sean-ahn/user/backend/client"
+	"github.com/sean-ahn/user/backend/model"
+	"github.com/sean-ahn/user/backend/persistence/mysql"
+)
+
+const (
+	registrationAttemptEmailSubject = "Someone tried to sign up with your account details"
+	registrationAttemptEmailBody    = `Someone tried to sign up at %s with the email address or phone number of your account.
+
+If this was you, you already have an account. Sign in, or reset your password if you have forgotten it.
+If this was not you, you can ignore this email.
+`
+
+	confirmationResendAttemptEmailSubject = "Your email address is already confirmed"
+	confirmationResendAttemptEmailBody    = `Someone asked at %s to confirm the email address of your account again.
+
+Your email address is already confirmed, so there is nothing to do. Sign in, or reset your password if you have forgotten it.
+If this was not you, you can ignore this email.
+`
+)
+
+//go:generate mockgen -package service -destination ./account_notice_service_mock.go -mock_names AccountNoticeService=MockAccountNoticeService github.com/sean-ahn/user/backend/server/service AccountNoticeService
+
+// AccountNoticeService tells the owner of an account what the requester is not told, so that whether the account
+// exists cannot be found out from the responses. A recipient is noticed at most once per cooldown, or anyone could flood
+// it with the unauthenticated requests, and the notices beyond are skipped silently.
+type AccountNoticeService interface {
+	// AllowNotice records a notice to the recipient, an email address or a phone number, and returns false if the
+	// recipient has already been noticed within the cooldown, in which case the notice should be skipped. exec can be
+	// the transaction the notice is enqueued in.
+	AllowNotice(ctx context.Context, exec boil.ContextExecutor, recipient string) (bool, error)
+	// NoticeRegistrationAttempt mails the user that someone has tried to register with the email or phone number of
+	// the user.
+	NoticeRegistrationAttempt(context.Context, *model.User) error
+	// NoticeConfirmationResendAttempt mails the user that someone has asked for the confirmation email again though
+	// the email of the user is already confirmed.
+	NoticeConfirmationResendAttempt(context.Context, *model.User) error
+}
+
+// EmailAccountNoticeService mails the notices, and keeps when each recipient has last been noticed in account_notice
+// table.
+type EmailAccountNoticeService struct {
+	clock    clockwork.Clock
+	db       *sql.DB
+	sender   client.EmailSender
+	cooldown time.Duration
+}
+
+var _ AccountNoticeService = (*EmailAccountNoticeService)(nil)
+
+func NewEmailAccountNoticeService(clock clockwork.Clock, db *sql.DB, sender client.EmailSender, cooldown time.Duration) *EmailAccountNoticeService {
+	return &EmailAccountNoticeService{
+		clock:    clock,
+		db:       db,
+		sender:   sender,
+		cooldown: cooldown,
+	}
+}
+
+func (s *EmailAccountNoticeService) AllowNotice(ctx context.Context, exec boil.ContextExecutor, recipient string) (bool, error) {
+	now := s.clock.Now()
+
+	// claimed with a conditional update, so that only one of the concurrent notices gets it
+	n, err := model.AccountNotices(
+		model.AccountNoticeWhere.Recipient.EQ(recipient),
+		model.AccountNoticeWhere.LastNoticedAt.LTE(now.Add(-s.cooldown)),
+	).UpdateAll(ctx, exec, model.M{
+		model.AccountNoticeColumns.LastNoticedAt: now,
+	})
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	if n > 0 {
+		return true, nil
+	}
+
+	// either never noticed, or noticed within the cooldown, in which case the row already exists
+	notice := &model.AccountNotice{
+		Recipient:     recipient,
+		LastNoticedAt: now,
+	}
+	if err := notice.Insert(ctx, exec, boil.Infer()); err != nil {
+		if merr, ok := errors.Cause(err).(*mysqldriver.MySQLError); ok && merr.Number == mysql.ErrorCodeDuplicateEntry {
+			return false, nil
+		}
+		return false, errors.WithStack(err)
+	}
+	return true, nil
+}
+
+func (s *EmailAccountNoticeService) NoticeRegistrationAttempt(ctx context.Context, user *model.User) error {
+	if ok, err := s.AllowNotice(ctx, s.db, user.Email); err != nil || !ok {
+		return err
+	}
+
+	if err := s.sender.Send(ctx, &client.Email{
+		To:      user.Email,
+		Subject: registrationAttemptEmailSubject,
+		Body:    fmt.Sprintf(registrationAttemptEmailBody, s.clock.Now().UTC().Format(time.RFC1123)),
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+func (s *EmailAccountNoticeService) NoticeConfirmationResendAttempt(ctx context.Context, user *model.User) error {
+	if ok, err := s.AllowNotice(ctx, s.db, user.Email); err != nil || !ok {
+		return err
+	}
+
+	if err := s.sender.Send(ctx, &client.Email{
+		To:      user.Email,
+		Subject: confirmationResendAttemptEmailSubject,
+		Body:    fmt.Sprintf(confirmationResendAttemptEmailBody, s.clock.Now().UTC().Format(time.RFC1123)),
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sean-ahn/user/backend/server/service (interfaces: AccountNoticeService)
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/sean-ahn/user/backend/model"
+	boil "github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// MockAccountNoticeService is a mock of AccountNoticeService interface.
+type MockAccountNoticeService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountNoticeServiceMockRecorder
+}
+
+// MockAccountNoticeServiceMockRecorder is the mock recorder for MockAccountNoticeService.
+type MockAccountNoticeServiceMockRecorder struct {
+	mock *MockAccountNoticeService
+}
+
+// NewMockAccountNoticeService creates a new mock instance.
+func NewMockAccountNoticeService(ctrl *gomock.Controller) *MockAccountNoticeService {
+	mock := &MockAccountNoticeService{ctrl: ctrl}
+	mock.recorder = &MockAccountNoticeServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountNoticeService) EXPECT() *MockAccountNoticeServiceMockRecorder {
+	return m.recorder
+}
+
+// AllowNotice mocks base method.
+func (m *MockAccountNoticeService) AllowNotice(arg0 context.Context, arg1 boil.ContextExecutor, arg2 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AllowNotice", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AllowNotice indicates an expected call of AllowNotice.
+func (mr *MockAccountNoticeServiceMockRecorder) AllowNotice(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AllowNotice", reflect.TypeOf((*MockAccountNoticeService)(nil).AllowNotice), arg0, arg1, arg2)
+}
+
+// NoticeConfirmationResendAttempt mocks base method.
+func (m *MockAccountNoticeService) NoticeConfirmationResendAttempt(arg0 context.Context, arg1 *model.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NoticeConfirmationResendAttempt", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NoticeConfirmationResendAttempt indicates an expected call of NoticeConfirmationResendAttempt.
+func (mr *MockAccountNoticeServiceMockRecorder) NoticeConfirmationResendAttempt(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NoticeConfirmationResendAttempt", reflect.TypeOf((*MockAccountNoticeService)(nil).NoticeConfirmationResendAttempt), arg0, arg1)
+}
+
+// NoticeRegistrationAttempt mocks base method.
+func (m *MockAccountNoticeService) NoticeRegistrationAttempt(arg0 context.Context, arg1 *model.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NoticeRegistrationAttempt", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NoticeRegistrationAttempt indicates an expected call of NoticeRegistrationAttempt.
+func (mr *MockAccountNoticeServiceMockRecorder) NoticeRegistrationAttempt(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NoticeRegistrationAttempt", reflect.TypeOf((*MockAccountNoticeService)(nil).NoticeRegistrationAttempt), arg0, arg1)
+}
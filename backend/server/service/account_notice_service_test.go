@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"github.com/golang/mock/gomock"
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sean-ahn/user/backend/client"
+	"github.com/sean-ahn/user/backend/model"
+	"github.com/sean-ahn/user/backend/persistence/mysql"
+	"github.com/sean-ahn/user/backend/test"
+)
+
+const testAccountNoticeCooldown = time.Hour
+
+func expectAccountNoticeClaim(mock sqlmock.Sqlmock, recipient string, now time.Time, rowsAffected int64) {
+	mock.ExpectExec(regexp.QuoteMeta(
+		"UPDATE `account_notice` SET `last_noticed_at` = ? WHERE (`account_notice`.`recipient` = ?) AND (`account_notice`.`last_noticed_at` <= ?);",
+	)).WithArgs(
+		now, recipient, now.Add(-testAccountNoticeCooldown),
+	).WillReturnResult(sqlmock.NewResult(0, rowsAffected))
+}
+
+func TestEmailAccountNoticeService_AllowNotice(t *testing.T) {
+	now := time.Date(2021, 10, 24, 7, 39, 46, 127956672, time.UTC)
+
+	cases := []struct {
+		name string
+
+		dbExpectFunc func(sqlmock.Sqlmock)
+
+		expected bool
+	}{
+		{
+			name: "never noticed",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				expectAccountNoticeClaim(mock, "john.doe@example.com", now, 0)
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"INSERT INTO `account_notice` (`recipient`,`last_noticed_at`) VALUES (?,?)",
+				)).WithArgs(
+					"john.doe@example.com", now,
+				).WillReturnResult(sqlmock.NewResult(1, 1))
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT `account_notice_id`,`created_at`,`updated_at` FROM `account_notice` WHERE `account_notice_id`=?",
+				)).WithArgs(
+					1,
+				).WillReturnRows(sqlmock.NewRows([]string{"account_notice_id", "created_at", "updated_at"}).AddRow(
+					1, now, now,
+				))
+			},
+			expected: true,
+		},
+		{
+			name: "noticed before the cooldown",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				expectAccountNoticeClaim(mock, "john.doe@example.com", now, 1)
+			},
+			expected: true,
+		},
+		{
+			name: "noticed within the cooldown",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				expectAccountNoticeClaim(mock, "john.doe@example.com", now, 0)
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"INSERT INTO `account_notice` (`recipient`,`last_noticed_at`) VALUES (?,?)",
+				)).WithArgs(
+					"john.doe@example.com", now,
+				).WillReturnError(
+					&mysqldriver.MySQLError{Number: mysql.ErrorCodeDuplicateEntry},
+				)
+			},
+			expected: false,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fail()
+			}
+			tc.dbExpectFunc(mock)
+			defer test.CloseSqlmock(t, db, mock)
+
+			svc := NewEmailAccountNoticeService(clockwork.NewFakeClockAt(now), db, client.NewMockEmailSender(gomock.NewController(t)), testAccountNoticeCooldown)
+
+			ok, err := svc.AllowNotice(context.Background(), db, "john.doe@example.com")
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, ok)
+		})
+	}
+}
+
+func TestEmailAccountNoticeService_NoticeRegistrationAttempt(t *testing.T) {
+	ctx := context.Background()
+
+	now := time.Date(2021, 10, 24, 7, 39, 46, 127956672, time.UTC)
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fail()
+	}
+	expectAccountNoticeClaim(mock, "john.doe@example.com", now, 1)
+	defer test.CloseSqlmock(t, db, mock)
+
+	mockEmailSender := client.NewMockEmailSender(gomock.NewController(t))
+	mockEmailSender.EXPECT().
+		Send(ctx, &client.Email{
+			To:      "john.doe@example.com",
+			Subject: "Someone tried to sign up with your account details",
+			Body: `Someone tried to sign up at Sun, 24 Oct 2021 07:39:46 UTC with the email address or phone number of your account.
+
+If this was you, you already have an account. Sign in, or reset your password if you have forgotten it.
+If this was not you, you can ignore this email.
+`,
+		}).
+		Return(nil)
+
+	svc := NewEmailAccountNoticeService(clockwork.NewFakeClockAt(now), db, mockEmailSender, testAccountNoticeCooldown)
+
+	err = svc.NoticeRegistrationAttempt(ctx, &model.User{UserID: 1, Email: "john.doe@example.com"})
+	assert.NoError(t, err)
+}
+
+func TestEmailAccountNoticeService_NoticeRegistrationAttempt_withinCooldown(t *testing.T) {
+	ctx := context.Background()
+
+	now := time.Date(2021, 10, 24, 7, 39, 46, 127956672, time.UTC)
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fail()
+	}
+	expectAccountNoticeClaim(mock, "john.doe@example.com", now, 0)
+	mock.ExpectExec(regexp.QuoteMeta(
+		"INSERT INTO `account_notice` (`recipient`,`last_noticed_at`) VALUES (?,?)",
+	)).WithArgs(
+		"john.doe@example.com", now,
+	).WillReturnError(
+		&mysqldriver.MySQLError{Number: mysql.ErrorCodeDuplicateEntry},
+	)
+	defer test.CloseSqlmock(t, db, mock)
+
+	// no email is sent
+	svc := NewEmailAccountNoticeService(clockwork.NewFakeClockAt(now), db, client.NewMockEmailSender(gomock.NewController(t)), testAccountNoticeCooldown)
+
+	err = svc.NoticeRegistrationAttempt(ctx, &model.User{UserID: 1, Email: "john.doe@example.com"})
+	assert.NoError(t, err)
+}
+
+func TestEmailAccountNoticeService_NoticeConfirmationResendAttempt(t *testing.T) {
+	ctx := context.Background()
+
+	now := time.Date(2021, 10, 24, 7, 39, 46, 127956672, time.UTC)
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fail()
+	}
+	expectAccountNoticeClaim(mock, "john.doe@example.com", now, 1)
+	defer test.CloseSqlmock(t, db, mock)
+
+	mockEmailSender := client.NewMockEmailSender(gomock.NewController(t))
+	mockEmailSender.EXPECT().
+		Send(ctx, &client.Email{
+			To:      "john.doe@example.com",
+			Subject: "Your email address is already confirmed",
+			Body: `Someone asked at Sun, 24 Oct 2021 07:39:46 UTC to confirm the email address of your account again.
+
+Your email address is already confirmed, so there is nothing to do. Sign in, or reset your password if you have forgotten it.
+If this was not you, you can ignore this email.
+`,
+		}).
+		Return(nil)
+
+	svc := NewEmailAccountNoticeService(clockwork.NewFakeClockAt(now), db, mockEmailSender, testAccountNoticeCooldown)
+
+	err = svc.NoticeConfirmationResendAttempt(ctx, &model.User{UserID: 1, Email: "john.doe@example.com"})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,252 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/url"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+
+	"github.com/sean-ahn/user/backend/client"
+	"github.com/sean-ahn/user/backend/model"
+	"github.com/sean-ahn/user/backend/persistence/mysql"
+)
+
+const (
+	confirmationCodeLen = 32
+
+	confirmationEmailSubject = "Confirm your email address"
+	confirmationEmailBody    = `Open the link below to confirm your email address.
+
+%s
+
+The link expires at %s. If you did not sign up, you can ignore this email.
+`
+
+	queryKeyConfirmationCode = "code"
+)
+
+var (
+	ErrInvalidConfirmationCode   = errors.New("invalid confirmation code")
+	ErrEmailAlreadyConfirmed     = errors.New("already confirmed email")
+	ErrConfirmationEmailCooldown = errors.New("confirmation email recently sent")
+)
+
+//go:generate mockgen -package service -destination ./email_confirmation_service_mock.go -mock_names EmailConfirmationService=MockEmailConfirmationService github.com/sean-ahn/user/backend/server/service EmailConfirmationService
+
+type EmailConfirmationService interface {
+	// Send issues a new confirmation code and mails the confirmation link to the user.
+	Send(context.Context, *model.User) error
+	// Resend is the same as Send, but fails with ErrConfirmationEmailCooldown if one has been sent recently. The code is
+	// not kept if the email cannot be sent, so that it does not hold back the next resend.
+	Resend(context.Context, *model.User) error
+	// Confirm consumes the code and marks the email it was issued for as confirmed.
+	Confirm(context.Context, string) error
+}
+
+type DBEmailConfirmationService struct {
+	clock  clockwork.Clock
+	db     *sql.DB
+	sender client.EmailSender
+	rand   io.Reader
+
+	confirmationURL string
+	expiresIn       time.Duration
+	resendCooldown  time.Duration
+}
+
+var _ EmailConfirmationService = (*DBEmailConfirmationService)(nil)
+
+// NewDBEmailConfirmationService returns a service which mails links to confirmationURL with the code in the `code` query parameter.
+func NewDBEmailConfirmationService(clock clockwork.Clock, db *sql.DB, sender client.EmailSender, confirmationURL string, expiresIn, resendCooldown time.Duration) *DBEmailConfirmationService {
+	return &DBEmailConfirmationService{
+		clock:           clock,
+		db:              db,
+		sender:          sender,
+		rand:            rand.Reader,
+		confirmationURL: confirmationURL,
+		expiresIn:       expiresIn,
+		resendCooldown:  resendCooldown,
+	}
+}
+
+func (s *DBEmailConfirmationService) Send(ctx context.Context, user *model.User) error {
+	if user.IsEmailConfirmed {
+		return errors.WithStack(ErrEmailAlreadyConfirmed)
+	}
+
+	return s.send(ctx, s.db, user)
+}
+
+func (s *DBEmailConfirmationService) Resend(ctx context.Context, user *model.User) error {
+	if user.IsEmailConfirmed {
+		return errors.WithStack(ErrEmailAlreadyConfirmed)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			logrus.WithError(err).Error()
+		}
+	}()
+
+	// the user is locked so that the resends of the user are serialized, or all of them would pass the cooldown
+	if _, err := mysql.GetUserForUpdate(ctx, tx, user.UserID); err != nil {
+		return err
+	}
+
+	latest, err := mysql.FindLatestEmailConfirmationByUserID(ctx, tx, user.UserID)
+	if err != nil && errors.Cause(err) != sql.ErrNoRows {
+		return err
+	}
+	if err == nil && latest.CreatedAt.After(s.clock.Now().Add(-s.resendCooldown)) {
+		return errors.WithStack(ErrConfirmationEmailCooldown)
+	}
+
+	// rolled back if the email is not sent, so that the user can retry without waiting for the cooldown
+	if err := s.send(ctx, tx, user); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// send issues a new confirmation code with exec and mails the link.
+func (s *DBEmailConfirmationService) send(ctx context.Context, exec boil.ContextExecutor, user *model.User) error {
+	code, err := s.newCode()
+	if err != nil {
+		return err
+	}
+
+	link, err := s.confirmationLink(code)
+	if err != nil {
+		return err
+	}
+
+	now := s.clock.Now()
+	c := &model.EmailConfirmation{
+		UserID:    user.UserID,
+		Email:     user.Email,
+		CodeHash:  hashConfirmationCode(code),
+		ExpiresAt: now.Add(s.expiresIn),
+		// by the clock the cooldown is checked with
+		CreatedAt: now,
+	}
+	if err := c.Insert(ctx, exec, boil.Infer()); err != nil {
+		return errors.WithStack(err)
+	}
+
+	if err := s.sender.Send(ctx, &client.Email{
+		To:      user.Email,
+		Subject: confirmationEmailSubject,
+		Body:    fmt.Sprintf(confirmationEmailBody, link, c.ExpiresAt.UTC().Format(time.RFC1123)),
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+func (s *DBEmailConfirmationService) Confirm(ctx context.Context, code string) error {
+	now := s.clock.Now()
+
+	c, err := mysql.FindEmailConfirmationByCodeHash(ctx, s.db, hashConfirmationCode(code))
+	if errors.Cause(err) == sql.ErrNoRows {
+		return errors.WithStack(ErrInvalidConfirmationCode)
+	}
+	if err != nil {
+		return err
+	}
+	if c.ConsumedAt.Valid || !c.ExpiresAt.After(now) {
+		return errors.WithStack(ErrInvalidConfirmationCode)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			logrus.WithError(err).Error()
+		}
+	}()
+
+	// consumed only if no one else has, so that the code cannot be used twice
+	n, err := model.EmailConfirmations(
+		model.EmailConfirmationWhere.EmailConfirmationID.EQ(c.EmailConfirmationID),
+		model.EmailConfirmationWhere.ConsumedAt.IsNull(),
+	).UpdateAll(ctx, tx, model.M{
+		model.EmailConfirmationColumns.ConsumedAt: null.TimeFrom(now),
+	})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if n == 0 {
+		return errors.WithStack(ErrInvalidConfirmationCode)
+	}
+
+	user, err := mysql.GetUser(ctx, tx, c.UserID)
+	if err != nil {
+		return err
+	}
+	// the code is for the email the user had when it was issued
+	if user.Email != c.Email {
+		return errors.WithStack(ErrInvalidConfirmationCode)
+	}
+	if user.IsEmailConfirmed {
+		return errors.WithStack(ErrEmailAlreadyConfirmed)
+	}
+
+	user.IsEmailConfirmed = true
+	if _, err := user.Update(ctx, tx, boil.Whitelist(model.UserColumns.IsEmailConfirmed)); err != nil {
+		return errors.WithStack(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+func (s *DBEmailConfirmationService) newCode() (string, error) {
+	b := make([]byte, confirmationCodeLen)
+	if _, err := io.ReadFull(s.rand, b); err != nil {
+		return "", errors.WithStack(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func (s *DBEmailConfirmationService) confirmationLink(code string) (string, error) {
+	u, err := url.Parse(s.confirmationURL)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	q := u.Query()
+	q.Set(queryKeyConfirmationCode, code)
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// hashConfirmationCode digests the code before it is stored, so that codes cannot be used even if the table is leaked.
+// A fast hash is enough since the code has 256 bits of entropy.
+func hashConfirmationCode(code string) string {
+	h := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(h[:])
+}
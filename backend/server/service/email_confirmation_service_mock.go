@@ -0,0 +1,78 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sean-ahn/user/backend/server/service (interfaces: EmailConfirmationService)
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/sean-ahn/user/backend/model"
+)
+
+// MockEmailConfirmationService is a mock of EmailConfirmationService interface.
+type MockEmailConfirmationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockEmailConfirmationServiceMockRecorder
+}
+
+// MockEmailConfirmationServiceMockRecorder is the mock recorder for MockEmailConfirmationService.
+type MockEmailConfirmationServiceMockRecorder struct {
+	mock *MockEmailConfirmationService
+}
+
+// NewMockEmailConfirmationService creates a new mock instance.
+func NewMockEmailConfirmationService(ctrl *gomock.Controller) *MockEmailConfirmationService {
+	mock := &MockEmailConfirmationService{ctrl: ctrl}
+	mock.recorder = &MockEmailConfirmationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEmailConfirmationService) EXPECT() *MockEmailConfirmationServiceMockRecorder {
+	return m.recorder
+}
+
+// Confirm mocks base method.
+func (m *MockEmailConfirmationService) Confirm(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Confirm", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Confirm indicates an expected call of Confirm.
+func (mr *MockEmailConfirmationServiceMockRecorder) Confirm(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Confirm", reflect.TypeOf((*MockEmailConfirmationService)(nil).Confirm), arg0, arg1)
+}
+
+// Resend mocks base method.
+func (m *MockEmailConfirmationService) Resend(arg0 context.Context, arg1 *model.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resend", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Resend indicates an expected call of Resend.
+func (mr *MockEmailConfirmationServiceMockRecorder) Resend(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resend", reflect.TypeOf((*MockEmailConfirmationService)(nil).Resend), arg0, arg1)
+}
+
+// Send mocks base method.
+func (m *MockEmailConfirmationService) Send(arg0 context.Context, arg1 *model.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockEmailConfirmationServiceMockRecorder) Send(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockEmailConfirmationService)(nil).Send), arg0, arg1)
+}
@@ -0,0 +1,422 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/jonboulle/clockwork"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/volatiletech/null/v8"
+
+	"github.com/sean-ahn/user/backend/client"
+	"github.com/sean-ahn/user/backend/model"
+	"github.com/sean-ahn/user/backend/test"
+)
+
+const (
+	testConfirmationCode = "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA"
+)
+
+func expectInsertEmailConfirmation(mock sqlmock.Sqlmock, id int, now time.Time) {
+	mock.ExpectExec(regexp.QuoteMeta(
+		"INSERT INTO `email_confirmation` (`user_id`,`email`,`code_hash`,`expires_at`,`consumed_at`,`created_at`) VALUES (?,?,?,?,?,?)",
+	)).WithArgs(
+		1, "john.doe@example.com", hashConfirmationCode(testConfirmationCode), now.Add(24*time.Hour), nil, now,
+	).WillReturnResult(
+		sqlmock.NewResult(int64(id), 1),
+	)
+
+	mock.ExpectQuery(regexp.QuoteMeta(
+		"SELECT `email_confirmation_id`,`updated_at` FROM `email_confirmation` WHERE `email_confirmation_id`=?",
+	)).WithArgs(
+		id,
+	).WillReturnRows(sqlmock.NewRows([]string{"email_confirmation_id", "updated_at"}).
+		AddRow(id, now),
+	)
+}
+
+func TestDBEmailConfirmationService_Send(t *testing.T) {
+	now := time.Date(2021, 10, 24, 7, 39, 46, 127956672, time.UTC)
+
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fail()
+	}
+	defer test.CloseSqlmock(t, db, mock)
+
+	expectInsertEmailConfirmation(mock, 1, now)
+
+	mockEmailSender := client.NewMockEmailSender(ctrl)
+	mockEmailSender.EXPECT().
+		Send(ctx, &client.Email{
+			To:      "john.doe@example.com",
+			Subject: "Confirm your email address",
+			Body: `Open the link below to confirm your email address.
+
+https://example.com/email/confirm?code=` + testConfirmationCode + `
+
+The link expires at Mon, 25 Oct 2021 07:39:46 UTC. If you did not sign up, you can ignore this email.
+`,
+		}).
+		Return(nil)
+
+	svc := DBEmailConfirmationService{
+		clock:           clockwork.NewFakeClockAt(now),
+		db:              db,
+		sender:          mockEmailSender,
+		rand:            bytes.NewReader(make([]byte, confirmationCodeLen)),
+		confirmationURL: "https://example.com/email/confirm",
+		expiresIn:       24 * time.Hour,
+		resendCooldown:  time.Minute,
+	}
+
+	err = svc.Send(ctx, &model.User{UserID: 1, Email: "john.doe@example.com"})
+	assert.NoError(t, err)
+}
+
+func TestDBEmailConfirmationService_Resend(t *testing.T) {
+	now := time.Date(2021, 10, 24, 7, 39, 46, 127956672, time.UTC)
+
+	expectLockUser := func(mock sqlmock.Sqlmock) {
+		mock.ExpectBegin()
+
+		mock.ExpectQuery(regexp.QuoteMeta(
+			"SELECT * FROM `user` WHERE (`user`.`user_id` = ?) LIMIT 1 FOR UPDATE;",
+		)).WithArgs(
+			1,
+		).WillReturnRows(test.NewUserRows([]*model.User{
+			{UserID: 1, Email: "john.doe@example.com"},
+		}))
+	}
+	expectFindLatest := func(mock sqlmock.Sqlmock, createdAt time.Time) {
+		mock.ExpectQuery(regexp.QuoteMeta(
+			"SELECT * FROM `email_confirmation` WHERE (`email_confirmation`.`user_id` = ?) ORDER BY created_at DESC LIMIT 1;",
+		)).WithArgs(
+			1,
+		).WillReturnRows(test.NewEmailConfirmationRows([]*model.EmailConfirmation{
+			{EmailConfirmationID: 1, UserID: 1, Email: "john.doe@example.com", ExpiresAt: createdAt.Add(24 * time.Hour), CreatedAt: createdAt},
+		}))
+	}
+
+	cases := []struct {
+		name string
+		user *model.User
+
+		dbExpectFunc          func(sqlmock.Sqlmock)
+		emailSenderExpectFunc func(*client.MockEmailSender)
+
+		expectedErr string
+	}{
+		{
+			name: "resend",
+			user: &model.User{UserID: 1, Email: "john.doe@example.com"},
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				expectLockUser(mock)
+				expectFindLatest(mock, now.Add(-time.Hour))
+				expectInsertEmailConfirmation(mock, 2, now)
+				mock.ExpectCommit()
+			},
+			emailSenderExpectFunc: func(mock *client.MockEmailSender) {
+				mock.EXPECT().
+					Send(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+		},
+		{
+			name: "never sent",
+			user: &model.User{UserID: 1, Email: "john.doe@example.com"},
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				expectLockUser(mock)
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `email_confirmation` WHERE (`email_confirmation`.`user_id` = ?) ORDER BY created_at DESC LIMIT 1;",
+				)).WithArgs(
+					1,
+				).WillReturnError(
+					sql.ErrNoRows,
+				)
+
+				expectInsertEmailConfirmation(mock, 1, now)
+				mock.ExpectCommit()
+			},
+			emailSenderExpectFunc: func(mock *client.MockEmailSender) {
+				mock.EXPECT().
+					Send(gomock.Any(), gomock.Any()).
+					Return(nil)
+			},
+		},
+		{
+			name: "cooldown",
+			user: &model.User{UserID: 1, Email: "john.doe@example.com"},
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				expectLockUser(mock)
+				expectFindLatest(mock, now.Add(-30*time.Second))
+				mock.ExpectRollback()
+			},
+			expectedErr: "confirmation email recently sent",
+		},
+		{
+			name: "failed to send",
+			user: &model.User{UserID: 1, Email: "john.doe@example.com"},
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				expectLockUser(mock)
+				expectFindLatest(mock, now.Add(-time.Hour))
+				expectInsertEmailConfirmation(mock, 2, now)
+				// not to hold back the next resend for the cooldown
+				mock.ExpectRollback()
+			},
+			emailSenderExpectFunc: func(mock *client.MockEmailSender) {
+				mock.EXPECT().
+					Send(gomock.Any(), gomock.Any()).
+					Return(errors.New("failed to send"))
+			},
+			expectedErr: "failed to send",
+		},
+		{
+			name:        "already confirmed",
+			user:        &model.User{UserID: 1, Email: "john.doe@example.com", IsEmailConfirmed: true},
+			expectedErr: "already confirmed email",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fail()
+			}
+			defer test.CloseSqlmock(t, db, mock)
+
+			if tc.dbExpectFunc != nil {
+				tc.dbExpectFunc(mock)
+			}
+
+			mockEmailSender := client.NewMockEmailSender(ctrl)
+			if tc.emailSenderExpectFunc != nil {
+				tc.emailSenderExpectFunc(mockEmailSender)
+			}
+
+			svc := DBEmailConfirmationService{
+				clock:           clockwork.NewFakeClockAt(now),
+				db:              db,
+				sender:          mockEmailSender,
+				rand:            bytes.NewReader(make([]byte, confirmationCodeLen)),
+				confirmationURL: "https://example.com/email/confirm",
+				expiresIn:       24 * time.Hour,
+				resendCooldown:  time.Minute,
+			}
+
+			err = svc.Resend(ctx, tc.user)
+
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDBEmailConfirmationService_Confirm(t *testing.T) {
+	now := time.Date(2021, 10, 24, 7, 39, 46, 127956672, time.UTC)
+
+	codeHash := hashConfirmationCode(testConfirmationCode)
+
+	cases := []struct {
+		name string
+
+		dbExpectFunc func(sqlmock.Sqlmock)
+
+		expectedErr string
+	}{
+		{
+			name: "confirm",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `email_confirmation` WHERE (`email_confirmation`.`code_hash` = ?) LIMIT 1;",
+				)).WithArgs(
+					codeHash,
+				).WillReturnRows(test.NewEmailConfirmationRows([]*model.EmailConfirmation{
+					{EmailConfirmationID: 1, UserID: 1, Email: "john.doe@example.com", CodeHash: codeHash, ExpiresAt: now.Add(time.Hour)},
+				}))
+
+				mock.ExpectBegin()
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"UPDATE `email_confirmation` SET `consumed_at` = ? WHERE (`email_confirmation`.`email_confirmation_id` = ?) AND (`email_confirmation`.`consumed_at` is null);",
+				)).WithArgs(
+					now, 1,
+				).WillReturnResult(
+					sqlmock.NewResult(0, 1),
+				)
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user` WHERE (`user`.`user_id` = ?) LIMIT 1;",
+				)).WithArgs(
+					1,
+				).WillReturnRows(test.NewUserRows([]*model.User{
+					{UserID: 1, Email: "john.doe@example.com", IsEmailConfirmed: false},
+				}))
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"UPDATE `user` SET `is_email_confirmed`=? WHERE `user_id`=?",
+				)).WithArgs(
+					true, 1,
+				).WillReturnResult(
+					sqlmock.NewResult(0, 1),
+				)
+
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "not exists",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `email_confirmation` WHERE (`email_confirmation`.`code_hash` = ?) LIMIT 1;",
+				)).WithArgs(
+					codeHash,
+				).WillReturnError(
+					sql.ErrNoRows,
+				)
+			},
+			expectedErr: "invalid confirmation code",
+		},
+		{
+			name: "expired",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `email_confirmation` WHERE (`email_confirmation`.`code_hash` = ?) LIMIT 1;",
+				)).WithArgs(
+					codeHash,
+				).WillReturnRows(test.NewEmailConfirmationRows([]*model.EmailConfirmation{
+					{EmailConfirmationID: 1, UserID: 1, Email: "john.doe@example.com", CodeHash: codeHash, ExpiresAt: now.Add(-time.Second)},
+				}))
+			},
+			expectedErr: "invalid confirmation code",
+		},
+		{
+			name: "already consumed",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `email_confirmation` WHERE (`email_confirmation`.`code_hash` = ?) LIMIT 1;",
+				)).WithArgs(
+					codeHash,
+				).WillReturnRows(test.NewEmailConfirmationRows([]*model.EmailConfirmation{
+					{EmailConfirmationID: 1, UserID: 1, Email: "john.doe@example.com", CodeHash: codeHash, ExpiresAt: now.Add(time.Hour), ConsumedAt: null.TimeFrom(now.Add(-time.Minute))},
+				}))
+			},
+			expectedErr: "invalid confirmation code",
+		},
+		{
+			name: "consumed concurrently",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `email_confirmation` WHERE (`email_confirmation`.`code_hash` = ?) LIMIT 1;",
+				)).WithArgs(
+					codeHash,
+				).WillReturnRows(test.NewEmailConfirmationRows([]*model.EmailConfirmation{
+					{EmailConfirmationID: 1, UserID: 1, Email: "john.doe@example.com", CodeHash: codeHash, ExpiresAt: now.Add(time.Hour)},
+				}))
+
+				mock.ExpectBegin()
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"UPDATE `email_confirmation` SET `consumed_at` = ? WHERE (`email_confirmation`.`email_confirmation_id` = ?) AND (`email_confirmation`.`consumed_at` is null);",
+				)).WithArgs(
+					now, 1,
+				).WillReturnResult(
+					sqlmock.NewResult(0, 0),
+				)
+
+				mock.ExpectRollback()
+			},
+			expectedErr: "invalid confirmation code",
+		},
+		{
+			name: "email changed",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `email_confirmation` WHERE (`email_confirmation`.`code_hash` = ?) LIMIT 1;",
+				)).WithArgs(
+					codeHash,
+				).WillReturnRows(test.NewEmailConfirmationRows([]*model.EmailConfirmation{
+					{EmailConfirmationID: 1, UserID: 1, Email: "john.doe@example.com", CodeHash: codeHash, ExpiresAt: now.Add(time.Hour)},
+				}))
+
+				mock.ExpectBegin()
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"UPDATE `email_confirmation` SET `consumed_at` = ? WHERE (`email_confirmation`.`email_confirmation_id` = ?) AND (`email_confirmation`.`consumed_at` is null);",
+				)).WithArgs(
+					now, 1,
+				).WillReturnResult(
+					sqlmock.NewResult(0, 1),
+				)
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user` WHERE (`user`.`user_id` = ?) LIMIT 1;",
+				)).WithArgs(
+					1,
+				).WillReturnRows(test.NewUserRows([]*model.User{
+					{UserID: 1, Email: "jane.doe@example.com", IsEmailConfirmed: false},
+				}))
+
+				mock.ExpectRollback()
+			},
+			expectedErr: "invalid confirmation code",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fail()
+			}
+			defer test.CloseSqlmock(t, db, mock)
+
+			if tc.dbExpectFunc != nil {
+				tc.dbExpectFunc(mock)
+			}
+
+			svc := DBEmailConfirmationService{
+				clock:           clockwork.NewFakeClockAt(now),
+				db:              db,
+				rand:            bytes.NewReader(make([]byte, confirmationCodeLen)),
+				confirmationURL: "https://example.com/email/confirm",
+				expiresIn:       24 * time.Hour,
+				resendCooldown:  time.Minute,
+			}
+
+			err = svc.Confirm(ctx, testConfirmationCode)
+
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
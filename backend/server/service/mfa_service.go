@@ -0,0 +1,501 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+
+	"github.com/sean-ahn/user/backend/client"
+	"github.com/sean-ahn/user/backend/crypto"
+	"github.com/sean-ahn/user/backend/model"
+	"github.com/sean-ahn/user/backend/persistence/mysql"
+	"github.com/sean-ahn/user/backend/server/generator"
+)
+
+const (
+	MFAMethodTOTP         = "totp"
+	MFAMethodRecoveryCode = "recovery_code"
+
+	mfaChallengeExpiresIn = 5 * time.Minute
+	mfaChallengeMaxTrials = 5
+
+	recoveryCodeCount     = 10
+	recoveryCodeLen       = 10 // 80 bits, 16 characters in base32
+	recoveryCodeGroupSize = 4
+
+	recoveryCodeUsedEmailSubject = "A recovery code was used to sign in"
+	recoveryCodeUsedEmailBody    = `A recovery code was used to sign in to your account at %s.
+You have %d recovery codes left.
+
+If this was not you, change your password and regenerate your recovery codes.
+`
+)
+
+var (
+	ErrInvalidMFAChallenge    = errors.New("invalid mfa challenge")
+	ErrInvalidMFACode         = errors.New("invalid mfa code")
+	ErrUnsupportedMFAMethod   = errors.New("unsupported mfa method")
+	ErrTOTPAlreadyEnabled     = errors.New("totp already enabled")
+	ErrTOTPEnrollmentNotFound = errors.New("totp enrollment not found")
+	ErrMFANotEnabled          = errors.New("mfa not enabled")
+)
+
+//go:generate mockgen -package service -destination ./mfa_service_mock.go -mock_names MFAService=MockMFAService github.com/sean-ahn/user/backend/server/service MFAService
+
+type MFAService interface {
+	// BeginTOTPEnrollment generates a new TOTP secret for the user, which is not used for sign-in until confirmed.
+	BeginTOTPEnrollment(context.Context, *model.User) (*TOTPEnrollment, error)
+	// ConfirmTOTPEnrollment enables TOTP and returns the recovery codes issued along with it.
+	ConfirmTOTPEnrollment(context.Context, *model.User, string) ([]string, error)
+	// RegenerateRecoveryCodes invalidates the recovery codes of the user and issues new ones.
+	RegenerateRecoveryCodes(context.Context, *model.User) ([]string, error)
+	// Methods returns the methods the user has enabled. It is empty if the user has not enabled 2FA.
+	Methods(context.Context, *model.User) ([]string, error)
+	// CreateChallenge returns a token which can be exchanged for the user once the second factor is verified.
+	// The device label is kept so that the session is labeled as requested at sign-in.
+	CreateChallenge(context.Context, *model.User, string) (string, error)
+	// CompleteChallenge verifies the code with the method and consumes the challenge.
+	// It returns the user and the device label the challenge is created with.
+	// Wrong codes are counted as failed sign-ins of the account, which are reset only once the code is verified.
+	CompleteChallenge(ctx context.Context, challengeToken, method, code string) (*model.User, string, error)
+}
+
+// TOTPEnrollment is what an authenticator app needs to generate codes.
+type TOTPEnrollment struct {
+	// Secret is base32 encoded without padding.
+	Secret string
+	URI    string
+}
+
+type DBMFAService struct {
+	clock          clockwork.Clock
+	db             *sql.DB
+	sender         client.EmailSender
+	signInThrottle SignInThrottle
+	idGenerator    generator.Generator
+	rand           io.Reader
+
+	totpIssuer string
+}
+
+var _ MFAService = (*DBMFAService)(nil)
+
+func NewDBMFAService(clock clockwork.Clock, db *sql.DB, sender client.EmailSender, signInThrottle SignInThrottle, totpIssuer string) *DBMFAService {
+	return &DBMFAService{
+		clock:          clock,
+		db:             db,
+		sender:         sender,
+		signInThrottle: signInThrottle,
+		idGenerator:    &generator.UUIDGenerator{},
+		rand:           rand.Reader,
+		totpIssuer:     totpIssuer,
+	}
+}
+
+func (s *DBMFAService) BeginTOTPEnrollment(ctx context.Context, user *model.User) (*TOTPEnrollment, error) {
+	t, err := mysql.FindUserTOTPByUserID(ctx, s.db, user.UserID)
+	if err != nil && errors.Cause(err) != sql.ErrNoRows {
+		return nil, err
+	}
+	if err == nil && t.ConfirmedAt.Valid {
+		return nil, errors.WithStack(ErrTOTPAlreadyEnabled)
+	}
+
+	secret, err := crypto.GenerateTOTPSecret()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	encoded := crypto.TOTPSecretEncoding.EncodeToString(secret)
+
+	// an unconfirmed enrollment is replaced, since the user may have lost the previous secret before confirming it
+	if t != nil {
+		t.Secret = encoded
+		t.LastUsedStep = 0
+		if _, err := t.Update(ctx, s.db, boil.Whitelist(model.UserTotpColumns.Secret, model.UserTotpColumns.LastUsedStep)); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	} else {
+		t = &model.UserTotp{
+			UserID: user.UserID,
+			Secret: encoded,
+		}
+		if err := t.Insert(ctx, s.db, boil.Infer()); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+
+	return &TOTPEnrollment{
+		Secret: encoded,
+		URI:    crypto.TOTPURI(s.totpIssuer, user.Email, secret),
+	}, nil
+}
+
+func (s *DBMFAService) ConfirmTOTPEnrollment(ctx context.Context, user *model.User, code string) ([]string, error) {
+	now := s.clock.Now()
+
+	t, err := mysql.FindUserTOTPByUserID(ctx, s.db, user.UserID)
+	if errors.Cause(err) == sql.ErrNoRows {
+		return nil, errors.WithStack(ErrTOTPEnrollmentNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if t.ConfirmedAt.Valid {
+		return nil, errors.WithStack(ErrTOTPAlreadyEnabled)
+	}
+
+	step, err := s.verifyTOTP(t, code)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			logrus.WithError(err).Error()
+		}
+	}()
+
+	t.ConfirmedAt = null.TimeFrom(now)
+	t.LastUsedStep = step
+	if _, err := t.Update(ctx, tx, boil.Whitelist(model.UserTotpColumns.ConfirmedAt, model.UserTotpColumns.LastUsedStep)); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	codes, err := s.replaceRecoveryCodes(ctx, tx, user.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return codes, nil
+}
+
+func (s *DBMFAService) RegenerateRecoveryCodes(ctx context.Context, user *model.User) ([]string, error) {
+	t, err := mysql.FindUserTOTPByUserID(ctx, s.db, user.UserID)
+	if errors.Cause(err) == sql.ErrNoRows {
+		return nil, errors.WithStack(ErrMFANotEnabled)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !t.ConfirmedAt.Valid {
+		return nil, errors.WithStack(ErrMFANotEnabled)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			logrus.WithError(err).Error()
+		}
+	}()
+
+	codes, err := s.replaceRecoveryCodes(ctx, tx, user.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return codes, nil
+}
+
+func (s *DBMFAService) Methods(ctx context.Context, user *model.User) ([]string, error) {
+	t, err := mysql.FindUserTOTPByUserID(ctx, s.db, user.UserID)
+	if errors.Cause(err) == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !t.ConfirmedAt.Valid {
+		return nil, nil
+	}
+
+	methods := []string{MFAMethodTOTP}
+
+	n, err := mysql.CountUnconsumedMFARecoveryCodesByUserID(ctx, s.db, user.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if n > 0 {
+		methods = append(methods, MFAMethodRecoveryCode)
+	}
+	return methods, nil
+}
+
+func (s *DBMFAService) CreateChallenge(ctx context.Context, user *model.User, deviceLabel string) (string, error) {
+	c := &model.MfaChallenge{
+		ChallengeToken: s.idGenerator.Generate(),
+		UserID:         user.UserID,
+		DeviceLabel:    deviceLabel,
+		ExpiresAt:      s.clock.Now().Add(mfaChallengeExpiresIn),
+	}
+	if err := c.Insert(ctx, s.db, boil.Infer()); err != nil {
+		return "", errors.WithStack(err)
+	}
+	return c.ChallengeToken, nil
+}
+
+func (s *DBMFAService) CompleteChallenge(ctx context.Context, challengeToken, method, code string) (*model.User, string, error) {
+	if method != MFAMethodTOTP && method != MFAMethodRecoveryCode {
+		return nil, "", errors.WithStack(ErrUnsupportedMFAMethod)
+	}
+
+	c, err := mysql.FindMFAChallengeByChallengeToken(ctx, s.db, challengeToken)
+	if errors.Cause(err) == sql.ErrNoRows {
+		return nil, "", errors.WithStack(ErrInvalidMFAChallenge)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	if c.ConsumedAt.Valid || !c.ExpiresAt.After(s.clock.Now()) || c.VerificationTrials >= mfaChallengeMaxTrials {
+		return nil, "", errors.WithStack(ErrInvalidMFAChallenge)
+	}
+
+	// the challenges are throttled along with the passwords, or the codes could be guessed with new challenges
+	accountKey := strconv.Itoa(c.UserID)
+	if err := s.signInThrottle.Check(ctx, SignInThrottleScopeAccount, accountKey); err != nil {
+		return nil, "", err
+	}
+
+	c.VerificationTrials += 1
+	if _, err := c.Update(ctx, s.db, boil.Whitelist(model.MfaChallengeColumns.VerificationTrials)); err != nil {
+		return nil, "", errors.WithStack(err)
+	}
+
+	t, err := mysql.FindUserTOTPByUserID(ctx, s.db, c.UserID)
+	if errors.Cause(err) == sql.ErrNoRows {
+		return nil, "", errors.WithStack(ErrInvalidMFAChallenge)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	if !t.ConfirmedAt.Valid {
+		return nil, "", errors.WithStack(ErrInvalidMFAChallenge)
+	}
+
+	var consumeFactor func(context.Context, boil.ContextExecutor) error
+	switch method {
+	case MFAMethodTOTP:
+		step, err := s.verifyTOTP(t, code)
+		if err != nil {
+			s.recordFailure(ctx, err, accountKey)
+			return nil, "", err
+		}
+		consumeFactor = func(ctx context.Context, exec boil.ContextExecutor) error {
+			return s.consumeTOTPStep(ctx, exec, t, step)
+		}
+	case MFAMethodRecoveryCode:
+		consumeFactor = func(ctx context.Context, exec boil.ContextExecutor) error {
+			return s.consumeRecoveryCode(ctx, exec, c, code)
+		}
+	}
+
+	user, err := s.consumeChallenge(ctx, c, consumeFactor)
+	if err != nil {
+		s.recordFailure(ctx, err, accountKey)
+		return nil, "", err
+	}
+
+	if method == MFAMethodRecoveryCode {
+		s.notifyRecoveryCodeUsed(ctx, user)
+	}
+	return user, c.DeviceLabel, nil
+}
+
+// consumeChallenge marks both the challenge and the second factor as used, failing if either of them has been used
+// concurrently.
+func (s *DBMFAService) consumeChallenge(ctx context.Context, c *model.MfaChallenge, consumeFactor func(context.Context, boil.ContextExecutor) error) (*model.User, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			logrus.WithError(err).Error()
+		}
+	}()
+
+	n, err := model.MfaChallenges(
+		model.MfaChallengeWhere.MfaChallengeID.EQ(c.MfaChallengeID),
+		model.MfaChallengeWhere.ConsumedAt.IsNull(),
+	).UpdateAll(ctx, tx, model.M{
+		model.MfaChallengeColumns.ConsumedAt: null.TimeFrom(s.clock.Now()),
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	if n == 0 {
+		return nil, errors.WithStack(ErrInvalidMFAChallenge)
+	}
+
+	if err := consumeFactor(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	// reset here rather than at the password, or a stolen password would allow guessing the codes without limit
+	if err := s.signInThrottle.Reset(ctx, tx, SignInThrottleScopeAccount, strconv.Itoa(c.UserID)); err != nil {
+		return nil, err
+	}
+
+	user, err := mysql.GetUser(ctx, tx, c.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return user, nil
+}
+
+// recordFailure counts a wrong code as a failed sign-in. It is only logged if failed, since the sign-in fails anyway.
+func (s *DBMFAService) recordFailure(ctx context.Context, err error, accountKey string) {
+	if errors.Cause(err) != ErrInvalidMFACode {
+		return
+	}
+	if err := s.signInThrottle.Fail(ctx, SignInThrottleScopeAccount, accountKey); err != nil {
+		logrus.WithError(err).Warn("failed to record sign-in failure")
+	}
+}
+
+func (s *DBMFAService) consumeTOTPStep(ctx context.Context, exec boil.ContextExecutor, t *model.UserTotp, step int64) error {
+	n, err := model.UserTotps(
+		model.UserTotpWhere.UserTotpID.EQ(t.UserTotpID),
+		model.UserTotpWhere.LastUsedStep.LT(step),
+	).UpdateAll(ctx, exec, model.M{
+		model.UserTotpColumns.LastUsedStep: step,
+	})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if n == 0 {
+		return errors.WithStack(ErrInvalidMFACode)
+	}
+	return nil
+}
+
+func (s *DBMFAService) consumeRecoveryCode(ctx context.Context, exec boil.ContextExecutor, c *model.MfaChallenge, code string) error {
+	n, err := model.MfaRecoveryCodes(
+		model.MfaRecoveryCodeWhere.UserID.EQ(c.UserID),
+		model.MfaRecoveryCodeWhere.CodeHash.EQ(hashRecoveryCode(code)),
+		model.MfaRecoveryCodeWhere.ConsumedAt.IsNull(),
+	).UpdateAll(ctx, exec, model.M{
+		model.MfaRecoveryCodeColumns.ConsumedAt: null.TimeFrom(s.clock.Now()),
+	})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if n == 0 {
+		return errors.WithStack(ErrInvalidMFACode)
+	}
+
+	// it is how a user who has stolen the password and lost nothing would get past 2FA
+	return RecordSecurityEvent(ctx, exec, c.UserID, SecurityEventTypeMFARecoveryCodeUsed, map[string]string{
+		"mfa_challenge_id": strconv.Itoa(c.MfaChallengeID),
+	})
+}
+
+// notifyRecoveryCodeUsed lets the user know, so that the account can be secured if the code was not used by them.
+// Failures are only logged since the sign-in has already succeeded.
+func (s *DBMFAService) notifyRecoveryCodeUsed(ctx context.Context, user *model.User) {
+	n, err := mysql.CountUnconsumedMFARecoveryCodesByUserID(ctx, s.db, user.UserID)
+	if err != nil {
+		logrus.WithError(err).Warn("failed to notify recovery code used")
+		return
+	}
+
+	if err := s.sender.Send(ctx, &client.Email{
+		To:      user.Email,
+		Subject: recoveryCodeUsedEmailSubject,
+		Body:    fmt.Sprintf(recoveryCodeUsedEmailBody, s.clock.Now().UTC().Format(time.RFC1123), n),
+	}); err != nil {
+		logrus.WithError(err).Warn("failed to notify recovery code used")
+	}
+}
+
+// replaceRecoveryCodes invalidates all the recovery codes of the user and returns new ones.
+func (s *DBMFAService) replaceRecoveryCodes(ctx context.Context, exec boil.ContextExecutor, userID int) ([]string, error) {
+	if _, err := model.MfaRecoveryCodes(model.MfaRecoveryCodeWhere.UserID.EQ(userID)).DeleteAll(ctx, exec); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	codes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		code, err := s.newRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+
+		r := &model.MfaRecoveryCode{
+			UserID:   userID,
+			CodeHash: hashRecoveryCode(code),
+		}
+		if err := r.Insert(ctx, exec, boil.Infer()); err != nil {
+			return nil, errors.WithStack(err)
+		}
+		codes = append(codes, code)
+	}
+	return codes, nil
+}
+
+// newRecoveryCode returns a code in groups separated by hyphens, e.g. `abcd-efgh-ijkl-mnop`, which is easier to
+// write down.
+func (s *DBMFAService) newRecoveryCode() (string, error) {
+	b := make([]byte, recoveryCodeLen)
+	if _, err := io.ReadFull(s.rand, b); err != nil {
+		return "", errors.WithStack(err)
+	}
+	encoded := strings.ToLower(crypto.TOTPSecretEncoding.EncodeToString(b))
+
+	groups := make([]string, 0, len(encoded)/recoveryCodeGroupSize)
+	for i := 0; i < len(encoded); i += recoveryCodeGroupSize {
+		groups = append(groups, encoded[i:i+recoveryCodeGroupSize])
+	}
+	return strings.Join(groups, "-"), nil
+}
+
+// verifyTOTP returns the time step of the code, rejecting codes which have already been used.
+func (s *DBMFAService) verifyTOTP(t *model.UserTotp, code string) (int64, error) {
+	secret, err := crypto.TOTPSecretEncoding.DecodeString(t.Secret)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+
+	step, ok := crypto.VerifyTOTP(secret, code, s.clock.Now())
+	if !ok || step <= t.LastUsedStep {
+		return 0, errors.WithStack(ErrInvalidMFACode)
+	}
+	return step, nil
+}
+
+// hashRecoveryCode digests the code as hashConfirmationCode does, ignoring case and separators which users may type
+// differently.
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	h := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(h[:])
+}
@@ -0,0 +1,127 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sean-ahn/user/backend/server/service (interfaces: MFAService)
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/sean-ahn/user/backend/model"
+)
+
+// MockMFAService is a mock of MFAService interface.
+type MockMFAService struct {
+	ctrl     *gomock.Controller
+	recorder *MockMFAServiceMockRecorder
+}
+
+// MockMFAServiceMockRecorder is the mock recorder for MockMFAService.
+type MockMFAServiceMockRecorder struct {
+	mock *MockMFAService
+}
+
+// NewMockMFAService creates a new mock instance.
+func NewMockMFAService(ctrl *gomock.Controller) *MockMFAService {
+	mock := &MockMFAService{ctrl: ctrl}
+	mock.recorder = &MockMFAServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMFAService) EXPECT() *MockMFAServiceMockRecorder {
+	return m.recorder
+}
+
+// BeginTOTPEnrollment mocks base method.
+func (m *MockMFAService) BeginTOTPEnrollment(arg0 context.Context, arg1 *model.User) (*TOTPEnrollment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginTOTPEnrollment", arg0, arg1)
+	ret0, _ := ret[0].(*TOTPEnrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginTOTPEnrollment indicates an expected call of BeginTOTPEnrollment.
+func (mr *MockMFAServiceMockRecorder) BeginTOTPEnrollment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTOTPEnrollment", reflect.TypeOf((*MockMFAService)(nil).BeginTOTPEnrollment), arg0, arg1)
+}
+
+// CompleteChallenge mocks base method.
+func (m *MockMFAService) CompleteChallenge(arg0 context.Context, arg1, arg2, arg3 string) (*model.User, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteChallenge", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*model.User)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CompleteChallenge indicates an expected call of CompleteChallenge.
+func (mr *MockMFAServiceMockRecorder) CompleteChallenge(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteChallenge", reflect.TypeOf((*MockMFAService)(nil).CompleteChallenge), arg0, arg1, arg2, arg3)
+}
+
+// ConfirmTOTPEnrollment mocks base method.
+func (m *MockMFAService) ConfirmTOTPEnrollment(arg0 context.Context, arg1 *model.User, arg2 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmTOTPEnrollment", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmTOTPEnrollment indicates an expected call of ConfirmTOTPEnrollment.
+func (mr *MockMFAServiceMockRecorder) ConfirmTOTPEnrollment(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmTOTPEnrollment", reflect.TypeOf((*MockMFAService)(nil).ConfirmTOTPEnrollment), arg0, arg1, arg2)
+}
+
+// CreateChallenge mocks base method.
+func (m *MockMFAService) CreateChallenge(arg0 context.Context, arg1 *model.User, arg2 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateChallenge", arg0, arg1, arg2)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateChallenge indicates an expected call of CreateChallenge.
+func (mr *MockMFAServiceMockRecorder) CreateChallenge(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChallenge", reflect.TypeOf((*MockMFAService)(nil).CreateChallenge), arg0, arg1, arg2)
+}
+
+// Methods mocks base method.
+func (m *MockMFAService) Methods(arg0 context.Context, arg1 *model.User) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Methods", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Methods indicates an expected call of Methods.
+func (mr *MockMFAServiceMockRecorder) Methods(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Methods", reflect.TypeOf((*MockMFAService)(nil).Methods), arg0, arg1)
+}
+
+// RegenerateRecoveryCodes mocks base method.
+func (m *MockMFAService) RegenerateRecoveryCodes(arg0 context.Context, arg1 *model.User) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegenerateRecoveryCodes", arg0, arg1)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegenerateRecoveryCodes indicates an expected call of RegenerateRecoveryCodes.
+func (mr *MockMFAServiceMockRecorder) RegenerateRecoveryCodes(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegenerateRecoveryCodes", reflect.TypeOf((*MockMFAService)(nil).RegenerateRecoveryCodes), arg0, arg1)
+}
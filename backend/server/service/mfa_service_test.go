@@ -0,0 +1,919 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/jonboulle/clockwork"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/volatiletech/null/v8"
+
+	"github.com/sean-ahn/user/backend/client"
+	"github.com/sean-ahn/user/backend/crypto"
+	"github.com/sean-ahn/user/backend/model"
+	"github.com/sean-ahn/user/backend/server/generator"
+	"github.com/sean-ahn/user/backend/test"
+)
+
+const (
+	testTOTPSecret   = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ" // "12345678901234567890"
+	testRecoveryCode = "aaaa-aaaa-aaaa-aaaa"              // generated from zeros
+)
+
+func expectReplaceRecoveryCodes(mock sqlmock.Sqlmock, userID int, now time.Time) {
+	mock.ExpectExec(regexp.QuoteMeta(
+		"DELETE FROM `mfa_recovery_code` WHERE (`mfa_recovery_code`.`user_id` = ?);",
+	)).WithArgs(
+		userID,
+	).WillReturnResult(
+		sqlmock.NewResult(0, 10),
+	)
+
+	for i := 1; i <= recoveryCodeCount; i++ {
+		mock.ExpectExec(regexp.QuoteMeta(
+			"INSERT INTO `mfa_recovery_code` (`user_id`,`code_hash`,`consumed_at`) VALUES (?,?,?)",
+		)).WithArgs(
+			userID, hashRecoveryCode(testRecoveryCode), nil,
+		).WillReturnResult(
+			sqlmock.NewResult(int64(i), 1),
+		)
+
+		mock.ExpectQuery(regexp.QuoteMeta(
+			"SELECT `mfa_recovery_code_id`,`created_at`,`updated_at` FROM `mfa_recovery_code` WHERE `mfa_recovery_code_id`=?",
+		)).WithArgs(
+			i,
+		).WillReturnRows(sqlmock.NewRows([]string{"mfa_recovery_code_id", "created_at", "updated_at"}).
+			AddRow(i, now, now),
+		)
+	}
+}
+
+func testRecoveryCodes() []string {
+	codes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		codes[i] = testRecoveryCode
+	}
+	return codes
+}
+
+func TestDBMFAService_BeginTOTPEnrollment(t *testing.T) {
+	now := time.Date(2021, 10, 24, 7, 39, 46, 127956672, time.UTC)
+
+	cases := []struct {
+		name string
+
+		dbExpectFunc func(sqlmock.Sqlmock)
+
+		expectedErr string
+	}{
+		{
+			name: "begin",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user_totp` WHERE (`user_totp`.`user_id` = ?) LIMIT 1;",
+				)).WithArgs(
+					1,
+				).WillReturnError(
+					sql.ErrNoRows,
+				)
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"INSERT INTO `user_totp` (`user_id`,`secret`,`confirmed_at`,`last_used_step`) VALUES (?,?,?,?)",
+				)).WithArgs(
+					1, sqlmock.AnyArg(), nil, 0,
+				).WillReturnResult(
+					sqlmock.NewResult(1, 1),
+				)
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT `user_totp_id`,`created_at`,`updated_at` FROM `user_totp` WHERE `user_totp_id`=?",
+				)).WithArgs(
+					1,
+				).WillReturnRows(sqlmock.NewRows([]string{"user_totp_id", "created_at", "updated_at"}).
+					AddRow(1, now, now),
+				)
+			},
+		},
+		{
+			name: "replace unconfirmed",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user_totp` WHERE (`user_totp`.`user_id` = ?) LIMIT 1;",
+				)).WithArgs(
+					1,
+				).WillReturnRows(test.NewUserTotpRows([]*model.UserTotp{
+					{UserTotpID: 1, UserID: 1, Secret: testTOTPSecret},
+				}))
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"UPDATE `user_totp` SET `secret`=?,`last_used_step`=? WHERE `user_totp_id`=?",
+				)).WithArgs(
+					sqlmock.AnyArg(), 0, 1,
+				).WillReturnResult(
+					sqlmock.NewResult(0, 1),
+				)
+			},
+		},
+		{
+			name: "already enabled",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user_totp` WHERE (`user_totp`.`user_id` = ?) LIMIT 1;",
+				)).WithArgs(
+					1,
+				).WillReturnRows(test.NewUserTotpRows([]*model.UserTotp{
+					{UserTotpID: 1, UserID: 1, Secret: testTOTPSecret, ConfirmedAt: null.TimeFrom(now.Add(-time.Hour))},
+				}))
+			},
+			expectedErr: "totp already enabled",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fail()
+			}
+			defer test.CloseSqlmock(t, db, mock)
+
+			if tc.dbExpectFunc != nil {
+				tc.dbExpectFunc(mock)
+			}
+
+			svc := DBMFAService{
+				clock:       clockwork.NewFakeClockAt(now),
+				db:          db,
+				idGenerator: &generator.UUIDGenerator{},
+				totpIssuer:  "user",
+			}
+
+			enrollment, err := svc.BeginTOTPEnrollment(ctx, &model.User{UserID: 1, Email: "john.doe@example.com"})
+
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+
+			secret, err := crypto.TOTPSecretEncoding.DecodeString(enrollment.Secret)
+			assert.NoError(t, err)
+			assert.Len(t, secret, crypto.TOTPSecretLen)
+			assert.Equal(t, crypto.TOTPURI("user", "john.doe@example.com", secret), enrollment.URI)
+		})
+	}
+}
+
+func TestDBMFAService_ConfirmTOTPEnrollment(t *testing.T) {
+	now := time.Date(2021, 10, 24, 7, 39, 46, 127956672, time.UTC)
+	secret, _ := crypto.TOTPSecretEncoding.DecodeString(testTOTPSecret)
+	step := crypto.TOTPStep(now)
+
+	cases := []struct {
+		name string
+		code string
+
+		dbExpectFunc func(sqlmock.Sqlmock)
+
+		expectedCodes []string
+		expectedErr   string
+	}{
+		{
+			name: "confirm",
+			code: crypto.TOTPCode(secret, step),
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user_totp` WHERE (`user_totp`.`user_id` = ?) LIMIT 1;",
+				)).WithArgs(
+					1,
+				).WillReturnRows(test.NewUserTotpRows([]*model.UserTotp{
+					{UserTotpID: 1, UserID: 1, Secret: testTOTPSecret},
+				}))
+
+				mock.ExpectBegin()
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"UPDATE `user_totp` SET `confirmed_at`=?,`last_used_step`=? WHERE `user_totp_id`=?",
+				)).WithArgs(
+					now, step, 1,
+				).WillReturnResult(
+					sqlmock.NewResult(0, 1),
+				)
+
+				expectReplaceRecoveryCodes(mock, 1, now)
+
+				mock.ExpectCommit()
+			},
+			expectedCodes: testRecoveryCodes(),
+		},
+		{
+			name: "incorrect code",
+			code: crypto.TOTPCode(secret, step+2),
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user_totp` WHERE (`user_totp`.`user_id` = ?) LIMIT 1;",
+				)).WithArgs(
+					1,
+				).WillReturnRows(test.NewUserTotpRows([]*model.UserTotp{
+					{UserTotpID: 1, UserID: 1, Secret: testTOTPSecret},
+				}))
+			},
+			expectedErr: "invalid mfa code",
+		},
+		{
+			name: "not enrolled",
+			code: crypto.TOTPCode(secret, step),
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user_totp` WHERE (`user_totp`.`user_id` = ?) LIMIT 1;",
+				)).WithArgs(
+					1,
+				).WillReturnError(
+					sql.ErrNoRows,
+				)
+			},
+			expectedErr: "totp enrollment not found",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fail()
+			}
+			defer test.CloseSqlmock(t, db, mock)
+
+			if tc.dbExpectFunc != nil {
+				tc.dbExpectFunc(mock)
+			}
+
+			svc := DBMFAService{
+				clock:       clockwork.NewFakeClockAt(now),
+				db:          db,
+				idGenerator: &generator.UUIDGenerator{},
+				rand:        bytes.NewReader(make([]byte, recoveryCodeCount*recoveryCodeLen)),
+				totpIssuer:  "user",
+			}
+
+			codes, err := svc.ConfirmTOTPEnrollment(ctx, &model.User{UserID: 1}, tc.code)
+
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedCodes, codes)
+		})
+	}
+}
+
+func TestDBMFAService_Methods(t *testing.T) {
+	now := time.Date(2021, 10, 24, 7, 39, 46, 127956672, time.UTC)
+
+	cases := []struct {
+		name string
+
+		dbExpectFunc func(sqlmock.Sqlmock)
+
+		expected []string
+	}{
+		{
+			name: "totp enabled",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user_totp` WHERE (`user_totp`.`user_id` = ?) LIMIT 1;",
+				)).WithArgs(
+					1,
+				).WillReturnRows(test.NewUserTotpRows([]*model.UserTotp{
+					{UserTotpID: 1, UserID: 1, Secret: testTOTPSecret, ConfirmedAt: null.TimeFrom(now)},
+				}))
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT COUNT(*) FROM `mfa_recovery_code` WHERE (`mfa_recovery_code`.`user_id` = ?) AND (`mfa_recovery_code`.`consumed_at` is null);",
+				)).WithArgs(
+					1,
+				).WillReturnRows(sqlmock.NewRows([]string{"count"}).
+					AddRow(3),
+				)
+			},
+			expected: []string{MFAMethodTOTP, MFAMethodRecoveryCode},
+		},
+		{
+			name: "all recovery codes consumed",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user_totp` WHERE (`user_totp`.`user_id` = ?) LIMIT 1;",
+				)).WithArgs(
+					1,
+				).WillReturnRows(test.NewUserTotpRows([]*model.UserTotp{
+					{UserTotpID: 1, UserID: 1, Secret: testTOTPSecret, ConfirmedAt: null.TimeFrom(now)},
+				}))
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT COUNT(*) FROM `mfa_recovery_code` WHERE (`mfa_recovery_code`.`user_id` = ?) AND (`mfa_recovery_code`.`consumed_at` is null);",
+				)).WithArgs(
+					1,
+				).WillReturnRows(sqlmock.NewRows([]string{"count"}).
+					AddRow(0),
+				)
+			},
+			expected: []string{MFAMethodTOTP},
+		},
+		{
+			name: "totp not confirmed",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user_totp` WHERE (`user_totp`.`user_id` = ?) LIMIT 1;",
+				)).WithArgs(
+					1,
+				).WillReturnRows(test.NewUserTotpRows([]*model.UserTotp{
+					{UserTotpID: 1, UserID: 1, Secret: testTOTPSecret},
+				}))
+			},
+		},
+		{
+			name: "none",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user_totp` WHERE (`user_totp`.`user_id` = ?) LIMIT 1;",
+				)).WithArgs(
+					1,
+				).WillReturnError(
+					sql.ErrNoRows,
+				)
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fail()
+			}
+			defer test.CloseSqlmock(t, db, mock)
+
+			if tc.dbExpectFunc != nil {
+				tc.dbExpectFunc(mock)
+			}
+
+			svc := DBMFAService{
+				clock: clockwork.NewFakeClockAt(now),
+				db:    db,
+			}
+
+			got, err := svc.Methods(ctx, &model.User{UserID: 1})
+
+			assert.NoError(t, err)
+			assert.Empty(t, cmp.Diff(tc.expected, got))
+		})
+	}
+}
+
+func TestDBMFAService_CompleteChallenge(t *testing.T) {
+	now := time.Date(2021, 10, 24, 7, 39, 46, 127956672, time.UTC)
+	secret, _ := crypto.TOTPSecretEncoding.DecodeString(testTOTPSecret)
+	step := crypto.TOTPStep(now)
+
+	// wrong codes are counted as failed sign-ins of the account, which are reset once a code is verified
+	signInThrottleChecked := func(mock *MockSignInThrottle) {
+		mock.EXPECT().Check(gomock.Any(), SignInThrottleScopeAccount, "1").Return(nil)
+	}
+	signInThrottleReset := func(mock *MockSignInThrottle) {
+		signInThrottleChecked(mock)
+		mock.EXPECT().Reset(gomock.Any(), gomock.Any(), SignInThrottleScopeAccount, "1").Return(nil)
+	}
+	signInThrottleFailed := func(mock *MockSignInThrottle) {
+		signInThrottleChecked(mock)
+		mock.EXPECT().Fail(gomock.Any(), SignInThrottleScopeAccount, "1").Return(nil)
+	}
+
+	cases := []struct {
+		name   string
+		method string
+		code   string
+
+		dbExpectFunc             func(sqlmock.Sqlmock)
+		signInThrottleExpectFunc func(*MockSignInThrottle)
+		emailSenderExpectFunc    func(*client.MockEmailSender)
+
+		expectedUser        *model.User
+		expectedDeviceLabel string
+		expectedErr         string
+	}{
+		{
+			name:   "complete",
+			method: MFAMethodTOTP,
+			code:   crypto.TOTPCode(secret, step),
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `mfa_challenge` WHERE (`mfa_challenge`.`challenge_token` = ?) LIMIT 1;",
+				)).WithArgs(
+					"mfa_challenge_token",
+				).WillReturnRows(test.NewMfaChallengeRows([]*model.MfaChallenge{
+					{MfaChallengeID: 1, ChallengeToken: "mfa_challenge_token", UserID: 1, DeviceLabel: "Chrome on macOS", ExpiresAt: now.Add(time.Minute)},
+				}))
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"UPDATE `mfa_challenge` SET `verification_trials`=? WHERE `mfa_challenge_id`=?",
+				)).WithArgs(
+					1, 1,
+				).WillReturnResult(
+					sqlmock.NewResult(0, 1),
+				)
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user_totp` WHERE (`user_totp`.`user_id` = ?) LIMIT 1;",
+				)).WithArgs(
+					1,
+				).WillReturnRows(test.NewUserTotpRows([]*model.UserTotp{
+					{UserTotpID: 1, UserID: 1, Secret: testTOTPSecret, ConfirmedAt: null.TimeFrom(now.Add(-time.Hour)), LastUsedStep: step - 1},
+				}))
+
+				mock.ExpectBegin()
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"UPDATE `mfa_challenge` SET `consumed_at` = ? WHERE (`mfa_challenge`.`mfa_challenge_id` = ?) AND (`mfa_challenge`.`consumed_at` is null);",
+				)).WithArgs(
+					now, 1,
+				).WillReturnResult(
+					sqlmock.NewResult(0, 1),
+				)
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"UPDATE `user_totp` SET `last_used_step` = ? WHERE (`user_totp`.`user_totp_id` = ?) AND (`user_totp`.`last_used_step` < ?);",
+				)).WithArgs(
+					step, 1, step,
+				).WillReturnResult(
+					sqlmock.NewResult(0, 1),
+				)
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user` WHERE (`user`.`user_id` = ?) LIMIT 1;",
+				)).WithArgs(
+					1,
+				).WillReturnRows(test.NewUserRows([]*model.User{
+					{UserID: 1, Email: "john.doe@example.com"},
+				}))
+
+				mock.ExpectCommit()
+			},
+			signInThrottleExpectFunc: signInThrottleReset,
+			expectedUser:             &model.User{UserID: 1, Email: "john.doe@example.com"},
+			expectedDeviceLabel:      "Chrome on macOS",
+		},
+		{
+			name:   "complete with recovery code",
+			method: MFAMethodRecoveryCode,
+			code:   "AAAA-AAAA-AAAA-AAAA",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `mfa_challenge` WHERE (`mfa_challenge`.`challenge_token` = ?) LIMIT 1;",
+				)).WithArgs(
+					"mfa_challenge_token",
+				).WillReturnRows(test.NewMfaChallengeRows([]*model.MfaChallenge{
+					{MfaChallengeID: 1, ChallengeToken: "mfa_challenge_token", UserID: 1, DeviceLabel: "Chrome on macOS", ExpiresAt: now.Add(time.Minute)},
+				}))
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"UPDATE `mfa_challenge` SET `verification_trials`=? WHERE `mfa_challenge_id`=?",
+				)).WithArgs(
+					1, 1,
+				).WillReturnResult(
+					sqlmock.NewResult(0, 1),
+				)
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user_totp` WHERE (`user_totp`.`user_id` = ?) LIMIT 1;",
+				)).WithArgs(
+					1,
+				).WillReturnRows(test.NewUserTotpRows([]*model.UserTotp{
+					{UserTotpID: 1, UserID: 1, Secret: testTOTPSecret, ConfirmedAt: null.TimeFrom(now.Add(-time.Hour))},
+				}))
+
+				mock.ExpectBegin()
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"UPDATE `mfa_challenge` SET `consumed_at` = ? WHERE (`mfa_challenge`.`mfa_challenge_id` = ?) AND (`mfa_challenge`.`consumed_at` is null);",
+				)).WithArgs(
+					now, 1,
+				).WillReturnResult(
+					sqlmock.NewResult(0, 1),
+				)
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"UPDATE `mfa_recovery_code` SET `consumed_at` = ? WHERE (`mfa_recovery_code`.`user_id` = ?) AND (`mfa_recovery_code`.`code_hash` = ?) AND (`mfa_recovery_code`.`consumed_at` is null);",
+				)).WithArgs(
+					now, 1, hashRecoveryCode(testRecoveryCode),
+				).WillReturnResult(
+					sqlmock.NewResult(0, 1),
+				)
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"INSERT INTO `security_event` (`user_id`,`event_type`,`detail`) VALUES (?,?,?)",
+				)).WithArgs(
+					1, SecurityEventTypeMFARecoveryCodeUsed, `{"mfa_challenge_id":"1"}`,
+				).WillReturnResult(
+					sqlmock.NewResult(1, 1),
+				)
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT `security_event_id`,`created_at`,`updated_at` FROM `security_event` WHERE `security_event_id`=?",
+				)).WithArgs(
+					1,
+				).WillReturnRows(sqlmock.NewRows([]string{"security_event_id", "created_at", "updated_at"}).
+					AddRow(1, now, now),
+				)
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user` WHERE (`user`.`user_id` = ?) LIMIT 1;",
+				)).WithArgs(
+					1,
+				).WillReturnRows(test.NewUserRows([]*model.User{
+					{UserID: 1, Email: "john.doe@example.com"},
+				}))
+
+				mock.ExpectCommit()
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT COUNT(*) FROM `mfa_recovery_code` WHERE (`mfa_recovery_code`.`user_id` = ?) AND (`mfa_recovery_code`.`consumed_at` is null);",
+				)).WithArgs(
+					1,
+				).WillReturnRows(sqlmock.NewRows([]string{"count"}).
+					AddRow(9),
+				)
+			},
+			signInThrottleExpectFunc: signInThrottleReset,
+			emailSenderExpectFunc: func(mock *client.MockEmailSender) {
+				mock.EXPECT().
+					Send(gomock.Any(), &client.Email{
+						To:      "john.doe@example.com",
+						Subject: "A recovery code was used to sign in",
+						Body: `A recovery code was used to sign in to your account at Sun, 24 Oct 2021 07:39:46 UTC.
+You have 9 recovery codes left.
+
+If this was not you, change your password and regenerate your recovery codes.
+`,
+					}).
+					Return(nil)
+			},
+			expectedUser:        &model.User{UserID: 1, Email: "john.doe@example.com"},
+			expectedDeviceLabel: "Chrome on macOS",
+		},
+		{
+			name:   "consumed recovery code",
+			method: MFAMethodRecoveryCode,
+			code:   testRecoveryCode,
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `mfa_challenge` WHERE (`mfa_challenge`.`challenge_token` = ?) LIMIT 1;",
+				)).WithArgs(
+					"mfa_challenge_token",
+				).WillReturnRows(test.NewMfaChallengeRows([]*model.MfaChallenge{
+					{MfaChallengeID: 1, ChallengeToken: "mfa_challenge_token", UserID: 1, ExpiresAt: now.Add(time.Minute)},
+				}))
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"UPDATE `mfa_challenge` SET `verification_trials`=? WHERE `mfa_challenge_id`=?",
+				)).WithArgs(
+					1, 1,
+				).WillReturnResult(
+					sqlmock.NewResult(0, 1),
+				)
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user_totp` WHERE (`user_totp`.`user_id` = ?) LIMIT 1;",
+				)).WithArgs(
+					1,
+				).WillReturnRows(test.NewUserTotpRows([]*model.UserTotp{
+					{UserTotpID: 1, UserID: 1, Secret: testTOTPSecret, ConfirmedAt: null.TimeFrom(now.Add(-time.Hour))},
+				}))
+
+				mock.ExpectBegin()
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"UPDATE `mfa_challenge` SET `consumed_at` = ? WHERE (`mfa_challenge`.`mfa_challenge_id` = ?) AND (`mfa_challenge`.`consumed_at` is null);",
+				)).WithArgs(
+					now, 1,
+				).WillReturnResult(
+					sqlmock.NewResult(0, 1),
+				)
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"UPDATE `mfa_recovery_code` SET `consumed_at` = ? WHERE (`mfa_recovery_code`.`user_id` = ?) AND (`mfa_recovery_code`.`code_hash` = ?) AND (`mfa_recovery_code`.`consumed_at` is null);",
+				)).WithArgs(
+					now, 1, hashRecoveryCode(testRecoveryCode),
+				).WillReturnResult(
+					sqlmock.NewResult(0, 0),
+				)
+
+				mock.ExpectRollback()
+			},
+			signInThrottleExpectFunc: signInThrottleFailed,
+			expectedErr:              "invalid mfa code",
+		},
+		{
+			name:        "unsupported method",
+			method:      "sms",
+			code:        crypto.TOTPCode(secret, step),
+			expectedErr: "unsupported mfa method",
+		},
+		{
+			name:   "expired",
+			method: MFAMethodTOTP,
+			code:   crypto.TOTPCode(secret, step),
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `mfa_challenge` WHERE (`mfa_challenge`.`challenge_token` = ?) LIMIT 1;",
+				)).WithArgs(
+					"mfa_challenge_token",
+				).WillReturnRows(test.NewMfaChallengeRows([]*model.MfaChallenge{
+					{MfaChallengeID: 1, ChallengeToken: "mfa_challenge_token", UserID: 1, ExpiresAt: now.Add(-time.Second)},
+				}))
+			},
+			expectedErr: "invalid mfa challenge",
+		},
+		{
+			name:   "locked out",
+			method: MFAMethodTOTP,
+			code:   crypto.TOTPCode(secret, step),
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `mfa_challenge` WHERE (`mfa_challenge`.`challenge_token` = ?) LIMIT 1;",
+				)).WithArgs(
+					"mfa_challenge_token",
+				).WillReturnRows(test.NewMfaChallengeRows([]*model.MfaChallenge{
+					{MfaChallengeID: 1, ChallengeToken: "mfa_challenge_token", UserID: 1, ExpiresAt: now.Add(time.Minute)},
+				}))
+			},
+			signInThrottleExpectFunc: func(mock *MockSignInThrottle) {
+				mock.EXPECT().
+					Check(gomock.Any(), SignInThrottleScopeAccount, "1").
+					Return(errors.WithStack(&RateLimitedError{Limit: SignInLimitLockout, RetryAfter: time.Hour}))
+			},
+			expectedErr: "rate limited by sign_in_lockout",
+		},
+		{
+			name:   "too many trials",
+			method: MFAMethodTOTP,
+			code:   crypto.TOTPCode(secret, step),
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `mfa_challenge` WHERE (`mfa_challenge`.`challenge_token` = ?) LIMIT 1;",
+				)).WithArgs(
+					"mfa_challenge_token",
+				).WillReturnRows(test.NewMfaChallengeRows([]*model.MfaChallenge{
+					{MfaChallengeID: 1, ChallengeToken: "mfa_challenge_token", UserID: 1, ExpiresAt: now.Add(time.Minute), VerificationTrials: 5},
+				}))
+			},
+			expectedErr: "invalid mfa challenge",
+		},
+		{
+			name:   "replayed code",
+			method: MFAMethodTOTP,
+			code:   crypto.TOTPCode(secret, step),
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `mfa_challenge` WHERE (`mfa_challenge`.`challenge_token` = ?) LIMIT 1;",
+				)).WithArgs(
+					"mfa_challenge_token",
+				).WillReturnRows(test.NewMfaChallengeRows([]*model.MfaChallenge{
+					{MfaChallengeID: 1, ChallengeToken: "mfa_challenge_token", UserID: 1, ExpiresAt: now.Add(time.Minute)},
+				}))
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"UPDATE `mfa_challenge` SET `verification_trials`=? WHERE `mfa_challenge_id`=?",
+				)).WithArgs(
+					1, 1,
+				).WillReturnResult(
+					sqlmock.NewResult(0, 1),
+				)
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user_totp` WHERE (`user_totp`.`user_id` = ?) LIMIT 1;",
+				)).WithArgs(
+					1,
+				).WillReturnRows(test.NewUserTotpRows([]*model.UserTotp{
+					{UserTotpID: 1, UserID: 1, Secret: testTOTPSecret, ConfirmedAt: null.TimeFrom(now.Add(-time.Hour)), LastUsedStep: step},
+				}))
+			},
+			signInThrottleExpectFunc: signInThrottleFailed,
+			expectedErr:              "invalid mfa code",
+		},
+		{
+			name:   "consumed concurrently",
+			method: MFAMethodTOTP,
+			code:   crypto.TOTPCode(secret, step),
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `mfa_challenge` WHERE (`mfa_challenge`.`challenge_token` = ?) LIMIT 1;",
+				)).WithArgs(
+					"mfa_challenge_token",
+				).WillReturnRows(test.NewMfaChallengeRows([]*model.MfaChallenge{
+					{MfaChallengeID: 1, ChallengeToken: "mfa_challenge_token", UserID: 1, ExpiresAt: now.Add(time.Minute)},
+				}))
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"UPDATE `mfa_challenge` SET `verification_trials`=? WHERE `mfa_challenge_id`=?",
+				)).WithArgs(
+					1, 1,
+				).WillReturnResult(
+					sqlmock.NewResult(0, 1),
+				)
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user_totp` WHERE (`user_totp`.`user_id` = ?) LIMIT 1;",
+				)).WithArgs(
+					1,
+				).WillReturnRows(test.NewUserTotpRows([]*model.UserTotp{
+					{UserTotpID: 1, UserID: 1, Secret: testTOTPSecret, ConfirmedAt: null.TimeFrom(now.Add(-time.Hour))},
+				}))
+
+				mock.ExpectBegin()
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"UPDATE `mfa_challenge` SET `consumed_at` = ? WHERE (`mfa_challenge`.`mfa_challenge_id` = ?) AND (`mfa_challenge`.`consumed_at` is null);",
+				)).WithArgs(
+					now, 1,
+				).WillReturnResult(
+					sqlmock.NewResult(0, 0),
+				)
+
+				mock.ExpectRollback()
+			},
+			signInThrottleExpectFunc: signInThrottleChecked,
+			expectedErr:              "invalid mfa challenge",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fail()
+			}
+			defer test.CloseSqlmock(t, db, mock)
+
+			if tc.dbExpectFunc != nil {
+				tc.dbExpectFunc(mock)
+			}
+
+			ctrl := gomock.NewController(t)
+
+			mockSignInThrottle := NewMockSignInThrottle(ctrl)
+			if tc.signInThrottleExpectFunc != nil {
+				tc.signInThrottleExpectFunc(mockSignInThrottle)
+			}
+
+			mockEmailSender := client.NewMockEmailSender(ctrl)
+			if tc.emailSenderExpectFunc != nil {
+				tc.emailSenderExpectFunc(mockEmailSender)
+			}
+
+			svc := DBMFAService{
+				clock:          clockwork.NewFakeClockAt(now),
+				db:             db,
+				sender:         mockEmailSender,
+				signInThrottle: mockSignInThrottle,
+				idGenerator:    &generator.UUIDGenerator{},
+				totpIssuer:     "user",
+			}
+
+			user, deviceLabel, err := svc.CompleteChallenge(ctx, "mfa_challenge_token", tc.method, tc.code)
+
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedUser, user)
+			assert.Equal(t, tc.expectedDeviceLabel, deviceLabel)
+		})
+	}
+}
+
+func TestDBMFAService_RegenerateRecoveryCodes(t *testing.T) {
+	now := time.Date(2021, 10, 24, 7, 39, 46, 127956672, time.UTC)
+
+	cases := []struct {
+		name string
+
+		dbExpectFunc func(sqlmock.Sqlmock)
+
+		expectedCodes []string
+		expectedErr   string
+	}{
+		{
+			name: "regenerate",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user_totp` WHERE (`user_totp`.`user_id` = ?) LIMIT 1;",
+				)).WithArgs(
+					1,
+				).WillReturnRows(test.NewUserTotpRows([]*model.UserTotp{
+					{UserTotpID: 1, UserID: 1, Secret: testTOTPSecret, ConfirmedAt: null.TimeFrom(now.Add(-time.Hour))},
+				}))
+
+				mock.ExpectBegin()
+
+				expectReplaceRecoveryCodes(mock, 1, now)
+
+				mock.ExpectCommit()
+			},
+			expectedCodes: testRecoveryCodes(),
+		},
+		{
+			name: "totp not confirmed",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user_totp` WHERE (`user_totp`.`user_id` = ?) LIMIT 1;",
+				)).WithArgs(
+					1,
+				).WillReturnRows(test.NewUserTotpRows([]*model.UserTotp{
+					{UserTotpID: 1, UserID: 1, Secret: testTOTPSecret},
+				}))
+			},
+			expectedErr: "mfa not enabled",
+		},
+		{
+			name: "no totp",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user_totp` WHERE (`user_totp`.`user_id` = ?) LIMIT 1;",
+				)).WithArgs(
+					1,
+				).WillReturnError(
+					sql.ErrNoRows,
+				)
+			},
+			expectedErr: "mfa not enabled",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fail()
+			}
+			defer test.CloseSqlmock(t, db, mock)
+
+			if tc.dbExpectFunc != nil {
+				tc.dbExpectFunc(mock)
+			}
+
+			svc := DBMFAService{
+				clock: clockwork.NewFakeClockAt(now),
+				db:    db,
+				rand:  bytes.NewReader(make([]byte, recoveryCodeCount*recoveryCodeLen)),
+			}
+
+			codes, err := svc.RegenerateRecoveryCodes(ctx, &model.User{UserID: 1})
+
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedCodes, codes)
+		})
+	}
+}
+
+func Test_hashRecoveryCode(t *testing.T) {
+	expected := hashRecoveryCode("abcd-efgh-ijkl-mnop")
+
+	assert.Equal(t, expected, hashRecoveryCode("ABCD-EFGH-IJKL-MNOP"))
+	assert.Equal(t, expected, hashRecoveryCode("abcdefghijklmnop"))
+	assert.Equal(t, expected, hashRecoveryCode("abcd efgh ijkl mnop"))
+	assert.NotEqual(t, expected, hashRecoveryCode("abcd-efgh-ijkl-mnoq"))
+}
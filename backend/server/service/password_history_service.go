@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/pkg/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+
+	"github.com/sean-ahn/user/backend/crypto"
+	"github.com/sean-ahn/user/backend/model"
+	"github.com/sean-ahn/user/backend/persistence/mysql"
+)
+
+const (
+	PasswordLimitMinAge = "password_min_age"
+)
+
+var ErrPasswordReused = errors.New("recently used password")
+
+//go:generate mockgen -package service -destination ./password_history_service_mock.go -mock_names PasswordHistoryService=MockPasswordHistoryService github.com/sean-ahn/user/backend/server/service PasswordHistoryService
+
+type PasswordHistoryService interface {
+	// CheckReuse returns ErrPasswordReused if the password is the current one of the user or one of the recent ones.
+	CheckReuse(ctx context.Context, user *model.User, password string) error
+	// CheckMinAge returns *RateLimitedError, whose Limit is PasswordLimitMinAge, if the current password of the user
+	// has been set too recently to be changed.
+	CheckMinAge(ctx context.Context, user *model.User) error
+	// Record keeps the password hash the user is replacing, which should be called in the transaction updating it.
+	Record(ctx context.Context, exec boil.ContextExecutor, user *model.User, replacedHash string) error
+}
+
+type PasswordHistoryPolicy struct {
+	// Size is the number of the latest passwords, including the current one, which cannot be set again.
+	// The reuse is allowed if Size is zero.
+	Size int
+	// MinAge is how long a password should be kept before changed again, so that the history cannot be cycled through
+	// at once to set an old one. Disabled if zero.
+	MinAge time.Duration
+}
+
+// DBPasswordHistoryService keeps the replaced password hashes in password_history table, at most the ones needed by
+// the policy.
+type DBPasswordHistoryService struct {
+	clock  clockwork.Clock
+	db     *sql.DB
+	hasher crypto.Hasher
+	policy PasswordHistoryPolicy
+}
+
+var _ PasswordHistoryService = (*DBPasswordHistoryService)(nil)
+
+func NewDBPasswordHistoryService(clock clockwork.Clock, db *sql.DB, hasher crypto.Hasher, policy PasswordHistoryPolicy) *DBPasswordHistoryService {
+	return &DBPasswordHistoryService{
+		clock:  clock,
+		db:     db,
+		hasher: hasher,
+		policy: policy,
+	}
+}
+
+func (s *DBPasswordHistoryService) CheckReuse(ctx context.Context, user *model.User, password string) error {
+	if s.policy.Size <= 0 {
+		return nil
+	}
+
+	hashes := []string{user.PasswordHash}
+	if s.policy.Size > 1 {
+		hs, err := mysql.ListRecentPasswordHistoriesByUserID(ctx, s.db, user.UserID, s.policy.Size-1)
+		if err != nil {
+			return err
+		}
+		for _, h := range hs {
+			hashes = append(hashes, h.PasswordHash)
+		}
+	}
+
+	for _, hash := range hashes {
+		ok, err := s.hasher.Verify([]byte(hash), []byte(password))
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if ok {
+			return errors.WithStack(ErrPasswordReused)
+		}
+	}
+	return nil
+}
+
+func (s *DBPasswordHistoryService) CheckMinAge(ctx context.Context, user *model.User) error {
+	if s.policy.MinAge <= 0 {
+		return nil
+	}
+
+	// the current password has been set when the last one was replaced. there is none if never changed.
+	hs, err := mysql.ListRecentPasswordHistoriesByUserID(ctx, s.db, user.UserID, 1)
+	if err != nil {
+		return err
+	}
+	if len(hs) == 0 {
+		return nil
+	}
+
+	now := s.clock.Now()
+	changeableAt := hs[0].CreatedAt.Add(s.policy.MinAge)
+	if !changeableAt.After(now) {
+		return nil
+	}
+	return errors.WithStack(&RateLimitedError{Limit: PasswordLimitMinAge, RetryAfter: changeableAt.Sub(now)})
+}
+
+func (s *DBPasswordHistoryService) Record(ctx context.Context, exec boil.ContextExecutor, user *model.User, replacedHash string) error {
+	h := &model.PasswordHistory{
+		UserID:       user.UserID,
+		PasswordHash: replacedHash,
+	}
+	if err := h.Insert(ctx, exec, boil.Infer()); err != nil {
+		return errors.WithStack(err)
+	}
+
+	// the latest one is kept for CheckMinAge even if the reuse is allowed
+	keep := s.policy.Size - 1
+	if keep < 1 {
+		keep = 1
+	}
+
+	hs, err := mysql.ListRecentPasswordHistoriesByUserID(ctx, exec, user.UserID, keep)
+	if err != nil {
+		return err
+	}
+	if len(hs) < keep {
+		return nil
+	}
+
+	if _, err := model.PasswordHistories(
+		model.PasswordHistoryWhere.UserID.EQ(user.UserID),
+		model.PasswordHistoryWhere.PasswordHistoryID.LT(hs[len(hs)-1].PasswordHistoryID),
+	).DeleteAll(ctx, exec); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
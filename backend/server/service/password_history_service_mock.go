@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sean-ahn/user/backend/server/service (interfaces: PasswordHistoryService)
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/sean-ahn/user/backend/model"
+	boil "github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// MockPasswordHistoryService is a mock of PasswordHistoryService interface.
+type MockPasswordHistoryService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPasswordHistoryServiceMockRecorder
+}
+
+// MockPasswordHistoryServiceMockRecorder is the mock recorder for MockPasswordHistoryService.
+type MockPasswordHistoryServiceMockRecorder struct {
+	mock *MockPasswordHistoryService
+}
+
+// NewMockPasswordHistoryService creates a new mock instance.
+func NewMockPasswordHistoryService(ctrl *gomock.Controller) *MockPasswordHistoryService {
+	mock := &MockPasswordHistoryService{ctrl: ctrl}
+	mock.recorder = &MockPasswordHistoryServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPasswordHistoryService) EXPECT() *MockPasswordHistoryServiceMockRecorder {
+	return m.recorder
+}
+
+// CheckMinAge mocks base method.
+func (m *MockPasswordHistoryService) CheckMinAge(arg0 context.Context, arg1 *model.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckMinAge", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckMinAge indicates an expected call of CheckMinAge.
+func (mr *MockPasswordHistoryServiceMockRecorder) CheckMinAge(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckMinAge", reflect.TypeOf((*MockPasswordHistoryService)(nil).CheckMinAge), arg0, arg1)
+}
+
+// CheckReuse mocks base method.
+func (m *MockPasswordHistoryService) CheckReuse(arg0 context.Context, arg1 *model.User, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckReuse", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckReuse indicates an expected call of CheckReuse.
+func (mr *MockPasswordHistoryServiceMockRecorder) CheckReuse(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckReuse", reflect.TypeOf((*MockPasswordHistoryService)(nil).CheckReuse), arg0, arg1, arg2)
+}
+
+// Record mocks base method.
+func (m *MockPasswordHistoryService) Record(arg0 context.Context, arg1 boil.ContextExecutor, arg2 *model.User, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockPasswordHistoryServiceMockRecorder) Record(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockPasswordHistoryService)(nil).Record), arg0, arg1, arg2, arg3)
+}
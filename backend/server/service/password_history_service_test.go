@@ -0,0 +1,282 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jonboulle/clockwork"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/sean-ahn/user/backend/crypto"
+	"github.com/sean-ahn/user/backend/model"
+	"github.com/sean-ahn/user/backend/test"
+)
+
+const listPasswordHistoriesQuery = "SELECT * FROM `password_history` WHERE (`password_history`.`user_id` = ?) ORDER BY password_history_id DESC LIMIT %d;"
+
+func TestDBPasswordHistoryService_CheckReuse(t *testing.T) {
+	hasher := crypto.NewBcryptHasher(bcrypt.MinCost)
+
+	hash := func(password string) string {
+		h, err := hasher.Hash([]byte(password))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(h)
+	}
+
+	user := &model.User{UserID: 1, PasswordHash: hash("P@ssw0rd")}
+	histories := []*model.PasswordHistory{
+		{PasswordHistoryID: 2, UserID: 1, PasswordHash: hash("0ld_P@ssw0rd")},
+		{PasswordHistoryID: 1, UserID: 1, PasswordHash: hash("0ld3st_P@ssw0rd")},
+	}
+
+	cases := []struct {
+		name     string
+		size     int
+		password string
+
+		dbExpectFunc func(sqlmock.Sqlmock)
+
+		expectedErr error
+	}{
+		{
+			name:     "new password",
+			size:     3,
+			password: "N3w_P@ssw0rd",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(listPasswordHistoriesQuery, 2))).
+					WithArgs(1).
+					WillReturnRows(test.NewPasswordHistoryRows(histories))
+			},
+		},
+		{
+			name:     "current password",
+			size:     3,
+			password: "P@ssw0rd",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(listPasswordHistoriesQuery, 2))).
+					WithArgs(1).
+					WillReturnRows(test.NewPasswordHistoryRows(histories))
+			},
+			expectedErr: ErrPasswordReused,
+		},
+		{
+			name:     "recent password",
+			size:     3,
+			password: "0ld3st_P@ssw0rd",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(listPasswordHistoriesQuery, 2))).
+					WithArgs(1).
+					WillReturnRows(test.NewPasswordHistoryRows(histories))
+			},
+			expectedErr: ErrPasswordReused,
+		},
+		{
+			name:        "only current password checked",
+			size:        1,
+			password:    "P@ssw0rd",
+			expectedErr: ErrPasswordReused,
+		},
+		{
+			name:     "disabled",
+			size:     0,
+			password: "P@ssw0rd",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fail()
+			}
+			if tc.dbExpectFunc != nil {
+				tc.dbExpectFunc(mock)
+			}
+			defer test.CloseSqlmock(t, db, mock)
+
+			svc := NewDBPasswordHistoryService(clockwork.NewFakeClock(), db, hasher, PasswordHistoryPolicy{Size: tc.size})
+
+			err = svc.CheckReuse(context.Background(), user, tc.password)
+
+			if tc.expectedErr != nil {
+				assert.Equal(t, tc.expectedErr, errors.Cause(err))
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDBPasswordHistoryService_CheckMinAge(t *testing.T) {
+	now := time.Date(2021, 10, 27, 11, 2, 41, 731942016, time.UTC)
+
+	cases := []struct {
+		name string
+
+		dbExpectFunc func(sqlmock.Sqlmock)
+
+		expectedErr error
+	}{
+		{
+			name: "never changed",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(listPasswordHistoriesQuery, 1))).
+					WithArgs(1).
+					WillReturnRows(test.NewPasswordHistoryRows(nil))
+			},
+		},
+		{
+			name: "old enough",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(listPasswordHistoriesQuery, 1))).
+					WithArgs(1).
+					WillReturnRows(test.NewPasswordHistoryRows([]*model.PasswordHistory{
+						{PasswordHistoryID: 1, UserID: 1, PasswordHash: "old_hash", CreatedAt: now.Add(-24 * time.Hour)},
+					}))
+			},
+		},
+		{
+			name: "too recent",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(listPasswordHistoriesQuery, 1))).
+					WithArgs(1).
+					WillReturnRows(test.NewPasswordHistoryRows([]*model.PasswordHistory{
+						{PasswordHistoryID: 1, UserID: 1, PasswordHash: "old_hash", CreatedAt: now.Add(-time.Hour)},
+					}))
+			},
+			expectedErr: &RateLimitedError{Limit: PasswordLimitMinAge, RetryAfter: 23 * time.Hour},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fail()
+			}
+			if tc.dbExpectFunc != nil {
+				tc.dbExpectFunc(mock)
+			}
+			defer test.CloseSqlmock(t, db, mock)
+
+			svc := NewDBPasswordHistoryService(clockwork.NewFakeClockAt(now), db, nil, PasswordHistoryPolicy{Size: 5, MinAge: 24 * time.Hour})
+
+			err = svc.CheckMinAge(context.Background(), &model.User{UserID: 1})
+
+			if tc.expectedErr != nil {
+				assert.Equal(t, tc.expectedErr, errors.Cause(err))
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDBPasswordHistoryService_Record(t *testing.T) {
+	now := time.Date(2021, 10, 27, 11, 2, 41, 731942016, time.UTC)
+
+	const deleteQuery = "DELETE FROM `password_history` WHERE (`password_history`.`user_id` = ?) AND (`password_history`.`password_history_id` < ?);"
+
+	expectInsert := func(mock sqlmock.Sqlmock) {
+		mock.ExpectExec(regexp.QuoteMeta(
+			"INSERT INTO `password_history` (`user_id`,`password_hash`) VALUES (?,?)",
+		)).WithArgs(
+			1, "old_hash",
+		).WillReturnResult(sqlmock.NewResult(4, 1))
+
+		mock.ExpectQuery(regexp.QuoteMeta(
+			"SELECT `password_history_id`,`created_at`,`updated_at` FROM `password_history` WHERE `password_history_id`=?",
+		)).WithArgs(
+			4,
+		).WillReturnRows(sqlmock.NewRows([]string{"password_history_id", "created_at", "updated_at"}).AddRow(4, now, now))
+	}
+
+	cases := []struct {
+		name string
+		size int
+
+		dbExpectFunc func(sqlmock.Sqlmock)
+	}{
+		{
+			name: "older ones deleted",
+			size: 3,
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				expectInsert(mock)
+
+				mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(listPasswordHistoriesQuery, 2))).
+					WithArgs(1).
+					WillReturnRows(test.NewPasswordHistoryRows([]*model.PasswordHistory{
+						{PasswordHistoryID: 4, UserID: 1, PasswordHash: "old_hash"},
+						{PasswordHistoryID: 3, UserID: 1, PasswordHash: "older_hash"},
+					}))
+
+				mock.ExpectExec(regexp.QuoteMeta(deleteQuery)).
+					WithArgs(1, 3).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+		},
+		{
+			name: "fewer than kept",
+			size: 3,
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				expectInsert(mock)
+
+				mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(listPasswordHistoriesQuery, 2))).
+					WithArgs(1).
+					WillReturnRows(test.NewPasswordHistoryRows([]*model.PasswordHistory{
+						{PasswordHistoryID: 4, UserID: 1, PasswordHash: "old_hash"},
+					}))
+			},
+		},
+		{
+			name: "latest one kept when reuse allowed",
+			size: 0,
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				expectInsert(mock)
+
+				mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf(listPasswordHistoriesQuery, 1))).
+					WithArgs(1).
+					WillReturnRows(test.NewPasswordHistoryRows([]*model.PasswordHistory{
+						{PasswordHistoryID: 4, UserID: 1, PasswordHash: "old_hash"},
+					}))
+
+				mock.ExpectExec(regexp.QuoteMeta(deleteQuery)).
+					WithArgs(1, 4).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fail()
+			}
+			if tc.dbExpectFunc != nil {
+				tc.dbExpectFunc(mock)
+			}
+			defer test.CloseSqlmock(t, db, mock)
+
+			svc := NewDBPasswordHistoryService(clockwork.NewFakeClockAt(now), db, nil, PasswordHistoryPolicy{Size: tc.size})
+
+			err = svc.Record(context.Background(), db, &model.User{UserID: 1, PasswordHash: "new_hash"}, "old_hash")
+
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	PasswordCharClassLower  = "lower"
+	PasswordCharClassUpper  = "upper"
+	PasswordCharClassDigit  = "digit"
+	PasswordCharClassSymbol = "symbol"
+	// PasswordCharClassOther is of the letters without case, such as Hangul and Kanji.
+	PasswordCharClassOther = "other"
+
+	// personal info shorter than this is not looked for in passwords, or it would match too many by chance
+	minPersonalInfoLen = 4
+	// the subscriber number of a phone number is looked for as well, which is usually the last 8 digits
+	phoneSubscriberNumberLen = 8
+)
+
+var (
+	passwordCharClasses = []string{
+		PasswordCharClassLower,
+		PasswordCharClassUpper,
+		PasswordCharClassDigit,
+		PasswordCharClassSymbol,
+		PasswordCharClassOther,
+	}
+
+	// the number of the characters of each class a guesser has to try, which the entropy is estimated from
+	passwordCharClassPoolSizes = map[string]int{
+		PasswordCharClassLower:  26,
+		PasswordCharClassUpper:  26,
+		PasswordCharClassDigit:  10,
+		PasswordCharClassSymbol: 33,
+		PasswordCharClassOther:  100,
+	}
+
+	passwordCharClassDescriptions = map[string]string{
+		PasswordCharClassLower:  "a lowercase letter",
+		PasswordCharClassUpper:  "an uppercase letter",
+		PasswordCharClassDigit:  "a digit",
+		PasswordCharClassSymbol: "a symbol",
+		PasswordCharClassOther:  "a letter without case",
+	}
+)
+
+type PasswordPolicy struct {
+	// the length is counted in characters, not bytes. MaxLength is not limited if zero.
+	MinLength int
+	MaxLength int
+	// RequiredCharClasses are the PasswordCharClass* which should be in a password, and MinCharClasses is how many
+	// different ones should be.
+	RequiredCharClasses []string
+	MinCharClasses      int
+	// MinEntropyBits is the least estimated entropy, which is lowered by repeated or sequential characters such as
+	// "aaa" or "123". Disabled if zero.
+	MinEntropyBits float64
+	// RejectPersonalInfo rejects passwords containing the personal info given to Check, such as the name or email.
+	RejectPersonalInfo bool
+}
+
+// PasswordChecker checks passwords against the policy, and tells all the rules a password violates at once so that
+// users can fix them in one go.
+type PasswordChecker struct {
+	policy PasswordPolicy
+}
+
+func NewPasswordChecker(policy PasswordPolicy) (*PasswordChecker, error) {
+	for _, c := range policy.RequiredCharClasses {
+		if _, ok := passwordCharClassPoolSizes[c]; !ok {
+			return nil, errors.Errorf("unknown password character class: %s", c)
+		}
+	}
+	if policy.MaxLength > 0 && policy.MaxLength < policy.MinLength {
+		return nil, errors.Errorf("password max length %d is less than min length %d", policy.MaxLength, policy.MinLength)
+	}
+	return &PasswordChecker{policy: policy}, nil
+}
+
+// Check returns the descriptions of the rules the password violates, or nothing if it is acceptable. The personal info
+// is of the user setting the password, and empty ones are ignored.
+func (c *PasswordChecker) Check(password string, personalInfo ...string) []string {
+	var violations []string
+
+	rr := []rune(password)
+	if len(rr) < c.policy.MinLength {
+		violations = append(violations, fmt.Sprintf("must be at least %d characters", c.policy.MinLength))
+	}
+	if c.policy.MaxLength > 0 && len(rr) > c.policy.MaxLength {
+		violations = append(violations, fmt.Sprintf("must be at most %d characters", c.policy.MaxLength))
+	}
+
+	classes := map[string]bool{}
+	var hasControl bool
+	for _, r := range rr {
+		class, ok := passwordCharClass(r)
+		if !ok {
+			hasControl = true
+			continue
+		}
+		classes[class] = true
+	}
+	if hasControl {
+		violations = append(violations, "must not contain control characters")
+	}
+
+	for _, class := range c.policy.RequiredCharClasses {
+		if !classes[class] {
+			violations = append(violations, "must contain "+passwordCharClassDescriptions[class])
+		}
+	}
+	if len(classes) < c.policy.MinCharClasses {
+		violations = append(violations, fmt.Sprintf("must contain at least %d of lowercase letters, uppercase letters, digits, symbols and letters without case", c.policy.MinCharClasses))
+	}
+
+	if c.policy.MinEntropyBits > 0 && estimatePasswordEntropyBits(rr, classes) < c.policy.MinEntropyBits {
+		violations = append(violations, "too easy to guess. make it longer, or avoid repeated and sequential characters")
+	}
+
+	if c.policy.RejectPersonalInfo && containsPersonalInfo(password, personalInfo) {
+		violations = append(violations, "must not contain your name, nickname, email or phone number")
+	}
+
+	return violations
+}
+
+// passwordCharClass returns the class of r, or false if r is not allowed in passwords.
+func passwordCharClass(r rune) (string, bool) {
+	switch {
+	case unicode.IsLower(r):
+		return PasswordCharClassLower, true
+	case unicode.IsUpper(r):
+		return PasswordCharClassUpper, true
+	case unicode.IsDigit(r):
+		return PasswordCharClassDigit, true
+	case unicode.IsLetter(r), unicode.IsMark(r):
+		return PasswordCharClassOther, true
+	case unicode.IsPunct(r), unicode.IsSymbol(r), r == ' ':
+		return PasswordCharClassSymbol, true
+	default:
+		return "", false
+	}
+}
+
+// estimatePasswordEntropyBits estimates the entropy as if each character were picked at random from the classes in
+// the password, except that a character repeating or following on from the previous one adds only a bit.
+func estimatePasswordEntropyBits(rr []rune, classes map[string]bool) float64 {
+	var pool int
+	for _, class := range passwordCharClasses {
+		if classes[class] {
+			pool += passwordCharClassPoolSizes[class]
+		}
+	}
+	if pool == 0 {
+		return 0
+	}
+
+	bitsPerChar := math.Log2(float64(pool))
+
+	var bits float64
+	for i, r := range rr {
+		if i > 0 {
+			if d := r - rr[i-1]; d >= -1 && d <= 1 {
+				bits++
+				continue
+			}
+		}
+		bits += bitsPerChar
+	}
+	return bits
+}
+
+func containsPersonalInfo(password string, personalInfo []string) bool {
+	pw := strings.ToLower(password)
+	for _, info := range personalInfo {
+		for _, token := range personalInfoTokens(info) {
+			if strings.Contains(pw, token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// personalInfoTokens splits the info into the words which are likely to be used in passwords. Only the local part of
+// an email is used, since the domain is shared by many.
+func personalInfoTokens(info string) []string {
+	info = strings.ToLower(info)
+	if i := strings.LastIndex(info, "@"); i >= 0 {
+		info = info[:i]
+	}
+
+	words := strings.FieldsFunc(info, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	// the whole info without the separators as well, e.g. johndoe of john.doe or the digits of +82 10-1234-5678
+	if len(words) > 1 {
+		words = append(words, strings.Join(words, ""))
+	}
+
+	var tokens []string
+	for _, w := range words {
+		rr := []rune(w)
+		if len(rr) < minPersonalInfoLen {
+			continue
+		}
+		tokens = append(tokens, w)
+		if isDigits(w) && len(rr) > phoneSubscriberNumberLen {
+			tokens = append(tokens, w[len(w)-phoneSubscriberNumberLen:])
+		}
+	}
+	return tokens
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPasswordChecker(t *testing.T) {
+	_, err := NewPasswordChecker(PasswordPolicy{MinLength: 8, MaxLength: 64, RequiredCharClasses: []string{PasswordCharClassDigit}})
+	assert.NoError(t, err)
+
+	_, err = NewPasswordChecker(PasswordPolicy{MinLength: 8, MaxLength: 64, RequiredCharClasses: []string{"emoji"}})
+	assert.EqualError(t, err, "unknown password character class: emoji")
+
+	_, err = NewPasswordChecker(PasswordPolicy{MinLength: 8, MaxLength: 6})
+	assert.EqualError(t, err, "password max length 6 is less than min length 8")
+}
+
+func TestPasswordChecker_Check(t *testing.T) {
+	personalInfo := []string{"John Doe", "john.doe@example.com", "johnny", "+821012345678"}
+
+	cases := []struct {
+		name     string
+		policy   PasswordPolicy
+		password string
+
+		expected []string
+	}{
+		{
+			name:     "acceptable",
+			policy:   PasswordPolicy{MinLength: 8, MaxLength: 64, MinEntropyBits: 40, RejectPersonalInfo: true},
+			password: "P@ssw0rd",
+		},
+		{
+			name:     "passphrase",
+			policy:   PasswordPolicy{MinLength: 8, MaxLength: 64, MinEntropyBits: 40, RejectPersonalInfo: true},
+			password: "correct horse battery staple",
+		},
+		{
+			name:     "non-latin",
+			policy:   PasswordPolicy{MinLength: 6, MaxLength: 64, MinEntropyBits: 40, RejectPersonalInfo: true},
+			password: "비밀번호는비밀",
+		},
+		{
+			name:     "too short",
+			policy:   PasswordPolicy{MinLength: 8, MaxLength: 64},
+			password: "P@ssw0r",
+			expected: []string{"must be at least 8 characters"},
+		},
+		{
+			name:     "too long",
+			policy:   PasswordPolicy{MinLength: 8, MaxLength: 10},
+			password: "비밀번호는정말비밀이야",
+			expected: []string{"must be at most 10 characters"},
+		},
+		{
+			name:     "control characters",
+			policy:   PasswordPolicy{MinLength: 8, MaxLength: 64},
+			password: "P@ss\tw0rd",
+			expected: []string{"must not contain control characters"},
+		},
+		{
+			name:     "required character classes",
+			policy:   PasswordPolicy{MinLength: 8, MaxLength: 64, RequiredCharClasses: []string{PasswordCharClassDigit, PasswordCharClassSymbol}},
+			password: "password",
+			expected: []string{"must contain a digit", "must contain a symbol"},
+		},
+		{
+			name:     "too few character classes",
+			policy:   PasswordPolicy{MinLength: 8, MaxLength: 64, MinCharClasses: 3},
+			password: "password1",
+			expected: []string{"must contain at least 3 of lowercase letters, uppercase letters, digits, symbols and letters without case"},
+		},
+		{
+			name:     "repeated characters",
+			policy:   PasswordPolicy{MinLength: 8, MaxLength: 64, MinEntropyBits: 40},
+			password: "aaaaaaaaaaaaaaaa",
+			expected: []string{"too easy to guess. make it longer, or avoid repeated and sequential characters"},
+		},
+		{
+			name:     "sequential characters",
+			policy:   PasswordPolicy{MinLength: 8, MaxLength: 64, MinEntropyBits: 40},
+			password: "abcdefgh12345678",
+			expected: []string{"too easy to guess. make it longer, or avoid repeated and sequential characters"},
+		},
+		{
+			name:     "name",
+			policy:   PasswordPolicy{MinLength: 8, MaxLength: 64, RejectPersonalInfo: true},
+			password: "JohnDoe!2021",
+			expected: []string{"must not contain your name, nickname, email or phone number"},
+		},
+		{
+			name:     "nickname",
+			policy:   PasswordPolicy{MinLength: 8, MaxLength: 64, RejectPersonalInfo: true},
+			password: "i_am_johnny",
+			expected: []string{"must not contain your name, nickname, email or phone number"},
+		},
+		{
+			name:     "phone number",
+			policy:   PasswordPolicy{MinLength: 8, MaxLength: 64, RejectPersonalInfo: true},
+			password: "010-12345678",
+			expected: []string{"must not contain your name, nickname, email or phone number"},
+		},
+		{
+			name:     "email domain allowed",
+			policy:   PasswordPolicy{MinLength: 8, MaxLength: 64, RejectPersonalInfo: true},
+			password: "example.com!",
+		},
+		{
+			name:     "personal info allowed",
+			policy:   PasswordPolicy{MinLength: 8, MaxLength: 64},
+			password: "JohnDoe!2021",
+		},
+		{
+			name:     "all violations",
+			policy:   PasswordPolicy{MinLength: 8, MaxLength: 64, RequiredCharClasses: []string{PasswordCharClassUpper}, MinEntropyBits: 40, RejectPersonalInfo: true},
+			password: "doe123",
+			expected: []string{
+				"must be at least 8 characters",
+				"must contain an uppercase letter",
+				"too easy to guess. make it longer, or avoid repeated and sequential characters",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			checker, err := NewPasswordChecker(tc.policy)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := checker.Check(tc.password, personalInfo...)
+
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
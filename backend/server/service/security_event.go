@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+
+	"github.com/sean-ahn/user/backend/model"
+)
+
+const (
+	SecurityEventTypeRefreshTokenReuse           = "refresh_token_reuse"
+	SecurityEventTypeMFARecoveryCodeUsed         = "mfa_recovery_code_used"
+	SecurityEventTypeWebAuthnSignCountRegression = "webauthn_sign_count_regression"
+	SecurityEventTypePasswordChanged             = "password_changed"
+)
+
+// RecordSecurityEvent stores an event which may indicate that the account is compromised, or changes the credentials of
+// it.
+func RecordSecurityEvent(ctx context.Context, exec boil.ContextExecutor, userID int, eventType string, detail map[string]string) error {
+	b, err := json.Marshal(detail)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	e := &model.SecurityEvent{
+		UserID:    userID,
+		EventType: eventType,
+		Detail:    string(b),
+	}
+	if err := e.Insert(ctx, exec, boil.Infer()); err != nil {
+		return errors.WithStack(err)
+	}
+
+	logrus.WithField("user_id", userID).WithField("event_type", eventType).Warn("security event")
+	return nil
+}
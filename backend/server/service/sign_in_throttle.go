@@ -0,0 +1,215 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+
+	"github.com/sean-ahn/user/backend/model"
+	"github.com/sean-ahn/user/backend/persistence/mysql"
+)
+
+const (
+	SignInThrottleScopeAccount   = "account"
+	SignInThrottleScopeIPAddress = "ip_address"
+
+	SignInLimitDelay   = "sign_in_delay"
+	SignInLimitLockout = "sign_in_lockout"
+)
+
+//go:generate mockgen -package service -destination ./sign_in_throttle_mock.go -mock_names SignInThrottle=MockSignInThrottle github.com/sean-ahn/user/backend/server/service SignInThrottle
+
+// SignInThrottle slows down password guessing. The key of SignInThrottleScopeAccount is the user ID, and the one of
+// SignInThrottleScopeIPAddress is the client IP address.
+type SignInThrottle interface {
+	// Check returns *RateLimitedError if the sign-ins of the key are blocked now, whose Limit is SignInLimitLockout if
+	// locked out, or SignInLimitDelay otherwise.
+	Check(ctx context.Context, scope, key string) error
+	// Fail records a failed sign-in, which blocks the next ones for a delay growing with the consecutive failures, and
+	// locks them out once the failures reach the threshold.
+	Fail(ctx context.Context, scope, key string) error
+	// Reset clears the failures, which unlocks the key if locked out.
+	Reset(ctx context.Context, exec boil.ContextExecutor, scope, key string) error
+	// ListBlocked returns the throttles blocking sign-ins now, the latest to be unblocked first.
+	ListBlocked(ctx context.Context) (model.SignInThrottleSlice, error)
+}
+
+type SignInThrottleLimit struct {
+	// FreeFailures is the number of the consecutive failures allowed without a delay.
+	FreeFailures int
+	// sign-ins are locked out for LockoutDuration once the consecutive failures reach LockoutThreshold.
+	// the lockout is disabled if LockoutThreshold is zero.
+	LockoutThreshold int
+	LockoutDuration  time.Duration
+}
+
+type SignInThrottlePolicy struct {
+	Account   SignInThrottleLimit
+	IPAddress SignInThrottleLimit
+
+	// the delay after a failure beyond FreeFailures, which doubles every failure up to MaxDelay.
+	// the delays are disabled if InitialDelay is zero.
+	InitialDelay time.Duration
+	MaxDelay     time.Duration
+
+	// FailureWindow is how long the failures are remembered since the last one. They are kept until a success if zero.
+	FailureWindow time.Duration
+}
+
+// DBSignInThrottle keeps the consecutive failures in sign_in_throttle table.
+type DBSignInThrottle struct {
+	clock  clockwork.Clock
+	db     *sql.DB
+	policy SignInThrottlePolicy
+}
+
+var _ SignInThrottle = (*DBSignInThrottle)(nil)
+
+func NewDBSignInThrottle(clock clockwork.Clock, db *sql.DB, policy SignInThrottlePolicy) *DBSignInThrottle {
+	return &DBSignInThrottle{
+		clock:  clock,
+		db:     db,
+		policy: policy,
+	}
+}
+
+func (t *DBSignInThrottle) Check(ctx context.Context, scope, key string) error {
+	th, err := mysql.FindSignInThrottle(ctx, t.db, scope, key)
+	if errors.Cause(err) == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	now := t.clock.Now()
+	if !th.BlockedUntil.Valid || !th.BlockedUntil.Time.After(now) {
+		return nil
+	}
+
+	limit := SignInLimitDelay
+	if th.LockedAt.Valid {
+		limit = SignInLimitLockout
+	}
+	return errors.WithStack(&RateLimitedError{Limit: limit, RetryAfter: th.BlockedUntil.Time.Sub(now)})
+}
+
+func (t *DBSignInThrottle) Fail(ctx context.Context, scope, key string) error {
+	limit, err := t.limit(scope)
+	if err != nil {
+		return err
+	}
+
+	now := t.clock.Now()
+
+	tx, err := t.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			logrus.WithError(err).Error()
+		}
+	}()
+
+	th, err := mysql.FindSignInThrottleForUpdate(ctx, tx, scope, key)
+	if err != nil && errors.Cause(err) != sql.ErrNoRows {
+		return err
+	}
+
+	exists := th != nil
+	if !exists {
+		th = &model.SignInThrottle{Scope: scope, ScopeKey: key}
+	} else if t.isStale(th, now) {
+		th.Failures = 0
+	}
+
+	th.Failures++
+	th.LastFailedAt = now
+	th.BlockedUntil, th.LockedAt = t.block(limit, th.Failures, now)
+
+	if exists {
+		_, err = th.Update(ctx, tx, boil.Whitelist(
+			model.SignInThrottleColumns.Failures,
+			model.SignInThrottleColumns.LastFailedAt,
+			model.SignInThrottleColumns.BlockedUntil,
+			model.SignInThrottleColumns.LockedAt,
+		))
+	} else {
+		err = th.Insert(ctx, tx, boil.Infer())
+	}
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.WithStack(err)
+	}
+
+	if th.LockedAt.Valid {
+		logrus.WithField("scope", scope).WithField("key", key).Warn("sign-in locked out")
+	}
+	return nil
+}
+
+func (t *DBSignInThrottle) Reset(ctx context.Context, exec boil.ContextExecutor, scope, key string) error {
+	if _, err := model.SignInThrottles(
+		model.SignInThrottleWhere.Scope.EQ(scope),
+		model.SignInThrottleWhere.ScopeKey.EQ(key),
+	).UpdateAll(ctx, exec, model.M{
+		model.SignInThrottleColumns.Failures:     0,
+		model.SignInThrottleColumns.BlockedUntil: null.Time{},
+		model.SignInThrottleColumns.LockedAt:     null.Time{},
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+func (t *DBSignInThrottle) ListBlocked(ctx context.Context) (model.SignInThrottleSlice, error) {
+	return mysql.ListBlockedSignInThrottles(ctx, t.db, t.clock.Now())
+}
+
+func (t *DBSignInThrottle) limit(scope string) (SignInThrottleLimit, error) {
+	switch scope {
+	case SignInThrottleScopeAccount:
+		return t.policy.Account, nil
+	case SignInThrottleScopeIPAddress:
+		return t.policy.IPAddress, nil
+	default:
+		return SignInThrottleLimit{}, errors.Errorf("unknown sign-in throttle scope: %s", scope)
+	}
+}
+
+// isStale returns whether the failures should be counted from zero again, since the lockout has expired or the last
+// failure has left the window.
+func (t *DBSignInThrottle) isStale(th *model.SignInThrottle, now time.Time) bool {
+	if th.LockedAt.Valid && th.BlockedUntil.Valid && !th.BlockedUntil.Time.After(now) {
+		return true
+	}
+	return t.policy.FailureWindow > 0 && now.Sub(th.LastFailedAt) >= t.policy.FailureWindow
+}
+
+func (t *DBSignInThrottle) block(limit SignInThrottleLimit, failures int, now time.Time) (blockedUntil, lockedAt null.Time) {
+	if limit.LockoutThreshold > 0 && failures >= limit.LockoutThreshold {
+		return null.TimeFrom(now.Add(limit.LockoutDuration)), null.TimeFrom(now)
+	}
+	if failures <= limit.FreeFailures || t.policy.InitialDelay <= 0 {
+		return null.Time{}, null.Time{}
+	}
+
+	delay := t.policy.InitialDelay
+	for i := limit.FreeFailures + 1; i < failures && delay < t.policy.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > t.policy.MaxDelay {
+		delay = t.policy.MaxDelay
+	}
+	return null.TimeFrom(now.Add(delay)), null.Time{}
+}
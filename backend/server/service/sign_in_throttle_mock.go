@@ -0,0 +1,94 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sean-ahn/user/backend/server/service (interfaces: SignInThrottle)
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/sean-ahn/user/backend/model"
+	boil "github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// MockSignInThrottle is a mock of SignInThrottle interface.
+type MockSignInThrottle struct {
+	ctrl     *gomock.Controller
+	recorder *MockSignInThrottleMockRecorder
+}
+
+// MockSignInThrottleMockRecorder is the mock recorder for MockSignInThrottle.
+type MockSignInThrottleMockRecorder struct {
+	mock *MockSignInThrottle
+}
+
+// NewMockSignInThrottle creates a new mock instance.
+func NewMockSignInThrottle(ctrl *gomock.Controller) *MockSignInThrottle {
+	mock := &MockSignInThrottle{ctrl: ctrl}
+	mock.recorder = &MockSignInThrottleMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSignInThrottle) EXPECT() *MockSignInThrottleMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockSignInThrottle) Check(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockSignInThrottleMockRecorder) Check(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockSignInThrottle)(nil).Check), arg0, arg1, arg2)
+}
+
+// Fail mocks base method.
+func (m *MockSignInThrottle) Fail(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Fail", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Fail indicates an expected call of Fail.
+func (mr *MockSignInThrottleMockRecorder) Fail(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fail", reflect.TypeOf((*MockSignInThrottle)(nil).Fail), arg0, arg1, arg2)
+}
+
+// ListBlocked mocks base method.
+func (m *MockSignInThrottle) ListBlocked(arg0 context.Context) (model.SignInThrottleSlice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBlocked", arg0)
+	ret0, _ := ret[0].(model.SignInThrottleSlice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBlocked indicates an expected call of ListBlocked.
+func (mr *MockSignInThrottleMockRecorder) ListBlocked(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBlocked", reflect.TypeOf((*MockSignInThrottle)(nil).ListBlocked), arg0)
+}
+
+// Reset mocks base method.
+func (m *MockSignInThrottle) Reset(arg0 context.Context, arg1 boil.ContextExecutor, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reset", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reset indicates an expected call of Reset.
+func (mr *MockSignInThrottleMockRecorder) Reset(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reset", reflect.TypeOf((*MockSignInThrottle)(nil).Reset), arg0, arg1, arg2, arg3)
+}
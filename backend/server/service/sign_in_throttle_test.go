@@ -0,0 +1,291 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jonboulle/clockwork"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/volatiletech/null/v8"
+
+	"github.com/sean-ahn/user/backend/model"
+	"github.com/sean-ahn/user/backend/test"
+)
+
+var testSignInThrottlePolicy = SignInThrottlePolicy{
+	Account:       SignInThrottleLimit{FreeFailures: 3, LockoutThreshold: 10, LockoutDuration: time.Hour},
+	IPAddress:     SignInThrottleLimit{FreeFailures: 10, LockoutThreshold: 100, LockoutDuration: time.Hour},
+	InitialDelay:  time.Second,
+	MaxDelay:      5 * time.Second,
+	FailureWindow: 24 * time.Hour,
+}
+
+func TestDBSignInThrottle_Check(t *testing.T) {
+	now := time.Date(2021, 10, 24, 17, 18, 16, 304850171, time.UTC)
+
+	const query = "SELECT * FROM `sign_in_throttle` WHERE (`sign_in_throttle`.`scope` = ?) AND (`sign_in_throttle`.`scope_key` = ?) LIMIT 1;"
+
+	cases := []struct {
+		name string
+
+		dbExpectFunc func(sqlmock.Sqlmock)
+
+		expectedErr error
+	}{
+		{
+			name: "no failures",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(SignInThrottleScopeAccount, "1").
+					WillReturnError(sql.ErrNoRows)
+			},
+		},
+		{
+			name: "not blocked",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(SignInThrottleScopeAccount, "1").
+					WillReturnRows(test.NewSignInThrottleRows([]*model.SignInThrottle{
+						{SignInThrottleID: 1, Scope: SignInThrottleScopeAccount, ScopeKey: "1", Failures: 2, LastFailedAt: now.Add(-time.Minute)},
+					}))
+			},
+		},
+		{
+			name: "delay passed",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(SignInThrottleScopeAccount, "1").
+					WillReturnRows(test.NewSignInThrottleRows([]*model.SignInThrottle{
+						{SignInThrottleID: 1, Scope: SignInThrottleScopeAccount, ScopeKey: "1", Failures: 5, LastFailedAt: now.Add(-4 * time.Second), BlockedUntil: null.TimeFrom(now)},
+					}))
+			},
+		},
+		{
+			name: "delayed",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(SignInThrottleScopeAccount, "1").
+					WillReturnRows(test.NewSignInThrottleRows([]*model.SignInThrottle{
+						{SignInThrottleID: 1, Scope: SignInThrottleScopeAccount, ScopeKey: "1", Failures: 5, LastFailedAt: now.Add(-time.Second), BlockedUntil: null.TimeFrom(now.Add(3 * time.Second))},
+					}))
+			},
+			expectedErr: &RateLimitedError{Limit: SignInLimitDelay, RetryAfter: 3 * time.Second},
+		},
+		{
+			name: "locked out",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(SignInThrottleScopeAccount, "1").
+					WillReturnRows(test.NewSignInThrottleRows([]*model.SignInThrottle{
+						{SignInThrottleID: 1, Scope: SignInThrottleScopeAccount, ScopeKey: "1", Failures: 10, LastFailedAt: now.Add(-time.Minute), BlockedUntil: null.TimeFrom(now.Add(59 * time.Minute)), LockedAt: null.TimeFrom(now.Add(-time.Minute))},
+					}))
+			},
+			expectedErr: &RateLimitedError{Limit: SignInLimitLockout, RetryAfter: 59 * time.Minute},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fail()
+			}
+			if tc.dbExpectFunc != nil {
+				tc.dbExpectFunc(mock)
+			}
+			defer test.CloseSqlmock(t, db, mock)
+
+			throttle := NewDBSignInThrottle(clockwork.NewFakeClockAt(now), db, testSignInThrottlePolicy)
+
+			err = throttle.Check(context.Background(), SignInThrottleScopeAccount, "1")
+
+			if tc.expectedErr != nil {
+				assert.Equal(t, tc.expectedErr, errors.Cause(err))
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDBSignInThrottle_Fail(t *testing.T) {
+	now := time.Date(2021, 10, 24, 17, 18, 16, 304850171, time.UTC)
+
+	const (
+		selectQuery = "SELECT * FROM `sign_in_throttle` WHERE (`sign_in_throttle`.`scope` = ?) AND (`sign_in_throttle`.`scope_key` = ?) LIMIT 1 FOR UPDATE;"
+		insertQuery = "INSERT INTO `sign_in_throttle` (`scope`,`scope_key`,`failures`,`last_failed_at`,`blocked_until`,`locked_at`) VALUES (?,?,?,?,?,?)"
+		updateQuery = "UPDATE `sign_in_throttle` SET `failures`=?,`last_failed_at`=?,`blocked_until`=?,`locked_at`=? WHERE `sign_in_throttle_id`=?"
+	)
+
+	throttle := func(failures int, lastFailedAt time.Time, blockedUntil, lockedAt null.Time) *sqlmock.Rows {
+		return test.NewSignInThrottleRows([]*model.SignInThrottle{
+			{SignInThrottleID: 1, Scope: SignInThrottleScopeAccount, ScopeKey: "1", Failures: failures, LastFailedAt: lastFailedAt, BlockedUntil: blockedUntil, LockedAt: lockedAt},
+		})
+	}
+
+	cases := []struct {
+		name  string
+		scope string
+
+		dbExpectFunc func(sqlmock.Sqlmock)
+
+		expectedErr string
+	}{
+		{
+			name:  "first failure",
+			scope: SignInThrottleScopeAccount,
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(selectQuery)).
+					WithArgs(SignInThrottleScopeAccount, "1").
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectExec(regexp.QuoteMeta(insertQuery)).
+					WithArgs(SignInThrottleScopeAccount, "1", 1, now, nil, nil).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT `sign_in_throttle_id`,`created_at`,`updated_at` FROM `sign_in_throttle` WHERE `sign_in_throttle_id`=?")).
+					WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"sign_in_throttle_id", "created_at", "updated_at"}).AddRow(1, now, now))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:  "free failure",
+			scope: SignInThrottleScopeAccount,
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(selectQuery)).
+					WithArgs(SignInThrottleScopeAccount, "1").
+					WillReturnRows(throttle(2, now.Add(-time.Minute), null.Time{}, null.Time{}))
+				mock.ExpectExec(regexp.QuoteMeta(updateQuery)).
+					WithArgs(3, now, nil, nil, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:  "delayed beyond free failures",
+			scope: SignInThrottleScopeAccount,
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(selectQuery)).
+					WithArgs(SignInThrottleScopeAccount, "1").
+					WillReturnRows(throttle(4, now.Add(-time.Minute), null.TimeFrom(now.Add(-59*time.Second)), null.Time{}))
+				mock.ExpectExec(regexp.QuoteMeta(updateQuery)).
+					WithArgs(5, now, now.Add(2*time.Second), nil, 1). // doubled from the 4th failure
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:  "delay capped",
+			scope: SignInThrottleScopeAccount,
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(selectQuery)).
+					WithArgs(SignInThrottleScopeAccount, "1").
+					WillReturnRows(throttle(7, now.Add(-time.Minute), null.TimeFrom(now.Add(-55*time.Second)), null.Time{}))
+				mock.ExpectExec(regexp.QuoteMeta(updateQuery)).
+					WithArgs(8, now, now.Add(5*time.Second), nil, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:  "locked out at threshold",
+			scope: SignInThrottleScopeAccount,
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(selectQuery)).
+					WithArgs(SignInThrottleScopeAccount, "1").
+					WillReturnRows(throttle(9, now.Add(-time.Minute), null.TimeFrom(now.Add(-55*time.Second)), null.Time{}))
+				mock.ExpectExec(regexp.QuoteMeta(updateQuery)).
+					WithArgs(10, now, now.Add(time.Hour), now, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:  "counted from zero after lockout expired",
+			scope: SignInThrottleScopeAccount,
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(selectQuery)).
+					WithArgs(SignInThrottleScopeAccount, "1").
+					WillReturnRows(throttle(10, now.Add(-time.Hour), null.TimeFrom(now), null.TimeFrom(now.Add(-time.Hour))))
+				mock.ExpectExec(regexp.QuoteMeta(updateQuery)).
+					WithArgs(1, now, nil, nil, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:  "counted from zero after failure window",
+			scope: SignInThrottleScopeAccount,
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(selectQuery)).
+					WithArgs(SignInThrottleScopeAccount, "1").
+					WillReturnRows(throttle(5, now.Add(-24*time.Hour), null.TimeFrom(now.Add(-24*time.Hour+2*time.Second)), null.Time{}))
+				mock.ExpectExec(regexp.QuoteMeta(updateQuery)).
+					WithArgs(1, now, nil, nil, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:        "unknown scope",
+			scope:       "email",
+			expectedErr: "unknown sign-in throttle scope: email",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fail()
+			}
+			if tc.dbExpectFunc != nil {
+				tc.dbExpectFunc(mock)
+			}
+			defer test.CloseSqlmock(t, db, mock)
+
+			throttle := NewDBSignInThrottle(clockwork.NewFakeClockAt(now), db, testSignInThrottlePolicy)
+
+			err = throttle.Fail(context.Background(), tc.scope, "1")
+
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDBSignInThrottle_Reset(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fail()
+	}
+	defer test.CloseSqlmock(t, db, mock)
+
+	mock.ExpectExec(regexp.QuoteMeta(
+		"UPDATE `sign_in_throttle` SET `blocked_until` = ?, `failures` = ?, `locked_at` = ? WHERE (`sign_in_throttle`.`scope` = ?) AND (`sign_in_throttle`.`scope_key` = ?);",
+	)).WithArgs(
+		nil, 0, nil, SignInThrottleScopeAccount, "1",
+	).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	throttle := NewDBSignInThrottle(clockwork.NewFakeClock(), db, testSignInThrottlePolicy)
+
+	assert.NoError(t, throttle.Reset(context.Background(), db, SignInThrottleScopeAccount, "1"))
+}
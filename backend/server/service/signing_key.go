@@ -0,0 +1,203 @@
+package service
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/pkg/errors"
+)
+
+const (
+	SigningAlgorithmRS256 = "RS256"
+	SigningAlgorithmES256 = "ES256"
+	SigningAlgorithmEdDSA = "EdDSA"
+
+	rsaKeyBits = 2048
+
+	pemTypePrivateKey = "PRIVATE KEY"
+)
+
+var (
+	errUnsupportedSigningAlgorithm = errors.New("unsupported signing algorithm")
+	errInvalidSigningKey           = errors.New("invalid signing key")
+)
+
+// SigningKey is a private key used to sign JWTs, identified by the kid header.
+type SigningKey struct {
+	ID         string
+	Algorithm  string
+	PrivateKey crypto.Signer
+
+	// ActivatesAt is when the key starts signing tokens.
+	ActivatesAt time.Time
+	// RetiresAt is when tokens signed with the key stop being accepted. Zero if the key has not been replaced yet.
+	RetiresAt time.Time
+}
+
+// JWK is a public key in JSON Web Key format. See RFC 7517.
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use,omitempty"`
+	Kid string `json:"kid,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// JWKSet is a set of JWKs served at /.well-known/jwks.json.
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+func IsSupportedSigningAlgorithm(algorithm string) bool {
+	switch algorithm {
+	case SigningAlgorithmRS256, SigningAlgorithmES256, SigningAlgorithmEdDSA:
+		return true
+	default:
+		return false
+	}
+}
+
+func GenerateSigningKey(algorithm string) (*SigningKey, error) {
+	var (
+		priv crypto.Signer
+		err  error
+	)
+	switch algorithm {
+	case SigningAlgorithmRS256:
+		priv, err = rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	case SigningAlgorithmES256:
+		priv, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case SigningAlgorithmEdDSA:
+		_, priv, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return nil, errors.WithStack(errUnsupportedSigningAlgorithm)
+	}
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return newSigningKey(algorithm, priv)
+}
+
+func ParseSigningKey(algorithm, privateKeyPEM string) (*SigningKey, error) {
+	block, _ := pem.Decode([]byte(privateKeyPEM))
+	if block == nil || block.Type != pemTypePrivateKey {
+		return nil, errors.WithStack(errInvalidSigningKey)
+	}
+
+	priv, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	signer, ok := priv.(crypto.Signer)
+	if !ok {
+		return nil, errors.WithStack(errInvalidSigningKey)
+	}
+	return newSigningKey(algorithm, signer)
+}
+
+func newSigningKey(algorithm string, priv crypto.Signer) (*SigningKey, error) {
+	switch k := priv.(type) {
+	case *rsa.PrivateKey:
+		if algorithm != SigningAlgorithmRS256 {
+			return nil, errors.WithStack(errInvalidSigningKey)
+		}
+	case *ecdsa.PrivateKey:
+		if algorithm != SigningAlgorithmES256 || k.Curve != elliptic.P256() {
+			return nil, errors.WithStack(errInvalidSigningKey)
+		}
+	case ed25519.PrivateKey:
+		if algorithm != SigningAlgorithmEdDSA {
+			return nil, errors.WithStack(errInvalidSigningKey)
+		}
+	default:
+		return nil, errors.WithStack(errUnsupportedSigningAlgorithm)
+	}
+
+	key := &SigningKey{Algorithm: algorithm, PrivateKey: priv}
+	key.ID = key.thumbprint()
+	return key, nil
+}
+
+func (k *SigningKey) SigningMethod() jwt.SigningMethod {
+	return jwt.GetSigningMethod(k.Algorithm)
+}
+
+func (k *SigningKey) PublicKey() crypto.PublicKey {
+	return k.PrivateKey.Public()
+}
+
+func (k *SigningKey) MarshalPEM() (string, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(k.PrivateKey)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: pemTypePrivateKey, Bytes: der})), nil
+}
+
+func (k *SigningKey) JWK() JWK {
+	jwk := JWK{
+		Use: "sig",
+		Kid: k.ID,
+		Alg: k.Algorithm,
+	}
+
+	switch pub := k.PublicKey().(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = encodeBase64URL(pub.N.Bytes())
+		jwk.E = encodeBase64URL(big.NewInt(int64(pub.E)).Bytes())
+	case *ecdsa.PublicKey:
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		jwk.Kty = "EC"
+		jwk.Crv = pub.Curve.Params().Name
+		jwk.X = encodeBase64URL(pub.X.FillBytes(make([]byte, size)))
+		jwk.Y = encodeBase64URL(pub.Y.FillBytes(make([]byte, size)))
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = encodeBase64URL(pub)
+	}
+
+	return jwk
+}
+
+// thumbprint returns the JWK SHA-256 thumbprint of the public key.
+// See https://datatracker.ietf.org/doc/html/rfc7638
+func (k *SigningKey) thumbprint() string {
+	jwk := k.JWK()
+
+	var members string
+	switch jwk.Kty {
+	case "RSA":
+		members = fmt.Sprintf(`{"e":%q,"kty":%q,"n":%q}`, jwk.E, jwk.Kty, jwk.N)
+	case "EC":
+		members = fmt.Sprintf(`{"crv":%q,"kty":%q,"x":%q,"y":%q}`, jwk.Crv, jwk.Kty, jwk.X, jwk.Y)
+	case "OKP":
+		members = fmt.Sprintf(`{"crv":%q,"kty":%q,"x":%q}`, jwk.Crv, jwk.Kty, jwk.X)
+	}
+
+	sum := sha256.Sum256([]byte(members))
+	return encodeBase64URL(sum[:])
+}
+
+func encodeBase64URL(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
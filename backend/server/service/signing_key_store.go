@@ -0,0 +1,407 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+
+	"github.com/sean-ahn/user/backend/model"
+	"github.com/sean-ahn/user/backend/persistence/mysql"
+)
+
+const (
+	signingKeyCacheTTL = 1 * time.Minute
+
+	signingKeyRotationCheckInterval = 10 * time.Minute
+	signingKeyRotationLockName      = "jwt_signing_key_rotation"
+	signingKeyLockTimeoutSec        = 10
+)
+
+var (
+	errSigningKeyNotFound    = errors.New("signing key not found")
+	errSigningKeyLockTimeout = errors.New("timed out waiting for signing key lock")
+)
+
+//go:generate mockgen -package service -destination ./signing_key_store_mock.go -mock_names SigningKeyStore=MockSigningKeyStore,SigningKeyRotator=MockSigningKeyRotator github.com/sean-ahn/user/backend/server/service SigningKeyStore,SigningKeyRotator
+
+type SigningKeyStore interface {
+	// GetSigningKey returns the key new tokens should be signed with.
+	GetSigningKey(context.Context) (*SigningKey, error)
+	// GetVerificationKey returns the key with given kid.
+	GetVerificationKey(context.Context, string) (*SigningKey, error)
+	// ListVerificationKeys returns all keys tokens may be verified with, including ones not activated yet.
+	ListVerificationKeys(context.Context) ([]*SigningKey, error)
+}
+
+type SigningKeyRotator interface {
+	// Rotate creates a new signing key and schedules retirement of the existing ones. If a rotation is already in
+	// progress, the key waiting to be activated is returned instead.
+	Rotate(context.Context) (*SigningKey, error)
+}
+
+type SigningKeyRotationPolicy struct {
+	// RotationPeriod is how long a key signs tokens before it is replaced automatically. Zero disables automatic rotation.
+	RotationPeriod time.Duration
+	// PublishDelay is how long a new key is published before it starts signing tokens, so that verifiers caching
+	// the JWKS get to know it in advance.
+	PublishDelay time.Duration
+	// RetireDelay is how long a replaced key stays valid for verification after its successor has been activated.
+	// It should not be shorter than the lifetime of the longest-lived token.
+	RetireDelay time.Duration
+}
+
+// DBSigningKeyStore keeps signing keys in jwt_signing_key table. The most recently activated key is used for signing.
+// If there is no key at all, a new key is generated with the configured algorithm. Keys are created holding a named
+// lock, or the instances creating them at the same time would each activate one.
+type DBSigningKeyStore struct {
+	clock     clockwork.Clock
+	db        *sql.DB
+	algorithm string
+	policy    SigningKeyRotationPolicy
+
+	mu       sync.RWMutex
+	keys     []*SigningKey
+	loadedAt time.Time
+}
+
+var (
+	_ SigningKeyStore   = (*DBSigningKeyStore)(nil)
+	_ SigningKeyRotator = (*DBSigningKeyStore)(nil)
+)
+
+func NewDBSigningKeyStore(clock clockwork.Clock, db *sql.DB, algorithm string, policy SigningKeyRotationPolicy) (*DBSigningKeyStore, error) {
+	if !IsSupportedSigningAlgorithm(algorithm) {
+		return nil, errors.Wrap(errUnsupportedSigningAlgorithm, algorithm)
+	}
+
+	return &DBSigningKeyStore{
+		clock:     clock,
+		db:        db,
+		algorithm: algorithm,
+		policy:    policy,
+	}, nil
+}
+
+func (s *DBSigningKeyStore) GetSigningKey(ctx context.Context) (*SigningKey, error) {
+	keys, err := s.getKeys(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	if key := findActiveSigningKey(keys, s.clock.Now()); key != nil {
+		return key, nil
+	}
+
+	locked, err := s.withLock(ctx, signingKeyLockTimeoutSec, func() error {
+		// another instance may have created one while waiting for the lock
+		keys, err := s.getKeys(ctx, true)
+		if err != nil {
+			return err
+		}
+		if findActiveSigningKey(keys, s.clock.Now()) != nil {
+			return nil
+		}
+
+		_, err = s.createKey(ctx, s.db, s.clock.Now())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !locked {
+		return nil, errors.WithStack(errSigningKeyLockTimeout)
+	}
+
+	if keys, err = s.getKeys(ctx, true); err != nil {
+		return nil, err
+	}
+	if key := findActiveSigningKey(keys, s.clock.Now()); key != nil {
+		return key, nil
+	}
+
+	return nil, errors.WithStack(errSigningKeyNotFound)
+}
+
+func (s *DBSigningKeyStore) GetVerificationKey(ctx context.Context, kid string) (*SigningKey, error) {
+	keys, err := s.getKeys(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	if key := findSigningKey(keys, kid, s.clock.Now()); key != nil {
+		return key, nil
+	}
+
+	// the key may have been created by another instance after the last load
+	if keys, err = s.getKeys(ctx, true); err != nil {
+		return nil, err
+	}
+	if key := findSigningKey(keys, kid, s.clock.Now()); key != nil {
+		return key, nil
+	}
+
+	return nil, errors.WithStack(errSigningKeyNotFound)
+}
+
+func (s *DBSigningKeyStore) ListVerificationKeys(ctx context.Context) ([]*SigningKey, error) {
+	// make sure there is at least one key to publish
+	if _, err := s.GetSigningKey(ctx); err != nil {
+		return nil, err
+	}
+
+	keys, err := s.getKeys(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	verificationKeys := make([]*SigningKey, 0, len(keys))
+	for _, k := range keys {
+		if !isRetiredSigningKey(k, now) {
+			verificationKeys = append(verificationKeys, k)
+		}
+	}
+	return verificationKeys, nil
+}
+
+// Rotate creates a new key which starts signing tokens after the publish delay. Existing keys keep being accepted
+// for the retire delay after that, so that tokens already issued stay valid until they expire.
+func (s *DBSigningKeyStore) Rotate(ctx context.Context) (*SigningKey, error) {
+	var key *SigningKey
+	locked, err := s.withLock(ctx, signingKeyLockTimeoutSec, func() error {
+		keys, err := s.getKeys(ctx, true)
+		if err != nil {
+			return err
+		}
+		// another rotation, which may have been scheduled, has finished while waiting for the lock, and another key
+		// would overlap its key
+		if len(keys) > 0 && keys[0].ActivatesAt.After(s.clock.Now()) {
+			key = keys[0]
+			return nil
+		}
+
+		key, err = s.rotate(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !locked {
+		return nil, errors.WithStack(errSigningKeyLockTimeout)
+	}
+	return key, nil
+}
+
+// rotate should be called holding the lock.
+func (s *DBSigningKeyStore) rotate(ctx context.Context) (*SigningKey, error) {
+	activatesAt := s.clock.Now().Add(s.policy.PublishDelay)
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			logrus.WithError(err).Error()
+		}
+	}()
+
+	if _, err := model.JWTSigningKeys(
+		model.JWTSigningKeyWhere.RetiresAt.IsNull(),
+	).UpdateAll(ctx, tx, model.M{
+		model.JWTSigningKeyColumns.RetiresAt: null.TimeFrom(activatesAt.Add(s.policy.RetireDelay)),
+	}); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	key, err := s.createKey(ctx, tx, activatesAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	s.invalidate()
+
+	return key, nil
+}
+
+// RunRotation rotates the signing key every rotation period and deletes retired keys until ctx is done.
+func (s *DBSigningKeyStore) RunRotation(ctx context.Context) {
+	ticker := s.clock.NewTicker(signingKeyRotationCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.rotateIfDue(ctx); err != nil {
+			logrus.WithError(err).Error("rotate signing key")
+		}
+		if err := s.deleteRetiredKeys(ctx); err != nil {
+			logrus.WithError(err).Error("delete retired signing keys")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.Chan():
+		}
+	}
+}
+
+// rotateIfDue checks and rotates holding the lock, or every instance finding the rotation due at the same time would
+// create a new key.
+func (s *DBSigningKeyStore) rotateIfDue(ctx context.Context) error {
+	if s.policy.RotationPeriod <= 0 {
+		return nil
+	}
+
+	// another instance is rotating if not locked, and this one sees the new key the next time
+	_, err := s.withLock(ctx, 0, func() error {
+		keys, err := s.getKeys(ctx, true)
+		if err != nil {
+			return err
+		}
+		// the latest key is either the active one or one waiting to be activated, which means rotation is in progress.
+		if len(keys) == 0 || keys[0].ActivatesAt.After(s.clock.Now()) {
+			return nil
+		}
+		if s.clock.Since(keys[0].ActivatesAt) < s.policy.RotationPeriod {
+			return nil
+		}
+
+		key, err := s.rotate(ctx)
+		if err != nil {
+			return err
+		}
+
+		logrus.WithField("kid", key.ID).WithField("activates_at", key.ActivatesAt).Info("rotated signing key")
+		return nil
+	})
+	return err
+}
+
+// withLock calls fn holding the named lock, which keys are created with, waiting up to timeoutSec for it. It returns
+// false without calling fn if another session holds the lock by then.
+func (s *DBSigningKeyStore) withLock(ctx context.Context, timeoutSec int, fn func() error) (bool, error) {
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	defer conn.Close()
+
+	locked, err := mysql.GetLock(ctx, conn, signingKeyRotationLockName, timeoutSec)
+	if err != nil || !locked {
+		return false, err
+	}
+	defer func() {
+		if err := mysql.ReleaseLock(context.Background(), conn, signingKeyRotationLockName); err != nil {
+			logrus.WithError(err).Error()
+		}
+	}()
+
+	return true, fn()
+}
+
+func (s *DBSigningKeyStore) deleteRetiredKeys(ctx context.Context) error {
+	if _, err := model.JWTSigningKeys(
+		model.JWTSigningKeyWhere.RetiresAt.LTE(null.TimeFrom(s.clock.Now())),
+	).DeleteAll(ctx, s.db); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+func (s *DBSigningKeyStore) getKeys(ctx context.Context, forceReload bool) ([]*SigningKey, error) {
+	now := s.clock.Now()
+
+	s.mu.RLock()
+	keys, loadedAt := s.keys, s.loadedAt
+	s.mu.RUnlock()
+
+	if !forceReload && !loadedAt.IsZero() && now.Sub(loadedAt) < signingKeyCacheTTL {
+		return keys, nil
+	}
+
+	rows, err := mysql.ListUnretiredJWTSigningKeys(ctx, s.db, now)
+	if err != nil {
+		return nil, err
+	}
+
+	keys = make([]*SigningKey, 0, len(rows))
+	for _, row := range rows {
+		key, err := ParseSigningKey(row.Algorithm, row.PrivateKey)
+		if err != nil {
+			return nil, errors.Wrapf(err, "jwt_signing_key_id: %d", row.JWTSigningKeyID)
+		}
+		key.ActivatesAt = row.ActivatesAt
+		if row.RetiresAt.Valid {
+			key.RetiresAt = row.RetiresAt.Time
+		}
+		keys = append(keys, key)
+	}
+
+	s.mu.Lock()
+	s.keys, s.loadedAt = keys, now
+	s.mu.Unlock()
+
+	return keys, nil
+}
+
+func (s *DBSigningKeyStore) invalidate() {
+	s.mu.Lock()
+	s.loadedAt = time.Time{}
+	s.mu.Unlock()
+}
+
+func (s *DBSigningKeyStore) createKey(ctx context.Context, exec boil.ContextExecutor, activatesAt time.Time) (*SigningKey, error) {
+	key, err := GenerateSigningKey(s.algorithm)
+	if err != nil {
+		return nil, err
+	}
+	key.ActivatesAt = activatesAt
+
+	privateKeyPEM, err := key.MarshalPEM()
+	if err != nil {
+		return nil, err
+	}
+
+	row := &model.JWTSigningKey{
+		Kid:         key.ID,
+		Algorithm:   key.Algorithm,
+		PrivateKey:  privateKeyPEM,
+		ActivatesAt: activatesAt,
+	}
+	if err := row.Insert(ctx, exec, boil.Infer()); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return key, nil
+}
+
+// findActiveSigningKey returns the most recently activated key. keys should be ordered by activation time descending.
+func findActiveSigningKey(keys []*SigningKey, now time.Time) *SigningKey {
+	for _, k := range keys {
+		if !k.ActivatesAt.After(now) && !isRetiredSigningKey(k, now) {
+			return k
+		}
+	}
+	return nil
+}
+
+func findSigningKey(keys []*SigningKey, kid string, now time.Time) *SigningKey {
+	for _, k := range keys {
+		if k.ID == kid && !isRetiredSigningKey(k, now) {
+			return k
+		}
+	}
+	return nil
+}
+
+func isRetiredSigningKey(key *SigningKey, now time.Time) bool {
+	return !key.RetiresAt.IsZero() && !key.RetiresAt.After(now)
+}
@@ -0,0 +1,118 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sean-ahn/user/backend/server/service (interfaces: SigningKeyStore,SigningKeyRotator)
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSigningKeyStore is a mock of SigningKeyStore interface.
+type MockSigningKeyStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockSigningKeyStoreMockRecorder
+}
+
+// MockSigningKeyStoreMockRecorder is the mock recorder for MockSigningKeyStore.
+type MockSigningKeyStoreMockRecorder struct {
+	mock *MockSigningKeyStore
+}
+
+// NewMockSigningKeyStore creates a new mock instance.
+func NewMockSigningKeyStore(ctrl *gomock.Controller) *MockSigningKeyStore {
+	mock := &MockSigningKeyStore{ctrl: ctrl}
+	mock.recorder = &MockSigningKeyStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSigningKeyStore) EXPECT() *MockSigningKeyStoreMockRecorder {
+	return m.recorder
+}
+
+// GetSigningKey mocks base method.
+func (m *MockSigningKeyStore) GetSigningKey(arg0 context.Context) (*SigningKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSigningKey", arg0)
+	ret0, _ := ret[0].(*SigningKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSigningKey indicates an expected call of GetSigningKey.
+func (mr *MockSigningKeyStoreMockRecorder) GetSigningKey(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSigningKey", reflect.TypeOf((*MockSigningKeyStore)(nil).GetSigningKey), arg0)
+}
+
+// GetVerificationKey mocks base method.
+func (m *MockSigningKeyStore) GetVerificationKey(arg0 context.Context, arg1 string) (*SigningKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVerificationKey", arg0, arg1)
+	ret0, _ := ret[0].(*SigningKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVerificationKey indicates an expected call of GetVerificationKey.
+func (mr *MockSigningKeyStoreMockRecorder) GetVerificationKey(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVerificationKey", reflect.TypeOf((*MockSigningKeyStore)(nil).GetVerificationKey), arg0, arg1)
+}
+
+// ListVerificationKeys mocks base method.
+func (m *MockSigningKeyStore) ListVerificationKeys(arg0 context.Context) ([]*SigningKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVerificationKeys", arg0)
+	ret0, _ := ret[0].([]*SigningKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVerificationKeys indicates an expected call of ListVerificationKeys.
+func (mr *MockSigningKeyStoreMockRecorder) ListVerificationKeys(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVerificationKeys", reflect.TypeOf((*MockSigningKeyStore)(nil).ListVerificationKeys), arg0)
+}
+
+// MockSigningKeyRotator is a mock of SigningKeyRotator interface.
+type MockSigningKeyRotator struct {
+	ctrl     *gomock.Controller
+	recorder *MockSigningKeyRotatorMockRecorder
+}
+
+// MockSigningKeyRotatorMockRecorder is the mock recorder for MockSigningKeyRotator.
+type MockSigningKeyRotatorMockRecorder struct {
+	mock *MockSigningKeyRotator
+}
+
+// NewMockSigningKeyRotator creates a new mock instance.
+func NewMockSigningKeyRotator(ctrl *gomock.Controller) *MockSigningKeyRotator {
+	mock := &MockSigningKeyRotator{ctrl: ctrl}
+	mock.recorder = &MockSigningKeyRotatorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSigningKeyRotator) EXPECT() *MockSigningKeyRotatorMockRecorder {
+	return m.recorder
+}
+
+// Rotate mocks base method.
+func (m *MockSigningKeyRotator) Rotate(arg0 context.Context) (*SigningKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rotate", arg0)
+	ret0, _ := ret[0].(*SigningKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rotate indicates an expected call of Rotate.
+func (mr *MockSigningKeyRotatorMockRecorder) Rotate(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rotate", reflect.TypeOf((*MockSigningKeyRotator)(nil).Rotate), arg0)
+}
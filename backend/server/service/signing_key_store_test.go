@@ -0,0 +1,437 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/volatiletech/null/v8"
+
+	"github.com/sean-ahn/user/backend/model"
+	"github.com/sean-ahn/user/backend/test"
+)
+
+const (
+	listUnretiredJWTSigningKeysQuery = "SELECT * FROM `jwt_signing_key` WHERE (`jwt_signing_key`.`retires_at` is null OR `jwt_signing_key`.`retires_at` > ?) ORDER BY activates_at DESC, jwt_signing_key_id DESC;"
+)
+
+func expectSigningKeyGetLock(mock sqlmock.Sqlmock, timeoutSec, locked int) {
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT GET_LOCK(?, ?)")).
+		WithArgs(signingKeyRotationLockName, timeoutSec).
+		WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(locked))
+}
+
+func expectSigningKeyReleaseLock(mock sqlmock.Sqlmock) {
+	mock.ExpectExec(regexp.QuoteMeta("SELECT RELEASE_LOCK(?)")).
+		WithArgs(signingKeyRotationLockName).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+// expectSigningKeyLockConnClose expects the conn the lock was held on, which is closed along with the db.
+func expectSigningKeyLockConnClose(mock sqlmock.Sqlmock) {
+	mock.ExpectClose()
+}
+
+func TestDBSigningKeyStore_GetSigningKey(t *testing.T) {
+	now := time.Date(2021, 10, 24, 7, 39, 46, 127956672, time.UTC)
+
+	privateKeyPEM, err := testSigningKey.MarshalPEM()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pendingKey := mustGenerateSigningKey(SigningAlgorithmES256)
+	pendingKeyPEM, err := pendingKey.MarshalPEM()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name string
+
+		dbExpectFunc func(sqlmock.Sqlmock)
+	}{
+		{
+			name: "key exists",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					listUnretiredJWTSigningKeysQuery,
+				)).WithArgs(
+					now,
+				).WillReturnRows(test.NewJWTSigningKeyRows([]*model.JWTSigningKey{
+					{JWTSigningKeyID: 1, Kid: testSigningKey.ID, Algorithm: testSigningKey.Algorithm, PrivateKey: privateKeyPEM, ActivatesAt: now.Add(-24 * time.Hour)},
+				}))
+			},
+		},
+		{
+			name: "key not activated yet",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					listUnretiredJWTSigningKeysQuery,
+				)).WithArgs(
+					now,
+				).WillReturnRows(test.NewJWTSigningKeyRows([]*model.JWTSigningKey{
+					{JWTSigningKeyID: 2, Kid: pendingKey.ID, Algorithm: pendingKey.Algorithm, PrivateKey: pendingKeyPEM, ActivatesAt: now.Add(10 * time.Minute)},
+					{JWTSigningKeyID: 1, Kid: testSigningKey.ID, Algorithm: testSigningKey.Algorithm, PrivateKey: privateKeyPEM, ActivatesAt: now.Add(-24 * time.Hour), RetiresAt: null.TimeFrom(now.Add(14 * 24 * time.Hour))},
+				}))
+			},
+		},
+		{
+			name: "key not exists",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					listUnretiredJWTSigningKeysQuery,
+				)).WithArgs(
+					now,
+				).WillReturnRows(test.NewJWTSigningKeyRows(nil))
+
+				expectSigningKeyGetLock(mock, signingKeyLockTimeoutSec, 1)
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					listUnretiredJWTSigningKeysQuery,
+				)).WithArgs(
+					now,
+				).WillReturnRows(test.NewJWTSigningKeyRows(nil))
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"INSERT INTO `jwt_signing_key` (`kid`,`algorithm`,`private_key`,`activates_at`,`retires_at`) VALUES (?,?,?,?,?)",
+				)).WithArgs(
+					sqlmock.AnyArg(), SigningAlgorithmES256, sqlmock.AnyArg(), now, nil,
+				).WillReturnResult(
+					sqlmock.NewResult(1, 1),
+				)
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT `jwt_signing_key_id`,`created_at`,`updated_at` FROM `jwt_signing_key` WHERE `jwt_signing_key_id`=?",
+				)).WithArgs(
+					1,
+				).WillReturnRows(sqlmock.NewRows([]string{"jwt_signing_key_id", "created_at", "updated_at"}).
+					AddRow(1, now, now),
+				)
+
+				expectSigningKeyReleaseLock(mock)
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					listUnretiredJWTSigningKeysQuery,
+				)).WithArgs(
+					now,
+				).WillReturnRows(test.NewJWTSigningKeyRows([]*model.JWTSigningKey{
+					{JWTSigningKeyID: 1, Kid: testSigningKey.ID, Algorithm: testSigningKey.Algorithm, PrivateKey: privateKeyPEM, ActivatesAt: now},
+				}))
+
+				expectSigningKeyLockConnClose(mock)
+			},
+		},
+		{
+			name: "key created by another instance while waiting for the lock",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					listUnretiredJWTSigningKeysQuery,
+				)).WithArgs(
+					now,
+				).WillReturnRows(test.NewJWTSigningKeyRows(nil))
+
+				expectSigningKeyGetLock(mock, signingKeyLockTimeoutSec, 1)
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					listUnretiredJWTSigningKeysQuery,
+				)).WithArgs(
+					now,
+				).WillReturnRows(test.NewJWTSigningKeyRows([]*model.JWTSigningKey{
+					{JWTSigningKeyID: 1, Kid: testSigningKey.ID, Algorithm: testSigningKey.Algorithm, PrivateKey: privateKeyPEM, ActivatesAt: now},
+				}))
+
+				expectSigningKeyReleaseLock(mock)
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					listUnretiredJWTSigningKeysQuery,
+				)).WithArgs(
+					now,
+				).WillReturnRows(test.NewJWTSigningKeyRows([]*model.JWTSigningKey{
+					{JWTSigningKeyID: 1, Kid: testSigningKey.ID, Algorithm: testSigningKey.Algorithm, PrivateKey: privateKeyPEM, ActivatesAt: now},
+				}))
+
+				expectSigningKeyLockConnClose(mock)
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fail()
+			}
+			defer test.CloseSqlmock(t, db, mock)
+
+			if tc.dbExpectFunc != nil {
+				tc.dbExpectFunc(mock)
+			}
+
+			store, err := NewDBSigningKeyStore(clockwork.NewFakeClockAt(now), db, SigningAlgorithmES256, SigningKeyRotationPolicy{})
+			assert.NoError(t, err)
+
+			key, err := store.GetSigningKey(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, testSigningKey.ID, key.ID)
+
+			// cached
+			key, err = store.GetVerificationKey(ctx, testSigningKey.ID)
+			assert.NoError(t, err)
+			assert.Equal(t, testSigningKey.ID, key.ID)
+		})
+	}
+}
+
+func TestDBSigningKeyStore_Rotate(t *testing.T) {
+	now := time.Date(2021, 10, 24, 7, 39, 46, 127956672, time.UTC)
+
+	policy := SigningKeyRotationPolicy{
+		RotationPeriod: 30 * 24 * time.Hour,
+		PublishDelay:   10 * time.Minute,
+		RetireDelay:    14 * 24 * time.Hour,
+	}
+
+	privateKeyPEM, err := testSigningKey.MarshalPEM()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fail()
+	}
+	defer test.CloseSqlmock(t, db, mock)
+
+	expectSigningKeyGetLock(mock, signingKeyLockTimeoutSec, 1)
+
+	mock.ExpectQuery(regexp.QuoteMeta(
+		listUnretiredJWTSigningKeysQuery,
+	)).WithArgs(
+		now,
+	).WillReturnRows(test.NewJWTSigningKeyRows([]*model.JWTSigningKey{
+		{JWTSigningKeyID: 1, Kid: testSigningKey.ID, Algorithm: testSigningKey.Algorithm, PrivateKey: privateKeyPEM, ActivatesAt: now.Add(-24 * time.Hour)},
+	}))
+
+	mock.ExpectBegin()
+
+	mock.ExpectExec(regexp.QuoteMeta(
+		"UPDATE `jwt_signing_key` SET `retires_at` = ? WHERE (`jwt_signing_key`.`retires_at` is null);",
+	)).WithArgs(
+		now.Add(policy.PublishDelay + policy.RetireDelay),
+	).WillReturnResult(
+		sqlmock.NewResult(0, 1),
+	)
+
+	mock.ExpectExec(regexp.QuoteMeta(
+		"INSERT INTO `jwt_signing_key` (`kid`,`algorithm`,`private_key`,`activates_at`,`retires_at`) VALUES (?,?,?,?,?)",
+	)).WithArgs(
+		sqlmock.AnyArg(), SigningAlgorithmES256, sqlmock.AnyArg(), now.Add(policy.PublishDelay), nil,
+	).WillReturnResult(
+		sqlmock.NewResult(2, 1),
+	)
+
+	mock.ExpectQuery(regexp.QuoteMeta(
+		"SELECT `jwt_signing_key_id`,`created_at`,`updated_at` FROM `jwt_signing_key` WHERE `jwt_signing_key_id`=?",
+	)).WithArgs(
+		2,
+	).WillReturnRows(sqlmock.NewRows([]string{"jwt_signing_key_id", "created_at", "updated_at"}).
+		AddRow(2, now, now),
+	)
+
+	mock.ExpectCommit()
+
+	expectSigningKeyReleaseLock(mock)
+
+	store, err := NewDBSigningKeyStore(clockwork.NewFakeClockAt(now), db, SigningAlgorithmES256, policy)
+	assert.NoError(t, err)
+
+	newKey, err := store.Rotate(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, now.Add(policy.PublishDelay), newKey.ActivatesAt)
+	assert.True(t, newKey.RetiresAt.IsZero())
+
+	newKeyPEM, err := newKey.MarshalPEM()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the new key is published right away, but the previous one keeps signing until the new one is activated.
+	mock.ExpectQuery(regexp.QuoteMeta(
+		listUnretiredJWTSigningKeysQuery,
+	)).WithArgs(
+		now,
+	).WillReturnRows(test.NewJWTSigningKeyRows([]*model.JWTSigningKey{
+		{JWTSigningKeyID: 2, Kid: newKey.ID, Algorithm: newKey.Algorithm, PrivateKey: newKeyPEM, ActivatesAt: now.Add(policy.PublishDelay)},
+		{JWTSigningKeyID: 1, Kid: testSigningKey.ID, Algorithm: testSigningKey.Algorithm, PrivateKey: privateKeyPEM, ActivatesAt: now.Add(-24 * time.Hour), RetiresAt: null.TimeFrom(now.Add(policy.PublishDelay + policy.RetireDelay))},
+	}))
+
+	expectSigningKeyLockConnClose(mock)
+
+	keys, err := store.ListVerificationKeys(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, keys, 2)
+
+	signingKey, err := store.GetSigningKey(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, testSigningKey.ID, signingKey.ID)
+}
+
+func TestDBSigningKeyStore_Rotate_inProgress(t *testing.T) {
+	now := time.Date(2021, 10, 24, 7, 39, 46, 127956672, time.UTC)
+
+	policy := SigningKeyRotationPolicy{
+		RotationPeriod: 30 * 24 * time.Hour,
+		PublishDelay:   10 * time.Minute,
+		RetireDelay:    14 * 24 * time.Hour,
+	}
+
+	privateKeyPEM, err := testSigningKey.MarshalPEM()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pendingKey := mustGenerateSigningKey(SigningAlgorithmES256)
+	pendingKeyPEM, err := pendingKey.MarshalPEM()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fail()
+	}
+	defer test.CloseSqlmock(t, db, mock)
+
+	// rotated by the scheduled rotation while waiting for the lock, so no other key is created
+	expectSigningKeyGetLock(mock, signingKeyLockTimeoutSec, 1)
+	mock.ExpectQuery(regexp.QuoteMeta(
+		listUnretiredJWTSigningKeysQuery,
+	)).WithArgs(
+		now,
+	).WillReturnRows(test.NewJWTSigningKeyRows([]*model.JWTSigningKey{
+		{JWTSigningKeyID: 2, Kid: pendingKey.ID, Algorithm: pendingKey.Algorithm, PrivateKey: pendingKeyPEM, ActivatesAt: now.Add(5 * time.Minute)},
+		{JWTSigningKeyID: 1, Kid: testSigningKey.ID, Algorithm: testSigningKey.Algorithm, PrivateKey: privateKeyPEM, ActivatesAt: now.Add(-policy.RotationPeriod), RetiresAt: null.TimeFrom(now.Add(5*time.Minute + policy.RetireDelay))},
+	}))
+	expectSigningKeyReleaseLock(mock)
+	expectSigningKeyLockConnClose(mock)
+
+	store, err := NewDBSigningKeyStore(clockwork.NewFakeClockAt(now), db, SigningAlgorithmES256, policy)
+	assert.NoError(t, err)
+
+	key, err := store.Rotate(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, pendingKey.ID, key.ID)
+}
+
+func TestDBSigningKeyStore_rotateIfDue(t *testing.T) {
+	now := time.Date(2021, 10, 24, 7, 39, 46, 127956672, time.UTC)
+
+	policy := SigningKeyRotationPolicy{
+		RotationPeriod: 30 * 24 * time.Hour,
+		PublishDelay:   10 * time.Minute,
+		RetireDelay:    14 * 24 * time.Hour,
+	}
+
+	privateKeyPEM, err := testSigningKey.MarshalPEM()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectListKeys := func(mock sqlmock.Sqlmock, activatesAt time.Time) {
+		mock.ExpectQuery(regexp.QuoteMeta(
+			listUnretiredJWTSigningKeysQuery,
+		)).WithArgs(
+			now,
+		).WillReturnRows(test.NewJWTSigningKeyRows([]*model.JWTSigningKey{
+			{JWTSigningKeyID: 1, Kid: testSigningKey.ID, Algorithm: testSigningKey.Algorithm, PrivateKey: privateKeyPEM, ActivatesAt: activatesAt},
+		}))
+	}
+
+	cases := []struct {
+		name string
+
+		dbExpectFunc func(sqlmock.Sqlmock)
+	}{
+		{
+			name: "due",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				expectSigningKeyGetLock(mock, 0, 1)
+				expectListKeys(mock, now.Add(-policy.RotationPeriod))
+
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta(
+					"UPDATE `jwt_signing_key` SET `retires_at` = ? WHERE (`jwt_signing_key`.`retires_at` is null);",
+				)).WithArgs(
+					now.Add(policy.PublishDelay + policy.RetireDelay),
+				).WillReturnResult(
+					sqlmock.NewResult(0, 1),
+				)
+				mock.ExpectExec(regexp.QuoteMeta(
+					"INSERT INTO `jwt_signing_key` (`kid`,`algorithm`,`private_key`,`activates_at`,`retires_at`) VALUES (?,?,?,?,?)",
+				)).WithArgs(
+					sqlmock.AnyArg(), SigningAlgorithmES256, sqlmock.AnyArg(), now.Add(policy.PublishDelay), nil,
+				).WillReturnResult(
+					sqlmock.NewResult(2, 1),
+				)
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT `jwt_signing_key_id`,`created_at`,`updated_at` FROM `jwt_signing_key` WHERE `jwt_signing_key_id`=?",
+				)).WithArgs(
+					2,
+				).WillReturnRows(sqlmock.NewRows([]string{"jwt_signing_key_id", "created_at", "updated_at"}).
+					AddRow(2, now, now),
+				)
+				mock.ExpectCommit()
+
+				expectSigningKeyReleaseLock(mock)
+				expectSigningKeyLockConnClose(mock)
+			},
+		},
+		{
+			name: "not due",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				expectSigningKeyGetLock(mock, 0, 1)
+				expectListKeys(mock, now.Add(-24*time.Hour))
+				expectSigningKeyReleaseLock(mock)
+				expectSigningKeyLockConnClose(mock)
+			},
+		},
+		{
+			name: "rotating in another instance",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				expectSigningKeyGetLock(mock, 0, 0)
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fail()
+			}
+			if tc.dbExpectFunc != nil {
+				tc.dbExpectFunc(mock)
+			}
+			defer test.CloseSqlmock(t, db, mock)
+
+			store, err := NewDBSigningKeyStore(clockwork.NewFakeClockAt(now), db, SigningAlgorithmES256, policy)
+			assert.NoError(t, err)
+
+			err = store.rotateIfDue(context.Background())
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"encoding/base64"
+	"io"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type publicKeyOnlySigner struct {
+	pub crypto.PublicKey
+}
+
+func (s *publicKeyOnlySigner) Public() crypto.PublicKey {
+	return s.pub
+}
+
+func (s *publicKeyOnlySigner) Sign(_ io.Reader, _ []byte, _ crypto.SignerOpts) ([]byte, error) {
+	return nil, nil
+}
+
+func TestSigningKey_thumbprint(t *testing.T) {
+	// See https://datatracker.ietf.org/doc/html/rfc7638#section-3.1
+	n, err := base64.RawURLEncoding.DecodeString("0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key := &SigningKey{
+		Algorithm:  SigningAlgorithmRS256,
+		PrivateKey: &publicKeyOnlySigner{pub: &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: 65537}},
+	}
+
+	assert.Equal(t, "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs", key.thumbprint())
+}
+
+func TestParseSigningKey(t *testing.T) {
+	cases := []struct {
+		algorithm, mismatchedAlgorithm string
+	}{
+		{algorithm: SigningAlgorithmRS256, mismatchedAlgorithm: SigningAlgorithmES256},
+		{algorithm: SigningAlgorithmES256, mismatchedAlgorithm: SigningAlgorithmEdDSA},
+		{algorithm: SigningAlgorithmEdDSA, mismatchedAlgorithm: SigningAlgorithmRS256},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.algorithm, func(t *testing.T) {
+			key, err := GenerateSigningKey(tc.algorithm)
+			assert.NoError(t, err)
+
+			privateKeyPEM, err := key.MarshalPEM()
+			assert.NoError(t, err)
+
+			parsed, err := ParseSigningKey(tc.algorithm, privateKeyPEM)
+			assert.NoError(t, err)
+			assert.Equal(t, key.ID, parsed.ID)
+			assert.Equal(t, key.JWK(), parsed.JWK())
+			assert.Equal(t, tc.algorithm, parsed.SigningMethod().Alg())
+
+			_, err = ParseSigningKey(tc.mismatchedAlgorithm, privateKeyPEM)
+			assert.EqualError(t, err, "invalid signing key")
+		})
+	}
+}
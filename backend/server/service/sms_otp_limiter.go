@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/nyaruka/phonenumbers"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+
+	"github.com/sean-ahn/user/backend/model"
+	"github.com/sean-ahn/user/backend/persistence/mysql"
+)
+
+const (
+	SMSOTPLimitResendCooldown           = "resend_cooldown"
+	SMSOTPLimitDailyLimitPerPhoneNumber = "daily_limit_per_phone_number"
+	SMSOTPLimitHourlyLimitPerIPAddress  = "hourly_limit_per_ip_address"
+	SMSOTPLimitConcurrentRequest        = "concurrent_request"
+
+	smsOTPLimitLockTimeoutSec = 5
+)
+
+var ErrSMSOTPRegionNotAllowed = errors.New("sms otp region not allowed")
+
+// RateLimitedError is returned when a request is rejected by the limit, and can be retried after RetryAfter.
+type RateLimitedError struct {
+	Limit      string
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitedError) Error() string {
+	return fmt.Sprintf("rate limited by %s", e.Limit)
+}
+
+//go:generate mockgen -package service -destination ./sms_otp_limiter_mock.go -mock_names SMSOTPLimiter=MockSMSOTPLimiter github.com/sean-ahn/user/backend/server/service SMSOTPLimiter
+
+type SMSOTPLimiter interface {
+	// Allow calls send if an SMS OTP can be sent to the phone number at the request of the IP address, which is empty
+	// if unknown. Otherwise it returns ErrSMSOTPRegionNotAllowed or *RateLimitedError. The requests of the same phone
+	// number or IP address are serialized until send returns, so send should have recorded the SMS OTP by then.
+	Allow(ctx context.Context, phoneNumber, ipAddress string, send func(context.Context) error) error
+}
+
+type SMSOTPLimitPolicy struct {
+	// AllowedRegions are the CLDR region codes of the phone numbers, e.g. KR. All regions are allowed if empty.
+	AllowedRegions []string
+
+	// the limits are disabled if zero
+	ResendCooldown           time.Duration
+	DailyLimitPerPhoneNumber int
+	HourlyLimitPerIPAddress  int
+}
+
+// DBSMSOTPLimiter limits by the history of sms_otp_verification, in which every SMS OTP sent is recorded. The history
+// is checked and added to holding named locks of the phone number and the IP address, or the concurrent requests would
+// all pass the limits.
+type DBSMSOTPLimiter struct {
+	clock  clockwork.Clock
+	db     *sql.DB
+	policy SMSOTPLimitPolicy
+}
+
+var _ SMSOTPLimiter = (*DBSMSOTPLimiter)(nil)
+
+func NewDBSMSOTPLimiter(clock clockwork.Clock, db *sql.DB, policy SMSOTPLimitPolicy) *DBSMSOTPLimiter {
+	return &DBSMSOTPLimiter{
+		clock:  clock,
+		db:     db,
+		policy: policy,
+	}
+}
+
+func (l *DBSMSOTPLimiter) Allow(ctx context.Context, phoneNumber, ipAddress string, send func(context.Context) error) error {
+	if !l.regionAllowed(phoneNumber) {
+		return errors.WithStack(ErrSMSOTPRegionNotAllowed)
+	}
+
+	limitsPhoneNumber := l.policy.ResendCooldown > 0 || l.policy.DailyLimitPerPhoneNumber > 0
+	limitsIPAddress := l.policy.HourlyLimitPerIPAddress > 0 && ipAddress != ""
+	if !limitsPhoneNumber && !limitsIPAddress {
+		return send(ctx)
+	}
+
+	conn, err := l.db.Conn(ctx)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer conn.Close()
+
+	// always locked in this order, so that two requests never wait for each other
+	var lockNames []string
+	if limitsPhoneNumber {
+		lockNames = append(lockNames, "sms_otp:phone:"+phoneNumber)
+	}
+	if limitsIPAddress {
+		lockNames = append(lockNames, "sms_otp:ip:"+ipAddress)
+	}
+	for _, name := range lockNames {
+		locked, err := mysql.GetLock(ctx, conn, name, smsOTPLimitLockTimeoutSec)
+		if err != nil {
+			return err
+		}
+		if !locked {
+			return errors.WithStack(&RateLimitedError{Limit: SMSOTPLimitConcurrentRequest, RetryAfter: smsOTPLimitLockTimeoutSec * time.Second})
+		}
+
+		name := name
+		defer func() {
+			if err := mysql.ReleaseLock(context.Background(), conn, name); err != nil {
+				logrus.WithError(err).Error()
+			}
+		}()
+	}
+
+	now := l.clock.Now()
+
+	if limitsPhoneNumber {
+		limit := l.policy.DailyLimitPerPhoneNumber
+		if limit <= 0 {
+			limit = 1 // only the latest one is needed for the cooldown
+		}
+
+		vs, err := mysql.ListRecentSMSOTPVerificationsByPhoneNumber(ctx, l.db, phoneNumber, now.Add(-24*time.Hour), limit)
+		if err != nil {
+			return err
+		}
+
+		if l.policy.ResendCooldown > 0 && len(vs) > 0 {
+			if err := checkRetryAfter(SMSOTPLimitResendCooldown, vs[0].CreatedAt.Add(l.policy.ResendCooldown), now); err != nil {
+				return err
+			}
+		}
+		if l.policy.DailyLimitPerPhoneNumber > 0 {
+			if err := checkWindowLimit(SMSOTPLimitDailyLimitPerPhoneNumber, vs, l.policy.DailyLimitPerPhoneNumber, 24*time.Hour, now); err != nil {
+				return err
+			}
+		}
+	}
+
+	if limitsIPAddress {
+		vs, err := mysql.ListRecentSMSOTPVerificationsByIPAddress(ctx, l.db, ipAddress, now.Add(-time.Hour), l.policy.HourlyLimitPerIPAddress)
+		if err != nil {
+			return err
+		}
+
+		if err := checkWindowLimit(SMSOTPLimitHourlyLimitPerIPAddress, vs, l.policy.HourlyLimitPerIPAddress, time.Hour, now); err != nil {
+			return err
+		}
+	}
+
+	return send(ctx)
+}
+
+func (l *DBSMSOTPLimiter) regionAllowed(phoneNumber string) bool {
+	if len(l.policy.AllowedRegions) == 0 {
+		return true
+	}
+
+	p, err := phonenumbers.Parse(phoneNumber, "")
+	if err != nil {
+		return false
+	}
+	region := phonenumbers.GetRegionCodeForNumber(p)
+	for _, r := range l.policy.AllowedRegions {
+		if r == region {
+			return true
+		}
+	}
+	return false
+}
+
+// checkWindowLimit rejects if the limit has been reached in the sliding window, until the oldest of the latest ones
+// leaves the window. vs are the latest first.
+func checkWindowLimit(limit string, vs model.SMSOtpVerificationSlice, n int, window time.Duration, now time.Time) error {
+	if len(vs) < n {
+		return nil
+	}
+	return checkRetryAfter(limit, vs[n-1].CreatedAt.Add(window), now)
+}
+
+func checkRetryAfter(limit string, retryAt, now time.Time) error {
+	if !retryAt.After(now) {
+		return nil
+	}
+	return errors.WithStack(&RateLimitedError{Limit: limit, RetryAfter: retryAt.Sub(now)})
+}
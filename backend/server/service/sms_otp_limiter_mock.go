@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sean-ahn/user/backend/server/service (interfaces: SMSOTPLimiter)
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSMSOTPLimiter is a mock of SMSOTPLimiter interface.
+type MockSMSOTPLimiter struct {
+	ctrl     *gomock.Controller
+	recorder *MockSMSOTPLimiterMockRecorder
+}
+
+// MockSMSOTPLimiterMockRecorder is the mock recorder for MockSMSOTPLimiter.
+type MockSMSOTPLimiterMockRecorder struct {
+	mock *MockSMSOTPLimiter
+}
+
+// NewMockSMSOTPLimiter creates a new mock instance.
+func NewMockSMSOTPLimiter(ctrl *gomock.Controller) *MockSMSOTPLimiter {
+	mock := &MockSMSOTPLimiter{ctrl: ctrl}
+	mock.recorder = &MockSMSOTPLimiterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSMSOTPLimiter) EXPECT() *MockSMSOTPLimiterMockRecorder {
+	return m.recorder
+}
+
+// Allow mocks base method.
+func (m *MockSMSOTPLimiter) Allow(arg0 context.Context, arg1, arg2 string, arg3 func(context.Context) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Allow", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Allow indicates an expected call of Allow.
+func (mr *MockSMSOTPLimiterMockRecorder) Allow(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Allow", reflect.TypeOf((*MockSMSOTPLimiter)(nil).Allow), arg0, arg1, arg2, arg3)
+}
@@ -0,0 +1,275 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jonboulle/clockwork"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sean-ahn/user/backend/model"
+	"github.com/sean-ahn/user/backend/test"
+)
+
+const (
+	smsOTPPhoneNumberQuery = "SELECT * FROM `sms_otp_verification` WHERE (`sms_otp_verification`.`phone_number` = ?) AND (`sms_otp_verification`.`created_at` >= ?) ORDER BY created_at DESC LIMIT 2;"
+	smsOTPIPAddressQuery   = "SELECT * FROM `sms_otp_verification` WHERE (`sms_otp_verification`.`ip_address` = ?) AND (`sms_otp_verification`.`created_at` >= ?) ORDER BY created_at DESC LIMIT 2;"
+)
+
+var testSMSOTPLimitPolicy = SMSOTPLimitPolicy{
+	AllowedRegions:           []string{"KR"},
+	ResendCooldown:           time.Minute,
+	DailyLimitPerPhoneNumber: 2,
+	HourlyLimitPerIPAddress:  2,
+}
+
+func expectSMSOTPGetLock(mock sqlmock.Sqlmock, name string, locked int) {
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT GET_LOCK(?, ?)")).
+		WithArgs(name, smsOTPLimitLockTimeoutSec).
+		WillReturnRows(sqlmock.NewRows([]string{"GET_LOCK(?, ?)"}).AddRow(locked))
+}
+
+func expectSMSOTPReleaseLock(mock sqlmock.Sqlmock, name string) {
+	mock.ExpectExec(regexp.QuoteMeta("SELECT RELEASE_LOCK(?)")).
+		WithArgs(name).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func smsOTPVerificationsSentAt(ts ...time.Time) []*model.SMSOtpVerification {
+	var vs []*model.SMSOtpVerification
+	for i, t := range ts {
+		vs = append(vs, &model.SMSOtpVerification{SMSOtpVerificationID: i + 1, PhoneNumber: "+821012345678", IPAddress: "198.51.100.7", CreatedAt: t, UpdatedAt: t})
+	}
+	return vs
+}
+
+func TestDBSMSOTPLimiter_Allow(t *testing.T) {
+	now := time.Date(2021, 10, 24, 17, 18, 16, 304850171, time.UTC)
+
+	// the locks are held on a conn of their own, so the history is read on another, which is closed too
+	expectLockedQueries := func(mock sqlmock.Sqlmock, lockNames []string, queries func(sqlmock.Sqlmock)) {
+		for _, name := range lockNames {
+			expectSMSOTPGetLock(mock, name, 1)
+		}
+		queries(mock)
+		for i := len(lockNames) - 1; i >= 0; i-- {
+			expectSMSOTPReleaseLock(mock, lockNames[i])
+		}
+		mock.ExpectClose()
+	}
+	lockNames := []string{"sms_otp:phone:+821012345678", "sms_otp:ip:198.51.100.7"}
+
+	cases := []struct {
+		name        string
+		policy      SMSOTPLimitPolicy
+		phoneNumber string
+		ipAddress   string
+
+		dbExpectFunc func(sqlmock.Sqlmock)
+
+		expectedSent bool
+		expectedErr  error
+	}{
+		{
+			name:        "allowed",
+			policy:      testSMSOTPLimitPolicy,
+			phoneNumber: "+821012345678",
+			ipAddress:   "198.51.100.7",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				expectLockedQueries(mock, lockNames, func(mock sqlmock.Sqlmock) {
+					mock.ExpectQuery(regexp.QuoteMeta(smsOTPPhoneNumberQuery)).
+						WithArgs("+821012345678", now.Add(-24*time.Hour)).
+						WillReturnRows(test.NewSMSOtpVerificationRows(smsOTPVerificationsSentAt(now.Add(-2 * time.Minute))))
+					mock.ExpectQuery(regexp.QuoteMeta(smsOTPIPAddressQuery)).
+						WithArgs("198.51.100.7", now.Add(-time.Hour)).
+						WillReturnRows(test.NewSMSOtpVerificationRows(smsOTPVerificationsSentAt(now.Add(-2 * time.Minute))))
+				})
+			},
+			expectedSent: true,
+		},
+		{
+			name:        "resend cooldown",
+			policy:      testSMSOTPLimitPolicy,
+			phoneNumber: "+821012345678",
+			ipAddress:   "198.51.100.7",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				expectLockedQueries(mock, lockNames, func(mock sqlmock.Sqlmock) {
+					mock.ExpectQuery(regexp.QuoteMeta(smsOTPPhoneNumberQuery)).
+						WithArgs("+821012345678", now.Add(-24*time.Hour)).
+						WillReturnRows(test.NewSMSOtpVerificationRows(smsOTPVerificationsSentAt(now.Add(-18 * time.Second))))
+				})
+			},
+			expectedErr: &RateLimitedError{Limit: SMSOTPLimitResendCooldown, RetryAfter: 42 * time.Second},
+		},
+		{
+			name:        "daily limit per phone number",
+			policy:      testSMSOTPLimitPolicy,
+			phoneNumber: "+821012345678",
+			ipAddress:   "198.51.100.7",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				expectLockedQueries(mock, lockNames, func(mock sqlmock.Sqlmock) {
+					mock.ExpectQuery(regexp.QuoteMeta(smsOTPPhoneNumberQuery)).
+						WithArgs("+821012345678", now.Add(-24*time.Hour)).
+						WillReturnRows(test.NewSMSOtpVerificationRows(smsOTPVerificationsSentAt(now.Add(-time.Hour), now.Add(-20*time.Hour))))
+				})
+			},
+			expectedErr: &RateLimitedError{Limit: SMSOTPLimitDailyLimitPerPhoneNumber, RetryAfter: 4 * time.Hour},
+		},
+		{
+			name:        "hourly limit per ip address",
+			policy:      testSMSOTPLimitPolicy,
+			phoneNumber: "+821012345678",
+			ipAddress:   "198.51.100.7",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				expectLockedQueries(mock, lockNames, func(mock sqlmock.Sqlmock) {
+					mock.ExpectQuery(regexp.QuoteMeta(smsOTPPhoneNumberQuery)).
+						WithArgs("+821012345678", now.Add(-24*time.Hour)).
+						WillReturnRows(test.NewSMSOtpVerificationRows(nil))
+					mock.ExpectQuery(regexp.QuoteMeta(smsOTPIPAddressQuery)).
+						WithArgs("198.51.100.7", now.Add(-time.Hour)).
+						WillReturnRows(test.NewSMSOtpVerificationRows(smsOTPVerificationsSentAt(now.Add(-10*time.Minute), now.Add(-50*time.Minute))))
+				})
+			},
+			expectedErr: &RateLimitedError{Limit: SMSOTPLimitHourlyLimitPerIPAddress, RetryAfter: 10 * time.Minute},
+		},
+		{
+			name:        "unknown ip address",
+			policy:      testSMSOTPLimitPolicy,
+			phoneNumber: "+821012345678",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				expectLockedQueries(mock, lockNames[:1], func(mock sqlmock.Sqlmock) {
+					mock.ExpectQuery(regexp.QuoteMeta(smsOTPPhoneNumberQuery)).
+						WithArgs("+821012345678", now.Add(-24*time.Hour)).
+						WillReturnRows(test.NewSMSOtpVerificationRows(nil))
+				})
+			},
+			expectedSent: true,
+		},
+		{
+			name:        "concurrent request",
+			policy:      testSMSOTPLimitPolicy,
+			phoneNumber: "+821012345678",
+			ipAddress:   "198.51.100.7",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				expectSMSOTPGetLock(mock, "sms_otp:phone:+821012345678", 1)
+				expectSMSOTPGetLock(mock, "sms_otp:ip:198.51.100.7", 0)
+				expectSMSOTPReleaseLock(mock, "sms_otp:phone:+821012345678")
+			},
+			expectedErr: &RateLimitedError{Limit: SMSOTPLimitConcurrentRequest, RetryAfter: smsOTPLimitLockTimeoutSec * time.Second},
+		},
+		{
+			name:        "region not allowed",
+			policy:      testSMSOTPLimitPolicy,
+			phoneNumber: "+14155550100",
+			ipAddress:   "198.51.100.7",
+			expectedErr: ErrSMSOTPRegionNotAllowed,
+		},
+		{
+			name:         "limits disabled",
+			phoneNumber:  "+14155550100",
+			ipAddress:    "198.51.100.7",
+			expectedSent: true,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fail()
+			}
+			if tc.dbExpectFunc != nil {
+				tc.dbExpectFunc(mock)
+			}
+			defer test.CloseSqlmock(t, db, mock)
+
+			limiter := NewDBSMSOTPLimiter(clockwork.NewFakeClockAt(now), db, tc.policy)
+
+			sent := false
+			err = limiter.Allow(context.Background(), tc.phoneNumber, tc.ipAddress, func(context.Context) error {
+				sent = true
+				return nil
+			})
+
+			if tc.expectedErr != nil {
+				assert.Equal(t, tc.expectedErr, errors.Cause(err))
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedSent, sent)
+		})
+	}
+}
+
+func TestDBSMSOTPLimiter_Allow_concurrent(t *testing.T) {
+	now := time.Date(2021, 10, 24, 17, 18, 16, 304850171, time.UTC)
+
+	const (
+		phoneNumberQuery = "SELECT * FROM `sms_otp_verification` WHERE (`sms_otp_verification`.`phone_number` = ?) AND (`sms_otp_verification`.`created_at` >= ?) ORDER BY created_at DESC LIMIT 1;"
+		insertQuery      = "INSERT INTO `sms_otp_verification` (`phone_number`,`created_at`) VALUES (?,?)"
+	)
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fail()
+	}
+
+	// the first request is recorded before it releases the lock, so the second one finds it
+	expectSMSOTPGetLock(mock, "sms_otp:phone:+821012345678", 1)
+	mock.ExpectQuery(regexp.QuoteMeta(phoneNumberQuery)).
+		WithArgs("+821012345678", now.Add(-24*time.Hour)).
+		WillReturnRows(test.NewSMSOtpVerificationRows(nil))
+	mock.ExpectExec(regexp.QuoteMeta(insertQuery)).
+		WithArgs("+821012345678", now).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	expectSMSOTPReleaseLock(mock, "sms_otp:phone:+821012345678")
+
+	expectSMSOTPGetLock(mock, "sms_otp:phone:+821012345678", 1)
+	mock.ExpectQuery(regexp.QuoteMeta(phoneNumberQuery)).
+		WithArgs("+821012345678", now.Add(-24*time.Hour)).
+		WillReturnRows(test.NewSMSOtpVerificationRows(smsOTPVerificationsSentAt(now)))
+	expectSMSOTPReleaseLock(mock, "sms_otp:phone:+821012345678")
+
+	mock.ExpectClose()
+	defer test.CloseSqlmock(t, db, mock)
+
+	limiter := NewDBSMSOTPLimiter(clockwork.NewFakeClockAt(now), db, SMSOTPLimitPolicy{ResendCooldown: time.Minute})
+
+	var (
+		mu      sync.Mutex // sqlmock does not block on GET_LOCK, so mu stands for the named lock
+		wg      sync.WaitGroup
+		sent    int
+		limited int
+	)
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			err := limiter.Allow(context.Background(), "+821012345678", "", func(ctx context.Context) error {
+				sent++
+				_, err := db.ExecContext(ctx, insertQuery, "+821012345678", now)
+				return err
+			})
+			if _, ok := errors.Cause(err).(*RateLimitedError); ok {
+				limited++
+			} else {
+				assert.NoError(t, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, sent)
+	assert.Equal(t, 1, limited)
+}
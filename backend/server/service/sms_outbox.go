@@ -0,0 +1,224 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+
+	"github.com/sean-ahn/user/backend/client"
+	"github.com/sean-ahn/user/backend/model"
+	"github.com/sean-ahn/user/backend/persistence/mysql"
+	smsv1 "github.com/sean-ahn/user/proto/gen/go/sms/v1"
+)
+
+const (
+	SMSOutboxStatusPending = "pending"
+	SMSOutboxStatusSent    = "sent"
+	SMSOutboxStatusFailed  = "failed"
+
+	// a message claimed by a worker which has not reported the result by then, e.g. because the instance crashed,
+	// is sent again
+	smsOutboxClaimTimeout = 1 * time.Minute
+	// a send, including the retries and backoff of the gateway which are a few seconds at most by default, is
+	// cancelled by then, well before the claim times out. otherwise another instance could send the message again
+	// while this one is still sending it.
+	smsOutboxSendTimeout = 30 * time.Second
+
+	smsOutboxLastErrorMaxLen = 255
+)
+
+// OutboxSMS is a message to be sent by SMSOutboxDeliverer.
+type OutboxSMS struct {
+	PhoneNumber string
+	Message     string
+	// SMSOTPVerificationID is set if the message is of an SMS OTP verification.
+	SMSOTPVerificationID null.Int
+	// the message is given up if not sent by ExpiresAt, e.g. when the OTP code in it has expired.
+	ExpiresAt time.Time
+}
+
+// EnqueueSMS adds the message to sms_outbox. exec should be the transaction of the change the message is about, so
+// that the message is sent if and only if the change is committed.
+func EnqueueSMS(ctx context.Context, exec boil.ContextExecutor, sms OutboxSMS, now time.Time) (*model.SMSOutbox, error) {
+	o := &model.SMSOutbox{
+		SMSOtpVerificationID: sms.SMSOTPVerificationID,
+		PhoneNumber:          sms.PhoneNumber,
+		Message:              sms.Message,
+		Status:               SMSOutboxStatusPending,
+		NextAttemptAt:        now,
+		ExpiresAt:            sms.ExpiresAt,
+	}
+	if err := o.Insert(ctx, exec, boil.Infer()); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return o, nil
+}
+
+type SMSOutboxDeliveryPolicy struct {
+	// PollInterval is how often sms_outbox is checked for the messages due.
+	PollInterval time.Duration
+	// BatchSize is the max number of the messages sent per poll.
+	BatchSize int
+
+	// a message is given up after MaxAttempts, with exponential backoff between the attempts
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// SMSOutboxDeliverer sends the messages in sms_outbox and records the results. A message is claimed before being
+// sent, so that it is sent by one instance at a time, and is sent at least once unless given up.
+type SMSOutboxDeliverer struct {
+	clock    clockwork.Clock
+	db       *sql.DB
+	smsv1Cli smsv1.SmsServiceClient
+	policy   SMSOutboxDeliveryPolicy
+}
+
+func NewSMSOutboxDeliverer(clock clockwork.Clock, db *sql.DB, smsv1Cli smsv1.SmsServiceClient, policy SMSOutboxDeliveryPolicy) *SMSOutboxDeliverer {
+	return &SMSOutboxDeliverer{
+		clock:    clock,
+		db:       db,
+		smsv1Cli: smsv1Cli,
+		policy:   policy,
+	}
+}
+
+// RunDelivery sends the messages due until ctx is done. It is supposed to run in its own goroutine.
+func (d *SMSOutboxDeliverer) RunDelivery(ctx context.Context) {
+	ticker := d.clock.NewTicker(d.policy.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := d.deliverDue(ctx); err != nil {
+			logrus.WithError(err).Error("deliver sms outbox")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.Chan():
+		}
+	}
+}
+
+func (d *SMSOutboxDeliverer) deliverDue(ctx context.Context) error {
+	outboxes, err := mysql.ListDueSMSOutboxes(ctx, d.db, SMSOutboxStatusPending, d.clock.Now(), d.policy.BatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, o := range outboxes {
+		if err := d.deliver(ctx, o); err != nil {
+			logrus.WithError(err).WithField("sms_outbox_id", o.SMSOutboxID).Error("deliver sms")
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (d *SMSOutboxDeliverer) deliver(ctx context.Context, o *model.SMSOutbox) error {
+	now := d.clock.Now()
+
+	if !now.Before(o.ExpiresAt) {
+		return d.finish(ctx, o, SMSOutboxStatusFailed, "expired")
+	}
+
+	claimed, err := d.claim(ctx, o, now)
+	if err != nil || !claimed {
+		return err
+	}
+
+	sendCtx, cancel := context.WithTimeout(ctx, smsOutboxSendTimeout)
+	_, err = d.smsv1Cli.Send(sendCtx, &smsv1.SendRequest{
+		To:      o.PhoneNumber,
+		Message: o.Message,
+	})
+	cancel()
+	if err == nil {
+		return d.finish(ctx, o, SMSOutboxStatusSent, "")
+	}
+	if !client.IsRetryableSMSError(err) || o.Attempts >= d.policy.MaxAttempts {
+		return d.finish(ctx, o, SMSOutboxStatusFailed, err.Error())
+	}
+
+	o.NextAttemptAt = d.clock.Now().Add(d.backoff(o.Attempts))
+	o.LastError = null.StringFrom(truncateSMSOutboxError(err.Error()))
+	if _, err := o.Update(ctx, d.db, boil.Whitelist(
+		model.SMSOutboxColumns.NextAttemptAt,
+		model.SMSOutboxColumns.LastError,
+	)); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// claim counts the attempt and postpones the next one, which fails if another instance has done so first.
+func (d *SMSOutboxDeliverer) claim(ctx context.Context, o *model.SMSOutbox, now time.Time) (bool, error) {
+	n, err := model.SMSOutboxes(
+		model.SMSOutboxWhere.SMSOutboxID.EQ(o.SMSOutboxID),
+		model.SMSOutboxWhere.Status.EQ(SMSOutboxStatusPending),
+		model.SMSOutboxWhere.Attempts.EQ(o.Attempts),
+	).UpdateAll(ctx, d.db, model.M{
+		model.SMSOutboxColumns.Attempts:      o.Attempts + 1,
+		model.SMSOutboxColumns.NextAttemptAt: now.Add(smsOutboxClaimTimeout),
+	})
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+	if n == 0 {
+		return false, nil
+	}
+
+	o.Attempts++
+	return true, nil
+}
+
+// finish records the result, and erases the message which may contain an OTP code since it is not sent anymore.
+func (d *SMSOutboxDeliverer) finish(ctx context.Context, o *model.SMSOutbox, status, lastError string) error {
+	o.Status = status
+	o.Message = ""
+	if status == SMSOutboxStatusSent {
+		o.SentAt = null.TimeFrom(d.clock.Now())
+	}
+	if lastError != "" {
+		o.LastError = null.StringFrom(truncateSMSOutboxError(lastError))
+	}
+
+	if _, err := o.Update(ctx, d.db, boil.Whitelist(
+		model.SMSOutboxColumns.Status,
+		model.SMSOutboxColumns.Message,
+		model.SMSOutboxColumns.SentAt,
+		model.SMSOutboxColumns.LastError,
+	)); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// backoff returns the delay after the attempts, which doubles every attempt up to MaxBackoff.
+func (d *SMSOutboxDeliverer) backoff(attempts int) time.Duration {
+	b := d.policy.InitialBackoff
+	for i := 1; i < attempts && b < d.policy.MaxBackoff; i++ {
+		b *= 2
+	}
+	if b > d.policy.MaxBackoff {
+		b = d.policy.MaxBackoff
+	}
+	return b
+}
+
+func truncateSMSOutboxError(s string) string {
+	if r := []rune(s); len(r) > smsOutboxLastErrorMaxLen {
+		return string(r[:smsOutboxLastErrorMaxLen])
+	}
+	return s
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/volatiletech/null/v8"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/sean-ahn/user/backend/client"
+	"github.com/sean-ahn/user/backend/model"
+	"github.com/sean-ahn/user/backend/test"
+	smsv1 "github.com/sean-ahn/user/proto/gen/go/sms/v1"
+)
+
+func TestSMSOutboxDeliverer_deliverDue(t *testing.T) {
+	now := time.Date(2021, 10, 24, 17, 18, 16, 304850171, time.UTC)
+
+	policy := SMSOutboxDeliveryPolicy{
+		BatchSize:      10,
+		MaxAttempts:    3,
+		InitialBackoff: 5 * time.Second,
+		MaxBackoff:     8 * time.Second,
+	}
+
+	const (
+		listQuery   = "SELECT * FROM `sms_outbox` WHERE (`sms_outbox`.`status` = ?) AND (`sms_outbox`.`next_attempt_at` <= ?) ORDER BY next_attempt_at LIMIT 10;"
+		claimQuery  = "UPDATE `sms_outbox` SET `attempts` = ?, `next_attempt_at` = ? WHERE (`sms_outbox`.`sms_outbox_id` = ?) AND (`sms_outbox`.`status` = ?) AND (`sms_outbox`.`attempts` = ?);"
+		finishQuery = "UPDATE `sms_outbox` SET `status`=?,`message`=?,`sent_at`=?,`last_error`=? WHERE `sms_outbox_id`=?"
+		retryQuery  = "UPDATE `sms_outbox` SET `next_attempt_at`=?,`last_error`=? WHERE `sms_outbox_id`=?"
+	)
+
+	outbox := func(attempts int, expiresAt time.Time) []*model.SMSOutbox {
+		return []*model.SMSOutbox{
+			{SMSOutboxID: 1, SMSOtpVerificationID: null.IntFrom(2), PhoneNumber: "+821012345678", Message: "123456 is your authentication code.", Status: SMSOutboxStatusPending, Attempts: attempts, NextAttemptAt: now, ExpiresAt: expiresAt},
+		}
+	}
+	req := &smsv1.SendRequest{To: "+821012345678", Message: "123456 is your authentication code."}
+
+	cases := []struct {
+		name string
+
+		dbExpectFunc                   func(sqlmock.Sqlmock)
+		mockSmsServiceClientExpectFunc func(context.Context) func(*client.MockSmsServiceClient)
+	}{
+		{
+			name: "sent",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(listQuery)).
+					WithArgs(SMSOutboxStatusPending, now).
+					WillReturnRows(test.NewSMSOutboxRows(outbox(0, now.Add(3*time.Minute))))
+				mock.ExpectExec(regexp.QuoteMeta(claimQuery)).
+					WithArgs(1, now.Add(smsOutboxClaimTimeout), 1, SMSOutboxStatusPending, 0).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta(finishQuery)).
+					WithArgs(SMSOutboxStatusSent, "", now, nil, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			mockSmsServiceClientExpectFunc: func(ctx context.Context) func(*client.MockSmsServiceClient) {
+				return func(mock *client.MockSmsServiceClient) {
+					mock.EXPECT().Send(ctxWithDeadline{}, req).Return(&smsv1.SendResponse{}, nil)
+				}
+			},
+		},
+		{
+			name: "retry later",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(listQuery)).
+					WithArgs(SMSOutboxStatusPending, now).
+					WillReturnRows(test.NewSMSOutboxRows(outbox(1, now.Add(3*time.Minute))))
+				mock.ExpectExec(regexp.QuoteMeta(claimQuery)).
+					WithArgs(2, now.Add(smsOutboxClaimTimeout), 1, SMSOutboxStatusPending, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta(retryQuery)).
+					WithArgs(now.Add(8*time.Second), "rpc error: code = Unavailable desc = unavailable", 1). // 10 sec capped by MaxBackoff
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			mockSmsServiceClientExpectFunc: func(ctx context.Context) func(*client.MockSmsServiceClient) {
+				return func(mock *client.MockSmsServiceClient) {
+					mock.EXPECT().Send(ctxWithDeadline{}, req).Return(nil, status.Error(codes.Unavailable, "unavailable"))
+				}
+			},
+		},
+		{
+			name: "given up after max attempts",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(listQuery)).
+					WithArgs(SMSOutboxStatusPending, now).
+					WillReturnRows(test.NewSMSOutboxRows(outbox(2, now.Add(3*time.Minute))))
+				mock.ExpectExec(regexp.QuoteMeta(claimQuery)).
+					WithArgs(3, now.Add(smsOutboxClaimTimeout), 1, SMSOutboxStatusPending, 2).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta(finishQuery)).
+					WithArgs(SMSOutboxStatusFailed, "", nil, "rpc error: code = Unavailable desc = unavailable", 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			mockSmsServiceClientExpectFunc: func(ctx context.Context) func(*client.MockSmsServiceClient) {
+				return func(mock *client.MockSmsServiceClient) {
+					mock.EXPECT().Send(ctxWithDeadline{}, req).Return(nil, status.Error(codes.Unavailable, "unavailable"))
+				}
+			},
+		},
+		{
+			name: "not retryable",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(listQuery)).
+					WithArgs(SMSOutboxStatusPending, now).
+					WillReturnRows(test.NewSMSOutboxRows(outbox(0, now.Add(3*time.Minute))))
+				mock.ExpectExec(regexp.QuoteMeta(claimQuery)).
+					WithArgs(1, now.Add(smsOutboxClaimTimeout), 1, SMSOutboxStatusPending, 0).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta(finishQuery)).
+					WithArgs(SMSOutboxStatusFailed, "", nil, "rpc error: code = InvalidArgument desc = invalid", 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			mockSmsServiceClientExpectFunc: func(ctx context.Context) func(*client.MockSmsServiceClient) {
+				return func(mock *client.MockSmsServiceClient) {
+					mock.EXPECT().Send(ctxWithDeadline{}, req).Return(nil, status.Error(codes.InvalidArgument, "invalid"))
+				}
+			},
+		},
+		{
+			name: "expired",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(listQuery)).
+					WithArgs(SMSOutboxStatusPending, now).
+					WillReturnRows(test.NewSMSOutboxRows(outbox(1, now)))
+				mock.ExpectExec(regexp.QuoteMeta(finishQuery)).
+					WithArgs(SMSOutboxStatusFailed, "", nil, "expired", 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "claimed by another instance",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(listQuery)).
+					WithArgs(SMSOutboxStatusPending, now).
+					WillReturnRows(test.NewSMSOutboxRows(outbox(0, now.Add(3*time.Minute))))
+				mock.ExpectExec(regexp.QuoteMeta(claimQuery)).
+					WithArgs(1, now.Add(smsOutboxClaimTimeout), 1, SMSOutboxStatusPending, 0).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			ctrl := gomock.NewController(t)
+
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fail()
+			}
+			if tc.dbExpectFunc != nil {
+				tc.dbExpectFunc(mock)
+			}
+			defer test.CloseSqlmock(t, db, mock)
+
+			mockSmsServiceCli := client.NewMockSmsServiceClient(ctrl)
+			if tc.mockSmsServiceClientExpectFunc != nil {
+				tc.mockSmsServiceClientExpectFunc(ctx)(mockSmsServiceCli)
+			}
+
+			d := NewSMSOutboxDeliverer(clockwork.NewFakeClockAt(now), db, mockSmsServiceCli, policy)
+
+			assert.NoError(t, d.deliverDue(ctx))
+		})
+	}
+}
+
+// ctxWithDeadline matches the contexts with a deadline, since deliver sends with a timeout of its own.
+type ctxWithDeadline struct{}
+
+func (ctxWithDeadline) Matches(x interface{}) bool {
+	ctx, ok := x.(context.Context)
+	if !ok {
+		return false
+	}
+	_, ok = ctx.Deadline()
+	return ok
+}
+
+func (ctxWithDeadline) String() string {
+	return "is context with deadline"
+}
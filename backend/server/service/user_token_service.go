@@ -3,16 +3,20 @@ package service
 import (
 	"context"
 	"crypto/sha256"
+	"crypto/subtle"
 	"database/sql"
 	"encoding/base64"
 	"encoding/binary"
 	"strconv"
+	"strings"
 	"time"
 
 	mysqldriver "github.com/go-sql-driver/mysql"
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/jonboulle/clockwork"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 
 	"github.com/sean-ahn/user/backend/model"
@@ -22,30 +26,55 @@ import (
 
 const (
 	issuer = "https://github.com/sean-ahn/user"
+
+	TokenTypeAccess  = "access"
+	TokenTypeRefresh = "refresh"
+
+	ScopeUser = "user"
 )
 
 var (
 	ErrTokenRevocationFailed = errors.New("token revocation failed")
+	ErrSessionNotFound       = errors.New("session not found")
+
+	errJWTSecretNotFound       = errors.New("jwt secret not found")
+	errInvalidClaimsFormat     = errors.New("invalid claims format")
+	errRevokedToken            = errors.New("revoked token")
+	errExpiredToken            = errors.New("expired token")
+	errUnexpectedSigningMethod = errors.New("unexpected signing method")
+	errUnexpectedTokenType     = errors.New("unexpected token type")
 
-	errJWTSecretNotFound   = errors.New("jwt secret not found")
-	errInvalidClaimsFormat = errors.New("invalid claims format")
-	errRevokedToken        = errors.New("revoked token")
-	errExpiredToken        = errors.New("expired token")
+	defaultScopes = []string{ScopeUser}
 )
 
 //go:generate mockgen -package service -destination ./user_token_service_mock.go -mock_names UserTokenService=MockUserTokenService github.com/sean-ahn/user/backend/server/service UserTokenService
 
 type UserTokenService interface {
-	Issue(context.Context, *model.User) (string, string, error)
-	Refresh(context.Context, string) (string, string, error)
+	Issue(context.Context, *model.User, ClientInfo) (string, string, error)
+	Refresh(context.Context, string, ClientInfo) (string, string, error)
 	Revoke(context.Context, string) error
 	RevokeAll(context.Context, *model.User, *sql.Tx) error
 	GetUser(context.Context, string) (*model.User, error)
+	// GetUserSession returns the session ID along with the user. The session ID is empty for tokens issued before
+	// sessions were introduced.
+	GetUserSession(context.Context, string) (*model.User, string, error)
+	Introspect(context.Context, string) (*TokenIntrospection, error)
+	ListSessions(context.Context, *model.User) (model.UserSessionSlice, error)
+	RevokeSession(context.Context, *model.User, string) error
+	RevokeOtherSessions(context.Context, *model.User, string) error
+}
+
+// ClientInfo describes the client a session is used from.
+type ClientInfo struct {
+	DeviceLabel string
+	UserAgent   string
+	IPAddress   string
 }
 
 type UserJWTTokenService struct {
 	clock       clockwork.Clock
 	db          *sql.DB
+	keyStore    SigningKeyStore
 	idGenerator generator.Generator
 
 	accessTokenExpiresIn  time.Duration
@@ -58,19 +87,42 @@ type JWTClaims struct {
 	jwt.RegisteredClaims
 
 	UserID string `json:"user_id"`
+	// SecretDigest binds the token to the audience secret so that RevokeAll invalidates it.
+	SecretDigest string `json:"secret_digest"`
+	// TokenType is either access or refresh. Tokens issued before it was introduced do not have it.
+	TokenType string `json:"token_type,omitempty"`
+	// Scope is a space-separated list of scopes.
+	Scope string `json:"scope,omitempty"`
+	// FamilyID is shared by all tokens rotated from the same sign-in. Tokens issued before it was introduced do not have it.
+	FamilyID string `json:"fid,omitempty"`
 }
 
-func NewJWTTokenService(clock clockwork.Clock, db *sql.DB, accessTokenExpiresIn, refreshTokenExpiresIn time.Duration) *UserJWTTokenService {
+// TokenIntrospection describes the state of a token.
+// See https://datatracker.ietf.org/doc/html/rfc7662#section-2.2
+type TokenIntrospection struct {
+	// Active is false if the token is invalid, expired or revoked. Other fields are not set then.
+	Active bool
+
+	Subject   string
+	ExpiresAt time.Time
+	IssuedAt  time.Time
+	JTI       string
+	TokenType string
+	Scopes    []string
+}
+
+func NewJWTTokenService(clock clockwork.Clock, db *sql.DB, keyStore SigningKeyStore, accessTokenExpiresIn, refreshTokenExpiresIn time.Duration) *UserJWTTokenService {
 	return &UserJWTTokenService{
 		clock:                 clock,
 		db:                    db,
+		keyStore:              keyStore,
 		idGenerator:           &generator.UUIDGenerator{},
 		accessTokenExpiresIn:  accessTokenExpiresIn,
 		refreshTokenExpiresIn: refreshTokenExpiresIn,
 	}
 }
 
-func (s *UserJWTTokenService) Issue(ctx context.Context, user *model.User) (string, string, error) {
+func (s *UserJWTTokenService) Issue(ctx context.Context, user *model.User, client ClientInfo) (string, string, error) {
 	secret, err := s.getSecret(ctx, user)
 	if errors.Cause(err) == errJWTSecretNotFound {
 		secret, err = s.createSecret(ctx, user)
@@ -79,10 +131,15 @@ func (s *UserJWTTokenService) Issue(ctx context.Context, user *model.User) (stri
 		return "", "", err
 	}
 
-	return s.generateTokens(user, secret)
+	familyID, err := s.createFamily(ctx, user, client)
+	if err != nil {
+		return "", "", err
+	}
+
+	return s.generateTokens(ctx, user, secret, familyID)
 }
 
-func (s *UserJWTTokenService) Refresh(ctx context.Context, refreshToken string) (string, string, error) {
+func (s *UserJWTTokenService) Refresh(ctx context.Context, refreshToken string, client ClientInfo) (string, string, error) {
 	token, err := s.parseToken(ctx, refreshToken)
 	if err != nil {
 		return "", "", err
@@ -98,13 +155,12 @@ func (s *UserJWTTokenService) Refresh(ctx context.Context, refreshToken string)
 		return "", "", errors.WithStack(errInvalidClaimsFormat)
 	}
 
-	jd, err := mysql.FindJWTDenylistByJTI(ctx, s.db, claims.ID)
-	if err != nil && errors.Cause(err) != sql.ErrNoRows {
+	if err := s.checkDenylist(ctx, claims.ID); err != nil {
+		if errors.Cause(err) == errRevokedToken {
+			return "", "", s.handleReuse(ctx, int(userID64), claims)
+		}
 		return "", "", err
 	}
-	if jd != nil {
-		return "", "", errors.WithStack(errRevokedToken)
-	}
 
 	user, err := mysql.GetUser(ctx, s.db, int(userID64))
 	if err != nil {
@@ -116,11 +172,29 @@ func (s *UserJWTTokenService) Refresh(ctx context.Context, refreshToken string)
 		return "", "", err
 	}
 
-	if err := s.Revoke(ctx, refreshToken); err != nil {
+	familyID := claims.FamilyID
+	if familyID == "" {
+		if familyID, err = s.createFamily(ctx, user, client); err != nil {
+			return "", "", err
+		}
+	}
+
+	// the token may have been used concurrently
+	denylisted, err := s.denylist(ctx, user.UserID, claims.ID)
+	if err != nil {
 		return "", "", err
 	}
+	if denylisted {
+		return "", "", s.handleReuse(ctx, user.UserID, claims)
+	}
 
-	return s.generateTokens(user, secret)
+	if claims.FamilyID != "" {
+		if err := s.touchSession(ctx, claims.FamilyID, client); err != nil {
+			return "", "", err
+		}
+	}
+
+	return s.generateTokens(ctx, user, secret, familyID)
 }
 
 func (s *UserJWTTokenService) Revoke(ctx context.Context, refreshToken string) error {
@@ -139,12 +213,12 @@ func (s *UserJWTTokenService) Revoke(ctx context.Context, refreshToken string) e
 		return errors.WithStack(errInvalidClaimsFormat)
 	}
 
-	jd := &model.JWTDenylist{
-		UserID: int(userID64),
-		Jti:    claims.ID,
+	if _, err := s.denylist(ctx, int(userID64), claims.ID); err != nil {
+		return err
 	}
-	if err := jd.Insert(ctx, s.db, boil.Infer()); err != nil {
-		if merr, ok := errors.Cause(err).(*mysqldriver.MySQLError); !ok || merr.Number != mysql.ErrorCodeDuplicateEntry {
+
+	if claims.FamilyID != "" {
+		if err := s.revokeFamily(ctx, s.db, claims.FamilyID); err != nil {
 			return errors.Wrap(ErrTokenRevocationFailed, err.Error())
 		}
 	}
@@ -163,26 +237,259 @@ func (s *UserJWTTokenService) RevokeAll(ctx context.Context, user *model.User, t
 	).DeleteAll(ctx, exec); err != nil {
 		return errors.WithStack(err)
 	}
+
+	// the tokens are no longer valid without the secret, but the sessions would still be listed
+	if _, err := model.RefreshTokenFamilies(
+		model.RefreshTokenFamilyWhere.UserID.EQ(user.UserID),
+		model.RefreshTokenFamilyWhere.RevokedAt.IsNull(),
+	).UpdateAll(ctx, exec, model.M{
+		model.RefreshTokenFamilyColumns.RevokedAt: null.TimeFrom(s.clock.Now()),
+	}); err != nil {
+		return errors.WithStack(err)
+	}
 	return nil
 }
 
 func (s *UserJWTTokenService) GetUser(ctx context.Context, accessToken string) (*model.User, error) {
+	user, _, err := s.GetUserSession(ctx, accessToken)
+	return user, err
+}
+
+func (s *UserJWTTokenService) GetUserSession(ctx context.Context, accessToken string) (*model.User, string, error) {
 	token, err := s.parseToken(ctx, accessToken)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	claims := token.Claims.(*JWTClaims)
+	// refresh tokens are signed with the same key and secret, but are not meant to be sent along with requests
+	if claims.tokenType() == TokenTypeRefresh {
+		return nil, "", errors.WithStack(errUnexpectedTokenType)
+	}
+
 	userID64, err := strconv.ParseInt(claims.UserID, 10, 32)
 	if err != nil {
-		return nil, errors.WithStack(errInvalidClaimsFormat)
+		return nil, "", errors.WithStack(errInvalidClaimsFormat)
 	}
 
 	user, err := mysql.GetUser(ctx, s.db, int(userID64))
 	if err != nil {
+		return nil, "", err
+	}
+	return user, claims.FamilyID, nil
+}
+
+// ListSessions returns sessions whose refresh token family is neither revoked nor expired.
+func (s *UserJWTTokenService) ListSessions(ctx context.Context, user *model.User) (model.UserSessionSlice, error) {
+	return mysql.ListActiveUserSessions(ctx, s.db, user.UserID, s.clock.Now().Add(-s.refreshTokenExpiresIn))
+}
+
+func (s *UserJWTTokenService) RevokeSession(ctx context.Context, user *model.User, sessionID string) error {
+	f, err := mysql.FindRefreshTokenFamilyByFamilyID(ctx, s.db, sessionID)
+	if errors.Cause(err) == sql.ErrNoRows {
+		return errors.WithStack(ErrSessionNotFound)
+	}
+	if err != nil {
+		return err
+	}
+	if f.UserID != user.UserID || f.RevokedAt.Valid {
+		return errors.WithStack(ErrSessionNotFound)
+	}
+
+	return s.revokeFamily(ctx, s.db, sessionID)
+}
+
+func (s *UserJWTTokenService) RevokeOtherSessions(ctx context.Context, user *model.User, currentSessionID string) error {
+	// every session would be revoked including the current one, which is not known for tokens issued before sessions
+	if currentSessionID == "" {
+		return errors.WithStack(ErrSessionNotFound)
+	}
+
+	if _, err := model.RefreshTokenFamilies(
+		model.RefreshTokenFamilyWhere.UserID.EQ(user.UserID),
+		model.RefreshTokenFamilyWhere.FamilyID.NEQ(currentSessionID),
+		model.RefreshTokenFamilyWhere.RevokedAt.IsNull(),
+	).UpdateAll(ctx, s.db, model.M{
+		model.RefreshTokenFamilyColumns.RevokedAt: null.TimeFrom(s.clock.Now()),
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// Introspect reports whether the token is active. Invalid, expired or revoked tokens are reported as inactive
+// rather than as an error; an error is returned only when the state of the token cannot be determined.
+func (s *UserJWTTokenService) Introspect(ctx context.Context, token string) (*TokenIntrospection, error) {
+	tk, err := s.parseToken(ctx, token)
+	if err != nil {
+		if isInvalidTokenError(err) {
+			return &TokenIntrospection{Active: false}, nil
+		}
 		return nil, err
 	}
-	return user, nil
+
+	claims := tk.Claims.(*JWTClaims)
+	if claims.ID != "" {
+		if err := s.checkDenylist(ctx, claims.ID); err != nil {
+			if isInvalidTokenError(err) {
+				return &TokenIntrospection{Active: false}, nil
+			}
+			return nil, err
+		}
+	}
+
+	introspection := &TokenIntrospection{
+		Active:    true,
+		Subject:   claims.UserID,
+		JTI:       claims.ID,
+		TokenType: claims.tokenType(),
+		Scopes:    claims.scopes(),
+	}
+	if claims.ExpiresAt != nil {
+		introspection.ExpiresAt = claims.ExpiresAt.Time
+	}
+	if claims.IssuedAt != nil {
+		introspection.IssuedAt = claims.IssuedAt.Time
+	}
+	return introspection, nil
+}
+
+func (s *UserJWTTokenService) checkDenylist(ctx context.Context, jti string) error {
+	jd, err := mysql.FindJWTDenylistByJTI(ctx, s.db, jti)
+	if err != nil && errors.Cause(err) != sql.ErrNoRows {
+		return err
+	}
+	if jd != nil {
+		return errors.WithStack(errRevokedToken)
+	}
+	return nil
+}
+
+// denylist reports whether the token had already been denylisted.
+func (s *UserJWTTokenService) denylist(ctx context.Context, userID int, jti string) (bool, error) {
+	jd := &model.JWTDenylist{
+		UserID: userID,
+		Jti:    jti,
+	}
+	if err := jd.Insert(ctx, s.db, boil.Infer()); err != nil {
+		if merr, ok := errors.Cause(err).(*mysqldriver.MySQLError); ok && merr.Number == mysql.ErrorCodeDuplicateEntry {
+			return true, nil
+		}
+		return false, errors.Wrap(ErrTokenRevocationFailed, err.Error())
+	}
+	return false, nil
+}
+
+// handleReuse revokes the whole family of a refresh token which has already been rotated. Either the client or
+// an attacker is holding a stolen copy of the token, and there is no telling which one, so neither may keep it.
+func (s *UserJWTTokenService) handleReuse(ctx context.Context, userID int, claims *JWTClaims) error {
+	if claims.FamilyID == "" {
+		return errors.WithStack(errRevokedToken)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			logrus.WithError(err).Error()
+		}
+	}()
+
+	if err := s.revokeFamily(ctx, tx, claims.FamilyID); err != nil {
+		return err
+	}
+
+	if err := RecordSecurityEvent(ctx, tx, userID, SecurityEventTypeRefreshTokenReuse, map[string]string{
+		"family_id": claims.FamilyID,
+		"jti":       claims.ID,
+	}); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return errors.WithStack(errRevokedToken)
+}
+
+// createFamily starts a new refresh token family along with the session it represents.
+func (s *UserJWTTokenService) createFamily(ctx context.Context, user *model.User, client ClientInfo) (string, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			logrus.WithError(err).Error()
+		}
+	}()
+
+	f := &model.RefreshTokenFamily{
+		FamilyID: s.idGenerator.Generate(),
+		UserID:   user.UserID,
+	}
+	if err := f.Insert(ctx, tx, boil.Infer()); err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	us := &model.UserSession{
+		FamilyID:    f.FamilyID,
+		UserID:      user.UserID,
+		DeviceLabel: client.DeviceLabel,
+		UserAgent:   client.UserAgent,
+		IPAddress:   client.IPAddress,
+		LastUsedAt:  s.clock.Now(),
+	}
+	if err := us.Insert(ctx, tx, boil.Infer()); err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", errors.WithStack(err)
+	}
+	return f.FamilyID, nil
+}
+
+func (s *UserJWTTokenService) touchSession(ctx context.Context, familyID string, client ClientInfo) error {
+	if _, err := model.UserSessions(
+		model.UserSessionWhere.FamilyID.EQ(familyID),
+	).UpdateAll(ctx, s.db, model.M{
+		model.UserSessionColumns.UserAgent:  client.UserAgent,
+		model.UserSessionColumns.IPAddress:  client.IPAddress,
+		model.UserSessionColumns.LastUsedAt: s.clock.Now(),
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+func (s *UserJWTTokenService) checkFamily(ctx context.Context, familyID string) error {
+	f, err := mysql.FindRefreshTokenFamilyByFamilyID(ctx, s.db, familyID)
+	if errors.Cause(err) == sql.ErrNoRows {
+		return errors.WithStack(errRevokedToken)
+	}
+	if err != nil {
+		return err
+	}
+	if f.RevokedAt.Valid {
+		return errors.WithStack(errRevokedToken)
+	}
+	return nil
+}
+
+func (s *UserJWTTokenService) revokeFamily(ctx context.Context, exec boil.ContextExecutor, familyID string) error {
+	if _, err := model.RefreshTokenFamilies(
+		model.RefreshTokenFamilyWhere.FamilyID.EQ(familyID),
+		model.RefreshTokenFamilyWhere.RevokedAt.IsNull(),
+	).UpdateAll(ctx, exec, model.M{
+		model.RefreshTokenFamilyColumns.RevokedAt: null.TimeFrom(s.clock.Now()),
+	}); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
 }
 
 func (s *UserJWTTokenService) getAudience(user *model.User) string {
@@ -190,25 +497,27 @@ func (s *UserJWTTokenService) getAudience(user *model.User) string {
 }
 
 func (s *UserJWTTokenService) parseToken(ctx context.Context, token string) (*jwt.Token, error) {
-	tk, err := jwt.ParseWithClaims(token, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
-		claims, err := s.parseClaims(token.Claims)
-		if err != nil {
-			return nil, errors.WithStack(errInvalidClaimsFormat)
+	// claims are validated below against s.clock instead of the wall clock.
+	parser := &jwt.Parser{SkipClaimsValidation: true}
+
+	tk, err := parser.ParseWithClaims(token, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
+		kid, ok := token.Header["kid"].(string)
+		if !ok || kid == "" {
+			return nil, errors.WithStack(errSigningKeyNotFound)
 		}
 
-		sec, err := s.getSecretByAudience(ctx, claims.Audience[0])
+		key, err := s.keyStore.GetVerificationKey(ctx, kid)
 		if err != nil {
 			return nil, err
 		}
-		return sec, nil
+		if token.Method.Alg() != key.Algorithm {
+			return nil, errors.WithStack(errUnexpectedSigningMethod)
+		}
+		return key.PublicKey(), nil
 	})
 	if err != nil {
 		switch x := errors.Cause(err).(type) {
-		case nil:
 		case *jwt.ValidationError:
-			if x.Errors == jwt.ValidationErrorExpired {
-				return nil, errors.WithStack(errExpiredToken)
-			}
 			if x.Errors == jwt.ValidationErrorUnverifiable {
 				return nil, errors.WithStack(x.Inner)
 			}
@@ -218,6 +527,29 @@ func (s *UserJWTTokenService) parseToken(ctx context.Context, token string) (*jw
 		}
 	}
 
+	claims, err := s.parseClaims(tk.Claims)
+	if err != nil {
+		return nil, err
+	}
+
+	if !claims.VerifyExpiresAt(s.clock.Now(), true) {
+		return nil, errors.WithStack(errExpiredToken)
+	}
+
+	secret, err := s.getSecretByAudience(ctx, claims.Audience[0])
+	if err != nil {
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(claims.SecretDigest), []byte(s.digestSecret(secret))) != 1 {
+		return nil, errors.WithStack(errRevokedToken)
+	}
+
+	if claims.FamilyID != "" {
+		if err := s.checkFamily(ctx, claims.FamilyID); err != nil {
+			return nil, err
+		}
+	}
+
 	return tk, nil
 }
 
@@ -239,44 +571,68 @@ func (s *UserJWTTokenService) parseClaims(c jwt.Claims) (*JWTClaims, error) {
 	return claims, nil
 }
 
-func (s *UserJWTTokenService) newClaimsPair(user *model.User) (JWTClaims, JWTClaims) {
+func (s *UserJWTTokenService) newClaimsPair(user *model.User, secret []byte, familyID string) (JWTClaims, JWTClaims) {
 	now := s.clock.Now()
 	return JWTClaims{
-			RegisteredClaims: jwt.RegisteredClaims{
-				Audience:  []string{s.getAudience(user)},
-				ExpiresAt: jwt.NewNumericDate(now.Add(s.accessTokenExpiresIn)),
-				IssuedAt:  jwt.NewNumericDate(now),
-				Issuer:    issuer,
-			},
-			UserID: strconv.FormatInt(int64(user.UserID), 10),
-		}, JWTClaims{
-			RegisteredClaims: jwt.RegisteredClaims{
-				ID:        s.idGenerator.Generate(),
-				Audience:  []string{s.getAudience(user)},
-				ExpiresAt: jwt.NewNumericDate(now.Add(s.refreshTokenExpiresIn)),
-				IssuedAt:  jwt.NewNumericDate(now),
-				Issuer:    issuer,
-			},
-			UserID: strconv.FormatInt(int64(user.UserID), 10),
-		}
+		RegisteredClaims: jwt.RegisteredClaims{
+			Audience:  []string{s.getAudience(user)},
+			ExpiresAt: jwt.NewNumericDate(now.Add(s.accessTokenExpiresIn)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			Issuer:    issuer,
+		},
+		UserID:       strconv.FormatInt(int64(user.UserID), 10),
+		SecretDigest: s.digestSecret(secret),
+		TokenType:    TokenTypeAccess,
+		Scope:        strings.Join(defaultScopes, " "),
+		FamilyID:     familyID,
+	}, JWTClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        s.idGenerator.Generate(),
+			Audience:  []string{s.getAudience(user)},
+			ExpiresAt: jwt.NewNumericDate(now.Add(s.refreshTokenExpiresIn)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			Issuer:    issuer,
+		},
+		UserID:       strconv.FormatInt(int64(user.UserID), 10),
+		SecretDigest: s.digestSecret(secret),
+		TokenType:    TokenTypeRefresh,
+		Scope:        strings.Join(defaultScopes, " "),
+		FamilyID:     familyID,
+	}
 }
 
-func (s *UserJWTTokenService) generateTokens(user *model.User, secret []byte) (string, string, error) {
-	accessTokenClaims, refreshTokenClaims := s.newClaimsPair(user)
+func (s *UserJWTTokenService) generateTokens(ctx context.Context, user *model.User, secret []byte, familyID string) (string, string, error) {
+	key, err := s.keyStore.GetSigningKey(ctx)
+	if err != nil {
+		return "", "", err
+	}
+
+	accessTokenClaims, refreshTokenClaims := s.newClaimsPair(user, secret, familyID)
 
-	accessToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, accessTokenClaims).SignedString(secret)
+	accessToken, err := s.signToken(key, accessTokenClaims)
 	if err != nil {
-		return "", "", errors.WithStack(err)
+		return "", "", err
 	}
 
-	refreshToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, refreshTokenClaims).SignedString(secret)
+	refreshToken, err := s.signToken(key, refreshTokenClaims)
 	if err != nil {
-		return "", "", errors.WithStack(err)
+		return "", "", err
 	}
 
 	return accessToken, refreshToken, nil
 }
 
+func (s *UserJWTTokenService) signToken(key *SigningKey, claims JWTClaims) (string, error) {
+	token := jwt.NewWithClaims(key.SigningMethod(), claims)
+	token.Header["kid"] = key.ID
+
+	signed, err := token.SignedString(key.PrivateKey)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	return signed, nil
+}
+
 func (s *UserJWTTokenService) getSecret(ctx context.Context, user *model.User) ([]byte, error) {
 	return s.getSecretByAudience(ctx, s.getAudience(user))
 }
@@ -324,3 +680,37 @@ func (s *UserJWTTokenService) newSecret(user *model.User) ([]byte, error) {
 
 	return h.Sum(nil), nil
 }
+
+func (s *UserJWTTokenService) digestSecret(secret []byte) string {
+	sum := sha256.Sum256(secret)
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func (c *JWTClaims) tokenType() string {
+	if c.TokenType != "" {
+		return c.TokenType
+	}
+	// only refresh tokens have jti
+	if c.ID != "" {
+		return TokenTypeRefresh
+	}
+	return TokenTypeAccess
+}
+
+func (c *JWTClaims) scopes() []string {
+	if c.Scope == "" {
+		return defaultScopes
+	}
+	return strings.Fields(c.Scope)
+}
+
+// isInvalidTokenError reports whether err is caused by the token itself rather than by a failure to verify it.
+func isInvalidTokenError(err error) bool {
+	switch errors.Cause(err) {
+	case errJWTSecretNotFound, errInvalidClaimsFormat, errRevokedToken, errExpiredToken, errUnexpectedSigningMethod, errUnexpectedTokenType,
+		errSigningKeyNotFound:
+		return true
+	}
+	_, ok := errors.Cause(err).(*jwt.ValidationError)
+	return ok
+}
@@ -10,7 +10,6 @@ import (
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
-
 	model "github.com/sean-ahn/user/backend/model"
 )
 
@@ -52,10 +51,41 @@ func (mr *MockUserTokenServiceMockRecorder) GetUser(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockUserTokenService)(nil).GetUser), arg0, arg1)
 }
 
+// GetUserSession mocks base method.
+func (m *MockUserTokenService) GetUserSession(arg0 context.Context, arg1 string) (*model.User, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserSession", arg0, arg1)
+	ret0, _ := ret[0].(*model.User)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetUserSession indicates an expected call of GetUserSession.
+func (mr *MockUserTokenServiceMockRecorder) GetUserSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSession", reflect.TypeOf((*MockUserTokenService)(nil).GetUserSession), arg0, arg1)
+}
+
+// Introspect mocks base method.
+func (m *MockUserTokenService) Introspect(arg0 context.Context, arg1 string) (*TokenIntrospection, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Introspect", arg0, arg1)
+	ret0, _ := ret[0].(*TokenIntrospection)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Introspect indicates an expected call of Introspect.
+func (mr *MockUserTokenServiceMockRecorder) Introspect(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Introspect", reflect.TypeOf((*MockUserTokenService)(nil).Introspect), arg0, arg1)
+}
+
 // Issue mocks base method.
-func (m *MockUserTokenService) Issue(arg0 context.Context, arg1 *model.User) (string, string, error) {
+func (m *MockUserTokenService) Issue(arg0 context.Context, arg1 *model.User, arg2 ClientInfo) (string, string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Issue", arg0, arg1)
+	ret := m.ctrl.Call(m, "Issue", arg0, arg1, arg2)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(string)
 	ret2, _ := ret[2].(error)
@@ -63,15 +93,30 @@ func (m *MockUserTokenService) Issue(arg0 context.Context, arg1 *model.User) (st
 }
 
 // Issue indicates an expected call of Issue.
-func (mr *MockUserTokenServiceMockRecorder) Issue(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockUserTokenServiceMockRecorder) Issue(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Issue", reflect.TypeOf((*MockUserTokenService)(nil).Issue), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Issue", reflect.TypeOf((*MockUserTokenService)(nil).Issue), arg0, arg1, arg2)
+}
+
+// ListSessions mocks base method.
+func (m *MockUserTokenService) ListSessions(arg0 context.Context, arg1 *model.User) (model.UserSessionSlice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSessions", arg0, arg1)
+	ret0, _ := ret[0].(model.UserSessionSlice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSessions indicates an expected call of ListSessions.
+func (mr *MockUserTokenServiceMockRecorder) ListSessions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessions", reflect.TypeOf((*MockUserTokenService)(nil).ListSessions), arg0, arg1)
 }
 
 // Refresh mocks base method.
-func (m *MockUserTokenService) Refresh(arg0 context.Context, arg1 string) (string, string, error) {
+func (m *MockUserTokenService) Refresh(arg0 context.Context, arg1 string, arg2 ClientInfo) (string, string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Refresh", arg0, arg1)
+	ret := m.ctrl.Call(m, "Refresh", arg0, arg1, arg2)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(string)
 	ret2, _ := ret[2].(error)
@@ -79,9 +124,9 @@ func (m *MockUserTokenService) Refresh(arg0 context.Context, arg1 string) (strin
 }
 
 // Refresh indicates an expected call of Refresh.
-func (mr *MockUserTokenServiceMockRecorder) Refresh(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockUserTokenServiceMockRecorder) Refresh(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockUserTokenService)(nil).Refresh), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockUserTokenService)(nil).Refresh), arg0, arg1, arg2)
 }
 
 // Revoke mocks base method.
@@ -111,3 +156,31 @@ func (mr *MockUserTokenServiceMockRecorder) RevokeAll(arg0, arg1, arg2 interface
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAll", reflect.TypeOf((*MockUserTokenService)(nil).RevokeAll), arg0, arg1, arg2)
 }
+
+// RevokeOtherSessions mocks base method.
+func (m *MockUserTokenService) RevokeOtherSessions(arg0 context.Context, arg1 *model.User, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeOtherSessions", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeOtherSessions indicates an expected call of RevokeOtherSessions.
+func (mr *MockUserTokenServiceMockRecorder) RevokeOtherSessions(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeOtherSessions", reflect.TypeOf((*MockUserTokenService)(nil).RevokeOtherSessions), arg0, arg1, arg2)
+}
+
+// RevokeSession mocks base method.
+func (m *MockUserTokenService) RevokeSession(arg0 context.Context, arg1 *model.User, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockUserTokenServiceMockRecorder) RevokeSession(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockUserTokenService)(nil).RevokeSession), arg0, arg1, arg2)
+}
@@ -0,0 +1,403 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"io"
+	"strconv"
+	"time"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"github.com/jonboulle/clockwork"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+
+	"github.com/sean-ahn/user/backend/crypto"
+	"github.com/sean-ahn/user/backend/model"
+	"github.com/sean-ahn/user/backend/persistence/mysql"
+)
+
+const (
+	WebAuthnCeremonyRegistration = "registration"
+	WebAuthnCeremonySignIn       = "sign_in"
+
+	// WebAuthnLimitPendingSignInChallenges is the limit of the sign-in challenges not used yet per IP address.
+	WebAuthnLimitPendingSignInChallenges = "webauthn_pending_sign_in_challenges"
+
+	webAuthnChallengeLen       = 32
+	webAuthnChallengeExpiresIn = 5 * time.Minute
+	webAuthnUserHandleLen      = 32 // at most 64 bytes by the spec
+
+	webAuthnMaxPendingSignInChallenges = 10
+)
+
+// WebAuthnPubKeyCredAlgs are the COSE algorithms of the credentials we accept, in the order of preference.
+var WebAuthnPubKeyCredAlgs = []int32{crypto.COSEAlgorithmES256, crypto.COSEAlgorithmEdDSA, crypto.COSEAlgorithmRS256}
+
+var (
+	ErrInvalidWebAuthnChallenge            = errors.New("invalid webauthn challenge")
+	ErrWebAuthnCredentialNotFound          = errors.New("webauthn credential not found")
+	ErrWebAuthnCredentialAlreadyRegistered = errors.New("webauthn credential already registered")
+)
+
+//go:generate mockgen -package service -destination ./webauthn_service_mock.go -mock_names WebAuthnService=MockWebAuthnService github.com/sean-ahn/user/backend/server/service WebAuthnService
+
+type WebAuthnService interface {
+	// BeginRegistration returns the options for navigator.credentials.create() to register a passkey for the user.
+	BeginRegistration(context.Context, *model.User) (*WebAuthnRegistrationOptions, error)
+	// FinishRegistration verifies the response of the authenticator and registers the credential for the user.
+	FinishRegistration(ctx context.Context, user *model.User, credentialID string, clientDataJSON, attestationObject []byte) error
+	// BeginSignIn returns the options for navigator.credentials.get(). The device label is kept so that the session is
+	// labeled as requested at sign-in. It returns *RateLimitedError if too many challenges created from the IP
+	// address are pending.
+	BeginSignIn(ctx context.Context, deviceLabel, ipAddress string) (*WebAuthnSignInOptions, error)
+	// FinishSignIn verifies the assertion with the registered credential and consumes the challenge.
+	// It returns the owner of the credential and the device label the challenge is created with.
+	FinishSignIn(ctx context.Context, credentialID string, clientDataJSON, authenticatorData, signature, userHandle []byte) (*model.User, string, error)
+}
+
+// WebAuthnRegistrationOptions is what navigator.credentials.create() needs. Binary values are base64url encoded
+// without padding.
+type WebAuthnRegistrationOptions struct {
+	Challenge            string
+	RPID                 string
+	RPName               string
+	UserHandle           string
+	UserName             string
+	UserDisplayName      string
+	PubKeyCredAlgs       []int32
+	ExcludeCredentialIDs []string
+	Timeout              time.Duration
+}
+
+// WebAuthnSignInOptions is what navigator.credentials.get() needs. No credentials are allowed explicitly, so that
+// the authenticator offers the discoverable credentials for the relying party.
+type WebAuthnSignInOptions struct {
+	Challenge string
+	RPID      string
+	Timeout   time.Duration
+}
+
+type DBWebAuthnService struct {
+	clock clockwork.Clock
+	db    *sql.DB
+	rand  io.Reader
+
+	rp     *crypto.WebAuthnRelyingParty
+	rpName string
+}
+
+var _ WebAuthnService = (*DBWebAuthnService)(nil)
+
+func NewDBWebAuthnService(clock clockwork.Clock, db *sql.DB, rp *crypto.WebAuthnRelyingParty, rpName string) *DBWebAuthnService {
+	return &DBWebAuthnService{
+		clock:  clock,
+		db:     db,
+		rand:   rand.Reader,
+		rp:     rp,
+		rpName: rpName,
+	}
+}
+
+func (s *DBWebAuthnService) BeginRegistration(ctx context.Context, user *model.User) (*WebAuthnRegistrationOptions, error) {
+	creds, err := mysql.ListWebAuthnCredentialsByUserID(ctx, s.db, user.UserID)
+	if err != nil {
+		return nil, err
+	}
+	excludeIDs := make([]string, 0, len(creds))
+	for _, c := range creds {
+		excludeIDs = append(excludeIDs, c.CredentialID)
+	}
+
+	wu, err := s.getOrCreateWebAuthnUser(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := s.createChallenge(ctx, WebAuthnCeremonyRegistration, null.IntFrom(user.UserID), "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	return &WebAuthnRegistrationOptions{
+		Challenge:            c.Challenge,
+		RPID:                 s.rp.ID,
+		RPName:               s.rpName,
+		UserHandle:           wu.UserHandle,
+		UserName:             user.Email,
+		UserDisplayName:      user.Name,
+		PubKeyCredAlgs:       WebAuthnPubKeyCredAlgs,
+		ExcludeCredentialIDs: excludeIDs,
+		Timeout:              webAuthnChallengeExpiresIn,
+	}, nil
+}
+
+func (s *DBWebAuthnService) FinishRegistration(ctx context.Context, user *model.User, credentialID string, clientDataJSON, attestationObject []byte) error {
+	c, err := s.findChallenge(ctx, clientDataJSON, WebAuthnCeremonyRegistration)
+	if err != nil {
+		return err
+	}
+	if c.UserID.Int != user.UserID {
+		return errors.WithStack(ErrInvalidWebAuthnChallenge)
+	}
+
+	cred, err := s.rp.VerifyRegistration(c.Challenge, clientDataJSON, attestationObject)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if crypto.EncodeWebAuthnID(cred.ID) != credentialID {
+		return errors.WithStack(crypto.ErrInvalidWebAuthnResponse)
+	}
+
+	_, err = mysql.FindWebAuthnCredentialByCredentialID(ctx, s.db, credentialID)
+	if err == nil {
+		return errors.WithStack(ErrWebAuthnCredentialAlreadyRegistered)
+	}
+	if errors.Cause(err) != sql.ErrNoRows {
+		return err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			logrus.WithError(err).Error()
+		}
+	}()
+
+	if err := s.consumeChallenge(ctx, tx, c); err != nil {
+		return err
+	}
+
+	wc := &model.WebauthnCredential{
+		UserID:       user.UserID,
+		CredentialID: credentialID,
+		PublicKey:    crypto.EncodeWebAuthnID(cred.PublicKey),
+		SignCount:    int64(cred.SignCount),
+	}
+	if err := wc.Insert(ctx, tx, boil.Infer()); err != nil {
+		return errors.WithStack(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+func (s *DBWebAuthnService) BeginSignIn(ctx context.Context, deviceLabel, ipAddress string) (*WebAuthnSignInOptions, error) {
+	// anyone can begin, so the challenges are limited not to be piled up
+	if ipAddress != "" {
+		if err := s.checkPendingSignInChallenges(ctx, ipAddress); err != nil {
+			return nil, err
+		}
+	}
+
+	c, err := s.createChallenge(ctx, WebAuthnCeremonySignIn, null.Int{}, deviceLabel, ipAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WebAuthnSignInOptions{
+		Challenge: c.Challenge,
+		RPID:      s.rp.ID,
+		Timeout:   webAuthnChallengeExpiresIn,
+	}, nil
+}
+
+func (s *DBWebAuthnService) FinishSignIn(ctx context.Context, credentialID string, clientDataJSON, authenticatorData, signature, userHandle []byte) (*model.User, string, error) {
+	c, err := s.findChallenge(ctx, clientDataJSON, WebAuthnCeremonySignIn)
+	if err != nil {
+		return nil, "", err
+	}
+
+	wc, err := mysql.FindWebAuthnCredentialByCredentialID(ctx, s.db, credentialID)
+	if errors.Cause(err) == sql.ErrNoRows {
+		return nil, "", errors.WithStack(ErrWebAuthnCredentialNotFound)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	// the authenticator returns the user handle the credential is registered with, which must be of the owner
+	wu, err := mysql.FindWebAuthnUserByUserID(ctx, s.db, wc.UserID)
+	if errors.Cause(err) == sql.ErrNoRows {
+		return nil, "", errors.WithStack(crypto.ErrInvalidWebAuthnResponse)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	if crypto.EncodeWebAuthnID(userHandle) != wu.UserHandle {
+		return nil, "", errors.WithStack(crypto.ErrInvalidWebAuthnResponse)
+	}
+
+	publicKey, err := base64.RawURLEncoding.DecodeString(wc.PublicKey)
+	if err != nil {
+		return nil, "", errors.WithStack(err)
+	}
+
+	signCount, err := s.rp.VerifyAssertion(c.Challenge, publicKey, clientDataJSON, authenticatorData, signature)
+	if err != nil {
+		return nil, "", errors.WithStack(err)
+	}
+	// authenticators which do not implement the counter always return 0, otherwise it must increase on every
+	// assertion, or the credential may have been cloned
+	if (signCount != 0 || wc.SignCount != 0) && int64(signCount) <= wc.SignCount {
+		if err := RecordSecurityEvent(ctx, s.db, wc.UserID, SecurityEventTypeWebAuthnSignCountRegression, map[string]string{
+			"webauthn_credential_id": strconv.Itoa(wc.WebauthnCredentialID),
+		}); err != nil {
+			return nil, "", err
+		}
+		return nil, "", errors.WithStack(crypto.ErrInvalidWebAuthnResponse)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, "", errors.WithStack(err)
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil && err != sql.ErrTxDone {
+			logrus.WithError(err).Error()
+		}
+	}()
+
+	if err := s.consumeChallenge(ctx, tx, c); err != nil {
+		return nil, "", err
+	}
+
+	wc.SignCount = int64(signCount)
+	wc.LastUsedAt = null.TimeFrom(s.clock.Now())
+	if _, err := wc.Update(ctx, tx, boil.Whitelist(model.WebauthnCredentialColumns.SignCount, model.WebauthnCredentialColumns.LastUsedAt)); err != nil {
+		return nil, "", errors.WithStack(err)
+	}
+
+	user, err := mysql.GetUser(ctx, tx, wc.UserID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, "", errors.WithStack(err)
+	}
+	return user, c.DeviceLabel, nil
+}
+
+// getOrCreateWebAuthnUser returns the user handle of the user, which is created at the first registration. It is
+// random rather than derived from the user ID, as the authenticator may expose it.
+func (s *DBWebAuthnService) getOrCreateWebAuthnUser(ctx context.Context, user *model.User) (*model.WebauthnUser, error) {
+	wu, err := mysql.FindWebAuthnUserByUserID(ctx, s.db, user.UserID)
+	if err == nil {
+		return wu, nil
+	}
+	if errors.Cause(err) != sql.ErrNoRows {
+		return nil, err
+	}
+
+	b := make([]byte, webAuthnUserHandleLen)
+	if _, err := io.ReadFull(s.rand, b); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	wu = &model.WebauthnUser{
+		UserID:     user.UserID,
+		UserHandle: crypto.EncodeWebAuthnID(b),
+	}
+	if err := wu.Insert(ctx, s.db, boil.Infer()); err != nil {
+		// created by another registration at the same time
+		if mysqlErr, ok := errors.Cause(err).(*mysqldriver.MySQLError); ok && mysqlErr.Number == mysql.ErrorCodeDuplicateEntry {
+			return mysql.FindWebAuthnUserByUserID(ctx, s.db, user.UserID)
+		}
+		return nil, errors.WithStack(err)
+	}
+	return wu, nil
+}
+
+// checkPendingSignInChallenges returns *RateLimitedError if the IP address has too many sign-in challenges pending,
+// which is retried after the earliest one expires. The expired challenges of the IP address are deleted first.
+// It is checked before a challenge is created without a lock, so the limit may be exceeded by concurrent requests,
+// which is fine as long as the challenges do not pile up.
+func (s *DBWebAuthnService) checkPendingSignInChallenges(ctx context.Context, ipAddress string) error {
+	now := s.clock.Now()
+
+	if _, err := model.WebauthnChallenges(
+		model.WebauthnChallengeWhere.IPAddress.EQ(ipAddress),
+		model.WebauthnChallengeWhere.ExpiresAt.LTE(now),
+	).DeleteAll(ctx, s.db); err != nil {
+		return errors.WithStack(err)
+	}
+
+	cs, err := mysql.ListPendingWebAuthnChallengesByIPAddress(ctx, s.db, WebAuthnCeremonySignIn, ipAddress, now)
+	if err != nil {
+		return err
+	}
+	if len(cs) >= webAuthnMaxPendingSignInChallenges {
+		return errors.WithStack(&RateLimitedError{
+			Limit:      WebAuthnLimitPendingSignInChallenges,
+			RetryAfter: cs[0].ExpiresAt.Sub(now),
+		})
+	}
+	return nil
+}
+
+func (s *DBWebAuthnService) createChallenge(ctx context.Context, ceremony string, userID null.Int, deviceLabel, ipAddress string) (*model.WebauthnChallenge, error) {
+	b := make([]byte, webAuthnChallengeLen)
+	if _, err := io.ReadFull(s.rand, b); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	c := &model.WebauthnChallenge{
+		Challenge:   crypto.EncodeWebAuthnID(b),
+		Ceremony:    ceremony,
+		UserID:      userID,
+		DeviceLabel: deviceLabel,
+		IPAddress:   ipAddress,
+		ExpiresAt:   s.clock.Now().Add(webAuthnChallengeExpiresIn),
+	}
+	if err := c.Insert(ctx, s.db, boil.Infer()); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return c, nil
+}
+
+// findChallenge looks up the unexpired challenge of the ceremony the client data is signed for.
+func (s *DBWebAuthnService) findChallenge(ctx context.Context, clientDataJSON []byte, ceremony string) (*model.WebauthnChallenge, error) {
+	challenge, err := crypto.WebAuthnChallenge(clientDataJSON)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	c, err := mysql.FindWebAuthnChallengeByChallenge(ctx, s.db, challenge)
+	if errors.Cause(err) == sql.ErrNoRows {
+		return nil, errors.WithStack(ErrInvalidWebAuthnChallenge)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if c.Ceremony != ceremony || c.ConsumedAt.Valid || !c.ExpiresAt.After(s.clock.Now()) {
+		return nil, errors.WithStack(ErrInvalidWebAuthnChallenge)
+	}
+	return c, nil
+}
+
+// consumeChallenge marks the challenge as used, failing if it has been used concurrently.
+func (s *DBWebAuthnService) consumeChallenge(ctx context.Context, exec boil.ContextExecutor, c *model.WebauthnChallenge) error {
+	n, err := model.WebauthnChallenges(
+		model.WebauthnChallengeWhere.WebauthnChallengeID.EQ(c.WebauthnChallengeID),
+		model.WebauthnChallengeWhere.ConsumedAt.IsNull(),
+	).UpdateAll(ctx, exec, model.M{
+		model.WebauthnChallengeColumns.ConsumedAt: null.TimeFrom(s.clock.Now()),
+	})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if n == 0 {
+		return errors.WithStack(ErrInvalidWebAuthnChallenge)
+	}
+	return nil
+}
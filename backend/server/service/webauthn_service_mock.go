@@ -0,0 +1,96 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sean-ahn/user/backend/server/service (interfaces: WebAuthnService)
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	model "github.com/sean-ahn/user/backend/model"
+)
+
+// MockWebAuthnService is a mock of WebAuthnService interface.
+type MockWebAuthnService struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebAuthnServiceMockRecorder
+}
+
+// MockWebAuthnServiceMockRecorder is the mock recorder for MockWebAuthnService.
+type MockWebAuthnServiceMockRecorder struct {
+	mock *MockWebAuthnService
+}
+
+// NewMockWebAuthnService creates a new mock instance.
+func NewMockWebAuthnService(ctrl *gomock.Controller) *MockWebAuthnService {
+	mock := &MockWebAuthnService{ctrl: ctrl}
+	mock.recorder = &MockWebAuthnServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebAuthnService) EXPECT() *MockWebAuthnServiceMockRecorder {
+	return m.recorder
+}
+
+// BeginRegistration mocks base method.
+func (m *MockWebAuthnService) BeginRegistration(arg0 context.Context, arg1 *model.User) (*WebAuthnRegistrationOptions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginRegistration", arg0, arg1)
+	ret0, _ := ret[0].(*WebAuthnRegistrationOptions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginRegistration indicates an expected call of BeginRegistration.
+func (mr *MockWebAuthnServiceMockRecorder) BeginRegistration(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginRegistration", reflect.TypeOf((*MockWebAuthnService)(nil).BeginRegistration), arg0, arg1)
+}
+
+// BeginSignIn mocks base method.
+func (m *MockWebAuthnService) BeginSignIn(arg0 context.Context, arg1, arg2 string) (*WebAuthnSignInOptions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginSignIn", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*WebAuthnSignInOptions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginSignIn indicates an expected call of BeginSignIn.
+func (mr *MockWebAuthnServiceMockRecorder) BeginSignIn(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginSignIn", reflect.TypeOf((*MockWebAuthnService)(nil).BeginSignIn), arg0, arg1, arg2)
+}
+
+// FinishRegistration mocks base method.
+func (m *MockWebAuthnService) FinishRegistration(arg0 context.Context, arg1 *model.User, arg2 string, arg3, arg4 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishRegistration", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FinishRegistration indicates an expected call of FinishRegistration.
+func (mr *MockWebAuthnServiceMockRecorder) FinishRegistration(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishRegistration", reflect.TypeOf((*MockWebAuthnService)(nil).FinishRegistration), arg0, arg1, arg2, arg3, arg4)
+}
+
+// FinishSignIn mocks base method.
+func (m *MockWebAuthnService) FinishSignIn(arg0 context.Context, arg1 string, arg2, arg3, arg4, arg5 []byte) (*model.User, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishSignIn", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(*model.User)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FinishSignIn indicates an expected call of FinishSignIn.
+func (mr *MockWebAuthnServiceMockRecorder) FinishSignIn(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishSignIn", reflect.TypeOf((*MockWebAuthnService)(nil).FinishSignIn), arg0, arg1, arg2, arg3, arg4, arg5)
+}
@@ -0,0 +1,780 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jonboulle/clockwork"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/volatiletech/null/v8"
+
+	"github.com/sean-ahn/user/backend/crypto"
+	"github.com/sean-ahn/user/backend/model"
+	"github.com/sean-ahn/user/backend/test"
+)
+
+const (
+	testWebAuthnRPID       = "example.com"
+	testWebAuthnOrigin     = "https://example.com"
+	testWebAuthnChallenge  = "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA" // generated from zeros
+	testWebAuthnUserHandle = "dXNlcl9oYW5kbGU"                             // "user_handle"
+)
+
+func newTestWebAuthnService(now time.Time, db *sql.DB) *DBWebAuthnService {
+	return &DBWebAuthnService{
+		clock:  clockwork.NewFakeClockAt(now),
+		db:     db,
+		rand:   bytes.NewReader(make([]byte, webAuthnUserHandleLen+webAuthnChallengeLen)),
+		rp:     &crypto.WebAuthnRelyingParty{ID: testWebAuthnRPID, Origins: []string{testWebAuthnOrigin}},
+		rpName: "user",
+	}
+}
+
+func expectInsertWebAuthnChallenge(mock sqlmock.Sqlmock, ceremony string, userID interface{}, deviceLabel, ipAddress string, now time.Time) {
+	mock.ExpectExec(regexp.QuoteMeta(
+		"INSERT INTO `webauthn_challenge` (`challenge`,`ceremony`,`user_id`,`device_label`,`ip_address`,`expires_at`,`consumed_at`) VALUES (?,?,?,?,?,?,?)",
+	)).WithArgs(
+		testWebAuthnChallenge, ceremony, userID, deviceLabel, ipAddress, now.Add(webAuthnChallengeExpiresIn), nil,
+	).WillReturnResult(
+		sqlmock.NewResult(1, 1),
+	)
+
+	mock.ExpectQuery(regexp.QuoteMeta(
+		"SELECT `webauthn_challenge_id`,`created_at`,`updated_at` FROM `webauthn_challenge` WHERE `webauthn_challenge_id`=?",
+	)).WithArgs(
+		1,
+	).WillReturnRows(sqlmock.NewRows([]string{"webauthn_challenge_id", "created_at", "updated_at"}).
+		AddRow(1, now, now),
+	)
+}
+
+func expectFindWebAuthnUser(mock sqlmock.Sqlmock, userID int, users []*model.WebauthnUser) {
+	mock.ExpectQuery(regexp.QuoteMeta(
+		"SELECT * FROM `webauthn_user` WHERE (`webauthn_user`.`user_id` = ?) LIMIT 1;",
+	)).WithArgs(
+		userID,
+	).WillReturnRows(test.NewWebauthnUserRows(users))
+}
+
+func TestDBWebAuthnService_BeginRegistration(t *testing.T) {
+	now := time.Date(2021, 10, 24, 7, 39, 46, 127956672, time.UTC)
+
+	user := &model.User{UserID: 1, Name: "John Doe", Email: "john.doe@example.com"}
+
+	cases := []struct {
+		name string
+
+		dbExpectFunc func(sqlmock.Sqlmock)
+
+		expectedOptions *WebAuthnRegistrationOptions
+		expectedErr     string
+	}{
+		{
+			name: "begin",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `webauthn_credential` WHERE (`webauthn_credential`.`user_id` = ?) ORDER BY webauthn_credential_id;",
+				)).WithArgs(
+					1,
+				).WillReturnRows(test.NewWebauthnCredentialRows([]*model.WebauthnCredential{
+					{WebauthnCredentialID: 1, UserID: 1, CredentialID: "credential_id"},
+				}))
+
+				expectFindWebAuthnUser(mock, 1, []*model.WebauthnUser{
+					{WebauthnUserID: 1, UserID: 1, UserHandle: testWebAuthnUserHandle},
+				})
+
+				expectInsertWebAuthnChallenge(mock, WebAuthnCeremonyRegistration, 1, "", "", now)
+			},
+			expectedOptions: &WebAuthnRegistrationOptions{
+				Challenge:            testWebAuthnChallenge,
+				RPID:                 testWebAuthnRPID,
+				RPName:               "user",
+				UserHandle:           testWebAuthnUserHandle,
+				UserName:             "john.doe@example.com",
+				UserDisplayName:      "John Doe",
+				PubKeyCredAlgs:       []int32{crypto.COSEAlgorithmES256, crypto.COSEAlgorithmEdDSA, crypto.COSEAlgorithmRS256},
+				ExcludeCredentialIDs: []string{"credential_id"},
+				Timeout:              webAuthnChallengeExpiresIn,
+			},
+		},
+		{
+			name: "begin first",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `webauthn_credential` WHERE (`webauthn_credential`.`user_id` = ?) ORDER BY webauthn_credential_id;",
+				)).WithArgs(
+					1,
+				).WillReturnRows(test.NewWebauthnCredentialRows(nil))
+
+				expectFindWebAuthnUser(mock, 1, nil)
+
+				// generated from zeros
+				mock.ExpectExec(regexp.QuoteMeta(
+					"INSERT INTO `webauthn_user` (`user_id`,`user_handle`) VALUES (?,?)",
+				)).WithArgs(
+					1, "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA",
+				).WillReturnResult(
+					sqlmock.NewResult(1, 1),
+				)
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT `webauthn_user_id`,`created_at`,`updated_at` FROM `webauthn_user` WHERE `webauthn_user_id`=?",
+				)).WithArgs(
+					1,
+				).WillReturnRows(sqlmock.NewRows([]string{"webauthn_user_id", "created_at", "updated_at"}).
+					AddRow(1, now, now),
+				)
+
+				expectInsertWebAuthnChallenge(mock, WebAuthnCeremonyRegistration, 1, "", "", now)
+			},
+			expectedOptions: &WebAuthnRegistrationOptions{
+				Challenge:            testWebAuthnChallenge,
+				RPID:                 testWebAuthnRPID,
+				RPName:               "user",
+				UserHandle:           "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA",
+				UserName:             "john.doe@example.com",
+				UserDisplayName:      "John Doe",
+				PubKeyCredAlgs:       []int32{crypto.COSEAlgorithmES256, crypto.COSEAlgorithmEdDSA, crypto.COSEAlgorithmRS256},
+				ExcludeCredentialIDs: []string{},
+				Timeout:              webAuthnChallengeExpiresIn,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fail()
+			}
+			defer test.CloseSqlmock(t, db, mock)
+
+			if tc.dbExpectFunc != nil {
+				tc.dbExpectFunc(mock)
+			}
+
+			svc := newTestWebAuthnService(now, db)
+
+			options, err := svc.BeginRegistration(ctx, user)
+
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedOptions, options)
+		})
+	}
+}
+
+func TestDBWebAuthnService_FinishRegistration(t *testing.T) {
+	now := time.Date(2021, 10, 24, 7, 39, 46, 127956672, time.UTC)
+
+	user := &model.User{UserID: 1, Name: "John Doe", Email: "john.doe@example.com"}
+
+	authenticator, err := test.NewSoftwareAuthenticator()
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientDataJSON, attestationObject := authenticator.Create(testWebAuthnRPID, testWebAuthnOrigin, testWebAuthnChallenge)
+
+	cases := []struct {
+		name         string
+		credentialID string
+
+		dbExpectFunc func(sqlmock.Sqlmock)
+
+		expectedErr string
+	}{
+		{
+			name:         "finish",
+			credentialID: authenticator.EncodedCredentialID(),
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `webauthn_challenge` WHERE (`webauthn_challenge`.`challenge` = ?) LIMIT 1;",
+				)).WithArgs(
+					testWebAuthnChallenge,
+				).WillReturnRows(test.NewWebauthnChallengeRows([]*model.WebauthnChallenge{
+					{WebauthnChallengeID: 1, Challenge: testWebAuthnChallenge, Ceremony: WebAuthnCeremonyRegistration, UserID: null.IntFrom(1), ExpiresAt: now.Add(time.Minute)},
+				}))
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `webauthn_credential` WHERE (`webauthn_credential`.`credential_id` = ?) LIMIT 1;",
+				)).WithArgs(
+					authenticator.EncodedCredentialID(),
+				).WillReturnRows(test.NewWebauthnCredentialRows(nil))
+
+				mock.ExpectBegin()
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"UPDATE `webauthn_challenge` SET `consumed_at` = ? WHERE (`webauthn_challenge`.`webauthn_challenge_id` = ?) AND (`webauthn_challenge`.`consumed_at` is null);",
+				)).WithArgs(
+					now, 1,
+				).WillReturnResult(
+					sqlmock.NewResult(0, 1),
+				)
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"INSERT INTO `webauthn_credential` (`user_id`,`credential_id`,`public_key`,`sign_count`,`last_used_at`) VALUES (?,?,?,?,?)",
+				)).WithArgs(
+					1, authenticator.EncodedCredentialID(), crypto.EncodeWebAuthnID(authenticator.PublicKey()), 0, nil,
+				).WillReturnResult(
+					sqlmock.NewResult(1, 1),
+				)
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT `webauthn_credential_id`,`created_at`,`updated_at` FROM `webauthn_credential` WHERE `webauthn_credential_id`=?",
+				)).WithArgs(
+					1,
+				).WillReturnRows(sqlmock.NewRows([]string{"webauthn_credential_id", "created_at", "updated_at"}).
+					AddRow(1, now, now),
+				)
+
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:         "challenge not found",
+			credentialID: authenticator.EncodedCredentialID(),
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `webauthn_challenge` WHERE (`webauthn_challenge`.`challenge` = ?) LIMIT 1;",
+				)).WithArgs(
+					testWebAuthnChallenge,
+				).WillReturnRows(test.NewWebauthnChallengeRows(nil))
+			},
+			expectedErr: "invalid webauthn challenge",
+		},
+		{
+			name:         "challenge for sign-in",
+			credentialID: authenticator.EncodedCredentialID(),
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `webauthn_challenge` WHERE (`webauthn_challenge`.`challenge` = ?) LIMIT 1;",
+				)).WithArgs(
+					testWebAuthnChallenge,
+				).WillReturnRows(test.NewWebauthnChallengeRows([]*model.WebauthnChallenge{
+					{WebauthnChallengeID: 1, Challenge: testWebAuthnChallenge, Ceremony: WebAuthnCeremonySignIn, ExpiresAt: now.Add(time.Minute)},
+				}))
+			},
+			expectedErr: "invalid webauthn challenge",
+		},
+		{
+			name:         "challenge of another user",
+			credentialID: authenticator.EncodedCredentialID(),
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `webauthn_challenge` WHERE (`webauthn_challenge`.`challenge` = ?) LIMIT 1;",
+				)).WithArgs(
+					testWebAuthnChallenge,
+				).WillReturnRows(test.NewWebauthnChallengeRows([]*model.WebauthnChallenge{
+					{WebauthnChallengeID: 1, Challenge: testWebAuthnChallenge, Ceremony: WebAuthnCeremonyRegistration, UserID: null.IntFrom(2), ExpiresAt: now.Add(time.Minute)},
+				}))
+			},
+			expectedErr: "invalid webauthn challenge",
+		},
+		{
+			name:         "expired challenge",
+			credentialID: authenticator.EncodedCredentialID(),
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `webauthn_challenge` WHERE (`webauthn_challenge`.`challenge` = ?) LIMIT 1;",
+				)).WithArgs(
+					testWebAuthnChallenge,
+				).WillReturnRows(test.NewWebauthnChallengeRows([]*model.WebauthnChallenge{
+					{WebauthnChallengeID: 1, Challenge: testWebAuthnChallenge, Ceremony: WebAuthnCeremonyRegistration, UserID: null.IntFrom(1), ExpiresAt: now},
+				}))
+			},
+			expectedErr: "invalid webauthn challenge",
+		},
+		{
+			name:         "different credential id",
+			credentialID: "credential_id",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `webauthn_challenge` WHERE (`webauthn_challenge`.`challenge` = ?) LIMIT 1;",
+				)).WithArgs(
+					testWebAuthnChallenge,
+				).WillReturnRows(test.NewWebauthnChallengeRows([]*model.WebauthnChallenge{
+					{WebauthnChallengeID: 1, Challenge: testWebAuthnChallenge, Ceremony: WebAuthnCeremonyRegistration, UserID: null.IntFrom(1), ExpiresAt: now.Add(time.Minute)},
+				}))
+			},
+			expectedErr: "invalid webauthn response",
+		},
+		{
+			name:         "already registered",
+			credentialID: authenticator.EncodedCredentialID(),
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `webauthn_challenge` WHERE (`webauthn_challenge`.`challenge` = ?) LIMIT 1;",
+				)).WithArgs(
+					testWebAuthnChallenge,
+				).WillReturnRows(test.NewWebauthnChallengeRows([]*model.WebauthnChallenge{
+					{WebauthnChallengeID: 1, Challenge: testWebAuthnChallenge, Ceremony: WebAuthnCeremonyRegistration, UserID: null.IntFrom(1), ExpiresAt: now.Add(time.Minute)},
+				}))
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `webauthn_credential` WHERE (`webauthn_credential`.`credential_id` = ?) LIMIT 1;",
+				)).WithArgs(
+					authenticator.EncodedCredentialID(),
+				).WillReturnRows(test.NewWebauthnCredentialRows([]*model.WebauthnCredential{
+					{WebauthnCredentialID: 1, UserID: 2, CredentialID: authenticator.EncodedCredentialID()},
+				}))
+			},
+			expectedErr: "webauthn credential already registered",
+		},
+		{
+			name:         "challenge consumed concurrently",
+			credentialID: authenticator.EncodedCredentialID(),
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `webauthn_challenge` WHERE (`webauthn_challenge`.`challenge` = ?) LIMIT 1;",
+				)).WithArgs(
+					testWebAuthnChallenge,
+				).WillReturnRows(test.NewWebauthnChallengeRows([]*model.WebauthnChallenge{
+					{WebauthnChallengeID: 1, Challenge: testWebAuthnChallenge, Ceremony: WebAuthnCeremonyRegistration, UserID: null.IntFrom(1), ExpiresAt: now.Add(time.Minute)},
+				}))
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `webauthn_credential` WHERE (`webauthn_credential`.`credential_id` = ?) LIMIT 1;",
+				)).WithArgs(
+					authenticator.EncodedCredentialID(),
+				).WillReturnRows(test.NewWebauthnCredentialRows(nil))
+
+				mock.ExpectBegin()
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"UPDATE `webauthn_challenge` SET `consumed_at` = ? WHERE (`webauthn_challenge`.`webauthn_challenge_id` = ?) AND (`webauthn_challenge`.`consumed_at` is null);",
+				)).WithArgs(
+					now, 1,
+				).WillReturnResult(
+					sqlmock.NewResult(0, 0),
+				)
+
+				mock.ExpectRollback()
+			},
+			expectedErr: "invalid webauthn challenge",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fail()
+			}
+			defer test.CloseSqlmock(t, db, mock)
+
+			if tc.dbExpectFunc != nil {
+				tc.dbExpectFunc(mock)
+			}
+
+			svc := newTestWebAuthnService(now, db)
+
+			err = svc.FinishRegistration(ctx, user, tc.credentialID, clientDataJSON, attestationObject)
+
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDBWebAuthnService_BeginSignIn(t *testing.T) {
+	now := time.Date(2021, 10, 24, 7, 39, 46, 127956672, time.UTC)
+
+	expectDeleteExpired := func(mock sqlmock.Sqlmock) {
+		mock.ExpectExec(regexp.QuoteMeta(
+			"DELETE FROM `webauthn_challenge` WHERE (`webauthn_challenge`.`ip_address` = ?) AND (`webauthn_challenge`.`expires_at` <= ?);",
+		)).WithArgs(
+			"198.51.100.7", now,
+		).WillReturnResult(
+			sqlmock.NewResult(0, 3),
+		)
+	}
+	expectListPending := func(mock sqlmock.Sqlmock, n int) {
+		challenges := make([]*model.WebauthnChallenge, 0, n)
+		for i := 0; i < n; i++ {
+			challenges = append(challenges, &model.WebauthnChallenge{
+				WebauthnChallengeID: i + 1,
+				Ceremony:            WebAuthnCeremonySignIn,
+				IPAddress:           "198.51.100.7",
+				ExpiresAt:           now.Add(time.Duration(i+1) * time.Second),
+			})
+		}
+
+		mock.ExpectQuery(regexp.QuoteMeta(
+			"SELECT * FROM `webauthn_challenge` WHERE (`webauthn_challenge`.`ip_address` = ?) AND (`webauthn_challenge`.`expires_at` > ?) AND (`webauthn_challenge`.`ceremony` = ?) AND (`webauthn_challenge`.`consumed_at` is null) ORDER BY expires_at;",
+		)).WithArgs(
+			"198.51.100.7", now, WebAuthnCeremonySignIn,
+		).WillReturnRows(test.NewWebauthnChallengeRows(challenges))
+	}
+
+	cases := []struct {
+		name      string
+		ipAddress string
+
+		dbExpectFunc func(sqlmock.Sqlmock)
+
+		expectedOptions *WebAuthnSignInOptions
+		expectedErr     string
+	}{
+		{
+			name:      "begin",
+			ipAddress: "198.51.100.7",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				expectDeleteExpired(mock)
+				expectListPending(mock, webAuthnMaxPendingSignInChallenges-1)
+				expectInsertWebAuthnChallenge(mock, WebAuthnCeremonySignIn, nil, "Chrome on macOS", "198.51.100.7", now)
+			},
+			expectedOptions: &WebAuthnSignInOptions{
+				Challenge: testWebAuthnChallenge,
+				RPID:      testWebAuthnRPID,
+				Timeout:   webAuthnChallengeExpiresIn,
+			},
+		},
+		{
+			name: "ip address unknown",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				expectInsertWebAuthnChallenge(mock, WebAuthnCeremonySignIn, nil, "Chrome on macOS", "", now)
+			},
+			expectedOptions: &WebAuthnSignInOptions{
+				Challenge: testWebAuthnChallenge,
+				RPID:      testWebAuthnRPID,
+				Timeout:   webAuthnChallengeExpiresIn,
+			},
+		},
+		{
+			name:      "too many pending challenges",
+			ipAddress: "198.51.100.7",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				expectDeleteExpired(mock)
+				expectListPending(mock, webAuthnMaxPendingSignInChallenges)
+			},
+			expectedErr: "rate limited by webauthn_pending_sign_in_challenges",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fail()
+			}
+			defer test.CloseSqlmock(t, db, mock)
+
+			if tc.dbExpectFunc != nil {
+				tc.dbExpectFunc(mock)
+			}
+
+			svc := newTestWebAuthnService(now, db)
+
+			options, err := svc.BeginSignIn(ctx, "Chrome on macOS", tc.ipAddress)
+
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				if rateLimitedErr, ok := errors.Cause(err).(*RateLimitedError); ok {
+					// retried once the earliest one expires
+					assert.Equal(t, time.Second, rateLimitedErr.RetryAfter)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedOptions, options)
+		})
+	}
+}
+
+func TestDBWebAuthnService_FinishSignIn(t *testing.T) {
+	now := time.Date(2021, 10, 24, 7, 39, 46, 127956672, time.UTC)
+
+	authenticator, err := test.NewSoftwareAuthenticator()
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientDataJSON, authenticatorData, signature := authenticator.Get(testWebAuthnRPID, testWebAuthnOrigin, testWebAuthnChallenge)
+
+	credentialID := authenticator.EncodedCredentialID()
+	publicKey := crypto.EncodeWebAuthnID(authenticator.PublicKey())
+
+	expectFindChallenge := func(mock sqlmock.Sqlmock) {
+		mock.ExpectQuery(regexp.QuoteMeta(
+			"SELECT * FROM `webauthn_challenge` WHERE (`webauthn_challenge`.`challenge` = ?) LIMIT 1;",
+		)).WithArgs(
+			testWebAuthnChallenge,
+		).WillReturnRows(test.NewWebauthnChallengeRows([]*model.WebauthnChallenge{
+			{WebauthnChallengeID: 1, Challenge: testWebAuthnChallenge, Ceremony: WebAuthnCeremonySignIn, DeviceLabel: "Chrome on macOS", ExpiresAt: now.Add(time.Minute)},
+		}))
+	}
+
+	cases := []struct {
+		name         string
+		credentialID string
+
+		dbExpectFunc func(sqlmock.Sqlmock)
+
+		expectedUser        *model.User
+		expectedDeviceLabel string
+		expectedErr         string
+	}{
+		{
+			name:         "finish",
+			credentialID: credentialID,
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				expectFindChallenge(mock)
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `webauthn_credential` WHERE (`webauthn_credential`.`credential_id` = ?) LIMIT 1;",
+				)).WithArgs(
+					credentialID,
+				).WillReturnRows(test.NewWebauthnCredentialRows([]*model.WebauthnCredential{
+					{WebauthnCredentialID: 1, UserID: 1, CredentialID: credentialID, PublicKey: publicKey},
+				}))
+
+				expectFindWebAuthnUser(mock, 1, []*model.WebauthnUser{
+					{WebauthnUserID: 1, UserID: 1, UserHandle: testWebAuthnUserHandle},
+				})
+
+				mock.ExpectBegin()
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"UPDATE `webauthn_challenge` SET `consumed_at` = ? WHERE (`webauthn_challenge`.`webauthn_challenge_id` = ?) AND (`webauthn_challenge`.`consumed_at` is null);",
+				)).WithArgs(
+					now, 1,
+				).WillReturnResult(
+					sqlmock.NewResult(0, 1),
+				)
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"UPDATE `webauthn_credential` SET `sign_count`=?,`last_used_at`=? WHERE `webauthn_credential_id`=?",
+				)).WithArgs(
+					1, now, 1,
+				).WillReturnResult(
+					sqlmock.NewResult(0, 1),
+				)
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `user` WHERE (`user`.`user_id` = ?) LIMIT 1;",
+				)).WithArgs(
+					1,
+				).WillReturnRows(test.NewUserRows([]*model.User{
+					{UserID: 1, Email: "john.doe@example.com"},
+				}))
+
+				mock.ExpectCommit()
+			},
+			expectedUser:        &model.User{UserID: 1, Email: "john.doe@example.com"},
+			expectedDeviceLabel: "Chrome on macOS",
+		},
+		{
+			name:         "challenge consumed",
+			credentialID: credentialID,
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `webauthn_challenge` WHERE (`webauthn_challenge`.`challenge` = ?) LIMIT 1;",
+				)).WithArgs(
+					testWebAuthnChallenge,
+				).WillReturnRows(test.NewWebauthnChallengeRows([]*model.WebauthnChallenge{
+					{WebauthnChallengeID: 1, Challenge: testWebAuthnChallenge, Ceremony: WebAuthnCeremonySignIn, ExpiresAt: now.Add(time.Minute), ConsumedAt: null.TimeFrom(now.Add(-time.Second))},
+				}))
+			},
+			expectedErr: "invalid webauthn challenge",
+		},
+		{
+			name:         "credential not found",
+			credentialID: credentialID,
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				expectFindChallenge(mock)
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `webauthn_credential` WHERE (`webauthn_credential`.`credential_id` = ?) LIMIT 1;",
+				)).WithArgs(
+					credentialID,
+				).WillReturnRows(test.NewWebauthnCredentialRows(nil))
+			},
+			expectedErr: "webauthn credential not found",
+		},
+		{
+			name:         "user handle of another user",
+			credentialID: credentialID,
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				expectFindChallenge(mock)
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `webauthn_credential` WHERE (`webauthn_credential`.`credential_id` = ?) LIMIT 1;",
+				)).WithArgs(
+					credentialID,
+				).WillReturnRows(test.NewWebauthnCredentialRows([]*model.WebauthnCredential{
+					{WebauthnCredentialID: 1, UserID: 2, CredentialID: credentialID, PublicKey: publicKey},
+				}))
+
+				expectFindWebAuthnUser(mock, 2, []*model.WebauthnUser{
+					{WebauthnUserID: 2, UserID: 2, UserHandle: "b3RoZXJfdXNlcl9oYW5kbGU"},
+				})
+			},
+			expectedErr: "invalid webauthn response",
+		},
+		{
+			name:         "user handle not registered",
+			credentialID: credentialID,
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				expectFindChallenge(mock)
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `webauthn_credential` WHERE (`webauthn_credential`.`credential_id` = ?) LIMIT 1;",
+				)).WithArgs(
+					credentialID,
+				).WillReturnRows(test.NewWebauthnCredentialRows([]*model.WebauthnCredential{
+					{WebauthnCredentialID: 1, UserID: 1, CredentialID: credentialID, PublicKey: publicKey},
+				}))
+
+				expectFindWebAuthnUser(mock, 1, nil)
+			},
+			expectedErr: "invalid webauthn response",
+		},
+		{
+			name:         "signed by another credential",
+			credentialID: "credential_id",
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				other, err := test.NewSoftwareAuthenticator()
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				expectFindChallenge(mock)
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `webauthn_credential` WHERE (`webauthn_credential`.`credential_id` = ?) LIMIT 1;",
+				)).WithArgs(
+					"credential_id",
+				).WillReturnRows(test.NewWebauthnCredentialRows([]*model.WebauthnCredential{
+					{WebauthnCredentialID: 2, UserID: 2, CredentialID: "credential_id", PublicKey: crypto.EncodeWebAuthnID(other.PublicKey())},
+				}))
+
+				expectFindWebAuthnUser(mock, 2, []*model.WebauthnUser{
+					{WebauthnUserID: 2, UserID: 2, UserHandle: testWebAuthnUserHandle},
+				})
+			},
+			expectedErr: "invalid webauthn response",
+		},
+		{
+			name:         "sign count regression",
+			credentialID: credentialID,
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				expectFindChallenge(mock)
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `webauthn_credential` WHERE (`webauthn_credential`.`credential_id` = ?) LIMIT 1;",
+				)).WithArgs(
+					credentialID,
+				).WillReturnRows(test.NewWebauthnCredentialRows([]*model.WebauthnCredential{
+					{WebauthnCredentialID: 1, UserID: 1, CredentialID: credentialID, PublicKey: publicKey, SignCount: 5},
+				}))
+
+				expectFindWebAuthnUser(mock, 1, []*model.WebauthnUser{
+					{WebauthnUserID: 1, UserID: 1, UserHandle: testWebAuthnUserHandle},
+				})
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"INSERT INTO `security_event` (`user_id`,`event_type`,`detail`) VALUES (?,?,?)",
+				)).WithArgs(
+					1, SecurityEventTypeWebAuthnSignCountRegression, `{"webauthn_credential_id":"1"}`,
+				).WillReturnResult(
+					sqlmock.NewResult(1, 1),
+				)
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT `security_event_id`,`created_at`,`updated_at` FROM `security_event` WHERE `security_event_id`=?",
+				)).WithArgs(
+					1,
+				).WillReturnRows(sqlmock.NewRows([]string{"security_event_id", "created_at", "updated_at"}).
+					AddRow(1, now, now),
+				)
+			},
+			expectedErr: "invalid webauthn response",
+		},
+		{
+			name:         "challenge consumed concurrently",
+			credentialID: credentialID,
+			dbExpectFunc: func(mock sqlmock.Sqlmock) {
+				expectFindChallenge(mock)
+
+				mock.ExpectQuery(regexp.QuoteMeta(
+					"SELECT * FROM `webauthn_credential` WHERE (`webauthn_credential`.`credential_id` = ?) LIMIT 1;",
+				)).WithArgs(
+					credentialID,
+				).WillReturnRows(test.NewWebauthnCredentialRows([]*model.WebauthnCredential{
+					{WebauthnCredentialID: 1, UserID: 1, CredentialID: credentialID, PublicKey: publicKey},
+				}))
+
+				expectFindWebAuthnUser(mock, 1, []*model.WebauthnUser{
+					{WebauthnUserID: 1, UserID: 1, UserHandle: testWebAuthnUserHandle},
+				})
+
+				mock.ExpectBegin()
+
+				mock.ExpectExec(regexp.QuoteMeta(
+					"UPDATE `webauthn_challenge` SET `consumed_at` = ? WHERE (`webauthn_challenge`.`webauthn_challenge_id` = ?) AND (`webauthn_challenge`.`consumed_at` is null);",
+				)).WithArgs(
+					now, 1,
+				).WillReturnResult(
+					sqlmock.NewResult(0, 0),
+				)
+
+				mock.ExpectRollback()
+			},
+			expectedErr: "invalid webauthn challenge",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fail()
+			}
+			defer test.CloseSqlmock(t, db, mock)
+
+			if tc.dbExpectFunc != nil {
+				tc.dbExpectFunc(mock)
+			}
+
+			svc := newTestWebAuthnService(now, db)
+
+			user, deviceLabel, err := svc.FinishSignIn(ctx, tc.credentialID, clientDataJSON, authenticatorData, signature, []byte("user_handle"))
+
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedUser, user)
+			assert.Equal(t, tc.expectedDeviceLabel, deviceLabel)
+		})
+	}
+}
@@ -89,10 +89,14 @@ func NewSMSOtpVerificationRows(verifications []*model.SMSOtpVerification) *sqlmo
 		model.SMSOtpVerificationColumns.SMSOtpVerificationID,
 		model.SMSOtpVerificationColumns.VerificationToken,
 		model.SMSOtpVerificationColumns.PhoneNumber,
+		model.SMSOtpVerificationColumns.IPAddress,
+		model.SMSOtpVerificationColumns.Purpose,
 		model.SMSOtpVerificationColumns.OtpCode,
+		model.SMSOtpVerificationColumns.OtpCodeHash,
 		model.SMSOtpVerificationColumns.ExpiresAt,
 		model.SMSOtpVerificationColumns.VerificationTrials,
 		model.SMSOtpVerificationColumns.VerificationValidUntil,
+		model.SMSOtpVerificationColumns.ConsumedAt,
 		model.SMSOtpVerificationColumns.CreatedAt,
 		model.SMSOtpVerificationColumns.UpdatedAt,
 	})
@@ -101,13 +105,329 @@ func NewSMSOtpVerificationRows(verifications []*model.SMSOtpVerification) *sqlmo
 			v.SMSOtpVerificationID,
 			v.VerificationToken,
 			v.PhoneNumber,
+			v.IPAddress,
+			v.Purpose,
 			v.OtpCode,
+			v.OtpCodeHash,
 			v.ExpiresAt,
 			v.VerificationTrials,
 			v.VerificationValidUntil,
+			v.ConsumedAt,
 			v.CreatedAt,
 			v.UpdatedAt,
 		)
 	}
 	return rows
 }
+
+func NewSMSOutboxRows(outboxes []*model.SMSOutbox) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{
+		model.SMSOutboxColumns.SMSOutboxID,
+		model.SMSOutboxColumns.SMSOtpVerificationID,
+		model.SMSOutboxColumns.PhoneNumber,
+		model.SMSOutboxColumns.Message,
+		model.SMSOutboxColumns.Status,
+		model.SMSOutboxColumns.Attempts,
+		model.SMSOutboxColumns.NextAttemptAt,
+		model.SMSOutboxColumns.ExpiresAt,
+		model.SMSOutboxColumns.SentAt,
+		model.SMSOutboxColumns.LastError,
+		model.SMSOutboxColumns.CreatedAt,
+		model.SMSOutboxColumns.UpdatedAt,
+	})
+	for _, o := range outboxes {
+		rows.AddRow(
+			o.SMSOutboxID,
+			o.SMSOtpVerificationID,
+			o.PhoneNumber,
+			o.Message,
+			o.Status,
+			o.Attempts,
+			o.NextAttemptAt,
+			o.ExpiresAt,
+			o.SentAt,
+			o.LastError,
+			o.CreatedAt,
+			o.UpdatedAt,
+		)
+	}
+	return rows
+}
+
+func NewSignInThrottleRows(throttles []*model.SignInThrottle) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{
+		model.SignInThrottleColumns.SignInThrottleID,
+		model.SignInThrottleColumns.Scope,
+		model.SignInThrottleColumns.ScopeKey,
+		model.SignInThrottleColumns.Failures,
+		model.SignInThrottleColumns.LastFailedAt,
+		model.SignInThrottleColumns.BlockedUntil,
+		model.SignInThrottleColumns.LockedAt,
+		model.SignInThrottleColumns.CreatedAt,
+		model.SignInThrottleColumns.UpdatedAt,
+	})
+	for _, t := range throttles {
+		rows.AddRow(
+			t.SignInThrottleID,
+			t.Scope,
+			t.ScopeKey,
+			t.Failures,
+			t.LastFailedAt,
+			t.BlockedUntil,
+			t.LockedAt,
+			t.CreatedAt,
+			t.UpdatedAt,
+		)
+	}
+	return rows
+}
+
+func NewPasswordHistoryRows(histories []*model.PasswordHistory) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{
+		model.PasswordHistoryColumns.PasswordHistoryID,
+		model.PasswordHistoryColumns.UserID,
+		model.PasswordHistoryColumns.PasswordHash,
+		model.PasswordHistoryColumns.CreatedAt,
+		model.PasswordHistoryColumns.UpdatedAt,
+	})
+	for _, h := range histories {
+		rows.AddRow(
+			h.PasswordHistoryID,
+			h.UserID,
+			h.PasswordHash,
+			h.CreatedAt,
+			h.UpdatedAt,
+		)
+	}
+	return rows
+}
+
+func NewJWTSigningKeyRows(keys []*model.JWTSigningKey) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{
+		model.JWTSigningKeyColumns.JWTSigningKeyID,
+		model.JWTSigningKeyColumns.Kid,
+		model.JWTSigningKeyColumns.Algorithm,
+		model.JWTSigningKeyColumns.PrivateKey,
+		model.JWTSigningKeyColumns.ActivatesAt,
+		model.JWTSigningKeyColumns.RetiresAt,
+		model.JWTSigningKeyColumns.CreatedAt,
+		model.JWTSigningKeyColumns.UpdatedAt,
+	})
+	for _, k := range keys {
+		rows.AddRow(
+			k.JWTSigningKeyID,
+			k.Kid,
+			k.Algorithm,
+			k.PrivateKey,
+			k.ActivatesAt,
+			k.RetiresAt,
+			k.CreatedAt,
+			k.UpdatedAt,
+		)
+	}
+	return rows
+}
+
+func NewRefreshTokenFamilyRows(families []*model.RefreshTokenFamily) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{
+		model.RefreshTokenFamilyColumns.RefreshTokenFamilyID,
+		model.RefreshTokenFamilyColumns.FamilyID,
+		model.RefreshTokenFamilyColumns.UserID,
+		model.RefreshTokenFamilyColumns.RevokedAt,
+		model.RefreshTokenFamilyColumns.CreatedAt,
+		model.RefreshTokenFamilyColumns.UpdatedAt,
+	})
+	for _, f := range families {
+		rows.AddRow(
+			f.RefreshTokenFamilyID,
+			f.FamilyID,
+			f.UserID,
+			f.RevokedAt,
+			f.CreatedAt,
+			f.UpdatedAt,
+		)
+	}
+	return rows
+}
+
+func NewUserSessionRows(sessions []*model.UserSession) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{
+		model.UserSessionColumns.UserSessionID,
+		model.UserSessionColumns.FamilyID,
+		model.UserSessionColumns.UserID,
+		model.UserSessionColumns.DeviceLabel,
+		model.UserSessionColumns.UserAgent,
+		model.UserSessionColumns.IPAddress,
+		model.UserSessionColumns.LastUsedAt,
+		model.UserSessionColumns.CreatedAt,
+		model.UserSessionColumns.UpdatedAt,
+	})
+	for _, s := range sessions {
+		rows.AddRow(
+			s.UserSessionID,
+			s.FamilyID,
+			s.UserID,
+			s.DeviceLabel,
+			s.UserAgent,
+			s.IPAddress,
+			s.LastUsedAt,
+			s.CreatedAt,
+			s.UpdatedAt,
+		)
+	}
+	return rows
+}
+
+func NewEmailConfirmationRows(confirmations []*model.EmailConfirmation) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{
+		model.EmailConfirmationColumns.EmailConfirmationID,
+		model.EmailConfirmationColumns.UserID,
+		model.EmailConfirmationColumns.Email,
+		model.EmailConfirmationColumns.CodeHash,
+		model.EmailConfirmationColumns.ExpiresAt,
+		model.EmailConfirmationColumns.ConsumedAt,
+		model.EmailConfirmationColumns.CreatedAt,
+		model.EmailConfirmationColumns.UpdatedAt,
+	})
+	for _, c := range confirmations {
+		rows.AddRow(
+			c.EmailConfirmationID,
+			c.UserID,
+			c.Email,
+			c.CodeHash,
+			c.ExpiresAt,
+			c.ConsumedAt,
+			c.CreatedAt,
+			c.UpdatedAt,
+		)
+	}
+	return rows
+}
+
+func NewUserTotpRows(totps []*model.UserTotp) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{
+		model.UserTotpColumns.UserTotpID,
+		model.UserTotpColumns.UserID,
+		model.UserTotpColumns.Secret,
+		model.UserTotpColumns.ConfirmedAt,
+		model.UserTotpColumns.LastUsedStep,
+		model.UserTotpColumns.CreatedAt,
+		model.UserTotpColumns.UpdatedAt,
+	})
+	for _, t := range totps {
+		rows.AddRow(
+			t.UserTotpID,
+			t.UserID,
+			t.Secret,
+			t.ConfirmedAt,
+			t.LastUsedStep,
+			t.CreatedAt,
+			t.UpdatedAt,
+		)
+	}
+	return rows
+}
+
+func NewMfaChallengeRows(challenges []*model.MfaChallenge) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{
+		model.MfaChallengeColumns.MfaChallengeID,
+		model.MfaChallengeColumns.ChallengeToken,
+		model.MfaChallengeColumns.UserID,
+		model.MfaChallengeColumns.DeviceLabel,
+		model.MfaChallengeColumns.ExpiresAt,
+		model.MfaChallengeColumns.VerificationTrials,
+		model.MfaChallengeColumns.ConsumedAt,
+		model.MfaChallengeColumns.CreatedAt,
+		model.MfaChallengeColumns.UpdatedAt,
+	})
+	for _, c := range challenges {
+		rows.AddRow(
+			c.MfaChallengeID,
+			c.ChallengeToken,
+			c.UserID,
+			c.DeviceLabel,
+			c.ExpiresAt,
+			c.VerificationTrials,
+			c.ConsumedAt,
+			c.CreatedAt,
+			c.UpdatedAt,
+		)
+	}
+	return rows
+}
+
+func NewWebauthnCredentialRows(credentials []*model.WebauthnCredential) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{
+		model.WebauthnCredentialColumns.WebauthnCredentialID,
+		model.WebauthnCredentialColumns.UserID,
+		model.WebauthnCredentialColumns.CredentialID,
+		model.WebauthnCredentialColumns.PublicKey,
+		model.WebauthnCredentialColumns.SignCount,
+		model.WebauthnCredentialColumns.LastUsedAt,
+		model.WebauthnCredentialColumns.CreatedAt,
+		model.WebauthnCredentialColumns.UpdatedAt,
+	})
+	for _, c := range credentials {
+		rows.AddRow(
+			c.WebauthnCredentialID,
+			c.UserID,
+			c.CredentialID,
+			c.PublicKey,
+			c.SignCount,
+			c.LastUsedAt,
+			c.CreatedAt,
+			c.UpdatedAt,
+		)
+	}
+	return rows
+}
+
+func NewWebauthnChallengeRows(challenges []*model.WebauthnChallenge) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{
+		model.WebauthnChallengeColumns.WebauthnChallengeID,
+		model.WebauthnChallengeColumns.Challenge,
+		model.WebauthnChallengeColumns.Ceremony,
+		model.WebauthnChallengeColumns.UserID,
+		model.WebauthnChallengeColumns.DeviceLabel,
+		model.WebauthnChallengeColumns.IPAddress,
+		model.WebauthnChallengeColumns.ExpiresAt,
+		model.WebauthnChallengeColumns.ConsumedAt,
+		model.WebauthnChallengeColumns.CreatedAt,
+		model.WebauthnChallengeColumns.UpdatedAt,
+	})
+	for _, c := range challenges {
+		rows.AddRow(
+			c.WebauthnChallengeID,
+			c.Challenge,
+			c.Ceremony,
+			c.UserID,
+			c.DeviceLabel,
+			c.IPAddress,
+			c.ExpiresAt,
+			c.ConsumedAt,
+			c.CreatedAt,
+			c.UpdatedAt,
+		)
+	}
+	return rows
+}
+
+func NewWebauthnUserRows(users []*model.WebauthnUser) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{
+		model.WebauthnUserColumns.WebauthnUserID,
+		model.WebauthnUserColumns.UserID,
+		model.WebauthnUserColumns.UserHandle,
+		model.WebauthnUserColumns.CreatedAt,
+		model.WebauthnUserColumns.UpdatedAt,
+	})
+	for _, u := range users {
+		rows.AddRow(
+			u.WebauthnUserID,
+			u.UserID,
+			u.UserHandle,
+			u.CreatedAt,
+			u.UpdatedAt,
+		)
+	}
+	return rows
+}
@@ -0,0 +1,132 @@
+package test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+const (
+	webAuthnFlagUserPresent            = 0x01
+	webAuthnFlagUserVerified           = 0x04
+	webAuthnFlagAttestedCredentialData = 0x40
+)
+
+// ctap2EncMode encodes the way authenticators do, so that the encoding is deterministic.
+var ctap2EncMode, _ = cbor.CTAP2EncOptions().EncMode()
+
+// SoftwareAuthenticator is a WebAuthn authenticator which keeps an ES256 key in memory, for testing the relying party.
+type SoftwareAuthenticator struct {
+	CredentialID []byte
+	Key          *ecdsa.PrivateKey
+	SignCount    uint32
+
+	// SkipUserVerification makes the authenticator respond as if the user was present but not verified.
+	SkipUserVerification bool
+}
+
+func NewSoftwareAuthenticator() (*SoftwareAuthenticator, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SoftwareAuthenticator{CredentialID: id, Key: key}, nil
+}
+
+// PublicKey returns the COSE_Key encoded public key.
+func (a *SoftwareAuthenticator) PublicKey() []byte {
+	b, err := ctap2EncMode.Marshal(map[int]interface{}{
+		1:  2,  // kty: EC2
+		3:  -7, // alg: ES256
+		-1: 1,  // crv: P-256
+		-2: a.Key.X.FillBytes(make([]byte, 32)),
+		-3: a.Key.Y.FillBytes(make([]byte, 32)),
+	})
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// Create responds to navigator.credentials.create() with the `none` attestation.
+func (a *SoftwareAuthenticator) Create(rpID, origin, challenge string) (clientDataJSON, attestationObject []byte) {
+	clientDataJSON = a.clientData("webauthn.create", origin, challenge)
+
+	authData := a.authenticatorData(rpID, webAuthnFlagAttestedCredentialData)
+	authData = append(authData, make([]byte, 16)...) // AAGUID
+	authData = append(authData, 0, 0)
+	binary.BigEndian.PutUint16(authData[len(authData)-2:], uint16(len(a.CredentialID)))
+	authData = append(authData, a.CredentialID...)
+	authData = append(authData, a.PublicKey()...)
+
+	attestationObject, err := ctap2EncMode.Marshal(map[string]interface{}{
+		"fmt":      "none",
+		"attStmt":  map[string]interface{}{},
+		"authData": authData,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return clientDataJSON, attestationObject
+}
+
+// Get responds to navigator.credentials.get(), incrementing the signature counter.
+func (a *SoftwareAuthenticator) Get(rpID, origin, challenge string) (clientDataJSON, authenticatorData, signature []byte) {
+	a.SignCount++
+
+	clientDataJSON = a.clientData("webauthn.get", origin, challenge)
+	authenticatorData = a.authenticatorData(rpID, 0)
+
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	digest := sha256.Sum256(append(append([]byte{}, authenticatorData...), clientDataHash[:]...))
+
+	signature, err := ecdsa.SignASN1(rand.Reader, a.Key, digest[:])
+	if err != nil {
+		panic(err)
+	}
+	return clientDataJSON, authenticatorData, signature
+}
+
+func (a *SoftwareAuthenticator) clientData(typ, origin, challenge string) []byte {
+	b, err := json.Marshal(map[string]interface{}{
+		"type":        typ,
+		"challenge":   challenge,
+		"origin":      origin,
+		"crossOrigin": false,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func (a *SoftwareAuthenticator) authenticatorData(rpID string, flags byte) []byte {
+	flags |= webAuthnFlagUserPresent
+	if !a.SkipUserVerification {
+		flags |= webAuthnFlagUserVerified
+	}
+
+	rpIDHash := sha256.Sum256([]byte(rpID))
+
+	b := append([]byte{}, rpIDHash[:]...)
+	b = append(b, flags, 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(b[len(b)-4:], a.SignCount)
+	return b
+}
+
+// EncodedCredentialID returns the credential ID as it is sent by browsers.
+func (a *SoftwareAuthenticator) EncodedCredentialID() string {
+	return base64.RawURLEncoding.EncodeToString(a.CredentialID)
+}
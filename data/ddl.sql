@@ -7,9 +7,9 @@ CREATE TABLE `user`
     `name`               varchar(10)  NOT NULL COMMENT '이름',     -- NOTE: may not be real name
     `email`              varchar(128) NOT NULL COMMENT '이메일',
     `is_email_confirmed` tinyint(1)   NOT NULL COMMENT '이메일 확인 여부',
-    `phone_number`       varchar(15)  NOT NULL COMMENT '핸드폰 번호', -- format: E.164 (e.g. +821012345678)
+    `phone_number`       varchar(16)  NOT NULL COMMENT '핸드폰 번호', -- format: E.164 (e.g. +821012345678)
     `nickname`           varchar(15)  NOT NULL COMMENT '닉네임',
-    `password_hash`      varchar(86)  NOT NULL COMMENT '비밀번호',   -- format: base64 encoded 32 bytes scrypt output w/ salt: 16 bytes, N: 32768, r: 8, p: 1, keyLen: 32
+    `password_hash`      varchar(255) NOT NULL COMMENT '비밀번호',   -- format: `scrypt$N$r$p$salt$dk`, `argon2id$v$m$t$p$salt$dk` or `bcrypt-sha256$2a$cost$salthash` (plain bcrypt before)
     `created_at`         timestamp    NOT NULL DEFAULT CURRENT_TIMESTAMP,
     `updated_at`         timestamp    NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
     PRIMARY KEY (`user_id`),
@@ -22,6 +22,22 @@ CREATE TABLE `user`
   COLLATE = utf8mb4_unicode_ci COMMENT ='유저';
 
 
+CREATE TABLE `password_history`
+(
+    `password_history_id` int          NOT NULL AUTO_INCREMENT COMMENT '비밀번호 이력 아이디',
+    `user_id`             int          NOT NULL COMMENT '유저 아이디',  -- user.user_id
+    `password_hash`       varchar(255) NOT NULL COMMENT '이전 비밀번호', -- user.password_hash replaced at created_at
+    `created_at`          timestamp    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at`          timestamp    NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`password_history_id`),
+    KEY `password_history_m1` (`created_at`),
+    KEY `password_history_m2` (`updated_at`),
+    KEY `password_history_m3` (`user_id`, `password_history_id`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci COMMENT ='비밀번호 이력';
+
+
 CREATE TABLE `jwt_audience_secret`
 (
     `jwt_audience_secret_id` int         NOT NULL AUTO_INCREMENT COMMENT 'JWT audience secret 아이디',
@@ -58,17 +74,294 @@ CREATE TABLE `sms_otp_verification`
 (
     `sms_otp_verification_id`  int AUTO_INCREMENT COMMENT 'SMS OTP 인증 아이디',
     `verification_token`       varchar(36) NOT NULL COMMENT '인증 토큰',  -- format: uuid v4
-    `phone_number`             varchar(15) NOT NULL COMMENT '핸드폰 번호', -- format: E.164 (e.g. +821012345678)
-    `otp_code`                 varchar(6)  NOT NULL COMMENT '인증 코드',
+    `phone_number`             varchar(16) NOT NULL COMMENT '핸드폰 번호', -- format: E.164 (e.g. +821012345678)
+    `ip_address`               varchar(45) NOT NULL COMMENT '요청 IP 주소', -- IPv4 or IPv6, empty if unknown
+    `purpose`                  varchar(20) NOT NULL COMMENT '인증 목적',    -- register, reset_password, change_phone, sign_in, unlock_account
+    `otp_code`                 varchar(6)           DEFAULT NULL COMMENT '인증 코드',      -- deprecated: plaintext, only for rows created before otp_code_hash
+    `otp_code_hash`            varchar(64)          DEFAULT NULL COMMENT '인증 코드 해시', -- format: hex encoded HMAC-SHA256 keyed with SMS_OTP_HMAC_SECRET
     `expires_at`               timestamp   NOT NULL COMMENT '만료 일시',
     `verification_trials`      int(11)     NOT NULL COMMENT '검증 시도 횟수',
     `verification_valid_until` timestamp            DEFAULT NULL COMMENT '검증 유효 일시',
+    `consumed_at`              timestamp            DEFAULT NULL COMMENT '사용 일시',
     `created_at`               timestamp   NOT NULL DEFAULT CURRENT_TIMESTAMP,
     `updated_at`               timestamp   NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
     PRIMARY KEY (`sms_otp_verification_id`),
     UNIQUE KEY `sms_otp_verification_u1` (`verification_token`),
     KEY `sms_otp_verification_m1` (`created_at`),
-    KEY `sms_otp_verification_m2` (`updated_at`)
+    KEY `sms_otp_verification_m2` (`updated_at`),
+    KEY `sms_otp_verification_m3` (`phone_number`, `created_at`),
+    KEY `sms_otp_verification_m4` (`ip_address`, `created_at`)
 ) ENGINE = InnoDB
   DEFAULT CHARSET = utf8mb4
   COLLATE = utf8mb4_unicode_ci COMMENT ='SMS OTP 인증';
+
+
+CREATE TABLE `sms_outbox`
+(
+    `sms_outbox_id`           int AUTO_INCREMENT COMMENT 'SMS 발송 아이디',
+    `sms_otp_verification_id` int                    DEFAULT NULL COMMENT 'SMS OTP 인증 아이디', -- set if the message is of an SMS OTP verification
+    `phone_number`            varchar(16)   NOT NULL COMMENT '수신 핸드폰 번호',                -- format: E.164 (e.g. +821012345678)
+    `message`                 varchar(1000) NOT NULL COMMENT '메시지',                       -- emptied once sent or given up, since it may contain an OTP code
+    `status`                  varchar(10)   NOT NULL COMMENT '발송 상태',                     -- pending, sent or failed
+    `attempts`                int(11)       NOT NULL COMMENT '발송 시도 횟수',
+    `next_attempt_at`         timestamp     NOT NULL COMMENT '다음 발송 시도 일시',
+    `expires_at`              timestamp     NOT NULL COMMENT '발송 만료 일시',                  -- given up if not sent by this
+    `sent_at`                 timestamp              DEFAULT NULL COMMENT '발송 일시',
+    `last_error`              varchar(255)           DEFAULT NULL COMMENT '마지막 발송 오류',
+    `created_at`              timestamp     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at`              timestamp     NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`sms_outbox_id`),
+    KEY `sms_outbox_m1` (`created_at`),
+    KEY `sms_outbox_m2` (`updated_at`),
+    KEY `sms_outbox_m3` (`status`, `next_attempt_at`),
+    KEY `sms_outbox_m4` (`sms_otp_verification_id`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci COMMENT ='SMS 발송 대기열';
+
+
+CREATE TABLE `sign_in_throttle`
+(
+    `sign_in_throttle_id` int AUTO_INCREMENT COMMENT '로그인 제한 아이디',
+    `scope`               varchar(10) NOT NULL COMMENT '제한 범위',          -- account or ip_address
+    `scope_key`           varchar(45) NOT NULL COMMENT '제한 대상',          -- user_id of the account, or IPv4 or IPv6
+    `failures`            int(11)     NOT NULL COMMENT '연속 로그인 실패 횟수', -- since the last success, unlock or lockout expiry
+    `last_failed_at`      timestamp   NOT NULL COMMENT '마지막 로그인 실패 일시',
+    `blocked_until`       timestamp            DEFAULT NULL COMMENT '로그인 제한 일시', -- sign-ins are rejected until this, by the delay or the lockout
+    `locked_at`           timestamp            DEFAULT NULL COMMENT '잠금 일시',       -- set if blocked by the lockout
+    `created_at`          timestamp   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at`          timestamp   NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`sign_in_throttle_id`),
+    UNIQUE KEY `sign_in_throttle_u1` (`scope`, `scope_key`),
+    KEY `sign_in_throttle_m1` (`created_at`),
+    KEY `sign_in_throttle_m2` (`updated_at`),
+    KEY `sign_in_throttle_m3` (`blocked_until`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci COMMENT ='로그인 실패 제한';
+
+
+CREATE TABLE `jwt_signing_key`
+(
+    `jwt_signing_key_id` int         NOT NULL AUTO_INCREMENT COMMENT 'JWT signing key 아이디',
+    `kid`                varchar(43) NOT NULL COMMENT 'kid(Key ID) header', -- format: base64url encoded JWK SHA-256 thumbprint
+    `algorithm`          varchar(5)  NOT NULL COMMENT '서명 알고리즘',          -- RS256, ES256 or EdDSA
+    `private_key`        text        NOT NULL COMMENT '개인 키',              -- format: PEM encoded PKCS #8
+    `activates_at`       timestamp   NOT NULL COMMENT '서명 시작 일시',
+    `retires_at`         timestamp            DEFAULT NULL COMMENT '폐기 일시',  -- tokens signed with this key are no longer accepted after this
+    `created_at`         timestamp   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at`         timestamp   NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`jwt_signing_key_id`),
+    UNIQUE KEY `jwt_signing_key_u1` (`kid`),
+    KEY `jwt_signing_key_m1` (`created_at`),
+    KEY `jwt_signing_key_m2` (`updated_at`),
+    KEY `jwt_signing_key_m3` (`activates_at`),
+    KEY `jwt_signing_key_m4` (`retires_at`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci COMMENT ='JWT signing key';
+
+
+CREATE TABLE `refresh_token_family`
+(
+    `refresh_token_family_id` int         NOT NULL AUTO_INCREMENT COMMENT 'refresh token family 아이디',
+    `family_id`               varchar(36) NOT NULL COMMENT 'fid(family ID) claim', -- format: uuid v4
+    `user_id`                 int         NOT NULL COMMENT '유저 아이디',             -- user.user_id
+    `revoked_at`              timestamp            DEFAULT NULL COMMENT '폐기 일시',
+    `created_at`              timestamp   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at`              timestamp   NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`refresh_token_family_id`),
+    UNIQUE KEY `refresh_token_family_u1` (`family_id`),
+    KEY `refresh_token_family_m1` (`created_at`),
+    KEY `refresh_token_family_m2` (`updated_at`),
+    KEY `refresh_token_family_m3` (`user_id`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci COMMENT ='refresh token family';
+
+
+CREATE TABLE `security_event`
+(
+    `security_event_id` int         NOT NULL AUTO_INCREMENT COMMENT '보안 이벤트 아이디',
+    `user_id`           int         NOT NULL COMMENT '유저 아이디', -- user.user_id
+    `event_type`        varchar(32) NOT NULL COMMENT '이벤트 종류', -- e.g. refresh_token_reuse
+    `detail`            text        NOT NULL COMMENT '상세',     -- format: JSON object
+    `created_at`        timestamp   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at`        timestamp   NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`security_event_id`),
+    KEY `security_event_m1` (`created_at`),
+    KEY `security_event_m2` (`updated_at`),
+    KEY `security_event_m3` (`user_id`, `created_at`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci COMMENT ='보안 이벤트';
+
+
+CREATE TABLE `user_session`
+(
+    `user_session_id` int          NOT NULL AUTO_INCREMENT COMMENT '유저 세션 아이디',
+    `family_id`       varchar(36)  NOT NULL COMMENT 'refresh token family 아이디', -- refresh_token_family.family_id
+    `user_id`         int          NOT NULL COMMENT '유저 아이디',                  -- user.user_id
+    `device_label`    varchar(64)  NOT NULL COMMENT '기기 이름',
+    `user_agent`      varchar(255) NOT NULL COMMENT 'User-Agent',
+    `ip_address`      varchar(45)  NOT NULL COMMENT 'IP 주소',                     -- IPv4 or IPv6
+    `last_used_at`    timestamp    NOT NULL COMMENT '마지막 사용 일시',
+    `created_at`      timestamp    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at`      timestamp    NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`user_session_id`),
+    UNIQUE KEY `user_session_u1` (`family_id`),
+    KEY `user_session_m1` (`created_at`),
+    KEY `user_session_m2` (`updated_at`),
+    KEY `user_session_m3` (`user_id`, `last_used_at`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci COMMENT ='유저 세션';
+
+
+CREATE TABLE `email_confirmation`
+(
+    `email_confirmation_id` int          NOT NULL AUTO_INCREMENT COMMENT '이메일 확인 아이디',
+    `user_id`               int          NOT NULL COMMENT '유저 아이디',  -- user.user_id
+    `email`                 varchar(128) NOT NULL COMMENT '이메일',
+    `code_hash`             varchar(64)  NOT NULL COMMENT '확인 코드 해시', -- format: hex encoded sha256
+    `expires_at`            timestamp    NOT NULL COMMENT '만료 일시',
+    `consumed_at`           timestamp             DEFAULT NULL COMMENT '사용 일시',
+    `created_at`            timestamp    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at`            timestamp    NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`email_confirmation_id`),
+    UNIQUE KEY `email_confirmation_u1` (`code_hash`),
+    KEY `email_confirmation_m1` (`created_at`),
+    KEY `email_confirmation_m2` (`updated_at`),
+    KEY `email_confirmation_m3` (`user_id`, `created_at`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci COMMENT ='이메일 확인';
+
+
+CREATE TABLE `user_totp`
+(
+    `user_totp_id`   int         NOT NULL AUTO_INCREMENT COMMENT '유저 TOTP 아이디',
+    `user_id`        int         NOT NULL COMMENT '유저 아이디',          -- user.user_id
+    `secret`         varchar(32) NOT NULL COMMENT 'TOTP secret',     -- format: base32 encoded 20 bytes w/o padding
+    `confirmed_at`   timestamp            DEFAULT NULL COMMENT '등록 확인 일시', -- not used for sign-in until confirmed
+    `last_used_step` bigint      NOT NULL COMMENT '마지막 사용 time step', -- codes of the step or before are rejected to prevent replay
+    `created_at`     timestamp   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at`     timestamp   NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`user_totp_id`),
+    UNIQUE KEY `user_totp_u1` (`user_id`),
+    KEY `user_totp_m1` (`created_at`),
+    KEY `user_totp_m2` (`updated_at`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci COMMENT ='유저 TOTP';
+
+
+CREATE TABLE `mfa_challenge`
+(
+    `mfa_challenge_id`    int         NOT NULL AUTO_INCREMENT COMMENT 'MFA challenge 아이디',
+    `challenge_token`     varchar(36) NOT NULL COMMENT 'challenge 토큰', -- format: uuid v4
+    `user_id`             int         NOT NULL COMMENT '유저 아이디',     -- user.user_id
+    `device_label`        varchar(64) NOT NULL COMMENT '기기 이름',      -- SignInRequest.device_label
+    `expires_at`          timestamp   NOT NULL COMMENT '만료 일시',
+    `verification_trials` int(11)     NOT NULL COMMENT '검증 시도 횟수',
+    `consumed_at`         timestamp            DEFAULT NULL COMMENT '사용 일시',
+    `created_at`          timestamp   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at`          timestamp   NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`mfa_challenge_id`),
+    UNIQUE KEY `mfa_challenge_u1` (`challenge_token`),
+    KEY `mfa_challenge_m1` (`created_at`),
+    KEY `mfa_challenge_m2` (`updated_at`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci COMMENT ='MFA challenge';
+
+
+CREATE TABLE `mfa_recovery_code`
+(
+    `mfa_recovery_code_id` int         NOT NULL AUTO_INCREMENT COMMENT 'MFA 복구 코드 아이디',
+    `user_id`              int         NOT NULL COMMENT '유저 아이디',  -- user.user_id
+    `code_hash`            varchar(64) NOT NULL COMMENT '복구 코드 해시', -- format: hex encoded sha256
+    `consumed_at`          timestamp            DEFAULT NULL COMMENT '사용 일시',
+    `created_at`           timestamp   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at`           timestamp   NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`mfa_recovery_code_id`),
+    UNIQUE KEY `mfa_recovery_code_u1` (`user_id`, `code_hash`),
+    KEY `mfa_recovery_code_m1` (`created_at`),
+    KEY `mfa_recovery_code_m2` (`updated_at`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci COMMENT ='MFA 복구 코드';
+
+
+CREATE TABLE `webauthn_credential`
+(
+    `webauthn_credential_id` int           NOT NULL AUTO_INCREMENT COMMENT 'WebAuthn 크리덴셜 아이디',
+    `user_id`                int           NOT NULL COMMENT '유저 아이디',     -- user.user_id
+    `credential_id`          varchar(255)  NOT NULL COMMENT '크리덴셜 아이디',  -- format: base64url w/o padding
+    `public_key`             varchar(1024) NOT NULL COMMENT '공개 키',       -- format: base64url encoded COSE_Key w/o padding
+    `sign_count`             bigint        NOT NULL COMMENT '서명 카운터',     -- 0 if the authenticator does not count, e.g. synced passkeys
+    `last_used_at`           timestamp              DEFAULT NULL COMMENT '마지막 사용 일시',
+    `created_at`             timestamp     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at`             timestamp     NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`webauthn_credential_id`),
+    UNIQUE KEY `webauthn_credential_u1` (`credential_id`),
+    KEY `webauthn_credential_m1` (`created_at`),
+    KEY `webauthn_credential_m2` (`updated_at`),
+    KEY `webauthn_credential_m3` (`user_id`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci COMMENT ='WebAuthn 크리덴셜';
+
+
+CREATE TABLE `webauthn_challenge`
+(
+    `webauthn_challenge_id` int         NOT NULL AUTO_INCREMENT COMMENT 'WebAuthn challenge 아이디',
+    `challenge`             varchar(64) NOT NULL COMMENT 'challenge', -- format: base64url encoded 32 bytes w/o padding
+    `ceremony`              varchar(16) NOT NULL COMMENT '종류',        -- registration or sign_in
+    `user_id`               int                  DEFAULT NULL COMMENT '유저 아이디', -- user.user_id, null for sign_in since the user is not known until the assertion
+    `device_label`          varchar(64) NOT NULL COMMENT '기기 이름',     -- BeginWebauthnSignInRequest.device_label
+    `ip_address`            varchar(45) NOT NULL COMMENT '요청 IP 주소',  -- IPv4 or IPv6, empty if unknown or for registration
+    `expires_at`            timestamp   NOT NULL COMMENT '만료 일시',
+    `consumed_at`           timestamp            DEFAULT NULL COMMENT '사용 일시',
+    `created_at`            timestamp   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at`            timestamp   NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`webauthn_challenge_id`),
+    UNIQUE KEY `webauthn_challenge_u1` (`challenge`),
+    KEY `webauthn_challenge_m1` (`created_at`),
+    KEY `webauthn_challenge_m2` (`updated_at`),
+    KEY `webauthn_challenge_m3` (`ip_address`, `expires_at`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci COMMENT ='WebAuthn challenge';
+
+CREATE TABLE `webauthn_user`
+(
+    `webauthn_user_id` int         NOT NULL AUTO_INCREMENT COMMENT 'WebAuthn 유저 아이디',
+    `user_id`          int         NOT NULL COMMENT '유저 아이디',  -- user.user_id
+    `user_handle`      varchar(88) NOT NULL COMMENT '유저 핸들',   -- format: base64url encoded random 32 bytes w/o padding
+    `created_at`       timestamp   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at`       timestamp   NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`webauthn_user_id`),
+    UNIQUE KEY `webauthn_user_u1` (`user_id`),
+    UNIQUE KEY `webauthn_user_u2` (`user_handle`),
+    KEY `webauthn_user_m1` (`created_at`),
+    KEY `webauthn_user_m2` (`updated_at`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci COMMENT ='WebAuthn 유저';
+
+
+CREATE TABLE `account_notice`
+(
+    `account_notice_id` int          NOT NULL AUTO_INCREMENT COMMENT '계정 알림 아이디',
+    `recipient`         varchar(255) NOT NULL COMMENT '수신자', -- user.email or E.164 phone number
+    `last_noticed_at`   timestamp    NOT NULL COMMENT '마지막 알림 일시',
+    `created_at`        timestamp    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at`        timestamp    NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`account_notice_id`),
+    UNIQUE KEY `account_notice_u1` (`recipient`),
+    KEY `account_notice_m1` (`created_at`),
+    KEY `account_notice_m2` (`updated_at`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci COMMENT ='계정 알림';
@@ -0,0 +1,16 @@
+-- Records when each recipient has last been noticed about an account, so that the notices sent at the unauthenticated
+-- requests cannot be used to flood the recipient.
+CREATE TABLE `account_notice`
+(
+    `account_notice_id` int          NOT NULL AUTO_INCREMENT COMMENT '계정 알림 아이디',
+    `recipient`         varchar(255) NOT NULL COMMENT '수신자', -- user.email or E.164 phone number
+    `last_noticed_at`   timestamp    NOT NULL COMMENT '마지막 알림 일시',
+    `created_at`        timestamp    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at`        timestamp    NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`account_notice_id`),
+    UNIQUE KEY `account_notice_u1` (`recipient`),
+    KEY `account_notice_m1` (`created_at`),
+    KEY `account_notice_m2` (`updated_at`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci COMMENT ='계정 알림';
@@ -0,0 +1,19 @@
+-- Keeps the hashed single-use codes confirming the emails of users.
+CREATE TABLE `email_confirmation`
+(
+    `email_confirmation_id` int          NOT NULL AUTO_INCREMENT COMMENT '이메일 확인 아이디',
+    `user_id`               int          NOT NULL COMMENT '유저 아이디',  -- user.user_id
+    `email`                 varchar(128) NOT NULL COMMENT '이메일',
+    `code_hash`             varchar(64)  NOT NULL COMMENT '확인 코드 해시', -- format: hex encoded sha256
+    `expires_at`            timestamp    NOT NULL COMMENT '만료 일시',
+    `consumed_at`           timestamp             DEFAULT NULL COMMENT '사용 일시',
+    `created_at`            timestamp    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at`            timestamp    NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`email_confirmation_id`),
+    UNIQUE KEY `email_confirmation_u1` (`code_hash`),
+    KEY `email_confirmation_m1` (`created_at`),
+    KEY `email_confirmation_m2` (`updated_at`),
+    KEY `email_confirmation_m3` (`user_id`, `created_at`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci COMMENT ='이메일 확인';
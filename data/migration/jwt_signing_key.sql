@@ -0,0 +1,16 @@
+-- Keeps the key pairs JWTs are signed with, which are published in the JWKS.
+CREATE TABLE `jwt_signing_key`
+(
+    `jwt_signing_key_id` int         NOT NULL AUTO_INCREMENT COMMENT 'JWT signing key 아이디',
+    `kid`                varchar(43) NOT NULL COMMENT 'kid(Key ID) header', -- format: base64url encoded JWK SHA-256 thumbprint
+    `algorithm`          varchar(5)  NOT NULL COMMENT '서명 알고리즘',          -- RS256, ES256 or EdDSA
+    `private_key`        text        NOT NULL COMMENT '개인 키',              -- format: PEM encoded PKCS #8
+    `created_at`         timestamp   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at`         timestamp   NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`jwt_signing_key_id`),
+    UNIQUE KEY `jwt_signing_key_u1` (`kid`),
+    KEY `jwt_signing_key_m1` (`created_at`),
+    KEY `jwt_signing_key_m2` (`updated_at`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci COMMENT ='JWT signing key';
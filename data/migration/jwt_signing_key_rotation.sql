@@ -0,0 +1,14 @@
+-- Schedules when each signing key starts signing tokens and stops being accepted, so that keys can be rotated.
+--
+-- Keys created before it are activated when they were created, and are never retired until the next rotation.
+ALTER TABLE `jwt_signing_key`
+    ADD COLUMN `activates_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '서명 시작 일시' AFTER `private_key`,
+    ADD COLUMN `retires_at` timestamp DEFAULT NULL COMMENT '폐기 일시' AFTER `activates_at`,
+    ADD KEY `jwt_signing_key_m3` (`activates_at`),
+    ADD KEY `jwt_signing_key_m4` (`retires_at`);
+
+UPDATE `jwt_signing_key`
+SET `activates_at` = `created_at`;
+
+ALTER TABLE `jwt_signing_key`
+    ALTER COLUMN `activates_at` DROP DEFAULT;
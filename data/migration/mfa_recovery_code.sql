@@ -0,0 +1,16 @@
+-- Keeps the hashed single-use MFA recovery codes of users.
+CREATE TABLE `mfa_recovery_code`
+(
+    `mfa_recovery_code_id` int         NOT NULL AUTO_INCREMENT COMMENT 'MFA 복구 코드 아이디',
+    `user_id`              int         NOT NULL COMMENT '유저 아이디',  -- user.user_id
+    `code_hash`            varchar(64) NOT NULL COMMENT '복구 코드 해시', -- format: hex encoded sha256
+    `consumed_at`          timestamp            DEFAULT NULL COMMENT '사용 일시',
+    `created_at`           timestamp   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at`           timestamp   NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`mfa_recovery_code_id`),
+    UNIQUE KEY `mfa_recovery_code_u1` (`user_id`, `code_hash`),
+    KEY `mfa_recovery_code_m1` (`created_at`),
+    KEY `mfa_recovery_code_m2` (`updated_at`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci COMMENT ='MFA 복구 코드';
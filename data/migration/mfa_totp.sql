@@ -0,0 +1,36 @@
+-- Keeps the TOTP secrets of users and the MFA challenges issued at sign-in.
+CREATE TABLE `user_totp`
+(
+    `user_totp_id`   int         NOT NULL AUTO_INCREMENT COMMENT '유저 TOTP 아이디',
+    `user_id`        int         NOT NULL COMMENT '유저 아이디',          -- user.user_id
+    `secret`         varchar(32) NOT NULL COMMENT 'TOTP secret',     -- format: base32 encoded 20 bytes w/o padding
+    `confirmed_at`   timestamp            DEFAULT NULL COMMENT '등록 확인 일시', -- not used for sign-in until confirmed
+    `last_used_step` bigint      NOT NULL COMMENT '마지막 사용 time step', -- codes of the step or before are rejected to prevent replay
+    `created_at`     timestamp   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at`     timestamp   NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`user_totp_id`),
+    UNIQUE KEY `user_totp_u1` (`user_id`),
+    KEY `user_totp_m1` (`created_at`),
+    KEY `user_totp_m2` (`updated_at`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci COMMENT ='유저 TOTP';
+
+CREATE TABLE `mfa_challenge`
+(
+    `mfa_challenge_id`    int         NOT NULL AUTO_INCREMENT COMMENT 'MFA challenge 아이디',
+    `challenge_token`     varchar(36) NOT NULL COMMENT 'challenge 토큰', -- format: uuid v4
+    `user_id`             int         NOT NULL COMMENT '유저 아이디',     -- user.user_id
+    `device_label`        varchar(64) NOT NULL COMMENT '기기 이름',      -- SignInRequest.device_label
+    `expires_at`          timestamp   NOT NULL COMMENT '만료 일시',
+    `verification_trials` int(11)     NOT NULL COMMENT '검증 시도 횟수',
+    `consumed_at`         timestamp            DEFAULT NULL COMMENT '사용 일시',
+    `created_at`          timestamp   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at`          timestamp   NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`mfa_challenge_id`),
+    UNIQUE KEY `mfa_challenge_u1` (`challenge_token`),
+    KEY `mfa_challenge_m1` (`created_at`),
+    KEY `mfa_challenge_m2` (`updated_at`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci COMMENT ='MFA challenge';
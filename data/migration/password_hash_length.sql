@@ -0,0 +1,6 @@
+-- Widens the password hash column to fit the self-describing hashes of every hasher, e.g. `argon2id$v$m$t$p$salt$dk`.
+--
+-- Run before deploying the version which writes them, i.e. rehashes passwords at sign-in or hashes with
+-- PASSWORD_HASH_ALGORITHM other than scrypt.
+ALTER TABLE `user`
+    MODIFY COLUMN `password_hash` varchar(255) NOT NULL COMMENT '비밀번호';
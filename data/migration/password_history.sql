@@ -0,0 +1,15 @@
+-- Keeps the password hashes users have replaced, to block their reuse.
+CREATE TABLE `password_history`
+(
+    `password_history_id` int          NOT NULL AUTO_INCREMENT COMMENT '비밀번호 이력 아이디',
+    `user_id`             int          NOT NULL COMMENT '유저 아이디',  -- user.user_id
+    `password_hash`       varchar(255) NOT NULL COMMENT '이전 비밀번호', -- user.password_hash replaced at created_at
+    `created_at`          timestamp    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at`          timestamp    NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`password_history_id`),
+    KEY `password_history_m1` (`created_at`),
+    KEY `password_history_m2` (`updated_at`),
+    KEY `password_history_m3` (`user_id`, `password_history_id`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci COMMENT ='비밀번호 이력';
@@ -0,0 +1,6 @@
+-- Widens the phone number columns to fit every E.164 number, which has up to 15 digits after the leading `+`.
+ALTER TABLE `user`
+    MODIFY COLUMN `phone_number` varchar(16) NOT NULL COMMENT '핸드폰 번호';
+
+ALTER TABLE `sms_otp_verification`
+    MODIFY COLUMN `phone_number` varchar(16) NOT NULL COMMENT '핸드폰 번호';
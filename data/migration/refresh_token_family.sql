@@ -0,0 +1,34 @@
+-- Tracks the families of rotated refresh tokens, so that a reused one revokes its family, and records the security
+-- events of it.
+CREATE TABLE `refresh_token_family`
+(
+    `refresh_token_family_id` int         NOT NULL AUTO_INCREMENT COMMENT 'refresh token family 아이디',
+    `family_id`               varchar(36) NOT NULL COMMENT 'fid(family ID) claim', -- format: uuid v4
+    `user_id`                 int         NOT NULL COMMENT '유저 아이디',             -- user.user_id
+    `revoked_at`              timestamp            DEFAULT NULL COMMENT '폐기 일시',
+    `created_at`              timestamp   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at`              timestamp   NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`refresh_token_family_id`),
+    UNIQUE KEY `refresh_token_family_u1` (`family_id`),
+    KEY `refresh_token_family_m1` (`created_at`),
+    KEY `refresh_token_family_m2` (`updated_at`),
+    KEY `refresh_token_family_m3` (`user_id`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci COMMENT ='refresh token family';
+
+CREATE TABLE `security_event`
+(
+    `security_event_id` int         NOT NULL AUTO_INCREMENT COMMENT '보안 이벤트 아이디',
+    `user_id`           int         NOT NULL COMMENT '유저 아이디', -- user.user_id
+    `event_type`        varchar(32) NOT NULL COMMENT '이벤트 종류', -- e.g. refresh_token_reuse
+    `detail`            text        NOT NULL COMMENT '상세',     -- format: JSON object
+    `created_at`        timestamp   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at`        timestamp   NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`security_event_id`),
+    KEY `security_event_m1` (`created_at`),
+    KEY `security_event_m2` (`updated_at`),
+    KEY `security_event_m3` (`user_id`, `created_at`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci COMMENT ='보안 이벤트';
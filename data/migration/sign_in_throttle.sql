@@ -0,0 +1,20 @@
+-- Keeps the consecutive failed sign-ins per account and IP address, which delay and lock out the next ones.
+CREATE TABLE `sign_in_throttle`
+(
+    `sign_in_throttle_id` int AUTO_INCREMENT COMMENT '로그인 제한 아이디',
+    `scope`               varchar(10) NOT NULL COMMENT '제한 범위',          -- account or ip_address
+    `scope_key`           varchar(45) NOT NULL COMMENT '제한 대상',          -- user_id of the account, or IPv4 or IPv6
+    `failures`            int(11)     NOT NULL COMMENT '연속 로그인 실패 횟수', -- since the last success, unlock or lockout expiry
+    `last_failed_at`      timestamp   NOT NULL COMMENT '마지막 로그인 실패 일시',
+    `blocked_until`       timestamp            DEFAULT NULL COMMENT '로그인 제한 일시', -- sign-ins are rejected until this, by the delay or the lockout
+    `locked_at`           timestamp            DEFAULT NULL COMMENT '잠금 일시',       -- set if blocked by the lockout
+    `created_at`          timestamp   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at`          timestamp   NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`sign_in_throttle_id`),
+    UNIQUE KEY `sign_in_throttle_u1` (`scope`, `scope_key`),
+    KEY `sign_in_throttle_m1` (`created_at`),
+    KEY `sign_in_throttle_m2` (`updated_at`),
+    KEY `sign_in_throttle_m3` (`blocked_until`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci COMMENT ='로그인 실패 제한';
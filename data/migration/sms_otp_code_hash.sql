@@ -0,0 +1,14 @@
+-- Stores SMS OTP codes as HMACs instead of plaintext.
+--
+-- 1. Run before deploying the version which writes otp_code_hash. Rows created before it keep otp_code, which is
+--    still accepted by VerifySmsOtp until the rows expire.
+ALTER TABLE `sms_otp_verification`
+    MODIFY COLUMN `otp_code` varchar(6) DEFAULT NULL COMMENT '인증 코드',
+    ADD COLUMN `otp_code_hash` varchar(64) DEFAULT NULL COMMENT '인증 코드 해시' AFTER `otp_code`;
+
+-- 2. Run once every row with otp_code has expired, i.e. a few minutes after the deployment, to erase the plaintext
+--    codes left in the table.
+UPDATE `sms_otp_verification`
+SET `otp_code` = NULL
+WHERE `otp_code` IS NOT NULL
+  AND `expires_at` < CURRENT_TIMESTAMP;
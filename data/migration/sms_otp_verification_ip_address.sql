@@ -0,0 +1,8 @@
+-- Records the client IP address of SMS OTP requests, and indexes the history the requests are rate limited by.
+ALTER TABLE `sms_otp_verification`
+    ADD COLUMN `ip_address` varchar(45) NOT NULL DEFAULT '' COMMENT '요청 IP 주소' AFTER `phone_number`,
+    ADD KEY `sms_otp_verification_m3` (`phone_number`, `created_at`),
+    ADD KEY `sms_otp_verification_m4` (`ip_address`, `created_at`);
+
+ALTER TABLE `sms_otp_verification`
+    ALTER COLUMN `ip_address` DROP DEFAULT;
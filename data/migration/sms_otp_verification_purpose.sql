@@ -0,0 +1,10 @@
+-- Binds verification tokens to the purpose they are requested for, and makes them single-use.
+--
+-- Rows created before it have an empty purpose, which no RPC accepts, so verifications in progress during the
+-- deployment have to be requested again.
+ALTER TABLE `sms_otp_verification`
+    ADD COLUMN `purpose` varchar(20) NOT NULL DEFAULT '' COMMENT '인증 목적' AFTER `ip_address`,
+    ADD COLUMN `consumed_at` timestamp DEFAULT NULL COMMENT '사용 일시' AFTER `verification_valid_until`;
+
+ALTER TABLE `sms_otp_verification`
+    ALTER COLUMN `purpose` DROP DEFAULT;
@@ -0,0 +1,23 @@
+-- Queues SMS messages in the transaction of what they are sent for, to be sent by the background worker.
+CREATE TABLE `sms_outbox`
+(
+    `sms_outbox_id`           int AUTO_INCREMENT COMMENT 'SMS 발송 아이디',
+    `sms_otp_verification_id` int                    DEFAULT NULL COMMENT 'SMS OTP 인증 아이디', -- set if the message is of an SMS OTP verification
+    `phone_number`            varchar(16)   NOT NULL COMMENT '수신 핸드폰 번호',                -- format: E.164 (e.g. +821012345678)
+    `message`                 varchar(1000) NOT NULL COMMENT '메시지',                       -- emptied once sent or given up, since it may contain an OTP code
+    `status`                  varchar(10)   NOT NULL COMMENT '발송 상태',                     -- pending, sent or failed
+    `attempts`                int(11)       NOT NULL COMMENT '발송 시도 횟수',
+    `next_attempt_at`         timestamp     NOT NULL COMMENT '다음 발송 시도 일시',
+    `expires_at`              timestamp     NOT NULL COMMENT '발송 만료 일시',                  -- given up if not sent by this
+    `sent_at`                 timestamp              DEFAULT NULL COMMENT '발송 일시',
+    `last_error`              varchar(255)           DEFAULT NULL COMMENT '마지막 발송 오류',
+    `created_at`              timestamp     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at`              timestamp     NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`sms_outbox_id`),
+    KEY `sms_outbox_m1` (`created_at`),
+    KEY `sms_outbox_m2` (`updated_at`),
+    KEY `sms_outbox_m3` (`status`, `next_attempt_at`),
+    KEY `sms_outbox_m4` (`sms_otp_verification_id`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci COMMENT ='SMS 발송 대기열';
@@ -0,0 +1,20 @@
+-- Keeps the sign-in sessions of users, one per refresh token family, to list and revoke them.
+CREATE TABLE `user_session`
+(
+    `user_session_id` int          NOT NULL AUTO_INCREMENT COMMENT '유저 세션 아이디',
+    `family_id`       varchar(36)  NOT NULL COMMENT 'refresh token family 아이디', -- refresh_token_family.family_id
+    `user_id`         int          NOT NULL COMMENT '유저 아이디',                  -- user.user_id
+    `device_label`    varchar(64)  NOT NULL COMMENT '기기 이름',
+    `user_agent`      varchar(255) NOT NULL COMMENT 'User-Agent',
+    `ip_address`      varchar(45)  NOT NULL COMMENT 'IP 주소',                     -- IPv4 or IPv6
+    `last_used_at`    timestamp    NOT NULL COMMENT '마지막 사용 일시',
+    `created_at`      timestamp    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at`      timestamp    NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`user_session_id`),
+    UNIQUE KEY `user_session_u1` (`family_id`),
+    KEY `user_session_m1` (`created_at`),
+    KEY `user_session_m2` (`updated_at`),
+    KEY `user_session_m3` (`user_id`, `last_used_at`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci COMMENT ='유저 세션';
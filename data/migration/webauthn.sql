@@ -0,0 +1,38 @@
+-- Keeps the WebAuthn credentials of users and the challenges of the registration and sign-in ceremonies.
+CREATE TABLE `webauthn_credential`
+(
+    `webauthn_credential_id` int           NOT NULL AUTO_INCREMENT COMMENT 'WebAuthn 크리덴셜 아이디',
+    `user_id`                int           NOT NULL COMMENT '유저 아이디',     -- user.user_id
+    `credential_id`          varchar(255)  NOT NULL COMMENT '크리덴셜 아이디',  -- format: base64url w/o padding
+    `public_key`             varchar(1024) NOT NULL COMMENT '공개 키',       -- format: base64url encoded COSE_Key w/o padding
+    `sign_count`             bigint        NOT NULL COMMENT '서명 카운터',     -- 0 if the authenticator does not count, e.g. synced passkeys
+    `last_used_at`           timestamp              DEFAULT NULL COMMENT '마지막 사용 일시',
+    `created_at`             timestamp     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at`             timestamp     NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`webauthn_credential_id`),
+    UNIQUE KEY `webauthn_credential_u1` (`credential_id`),
+    KEY `webauthn_credential_m1` (`created_at`),
+    KEY `webauthn_credential_m2` (`updated_at`),
+    KEY `webauthn_credential_m3` (`user_id`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci COMMENT ='WebAuthn 크리덴셜';
+
+CREATE TABLE `webauthn_challenge`
+(
+    `webauthn_challenge_id` int         NOT NULL AUTO_INCREMENT COMMENT 'WebAuthn challenge 아이디',
+    `challenge`             varchar(64) NOT NULL COMMENT 'challenge', -- format: base64url encoded 32 bytes w/o padding
+    `ceremony`              varchar(16) NOT NULL COMMENT '종류',        -- registration or sign_in
+    `user_id`               int                  DEFAULT NULL COMMENT '유저 아이디', -- user.user_id, null for sign_in since the user is not known until the assertion
+    `device_label`          varchar(64) NOT NULL COMMENT '기기 이름',     -- BeginWebauthnSignInRequest.device_label
+    `expires_at`            timestamp   NOT NULL COMMENT '만료 일시',
+    `consumed_at`           timestamp            DEFAULT NULL COMMENT '사용 일시',
+    `created_at`            timestamp   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at`            timestamp   NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`webauthn_challenge_id`),
+    UNIQUE KEY `webauthn_challenge_u1` (`challenge`),
+    KEY `webauthn_challenge_m1` (`created_at`),
+    KEY `webauthn_challenge_m2` (`updated_at`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci COMMENT ='WebAuthn challenge';
@@ -0,0 +1,32 @@
+-- Identifies the users to authenticators with a random user handle instead of the user ID, and records the client IP
+-- address of WebAuthn challenges, which the pending sign-in challenges are limited by.
+--
+-- The credentials registered before keep the user handle derived from the user ID, which is base64url encoded decimal
+-- user ID without padding, since the authenticators have stored it.
+CREATE TABLE `webauthn_user`
+(
+    `webauthn_user_id` int         NOT NULL AUTO_INCREMENT COMMENT 'WebAuthn 유저 아이디',
+    `user_id`          int         NOT NULL COMMENT '유저 아이디',  -- user.user_id
+    `user_handle`      varchar(88) NOT NULL COMMENT '유저 핸들',   -- format: base64url encoded random 32 bytes w/o padding
+    `created_at`       timestamp   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at`       timestamp   NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`webauthn_user_id`),
+    UNIQUE KEY `webauthn_user_u1` (`user_id`),
+    UNIQUE KEY `webauthn_user_u2` (`user_handle`),
+    KEY `webauthn_user_m1` (`created_at`),
+    KEY `webauthn_user_m2` (`updated_at`)
+) ENGINE = InnoDB
+  DEFAULT CHARSET = utf8mb4
+  COLLATE = utf8mb4_unicode_ci COMMENT ='WebAuthn 유저';
+
+INSERT INTO `webauthn_user` (`user_id`, `user_handle`)
+SELECT DISTINCT `user_id`,
+                REPLACE(REPLACE(TRIM(TRAILING '=' FROM TO_BASE64(CAST(`user_id` AS CHAR))), '+', '-'), '/', '_')
+FROM `webauthn_credential`;
+
+ALTER TABLE `webauthn_challenge`
+    ADD COLUMN `ip_address` varchar(45) NOT NULL DEFAULT '' COMMENT '요청 IP 주소' AFTER `device_label`,
+    ADD KEY `webauthn_challenge_m3` (`ip_address`, `expires_at`);
+
+ALTER TABLE `webauthn_challenge`
+    ALTER COLUMN `ip_address` DROP DEFAULT;
@@ -22,20 +22,55 @@ type UserServiceClient interface {
 	RequestSmsOtp(ctx context.Context, in *RequestSmsOtpRequest, opts ...grpc.CallOption) (*RequestSmsOtpResponse, error)
 	// SMS OTP 검증
 	VerifySmsOtp(ctx context.Context, in *VerifySmsOtpRequest, opts ...grpc.CallOption) (*VerifySmsOtpResponse, error)
+	// SMS OTP 발송 상태 조회
+	GetSmsOtpDeliveryStatus(ctx context.Context, in *GetSmsOtpDeliveryStatusRequest, opts ...grpc.CallOption) (*GetSmsOtpDeliveryStatusResponse, error)
 	// 이메일 검증
 	ConfirmEmail(ctx context.Context, in *ConfirmEmailRequest, opts ...grpc.CallOption) (*ConfirmEmailResponse, error)
+	// 이메일 확인 메일 재전송
+	ResendConfirmationEmail(ctx context.Context, in *ResendConfirmationEmailRequest, opts ...grpc.CallOption) (*ResendConfirmationEmailResponse, error)
 	// 회원가입
 	Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*RegisterResponse, error)
 	// 로그인
 	SignIn(ctx context.Context, in *SignInRequest, opts ...grpc.CallOption) (*SignInResponse, error)
+	// 로그인 2단계 인증
+	CompleteMfaChallenge(ctx context.Context, in *CompleteMfaChallengeRequest, opts ...grpc.CallOption) (*CompleteMfaChallengeResponse, error)
+	// 패스키 로그인 시작
+	BeginWebauthnSignIn(ctx context.Context, in *BeginWebauthnSignInRequest, opts ...grpc.CallOption) (*BeginWebauthnSignInResponse, error)
+	// 패스키 로그인 완료
+	FinishWebauthnSignIn(ctx context.Context, in *FinishWebauthnSignInRequest, opts ...grpc.CallOption) (*FinishWebauthnSignInResponse, error)
 	// 로그아웃
 	SignOut(ctx context.Context, in *SignOutRequest, opts ...grpc.CallOption) (*SignOutResponse, error)
 	// 토큰 갱신
 	RefreshToken(ctx context.Context, in *RefreshTokenRequest, opts ...grpc.CallOption) (*RefreshTokenResponse, error)
+	// 토큰 검사
+	// See https://datatracker.ietf.org/doc/html/rfc7662
+	// requires `Authorization: Basic <base64 of client_id:secret>` of a resource
+	// server in INTROSPECTION_CLIENTS.
+	IntrospectToken(ctx context.Context, in *IntrospectTokenRequest, opts ...grpc.CallOption) (*IntrospectTokenResponse, error)
 	// 비밀번호 재설정
 	ResetPassword(ctx context.Context, in *ResetPasswordRequest, opts ...grpc.CallOption) (*ResetPasswordResponse, error)
+	// 로그인 잠금 해제
+	UnlockAccount(ctx context.Context, in *UnlockAccountRequest, opts ...grpc.CallOption) (*UnlockAccountResponse, error)
 	// 내 정보 조회
 	GetMyPersonalInfo(ctx context.Context, in *GetMyPersonalInfoRequest, opts ...grpc.CallOption) (*GetMyPersonalInfoResponse, error)
+	// TOTP 등록 시작
+	BeginTotpEnrollment(ctx context.Context, in *BeginTotpEnrollmentRequest, opts ...grpc.CallOption) (*BeginTotpEnrollmentResponse, error)
+	// TOTP 등록 확인
+	ConfirmTotpEnrollment(ctx context.Context, in *ConfirmTotpEnrollmentRequest, opts ...grpc.CallOption) (*ConfirmTotpEnrollmentResponse, error)
+	// MFA 복구 코드 재발급
+	RegenerateRecoveryCodes(ctx context.Context, in *RegenerateRecoveryCodesRequest, opts ...grpc.CallOption) (*RegenerateRecoveryCodesResponse, error)
+	// 패스키 등록 시작
+	BeginWebauthnRegistration(ctx context.Context, in *BeginWebauthnRegistrationRequest, opts ...grpc.CallOption) (*BeginWebauthnRegistrationResponse, error)
+	// 패스키 등록 완료
+	FinishWebauthnRegistration(ctx context.Context, in *FinishWebauthnRegistrationRequest, opts ...grpc.CallOption) (*FinishWebauthnRegistrationResponse, error)
+	// 내 세션 목록 조회
+	ListMySessions(ctx context.Context, in *ListMySessionsRequest, opts ...grpc.CallOption) (*ListMySessionsResponse, error)
+	// 세션 종료
+	RevokeSession(ctx context.Context, in *RevokeSessionRequest, opts ...grpc.CallOption) (*RevokeSessionResponse, error)
+	// 현재 세션을 제외한 모든 세션 종료
+	RevokeOtherSessions(ctx context.Context, in *RevokeOtherSessionsRequest, opts ...grpc.CallOption) (*RevokeOtherSessionsResponse, error)
+	// 비밀번호 변경
+	ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*ChangePasswordResponse, error)
 }
 
 type userServiceClient struct {
@@ -64,6 +99,15 @@ func (c *userServiceClient) VerifySmsOtp(ctx context.Context, in *VerifySmsOtpRe
 	return out, nil
 }
 
+func (c *userServiceClient) GetSmsOtpDeliveryStatus(ctx context.Context, in *GetSmsOtpDeliveryStatusRequest, opts ...grpc.CallOption) (*GetSmsOtpDeliveryStatusResponse, error) {
+	out := new(GetSmsOtpDeliveryStatusResponse)
+	err := c.cc.Invoke(ctx, "/user.v1.UserService/GetSmsOtpDeliveryStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userServiceClient) ConfirmEmail(ctx context.Context, in *ConfirmEmailRequest, opts ...grpc.CallOption) (*ConfirmEmailResponse, error) {
 	out := new(ConfirmEmailResponse)
 	err := c.cc.Invoke(ctx, "/user.v1.UserService/ConfirmEmail", in, out, opts...)
@@ -73,6 +117,15 @@ func (c *userServiceClient) ConfirmEmail(ctx context.Context, in *ConfirmEmailRe
 	return out, nil
 }
 
+func (c *userServiceClient) ResendConfirmationEmail(ctx context.Context, in *ResendConfirmationEmailRequest, opts ...grpc.CallOption) (*ResendConfirmationEmailResponse, error) {
+	out := new(ResendConfirmationEmailResponse)
+	err := c.cc.Invoke(ctx, "/user.v1.UserService/ResendConfirmationEmail", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userServiceClient) Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*RegisterResponse, error) {
 	out := new(RegisterResponse)
 	err := c.cc.Invoke(ctx, "/user.v1.UserService/Register", in, out, opts...)
@@ -91,6 +144,33 @@ func (c *userServiceClient) SignIn(ctx context.Context, in *SignInRequest, opts
 	return out, nil
 }
 
+func (c *userServiceClient) CompleteMfaChallenge(ctx context.Context, in *CompleteMfaChallengeRequest, opts ...grpc.CallOption) (*CompleteMfaChallengeResponse, error) {
+	out := new(CompleteMfaChallengeResponse)
+	err := c.cc.Invoke(ctx, "/user.v1.UserService/CompleteMfaChallenge", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) BeginWebauthnSignIn(ctx context.Context, in *BeginWebauthnSignInRequest, opts ...grpc.CallOption) (*BeginWebauthnSignInResponse, error) {
+	out := new(BeginWebauthnSignInResponse)
+	err := c.cc.Invoke(ctx, "/user.v1.UserService/BeginWebauthnSignIn", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) FinishWebauthnSignIn(ctx context.Context, in *FinishWebauthnSignInRequest, opts ...grpc.CallOption) (*FinishWebauthnSignInResponse, error) {
+	out := new(FinishWebauthnSignInResponse)
+	err := c.cc.Invoke(ctx, "/user.v1.UserService/FinishWebauthnSignIn", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userServiceClient) SignOut(ctx context.Context, in *SignOutRequest, opts ...grpc.CallOption) (*SignOutResponse, error) {
 	out := new(SignOutResponse)
 	err := c.cc.Invoke(ctx, "/user.v1.UserService/SignOut", in, out, opts...)
@@ -109,6 +189,15 @@ func (c *userServiceClient) RefreshToken(ctx context.Context, in *RefreshTokenRe
 	return out, nil
 }
 
+func (c *userServiceClient) IntrospectToken(ctx context.Context, in *IntrospectTokenRequest, opts ...grpc.CallOption) (*IntrospectTokenResponse, error) {
+	out := new(IntrospectTokenResponse)
+	err := c.cc.Invoke(ctx, "/user.v1.UserService/IntrospectToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userServiceClient) ResetPassword(ctx context.Context, in *ResetPasswordRequest, opts ...grpc.CallOption) (*ResetPasswordResponse, error) {
 	out := new(ResetPasswordResponse)
 	err := c.cc.Invoke(ctx, "/user.v1.UserService/ResetPassword", in, out, opts...)
@@ -118,6 +207,15 @@ func (c *userServiceClient) ResetPassword(ctx context.Context, in *ResetPassword
 	return out, nil
 }
 
+func (c *userServiceClient) UnlockAccount(ctx context.Context, in *UnlockAccountRequest, opts ...grpc.CallOption) (*UnlockAccountResponse, error) {
+	out := new(UnlockAccountResponse)
+	err := c.cc.Invoke(ctx, "/user.v1.UserService/UnlockAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userServiceClient) GetMyPersonalInfo(ctx context.Context, in *GetMyPersonalInfoRequest, opts ...grpc.CallOption) (*GetMyPersonalInfoResponse, error) {
 	out := new(GetMyPersonalInfoResponse)
 	err := c.cc.Invoke(ctx, "/user.v1.UserService/GetMyPersonalInfo", in, out, opts...)
@@ -127,6 +225,87 @@ func (c *userServiceClient) GetMyPersonalInfo(ctx context.Context, in *GetMyPers
 	return out, nil
 }
 
+func (c *userServiceClient) BeginTotpEnrollment(ctx context.Context, in *BeginTotpEnrollmentRequest, opts ...grpc.CallOption) (*BeginTotpEnrollmentResponse, error) {
+	out := new(BeginTotpEnrollmentResponse)
+	err := c.cc.Invoke(ctx, "/user.v1.UserService/BeginTotpEnrollment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ConfirmTotpEnrollment(ctx context.Context, in *ConfirmTotpEnrollmentRequest, opts ...grpc.CallOption) (*ConfirmTotpEnrollmentResponse, error) {
+	out := new(ConfirmTotpEnrollmentResponse)
+	err := c.cc.Invoke(ctx, "/user.v1.UserService/ConfirmTotpEnrollment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) RegenerateRecoveryCodes(ctx context.Context, in *RegenerateRecoveryCodesRequest, opts ...grpc.CallOption) (*RegenerateRecoveryCodesResponse, error) {
+	out := new(RegenerateRecoveryCodesResponse)
+	err := c.cc.Invoke(ctx, "/user.v1.UserService/RegenerateRecoveryCodes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) BeginWebauthnRegistration(ctx context.Context, in *BeginWebauthnRegistrationRequest, opts ...grpc.CallOption) (*BeginWebauthnRegistrationResponse, error) {
+	out := new(BeginWebauthnRegistrationResponse)
+	err := c.cc.Invoke(ctx, "/user.v1.UserService/BeginWebauthnRegistration", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) FinishWebauthnRegistration(ctx context.Context, in *FinishWebauthnRegistrationRequest, opts ...grpc.CallOption) (*FinishWebauthnRegistrationResponse, error) {
+	out := new(FinishWebauthnRegistrationResponse)
+	err := c.cc.Invoke(ctx, "/user.v1.UserService/FinishWebauthnRegistration", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListMySessions(ctx context.Context, in *ListMySessionsRequest, opts ...grpc.CallOption) (*ListMySessionsResponse, error) {
+	out := new(ListMySessionsResponse)
+	err := c.cc.Invoke(ctx, "/user.v1.UserService/ListMySessions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) RevokeSession(ctx context.Context, in *RevokeSessionRequest, opts ...grpc.CallOption) (*RevokeSessionResponse, error) {
+	out := new(RevokeSessionResponse)
+	err := c.cc.Invoke(ctx, "/user.v1.UserService/RevokeSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) RevokeOtherSessions(ctx context.Context, in *RevokeOtherSessionsRequest, opts ...grpc.CallOption) (*RevokeOtherSessionsResponse, error) {
+	out := new(RevokeOtherSessionsResponse)
+	err := c.cc.Invoke(ctx, "/user.v1.UserService/RevokeOtherSessions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*ChangePasswordResponse, error) {
+	out := new(ChangePasswordResponse)
+	err := c.cc.Invoke(ctx, "/user.v1.UserService/ChangePassword", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UserServiceServer is the server API for UserService service.
 // All implementations should embed UnimplementedUserServiceServer
 // for forward compatibility
@@ -135,20 +314,55 @@ type UserServiceServer interface {
 	RequestSmsOtp(context.Context, *RequestSmsOtpRequest) (*RequestSmsOtpResponse, error)
 	// SMS OTP 검증
 	VerifySmsOtp(context.Context, *VerifySmsOtpRequest) (*VerifySmsOtpResponse, error)
+	// SMS OTP 발송 상태 조회
+	GetSmsOtpDeliveryStatus(context.Context, *GetSmsOtpDeliveryStatusRequest) (*GetSmsOtpDeliveryStatusResponse, error)
 	// 이메일 검증
 	ConfirmEmail(context.Context, *ConfirmEmailRequest) (*ConfirmEmailResponse, error)
+	// 이메일 확인 메일 재전송
+	ResendConfirmationEmail(context.Context, *ResendConfirmationEmailRequest) (*ResendConfirmationEmailResponse, error)
 	// 회원가입
 	Register(context.Context, *RegisterRequest) (*RegisterResponse, error)
 	// 로그인
 	SignIn(context.Context, *SignInRequest) (*SignInResponse, error)
+	// 로그인 2단계 인증
+	CompleteMfaChallenge(context.Context, *CompleteMfaChallengeRequest) (*CompleteMfaChallengeResponse, error)
+	// 패스키 로그인 시작
+	BeginWebauthnSignIn(context.Context, *BeginWebauthnSignInRequest) (*BeginWebauthnSignInResponse, error)
+	// 패스키 로그인 완료
+	FinishWebauthnSignIn(context.Context, *FinishWebauthnSignInRequest) (*FinishWebauthnSignInResponse, error)
 	// 로그아웃
 	SignOut(context.Context, *SignOutRequest) (*SignOutResponse, error)
 	// 토큰 갱신
 	RefreshToken(context.Context, *RefreshTokenRequest) (*RefreshTokenResponse, error)
+	// 토큰 검사
+	// See https://datatracker.ietf.org/doc/html/rfc7662
+	// requires `Authorization: Basic <base64 of client_id:secret>` of a resource
+	// server in INTROSPECTION_CLIENTS.
+	IntrospectToken(context.Context, *IntrospectTokenRequest) (*IntrospectTokenResponse, error)
 	// 비밀번호 재설정
 	ResetPassword(context.Context, *ResetPasswordRequest) (*ResetPasswordResponse, error)
+	// 로그인 잠금 해제
+	UnlockAccount(context.Context, *UnlockAccountRequest) (*UnlockAccountResponse, error)
 	// 내 정보 조회
 	GetMyPersonalInfo(context.Context, *GetMyPersonalInfoRequest) (*GetMyPersonalInfoResponse, error)
+	// TOTP 등록 시작
+	BeginTotpEnrollment(context.Context, *BeginTotpEnrollmentRequest) (*BeginTotpEnrollmentResponse, error)
+	// TOTP 등록 확인
+	ConfirmTotpEnrollment(context.Context, *ConfirmTotpEnrollmentRequest) (*ConfirmTotpEnrollmentResponse, error)
+	// MFA 복구 코드 재발급
+	RegenerateRecoveryCodes(context.Context, *RegenerateRecoveryCodesRequest) (*RegenerateRecoveryCodesResponse, error)
+	// 패스키 등록 시작
+	BeginWebauthnRegistration(context.Context, *BeginWebauthnRegistrationRequest) (*BeginWebauthnRegistrationResponse, error)
+	// 패스키 등록 완료
+	FinishWebauthnRegistration(context.Context, *FinishWebauthnRegistrationRequest) (*FinishWebauthnRegistrationResponse, error)
+	// 내 세션 목록 조회
+	ListMySessions(context.Context, *ListMySessionsRequest) (*ListMySessionsResponse, error)
+	// 세션 종료
+	RevokeSession(context.Context, *RevokeSessionRequest) (*RevokeSessionResponse, error)
+	// 현재 세션을 제외한 모든 세션 종료
+	RevokeOtherSessions(context.Context, *RevokeOtherSessionsRequest) (*RevokeOtherSessionsResponse, error)
+	// 비밀번호 변경
+	ChangePassword(context.Context, *ChangePasswordRequest) (*ChangePasswordResponse, error)
 }
 
 // UnimplementedUserServiceServer should be embedded to have forward compatible implementations.
@@ -161,27 +375,75 @@ func (UnimplementedUserServiceServer) RequestSmsOtp(context.Context, *RequestSms
 func (UnimplementedUserServiceServer) VerifySmsOtp(context.Context, *VerifySmsOtpRequest) (*VerifySmsOtpResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method VerifySmsOtp not implemented")
 }
+func (UnimplementedUserServiceServer) GetSmsOtpDeliveryStatus(context.Context, *GetSmsOtpDeliveryStatusRequest) (*GetSmsOtpDeliveryStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSmsOtpDeliveryStatus not implemented")
+}
 func (UnimplementedUserServiceServer) ConfirmEmail(context.Context, *ConfirmEmailRequest) (*ConfirmEmailResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ConfirmEmail not implemented")
 }
+func (UnimplementedUserServiceServer) ResendConfirmationEmail(context.Context, *ResendConfirmationEmailRequest) (*ResendConfirmationEmailResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResendConfirmationEmail not implemented")
+}
 func (UnimplementedUserServiceServer) Register(context.Context, *RegisterRequest) (*RegisterResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Register not implemented")
 }
 func (UnimplementedUserServiceServer) SignIn(context.Context, *SignInRequest) (*SignInResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SignIn not implemented")
 }
+func (UnimplementedUserServiceServer) CompleteMfaChallenge(context.Context, *CompleteMfaChallengeRequest) (*CompleteMfaChallengeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompleteMfaChallenge not implemented")
+}
+func (UnimplementedUserServiceServer) BeginWebauthnSignIn(context.Context, *BeginWebauthnSignInRequest) (*BeginWebauthnSignInResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BeginWebauthnSignIn not implemented")
+}
+func (UnimplementedUserServiceServer) FinishWebauthnSignIn(context.Context, *FinishWebauthnSignInRequest) (*FinishWebauthnSignInResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FinishWebauthnSignIn not implemented")
+}
 func (UnimplementedUserServiceServer) SignOut(context.Context, *SignOutRequest) (*SignOutResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SignOut not implemented")
 }
 func (UnimplementedUserServiceServer) RefreshToken(context.Context, *RefreshTokenRequest) (*RefreshTokenResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RefreshToken not implemented")
 }
+func (UnimplementedUserServiceServer) IntrospectToken(context.Context, *IntrospectTokenRequest) (*IntrospectTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IntrospectToken not implemented")
+}
 func (UnimplementedUserServiceServer) ResetPassword(context.Context, *ResetPasswordRequest) (*ResetPasswordResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ResetPassword not implemented")
 }
+func (UnimplementedUserServiceServer) UnlockAccount(context.Context, *UnlockAccountRequest) (*UnlockAccountResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnlockAccount not implemented")
+}
 func (UnimplementedUserServiceServer) GetMyPersonalInfo(context.Context, *GetMyPersonalInfoRequest) (*GetMyPersonalInfoResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetMyPersonalInfo not implemented")
 }
+func (UnimplementedUserServiceServer) BeginTotpEnrollment(context.Context, *BeginTotpEnrollmentRequest) (*BeginTotpEnrollmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BeginTotpEnrollment not implemented")
+}
+func (UnimplementedUserServiceServer) ConfirmTotpEnrollment(context.Context, *ConfirmTotpEnrollmentRequest) (*ConfirmTotpEnrollmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmTotpEnrollment not implemented")
+}
+func (UnimplementedUserServiceServer) RegenerateRecoveryCodes(context.Context, *RegenerateRecoveryCodesRequest) (*RegenerateRecoveryCodesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegenerateRecoveryCodes not implemented")
+}
+func (UnimplementedUserServiceServer) BeginWebauthnRegistration(context.Context, *BeginWebauthnRegistrationRequest) (*BeginWebauthnRegistrationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BeginWebauthnRegistration not implemented")
+}
+func (UnimplementedUserServiceServer) FinishWebauthnRegistration(context.Context, *FinishWebauthnRegistrationRequest) (*FinishWebauthnRegistrationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FinishWebauthnRegistration not implemented")
+}
+func (UnimplementedUserServiceServer) ListMySessions(context.Context, *ListMySessionsRequest) (*ListMySessionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMySessions not implemented")
+}
+func (UnimplementedUserServiceServer) RevokeSession(context.Context, *RevokeSessionRequest) (*RevokeSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeSession not implemented")
+}
+func (UnimplementedUserServiceServer) RevokeOtherSessions(context.Context, *RevokeOtherSessionsRequest) (*RevokeOtherSessionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeOtherSessions not implemented")
+}
+func (UnimplementedUserServiceServer) ChangePassword(context.Context, *ChangePasswordRequest) (*ChangePasswordResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangePassword not implemented")
+}
 
 // UnsafeUserServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to UserServiceServer will
@@ -230,6 +492,24 @@ func _UserService_VerifySmsOtp_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_GetSmsOtpDeliveryStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSmsOtpDeliveryStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetSmsOtpDeliveryStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.v1.UserService/GetSmsOtpDeliveryStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetSmsOtpDeliveryStatus(ctx, req.(*GetSmsOtpDeliveryStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _UserService_ConfirmEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ConfirmEmailRequest)
 	if err := dec(in); err != nil {
@@ -248,6 +528,24 @@ func _UserService_ConfirmEmail_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_ResendConfirmationEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResendConfirmationEmailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ResendConfirmationEmail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.v1.UserService/ResendConfirmationEmail",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ResendConfirmationEmail(ctx, req.(*ResendConfirmationEmailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _UserService_Register_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(RegisterRequest)
 	if err := dec(in); err != nil {
@@ -284,6 +582,60 @@ func _UserService_SignIn_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_CompleteMfaChallenge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompleteMfaChallengeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).CompleteMfaChallenge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.v1.UserService/CompleteMfaChallenge",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).CompleteMfaChallenge(ctx, req.(*CompleteMfaChallengeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_BeginWebauthnSignIn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BeginWebauthnSignInRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).BeginWebauthnSignIn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.v1.UserService/BeginWebauthnSignIn",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).BeginWebauthnSignIn(ctx, req.(*BeginWebauthnSignInRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_FinishWebauthnSignIn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FinishWebauthnSignInRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).FinishWebauthnSignIn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.v1.UserService/FinishWebauthnSignIn",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).FinishWebauthnSignIn(ctx, req.(*FinishWebauthnSignInRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _UserService_SignOut_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SignOutRequest)
 	if err := dec(in); err != nil {
@@ -320,6 +672,24 @@ func _UserService_RefreshToken_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_IntrospectToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IntrospectTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).IntrospectToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.v1.UserService/IntrospectToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).IntrospectToken(ctx, req.(*IntrospectTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _UserService_ResetPassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ResetPasswordRequest)
 	if err := dec(in); err != nil {
@@ -338,6 +708,24 @@ func _UserService_ResetPassword_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_UnlockAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnlockAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).UnlockAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.v1.UserService/UnlockAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).UnlockAccount(ctx, req.(*UnlockAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _UserService_GetMyPersonalInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetMyPersonalInfoRequest)
 	if err := dec(in); err != nil {
@@ -356,6 +744,168 @@ func _UserService_GetMyPersonalInfo_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_BeginTotpEnrollment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BeginTotpEnrollmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).BeginTotpEnrollment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.v1.UserService/BeginTotpEnrollment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).BeginTotpEnrollment(ctx, req.(*BeginTotpEnrollmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ConfirmTotpEnrollment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmTotpEnrollmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ConfirmTotpEnrollment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.v1.UserService/ConfirmTotpEnrollment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ConfirmTotpEnrollment(ctx, req.(*ConfirmTotpEnrollmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RegenerateRecoveryCodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegenerateRecoveryCodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RegenerateRecoveryCodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.v1.UserService/RegenerateRecoveryCodes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RegenerateRecoveryCodes(ctx, req.(*RegenerateRecoveryCodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_BeginWebauthnRegistration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BeginWebauthnRegistrationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).BeginWebauthnRegistration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.v1.UserService/BeginWebauthnRegistration",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).BeginWebauthnRegistration(ctx, req.(*BeginWebauthnRegistrationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_FinishWebauthnRegistration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FinishWebauthnRegistrationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).FinishWebauthnRegistration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.v1.UserService/FinishWebauthnRegistration",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).FinishWebauthnRegistration(ctx, req.(*FinishWebauthnRegistrationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListMySessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMySessionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListMySessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.v1.UserService/ListMySessions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListMySessions(ctx, req.(*ListMySessionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RevokeSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RevokeSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.v1.UserService/RevokeSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RevokeSession(ctx, req.(*RevokeSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RevokeOtherSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeOtherSessionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RevokeOtherSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.v1.UserService/RevokeOtherSessions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RevokeOtherSessions(ctx, req.(*RevokeOtherSessionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ChangePassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangePasswordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ChangePassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.v1.UserService/ChangePassword",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ChangePassword(ctx, req.(*ChangePasswordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // UserService_ServiceDesc is the grpc.ServiceDesc for UserService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -371,10 +921,18 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "VerifySmsOtp",
 			Handler:    _UserService_VerifySmsOtp_Handler,
 		},
+		{
+			MethodName: "GetSmsOtpDeliveryStatus",
+			Handler:    _UserService_GetSmsOtpDeliveryStatus_Handler,
+		},
 		{
 			MethodName: "ConfirmEmail",
 			Handler:    _UserService_ConfirmEmail_Handler,
 		},
+		{
+			MethodName: "ResendConfirmationEmail",
+			Handler:    _UserService_ResendConfirmationEmail_Handler,
+		},
 		{
 			MethodName: "Register",
 			Handler:    _UserService_Register_Handler,
@@ -383,6 +941,18 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SignIn",
 			Handler:    _UserService_SignIn_Handler,
 		},
+		{
+			MethodName: "CompleteMfaChallenge",
+			Handler:    _UserService_CompleteMfaChallenge_Handler,
+		},
+		{
+			MethodName: "BeginWebauthnSignIn",
+			Handler:    _UserService_BeginWebauthnSignIn_Handler,
+		},
+		{
+			MethodName: "FinishWebauthnSignIn",
+			Handler:    _UserService_FinishWebauthnSignIn_Handler,
+		},
 		{
 			MethodName: "SignOut",
 			Handler:    _UserService_SignOut_Handler,
@@ -391,14 +961,58 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RefreshToken",
 			Handler:    _UserService_RefreshToken_Handler,
 		},
+		{
+			MethodName: "IntrospectToken",
+			Handler:    _UserService_IntrospectToken_Handler,
+		},
 		{
 			MethodName: "ResetPassword",
 			Handler:    _UserService_ResetPassword_Handler,
 		},
+		{
+			MethodName: "UnlockAccount",
+			Handler:    _UserService_UnlockAccount_Handler,
+		},
 		{
 			MethodName: "GetMyPersonalInfo",
 			Handler:    _UserService_GetMyPersonalInfo_Handler,
 		},
+		{
+			MethodName: "BeginTotpEnrollment",
+			Handler:    _UserService_BeginTotpEnrollment_Handler,
+		},
+		{
+			MethodName: "ConfirmTotpEnrollment",
+			Handler:    _UserService_ConfirmTotpEnrollment_Handler,
+		},
+		{
+			MethodName: "RegenerateRecoveryCodes",
+			Handler:    _UserService_RegenerateRecoveryCodes_Handler,
+		},
+		{
+			MethodName: "BeginWebauthnRegistration",
+			Handler:    _UserService_BeginWebauthnRegistration_Handler,
+		},
+		{
+			MethodName: "FinishWebauthnRegistration",
+			Handler:    _UserService_FinishWebauthnRegistration_Handler,
+		},
+		{
+			MethodName: "ListMySessions",
+			Handler:    _UserService_ListMySessions_Handler,
+		},
+		{
+			MethodName: "RevokeSession",
+			Handler:    _UserService_RevokeSession_Handler,
+		},
+		{
+			MethodName: "RevokeOtherSessions",
+			Handler:    _UserService_RevokeOtherSessions_Handler,
+		},
+		{
+			MethodName: "ChangePassword",
+			Handler:    _UserService_ChangePassword_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "user/v1/user.proto",